@@ -0,0 +1,101 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/tenortim/goisilon/api/v2"
+)
+
+// AccessPoint is a namespace access point.
+type AccessPoint *api.AccessPoint
+
+// GetAccessPoints returns the namespace access points.
+func (c *Client) GetAccessPoints(ctx context.Context) ([]AccessPoint, error) {
+	aps, err := api.AccessPointsGet(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	var res []AccessPoint
+	for _, ap := range aps {
+		res = append(res, ap)
+	}
+	return res, nil
+}
+
+// GetAccessPoint returns the namespace access point with the given name.
+func (c *Client) GetAccessPoint(
+	ctx context.Context, name string) (AccessPoint, error) {
+
+	aps, err := api.AccessPointsGet(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	for _, ap := range aps {
+		if ap.Name == name {
+			return ap, nil
+		}
+	}
+	return nil, fmt.Errorf("access point not found: %s", name)
+}
+
+// CreateAccessPoint creates a namespace access point that exposes the
+// directory at path, ex. /ifs/data/tenant. If owner is set the access point
+// is owned by that user, who is validated first. The access point is removed
+// again if its owner cannot be set.
+func (c *Client) CreateAccessPoint(
+	ctx context.Context, name, path, owner string) (AccessPoint, error) {
+
+	if name == "" {
+		return nil, errors.New("no access point name set")
+	}
+	if path == "" {
+		return nil, errors.New("no access point path set")
+	}
+
+	var persona *api.Persona
+	if owner != "" {
+		persona = &api.Persona{
+			ID: &api.PersonaID{
+				ID:   owner,
+				Type: api.PersonaIDTypeUser,
+			},
+		}
+		if err := c.ValidatePersona(ctx, "", persona); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := api.AccessPointCreate(ctx, c.API, name, path); err != nil {
+		return nil, err
+	}
+	if persona != nil {
+		if err := api.AccessPointACLUpdate(ctx, c.API, name, &api.ACL{
+			Action:        &api.PActionTypeUpdate,
+			Authoritative: &api.PAuthoritativeTypeACL,
+			Owner:         persona,
+		}); err != nil {
+			api.AccessPointDelete(ctx, c.API, name)
+			return nil, err
+		}
+	}
+	return &api.AccessPoint{Name: name, Path: path}, nil
+}
+
+// DeleteAccessPoint removes a namespace access point. The directory it
+// exposes is not deleted.
+func (c *Client) DeleteAccessPoint(ctx context.Context, name string) error {
+	return api.AccessPointDelete(ctx, c.API, name)
+}
+
+// GetAccessPointACL returns the ACL of a namespace access point.
+func (c *Client) GetAccessPointACL(
+	ctx context.Context, name string) (*ACL, error) {
+
+	acl, err := api.AccessPointACLInspect(ctx, c.API, name)
+	if err != nil {
+		return nil, err
+	}
+	return ACLFromAPI(acl), nil
+}
@@ -0,0 +1,50 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessPoints(t *testing.T) {
+	volumeName := "test_access_point_volume"
+	name := "test_access_point"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	path := client.API.VolumePath(volumeName)
+
+	ap, err := client.CreateAccessPoint(
+		defaultCtx, name, path, client.API.User())
+	assertNoError(t, err)
+	defer client.DeleteAccessPoint(defaultCtx, name)
+	assert.Equal(t, path, ap.Path)
+
+	ap, err = client.GetAccessPoint(defaultCtx, name)
+	assertNoError(t, err)
+	assert.Equal(t, path, ap.Path)
+
+	acl, err := client.GetAccessPointACL(defaultCtx, name)
+	assertNoError(t, err)
+	assert.Equal(t, client.API.User(), acl.OwnerName())
+
+	aps, err := client.GetAccessPoints(defaultCtx)
+	assertNoError(t, err)
+	found := false
+	for _, ap := range aps {
+		found = found || ap.Name == name
+	}
+	assert.True(t, found)
+
+	assertNoError(t, client.DeleteAccessPoint(defaultCtx, name))
+	_, err = client.GetAccessPoint(defaultCtx, name)
+	assertError(t, err)
+}
+
+func TestCreateAccessPointInvalid(t *testing.T) {
+	_, err := client.CreateAccessPoint(defaultCtx, "", "/ifs/data", "")
+	assert.EqualError(t, err, "no access point name set")
+	_, err = client.CreateAccessPoint(defaultCtx, "test", "", "")
+	assert.EqualError(t, err, "no access point path set")
+}
@@ -0,0 +1,48 @@
+package goisilon
+
+import (
+	"context"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// AccessTimeSettings are the cluster's access time tracking settings.
+type AccessTimeSettings *api.IsiAccessTimeSettings
+
+// GetAccessTimeSettings returns the cluster's access time tracking settings.
+func (c *Client) GetAccessTimeSettings(
+	ctx context.Context) (AccessTimeSettings, error) {
+
+	return api.GetIsiAccessTimeSettings(ctx, c.API)
+}
+
+// UpdateAccessTimeSettings modifies the cluster's access time tracking
+// settings. Fields that are nil are left unchanged.
+func (c *Client) UpdateAccessTimeSettings(
+	ctx context.Context, settings AccessTimeSettings) error {
+
+	return api.UpdateIsiAccessTimeSettings(ctx, c.API, settings)
+}
+
+// EnableAccessTime enables access time tracking. A file's atime is updated
+// when it is accessed more than precision after its current atime, which is
+// truncated to whole seconds.
+func (c *Client) EnableAccessTime(
+	ctx context.Context, precision time.Duration) error {
+
+	var (
+		enabled = true
+		seconds = int64(precision / time.Second)
+	)
+	return api.UpdateIsiAccessTimeSettings(
+		ctx, c.API,
+		&api.IsiAccessTimeSettings{Enabled: &enabled, Precision: &seconds})
+}
+
+// DisableAccessTime disables access time tracking.
+func (c *Client) DisableAccessTime(ctx context.Context) error {
+	enabled := false
+	return api.UpdateIsiAccessTimeSettings(
+		ctx, c.API, &api.IsiAccessTimeSettings{Enabled: &enabled})
+}
@@ -0,0 +1,24 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTimeSettings(t *testing.T) {
+	settings, err := client.GetAccessTimeSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+	defer client.UpdateAccessTimeSettings(defaultCtx, settings)
+
+	assertNoError(t, client.EnableAccessTime(defaultCtx, 24*time.Hour))
+
+	settings, err = client.GetAccessTimeSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings.Enabled)
+	assert.True(t, *settings.Enabled)
+	assertNotNil(t, settings.Precision)
+	assert.Equal(t, int64(86400), *settings.Precision)
+}
@@ -2,19 +2,82 @@ package goisilon
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	api "github.com/tenortim/goisilon/api/v2"
 )
 
 // ACL is an Isilon Access Control List used for managing an object's security.
-type ACL *api.ACL
+type ACL api.ACL
+
+// ACLFromAPI converts an API ACL to an ACL.
+func ACLFromAPI(acl *api.ACL) *ACL {
+	return (*ACL)(acl)
+}
+
+// API returns the ACL as an API ACL.
+func (acl *ACL) API() *api.ACL {
+	return (*api.ACL)(acl)
+}
+
+// OwnerName returns the name or ID of the ACL's owner, or an empty string if
+// the owner is not set.
+func (acl *ACL) OwnerName() string {
+	if acl == nil {
+		return ""
+	}
+	return personaName(acl.Owner)
+}
+
+// GroupName returns the name or ID of the ACL's group, or an empty string if
+// the group is not set.
+func (acl *ACL) GroupName() string {
+	if acl == nil {
+		return ""
+	}
+	return personaName(acl.Group)
+}
+
+// ACEMode returns the permission bits that approximate the ACL's ACEs for
+// its owner, group and Everyone. See api.ACEsToMode.
+func (acl *ACL) ACEMode() os.FileMode {
+	if acl == nil {
+		return 0
+	}
+	return os.FileMode(api.ACEsToMode(acl.ACEs, acl.Owner, acl.Group))
+}
+
+// FileMode returns the ACL's mode, or zero if the mode is not set.
+func (acl *ACL) FileMode() os.FileMode {
+	if acl == nil || acl.Mode == nil {
+		return 0
+	}
+	return os.FileMode(*acl.Mode)
+}
+
+func personaName(p *api.Persona) string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Name != nil:
+		return *p.Name
+	case p.ID != nil:
+		return personaIDString(p.ID)
+	}
+	return ""
+}
 
 // GetVolumeACL returns the ACL for a volume.
 func (c *Client) GetVolumeACL(
 	ctx context.Context,
-	volumeName string) (ACL, error) {
+	volumeName string) (*ACL, error) {
 
-	return api.ACLInspect(ctx, c.API, volumeName)
+	acl, err := api.ACLInspect(ctx, c.API, volumeName)
+	if err != nil {
+		return nil, err
+	}
+	return ACLFromAPI(acl), nil
 }
 
 // SetVolumeOwnerToCurrentUser sets the owner for a volume to the user that
@@ -32,6 +95,15 @@ func (c *Client) SetVolumeOwner(
 	volumeName, userName string) error {
 
 	mode := api.FileMode(0777)
+	owner := &api.Persona{
+		ID: &api.PersonaID{
+			ID:   userName,
+			Type: api.PersonaIDTypeUser,
+		},
+	}
+	if err := c.ValidatePersona(ctx, "", owner); err != nil {
+		return err
+	}
 
 	return api.ACLUpdate(
 		ctx,
@@ -40,13 +112,59 @@ func (c *Client) SetVolumeOwner(
 		&api.ACL{
 			Action:        &api.PActionTypeReplace,
 			Authoritative: &api.PAuthoritativeTypeMode,
-			Owner: &api.Persona{
-				ID: &api.PersonaID{
-					ID:   userName,
-					Type: api.PersonaIDTypeUser,
-				},
-			},
-			Mode: &mode,
+			Owner:         owner,
+			Mode:          &mode,
+		})
+}
+
+// SetVolumeACL updates the ACL of a volume. The owner and group, if set, are
+// validated before the update is sent.
+func (c *Client) SetVolumeACL(
+	ctx context.Context,
+	volumeName string, acl *ACL) error {
+
+	if acl == nil {
+		return errors.New("no acl set")
+	}
+	if acl.Owner != nil {
+		if err := c.ValidatePersona(ctx, "", acl.Owner); err != nil {
+			return err
+		}
+	}
+	if acl.Group != nil {
+		if err := c.ValidatePersona(ctx, "", acl.Group); err != nil {
+			return err
+		}
+	}
+	return api.ACLUpdate(ctx, c.API, volumeName, acl.API())
+}
+
+// UpdateVolumeACEs adds or removes ACEs on the ACL of a volume according to
+// each ACE's Op, ex. those returned by api.ReadOnlyACE or api.RevokeACE,
+// leaving its other ACEs unchanged. The user and group trustees are
+// validated before the update is sent.
+func (c *Client) UpdateVolumeACEs(
+	ctx context.Context,
+	volumeName string, aces ...*api.ACE) error {
+
+	for _, ace := range aces {
+		if t := ace.Trustee; t != nil && t.ID != nil &&
+			t.ID.Type == api.PersonaIDTypeSID {
+			// well-known SIDs such as Everyone are not users or groups
+			continue
+		}
+		if err := c.ValidatePersona(ctx, "", ace.Trustee); err != nil {
+			return err
+		}
+	}
+	return api.ACLUpdate(
+		ctx,
+		c.API,
+		volumeName,
+		&api.ACL{
+			Action:        &api.PActionTypeUpdate,
+			Authoritative: &api.PAuthoritativeTypeACL,
+			ACEs:          aces,
 		})
 }
 
@@ -1,6 +1,7 @@
 package goisilon
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,54 @@ func TestGetVolumeACL(t *testing.T) {
 	assertNotNil(t, acl.Owner.ID)
 	assert.Equal(t, "10", acl.Owner.ID.ID)
 	assert.Equal(t, api.PersonaIDTypeUID, acl.Owner.ID.Type)
+	assert.Equal(t, client.API.User(), acl.OwnerName())
+}
+
+func TestACLAccessors(t *testing.T) {
+	var nilACL *ACL
+	assert.Equal(t, "", nilACL.OwnerName())
+	assert.Equal(t, os.FileMode(0), nilACL.FileMode())
+
+	mode := api.FileMode(0755)
+	acl := ACLFromAPI(&api.ACL{
+		Owner: &api.Persona{
+			ID: &api.PersonaID{ID: "1000", Type: api.PersonaIDTypeUID},
+		},
+		Mode: &mode,
+	})
+	assert.Equal(t, "UID:1000", acl.OwnerName())
+	assert.Equal(t, "", acl.GroupName())
+	assert.Equal(t, os.FileMode(0755), acl.FileMode())
+	assert.Equal(t, &mode, acl.API().Mode)
+
+	acl.ACEs = api.ModeToACEs(0750, acl.Owner, acl.Group, true)
+	assert.Equal(t, os.FileMode(0700), acl.ACEMode())
+}
+
+func TestUpdateVolumeACEs(t *testing.T) {
+	volumeName := "test_update_volume_aces"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	trustee := api.UserPersona(client.API.User())
+	assertNoError(t, client.UpdateVolumeACEs(
+		defaultCtx, volumeName, api.ReadOnlyACE(trustee, true)))
+
+	acl, err := client.GetVolumeACL(defaultCtx, volumeName)
+	assertNoError(t, err)
+	var found bool
+	for _, ace := range acl.ACEs {
+		if ace.Trustee != nil && ace.Trustee.Name != nil &&
+			*ace.Trustee.Name == client.API.User() {
+			found = true
+		}
+	}
+	assert.True(t, found)
+
+	assertError(t, client.UpdateVolumeACEs(defaultCtx, volumeName,
+		api.ReadOnlyACE(api.GroupPersona("test_no_such_group"), true)))
 }
 
 func TestSetVolumeOwnerToCurrentUser(t *testing.T) {
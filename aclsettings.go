@@ -0,0 +1,32 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// ACLPolicySettings are the cluster's ACL policy settings.
+type ACLPolicySettings *api.IsiACLPolicySettings
+
+// GetACLPolicySettings returns the cluster's ACL policy settings.
+func (c *Client) GetACLPolicySettings(
+	ctx context.Context) (ACLPolicySettings, error) {
+
+	return api.GetIsiACLPolicySettings(ctx, c.API)
+}
+
+// UpdateACLPolicySettings modifies the cluster's ACL policy settings.
+// Fields that are nil are left unchanged.
+func (c *Client) UpdateACLPolicySettings(
+	ctx context.Context, settings ACLPolicySettings) error {
+
+	return api.UpdateIsiACLPolicySettings(ctx, c.API, settings)
+}
+
+// SetChmodACLBehavior sets what a chmod of a file with an ACL does to the
+// ACL, ex. "remove", "replace", "merge" or "ignore".
+func (c *Client) SetChmodACLBehavior(ctx context.Context, chmod string) error {
+	return api.UpdateIsiACLPolicySettings(
+		ctx, c.API, &api.IsiACLPolicySettings{Chmod: &chmod})
+}
@@ -0,0 +1,22 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACLPolicySettings(t *testing.T) {
+	settings, err := client.GetACLPolicySettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+	assertNotNil(t, settings.Chmod)
+	defer client.SetChmodACLBehavior(defaultCtx, *settings.Chmod)
+
+	assertNoError(t, client.SetChmodACLBehavior(defaultCtx, "merge"))
+
+	settings, err = client.GetACLPolicySettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings.Chmod)
+	assert.Equal(t, "merge", *settings.Chmod)
+}
@@ -0,0 +1,100 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// ADSProviderList is a list of Active Directory providers.
+type ADSProviderList []*api.IsiADSProvider
+
+// ADSProvider is an Active Directory authentication provider.
+type ADSProvider *api.IsiADSProvider
+
+// ADSJoinOptions are the optional settings used when joining a domain.
+type ADSJoinOptions *api.IsiADSJoinReq
+
+// adsProviderPrefix is the prefix of the names by which access zones refer
+// to Active Directory providers.
+const adsProviderPrefix = "lsa-activedirectory-provider:"
+
+// GetADSProviders returns the Active Directory providers. If zone is set then
+// only the providers used by that access zone are returned.
+func (c *Client) GetADSProviders(
+	ctx context.Context, zone string) (ADSProviderList, error) {
+
+	return api.GetIsiADSProviders(ctx, c.API, zone)
+}
+
+// GetADSProvider returns the Active Directory provider for a domain.
+func (c *Client) GetADSProvider(
+	ctx context.Context, domain string) (ADSProvider, error) {
+
+	return api.GetIsiADSProvider(ctx, c.API, domain)
+}
+
+// IsADSProviderOnline returns a flag indicating whether or not the
+// Active Directory provider for a domain is online.
+func (c *Client) IsADSProviderOnline(
+	ctx context.Context, domain string) (bool, error) {
+
+	provider, err := c.GetADSProvider(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+	return provider.Status == "online", nil
+}
+
+// JoinADSDomain joins the cluster to an Active Directory domain and returns
+// the ID of the new provider. The options may be nil.
+func (c *Client) JoinADSDomain(
+	ctx context.Context,
+	domain, user, password string,
+	opts ADSJoinOptions) (string, error) {
+
+	req := &api.IsiADSJoinReq{}
+	if opts != nil {
+		*req = *opts
+	}
+	req.Name, req.User, req.Password = domain, user, password
+	return api.JoinIsiADSDomain(ctx, c.API, req)
+}
+
+// UpdateADSProvider modifies the settings of the Active Directory provider
+// for a domain. Fields that are nil are left unchanged.
+func (c *Client) UpdateADSProvider(
+	ctx context.Context, domain string, provider ADSProvider) error {
+
+	return api.UpdateIsiADSProvider(ctx, c.API, domain, provider)
+}
+
+// LeaveADSDomain removes the Active Directory provider for a domain.
+func (c *Client) LeaveADSDomain(ctx context.Context, domain string) error {
+	return api.LeaveIsiADSDomain(ctx, c.API, domain)
+}
+
+// AddADSProviderToZone adds the Active Directory provider for a domain to the
+// authentication providers of an access zone.
+func (c *Client) AddADSProviderToZone(
+	ctx context.Context, zone, domain string) error {
+
+	z, err := c.GetZone(ctx, zone)
+	if err != nil {
+		return err
+	}
+
+	name := adsProviderPrefix + domain
+	var providers []string
+	if z.AuthProviders != nil {
+		for _, p := range *z.AuthProviders {
+			if p == name {
+				return nil
+			}
+		}
+		providers = *z.AuthProviders
+	}
+	providers = append(providers, name)
+	return api.UpdateIsiZone(
+		ctx, c.API, zone, &api.IsiZone{AuthProviders: &providers})
+}
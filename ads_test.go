@@ -0,0 +1,15 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetADSProviders(t *testing.T) {
+	providers, err := client.GetADSProviders(defaultCtx, "")
+	assertNoError(t, err)
+	for _, p := range providers {
+		online, err := client.IsADSProviderOnline(defaultCtx, p.Name)
+		assertNoError(t, err)
+		t.Logf("domain=%s status=%s online=%v", p.Name, p.Status, online)
+	}
+}
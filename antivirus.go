@@ -0,0 +1,192 @@
+package goisilon
+
+import (
+	"context"
+	"path"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// AntivirusServerList is a list of antivirus servers.
+type AntivirusServerList []*api.IsiAntivirusServer
+
+// AntivirusServer is an ICAP server used for antivirus scanning.
+type AntivirusServer *api.IsiAntivirusServer
+
+// AntivirusPolicyList is a list of antivirus policies.
+type AntivirusPolicyList []*api.IsiAntivirusPolicy
+
+// AntivirusPolicy is an antivirus scan policy.
+type AntivirusPolicy *api.IsiAntivirusPolicy
+
+// AntivirusSettings are the global antivirus settings.
+type AntivirusSettings *api.IsiAntivirusSettings
+
+// AntivirusScanResult is the result of scanning a single file.
+type AntivirusScanResult *api.IsiAntivirusScanResult
+
+// AntivirusScanReportList is a list of antivirus scan reports.
+type AntivirusScanReportList []*api.IsiAntivirusScanReport
+
+// AntivirusScanReport is the report of an antivirus scan.
+type AntivirusScanReport *api.IsiAntivirusScanReport
+
+// AntivirusThreatList is a list of threats found by antivirus scans.
+type AntivirusThreatList []*api.IsiAntivirusThreat
+
+// GetAntivirusServers returns the configured antivirus servers.
+func (c *Client) GetAntivirusServers(
+	ctx context.Context) (AntivirusServerList, error) {
+
+	return api.GetIsiAntivirusServers(ctx, c.API)
+}
+
+// AddAntivirusServer adds an ICAP server with the given URL and returns its
+// ID.
+func (c *Client) AddAntivirusServer(
+	ctx context.Context, url, description string) (string, error) {
+
+	enabled := true
+	server := &api.IsiAntivirusServer{URL: &url, Enabled: &enabled}
+	if description != "" {
+		server.Description = &description
+	}
+	return api.CreateIsiAntivirusServer(ctx, c.API, server)
+}
+
+// UpdateAntivirusServer modifies an antivirus server.
+func (c *Client) UpdateAntivirusServer(
+	ctx context.Context, server AntivirusServer) error {
+
+	return api.UpdateIsiAntivirusServer(ctx, c.API, server)
+}
+
+// RemoveAntivirusServer removes an antivirus server.
+func (c *Client) RemoveAntivirusServer(ctx context.Context, id string) error {
+	return api.DeleteIsiAntivirusServer(ctx, c.API, id)
+}
+
+// GetAntivirusPolicies returns the antivirus policies.
+func (c *Client) GetAntivirusPolicies(
+	ctx context.Context) (AntivirusPolicyList, error) {
+
+	return api.GetIsiAntivirusPolicies(ctx, c.API)
+}
+
+// GetAntivirusPolicy returns the antivirus policy with the given ID.
+func (c *Client) GetAntivirusPolicy(
+	ctx context.Context, id string) (AntivirusPolicy, error) {
+
+	return api.GetIsiAntivirusPolicy(ctx, c.API, id)
+}
+
+// CreateAntivirusPolicy creates an antivirus policy and returns its ID.
+func (c *Client) CreateAntivirusPolicy(
+	ctx context.Context, policy AntivirusPolicy) (string, error) {
+
+	return api.CreateIsiAntivirusPolicy(ctx, c.API, policy)
+}
+
+// UpdateAntivirusPolicy modifies an antivirus policy.
+func (c *Client) UpdateAntivirusPolicy(
+	ctx context.Context, policy AntivirusPolicy) error {
+
+	return api.UpdateIsiAntivirusPolicy(ctx, c.API, policy)
+}
+
+// DeleteAntivirusPolicy removes an antivirus policy.
+func (c *Client) DeleteAntivirusPolicy(ctx context.Context, id string) error {
+	return api.DeleteIsiAntivirusPolicy(ctx, c.API, id)
+}
+
+// GetAntivirusSettings returns the global antivirus settings.
+func (c *Client) GetAntivirusSettings(
+	ctx context.Context) (AntivirusSettings, error) {
+
+	return api.GetIsiAntivirusSettings(ctx, c.API)
+}
+
+// UpdateAntivirusSettings modifies the global antivirus settings. Fields that
+// are nil are left unchanged.
+func (c *Client) UpdateAntivirusSettings(
+	ctx context.Context, settings AntivirusSettings) error {
+
+	return api.UpdateIsiAntivirusSettings(ctx, c.API, settings)
+}
+
+// ScanFile scans a single file in a volume and returns the result.
+func (c *Client) ScanFile(
+	ctx context.Context, volumeName, filePath string) (AntivirusScanResult, error) {
+
+	return api.ScanIsiAntivirusFile(
+		ctx, c.API, c.API.VolumePath(path.Join(volumeName, filePath)))
+}
+
+// ScanVolume starts an antivirus scan of the volume with the given name and
+// returns the ID of the AVScan job. A policy named after the volume is
+// created on first use and reused for later scans.
+func (c *Client) ScanVolume(ctx context.Context, name string) (int64, error) {
+
+	policies, err := c.GetAntivirusPolicies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		found      bool
+		policyName = "goisilon_" + name
+	)
+	for _, p := range policies {
+		if p.Name != nil && *p.Name == policyName {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		var (
+			enabled = true
+			paths   = []string{c.API.VolumePath(name)}
+		)
+		if _, err := api.CreateIsiAntivirusPolicy(
+			ctx, c.API,
+			&api.IsiAntivirusPolicy{
+				Name:    &policyName,
+				Enabled: &enabled,
+				Paths:   &paths,
+			}); err != nil {
+			return 0, err
+		}
+	}
+
+	return api.StartIsiAntivirusPolicyScan(ctx, c.API, policyName)
+}
+
+// GetAntivirusScanReports returns the antivirus scan reports.
+func (c *Client) GetAntivirusScanReports(
+	ctx context.Context) (AntivirusScanReportList, error) {
+
+	return api.GetIsiAntivirusScanReports(ctx, c.API)
+}
+
+// GetAntivirusScanReport returns the antivirus scan report with the given ID.
+func (c *Client) GetAntivirusScanReport(
+	ctx context.Context, id string) (AntivirusScanReport, error) {
+
+	return api.GetIsiAntivirusScanReport(ctx, c.API, id)
+}
+
+// GetAntivirusThreats returns the threats found by antivirus scans.
+func (c *Client) GetAntivirusThreats(
+	ctx context.Context) (AntivirusThreatList, error) {
+
+	return api.GetIsiAntivirusThreats(ctx, c.API)
+}
+
+// GetAntivirusReportThreats returns the threats found by the antivirus scan
+// with the given report ID.
+func (c *Client) GetAntivirusReportThreats(
+	ctx context.Context, reportID string) (AntivirusThreatList, error) {
+
+	return api.GetIsiAntivirusReportThreats(ctx, c.API, reportID)
+}
@@ -0,0 +1,55 @@
+package goisilon
+
+import (
+	"testing"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetAntivirusSettings(t *testing.T) {
+	settings, err := client.GetAntivirusSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+}
+
+func TestAntivirusPolicyCreateDelete(t *testing.T) {
+	var (
+		policyName = "test_antivirus_policy"
+		paths      = []string{client.API.VolumesPath()}
+	)
+
+	id, err := client.CreateAntivirusPolicy(
+		defaultCtx,
+		&api.IsiAntivirusPolicy{Name: &policyName, Paths: &paths})
+	assertNoError(t, err)
+	defer client.DeleteAntivirusPolicy(defaultCtx, id)
+
+	policy, err := client.GetAntivirusPolicy(defaultCtx, id)
+	assertNoError(t, err)
+	assertNotNil(t, policy)
+	assertNotNil(t, policy.Name)
+	if *policy.Name != policyName {
+		t.Fatalf("unexpected policy name: %s", *policy.Name)
+	}
+}
+
+func TestIsCleanScanResult(t *testing.T) {
+	if !isCleanScanResult(&api.IsiAntivirusScanResult{Result: "Clean"}) {
+		t.Fatal("clean result not clean")
+	}
+	if isCleanScanResult(&api.IsiAntivirusScanResult{Result: "infected"}) {
+		t.Fatal("infected result clean")
+	}
+	if isCleanScanResult(nil) {
+		t.Fatal("nil result clean")
+	}
+}
+
+func TestGetQuarantinedFiles(t *testing.T) {
+	files, err := client.GetQuarantinedFiles(defaultCtx)
+	assertNoError(t, err)
+	for _, q := range files {
+		assertNotNil(t, q)
+		t.Logf("quarantined=%s", q.File)
+	}
+}
@@ -0,0 +1,110 @@
+package goisilon
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tenortim/goisilon/api"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// AntivirusQuarantine is the quarantine state of a file.
+type AntivirusQuarantine *apiv3.IsiAntivirusQuarantine
+
+// GetAntivirusQuarantine returns the quarantine state of a file by its
+// absolute path.
+func (c *Client) GetAntivirusQuarantine(
+	ctx context.Context, file string) (AntivirusQuarantine, error) {
+
+	return apiv3.GetIsiAntivirusQuarantine(ctx, c.API, file)
+}
+
+// GetQuarantinedFiles returns the quarantine state of the files that
+// antivirus scans have found threats in and that are still quarantined,
+// ordered by path. Files that have since been removed are skipped. The files
+// are checked concurrently.
+func (c *Client) GetQuarantinedFiles(
+	ctx context.Context) ([]AntivirusQuarantine, error) {
+
+	threats, err := apiv3.GetIsiAntivirusThreats(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu          sync.Mutex
+		quarantined = []AntivirusQuarantine{}
+		calls       []func(ctx context.Context) error
+		seen        = map[string]bool{}
+	)
+	for _, t := range threats {
+		if t.File == "" || seen[t.File] {
+			continue
+		}
+		seen[t.File] = true
+		file := t.File
+		calls = append(calls, func(ctx context.Context) error {
+			q, err := apiv3.GetIsiAntivirusQuarantine(ctx, c.API, file)
+			if IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if q.Quarantined {
+				mu.Lock()
+				quarantined = append(quarantined, q)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+		return nil, err
+	}
+	sort.Slice(quarantined, func(i, j int) bool {
+		return quarantined[i].File < quarantined[j].File
+	})
+	return quarantined, nil
+}
+
+// QuarantineFile quarantines a file by its absolute path so that it cannot
+// be opened.
+func (c *Client) QuarantineFile(ctx context.Context, file string) error {
+	return apiv3.UpdateIsiAntivirusQuarantine(ctx, c.API, file, true)
+}
+
+// ReleaseQuarantinedFile releases a file by its absolute path from
+// quarantine without scanning it again.
+func (c *Client) ReleaseQuarantinedFile(ctx context.Context, file string) error {
+	return apiv3.UpdateIsiAntivirusQuarantine(ctx, c.API, file, false)
+}
+
+// RescanQuarantinedFile scans a quarantined file by its absolute path again,
+// ex. after the antivirus servers' definitions are updated, and releases it
+// from quarantine if it is found to be clean. It returns the result of the
+// scan and whether the file was released.
+func (c *Client) RescanQuarantinedFile(
+	ctx context.Context, file string) (AntivirusScanResult, bool, error) {
+
+	result, err := apiv3.ScanIsiAntivirusFile(ctx, c.API, file)
+	if err != nil {
+		return nil, false, err
+	}
+	if !isCleanScanResult(result) {
+		return result, false, nil
+	}
+	if err := apiv3.UpdateIsiAntivirusQuarantine(
+		ctx, c.API, file, false); err != nil {
+
+		return result, false, err
+	}
+	return result, true, nil
+}
+
+// isCleanScanResult returns whether a scan found no threat in a file.
+func isCleanScanResult(r *apiv3.IsiAntivirusScanResult) bool {
+	return r != nil && strings.EqualFold(strings.TrimSpace(r.Result), "clean")
+}
@@ -7,11 +7,11 @@ import (
 	"errors"
 	"io"
 	"net/http"
-	"net/url"
 	"os"
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	log "github.com/akutz/gournal"
@@ -49,6 +49,16 @@ type Client interface {
 		params OrderedValues, headers map[string]string,
 		body, resp interface{}) error
 
+	// DoRaw sends an HTTP request to the OneFS API and returns the response
+	// without decoding it. Error responses are returned rather than
+	// converted to errors, so the error is only set if no response was
+	// received.
+	DoRaw(
+		ctx context.Context,
+		method, path, id string,
+		params OrderedValues, headers map[string]string,
+		body interface{}) (*RawResponse, error)
+
 	// Get sends an HTTP request using the GET method to the OneFS API.
 	Get(
 		ctx context.Context,
@@ -91,6 +101,30 @@ type Client interface {
 
 	// VolumePath returns the path to a volume with the provided name.
 	VolumePath(name string) string
+
+	// Zone returns the access zone used to scope zone-aware calls, or an
+	// empty string for the System zone.
+	Zone() string
+
+	// SetPassword changes the password used to access the OneFS API. It
+	// should be called after the user's password has been changed on the
+	// cluster, and applies to all clients derived from this one.
+	SetPassword(password string)
+
+	// ForZone returns a client that shares this client's connection and
+	// credentials but scopes zone-aware calls to the provided access zone.
+	// The volumes path is the configured one.
+	ForZone(zone string) Client
+
+	// ForZonePath returns a client like ForZone whose volumes path is also
+	// scoped to the zone's base path, ex. "/ifs/volumes" in a zone at
+	// "/ifs/zone1" becomes "/ifs/zone1/volumes". A configured volumes path
+	// that is already under the base path is used as is.
+	ForZonePath(zone, zonePath string) Client
+
+	// Stats returns the statistics of the requests made by this client and
+	// the clients derived from it, per endpoint.
+	Stats() []EndpointStats
 }
 
 type client struct {
@@ -98,12 +132,37 @@ type client struct {
 	hostname        string
 	username        string
 	groupname       string
-	password        string
+	password        *secret
 	volumePath      string
+	baseVolumePath  string
+	zone            string
+	redactor        Redactor
+	breaker         *circuitBreaker
+	stats           *clientStats
+	auth            Authenticator
+	timeout         time.Duration
 	apiVersion      uint8
 	apiMinorVersion uint8
 }
 
+// secret is a value that may be read and replaced concurrently.
+type secret struct {
+	sync.RWMutex
+	value string
+}
+
+func (s *secret) get() string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.value
+}
+
+func (s *secret) set(value string) {
+	s.Lock()
+	defer s.Unlock()
+	s.value = value
+}
+
 type apiVerResponse struct {
 	Latest *string `json:"latest"`
 }
@@ -130,8 +189,37 @@ type ClientOptions struct {
 	// stored.
 	VolumesPath string
 
-	// Timeout specifies a time limit for requests made by this client.
+	// Timeout specifies a time limit for requests made by this client,
+	// including session logins. A context deadline that is earlier also
+	// applies. It is overridden by WithTimeout.
 	Timeout time.Duration
+
+	// Zone is the access zone used to scope zone-aware calls, such as those
+	// for NFS exports and audit settings. The System zone is used if empty.
+	Zone string
+
+	// TLSConfig is the TLS configuration used to connect to the OneFS API,
+	// for example to trust a private CA. If Insecure is set then certificate
+	// verification is disabled on a copy of this configuration.
+	TLSConfig *tls.Config
+
+	// Redactor, if set, is applied to HTTP requests and responses dumped to
+	// the debug log, after the credentials in them have been redacted.
+	Redactor Redactor
+
+	// Capturer, if set, records every HTTP exchange with the credentials
+	// redacted, ex. a HARCapture or FileCapture. The Redactor, if set, is
+	// applied to the captured bodies.
+	Capturer Capturer
+
+	// CircuitBreaker, if set, enables a circuit breaker that fails requests
+	// with ErrCircuitOpen while the cluster is failing. It is shared with
+	// the clients returned by ForZone.
+	CircuitBreaker *CircuitBreakerOptions
+
+	// Authenticator, if set, adds credentials to requests, ex. a SessionAuth.
+	// It defaults to BasicAuth.
+	Authenticator Authenticator
 }
 
 // New returns a new API client.
@@ -148,8 +236,10 @@ func New(
 		hostname:   hostname,
 		username:   username,
 		groupname:  groupname,
-		password:   password,
+		password:   &secret{value: password},
 		volumePath: defaultVolumesPath,
+		stats:      newClientStats(),
+		auth:       BasicAuth{},
 	}
 
 	c.http = &http.Client{}
@@ -158,16 +248,43 @@ func New(
 		if opts.VolumesPath != "" {
 			c.volumePath = opts.VolumesPath
 		}
+		c.baseVolumePath = c.volumePath
+
+		c.zone = opts.Zone
+		c.redactor = opts.Redactor
+
+		if opts.Authenticator != nil {
+			c.auth = opts.Authenticator
+		}
 
-		if opts.Timeout != 0 {
-			c.http.Timeout = opts.Timeout
+		if opts.CircuitBreaker != nil {
+			c.breaker = newCircuitBreaker(opts.CircuitBreaker)
 		}
 
-		if opts.Insecure {
+		c.timeout = opts.Timeout
+
+		if opts.Insecure || opts.TLSConfig != nil {
+			tlsConfig := &tls.Config{}
+			if opts.TLSConfig != nil {
+				tlsConfig = opts.TLSConfig.Clone()
+			}
+			if opts.Insecure {
+				tlsConfig.InsecureSkipVerify = true
+			}
 			c.http.Transport = &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				},
+				TLSClientConfig: tlsConfig,
+			}
+		}
+
+		if opts.Capturer != nil {
+			next := c.http.Transport
+			if next == nil {
+				next = http.DefaultTransport
+			}
+			c.http.Transport = &captureTransport{
+				next:     next,
+				capturer: opts.Capturer,
+				redactor: opts.Redactor,
 			}
 		}
 	}
@@ -267,7 +384,10 @@ func (c *client) DoWithHeaders(
 	ctx context.Context,
 	method, uri, id string,
 	params OrderedValues, headers map[string]string,
-	body, resp interface{}) error {
+	body, resp interface{}) (err error) {
+
+	start := time.Now()
+	defer func() { c.stats.record(method, uri, time.Since(start), err) }()
 
 	res, isDebugLog, err := c.DoAndGetResponseBody(
 		ctx, method, uri, id, params, headers, body)
@@ -277,7 +397,7 @@ func (c *client) DoWithHeaders(
 	defer res.Body.Close()
 
 	if isDebugLog {
-		logResponse(ctx, res)
+		c.logResponse(ctx, res)
 	}
 
 	// parse the response
@@ -288,7 +408,16 @@ func (c *client) DoWithHeaders(
 		if resp == nil {
 			return nil
 		}
+		// stream the body for callers that want the raw content, such as
+		// file downloads from the namespace API
+		if w, ok := resp.(io.Writer); ok {
+			_, err = io.Copy(w, res.Body)
+			return err
+		}
+		// numbers decoded into interface{} values are kept as json.Number so
+		// that IDs and sizes beyond 2^53 are not rounded by a float64
 		dec := json.NewDecoder(res.Body)
+		dec.UseNumber()
 		if err = dec.Decode(resp); err != nil && err != io.EOF {
 			return err
 		}
@@ -299,64 +428,69 @@ func (c *client) DoWithHeaders(
 	return nil
 }
 
-func (c *client) DoAndGetResponseBody(
-	ctx context.Context,
-	method, uri, id string,
-	params OrderedValues, headers map[string]string,
-	body interface{}) (*http.Response, bool, error) {
-
+// buildURL returns the URL of an API resource. The URL is built in a pooled
+// buffer as this is done for every request.
+func (c *client) buildURL(uri, id string, params OrderedValues) string {
 	var (
-		err                   error
-		req                   *http.Request
-		res                   *http.Response
-		ubf                   = &bytes.Buffer{}
+		bp                    = getBuf()
+		b                     = *bp
 		lid                   = len(id)
 		luri                  = len(uri)
 		hostnameEndsWithSlash = endsWithSlash(c.hostname)
-		uriBeginsWithSlash    = beginsWithSlash(uri)
-		uriEndsWithSlash      = endsWithSlash(uri)
 	)
 
-	ubf.WriteString(c.hostname)
+	b = append(b, c.hostname...)
 
 	if !hostnameEndsWithSlash && (luri > 0 || lid > 0) {
-		ubf.WriteString("/")
+		b = append(b, '/')
 	}
 
 	if luri > 0 {
-		if uriBeginsWithSlash {
-			ubf.WriteString(uri[1:])
+		if beginsWithSlash(uri) {
+			b = append(b, uri[1:]...)
 		} else {
-			ubf.WriteString(uri)
+			b = append(b, uri...)
 		}
-		if !uriEndsWithSlash {
-			ubf.WriteString("/")
+		if !endsWithSlash(uri) {
+			b = append(b, '/')
 		}
 	}
 
 	if lid > 0 {
-		ubf.WriteString(id)
+		b = append(b, id...)
 	}
 
 	// add parameters to the URI
 	if len(params) > 0 {
-		ubf.WriteByte('?')
-		if err := params.EncodeTo(ubf); err != nil {
-			return nil, false, err
-		}
+		b = append(b, '?')
+		b = params.AppendEncoded(b)
 	}
 
-	u, err := url.Parse(ubf.String())
-	if err != nil {
-		return nil, false, err
-	}
+	u := string(b)
+	*bp = b
+	putBuf(bp)
+	return u
+}
+
+func (c *client) DoAndGetResponseBody(
+	ctx context.Context,
+	method, uri, id string,
+	params OrderedValues, headers map[string]string,
+	body interface{}) (*http.Response, bool, error) {
+
+	var (
+		err error
+		req *http.Request
+		res *http.Response
+		u   = c.buildURL(uri, id, params)
+	)
 
 	var isContentTypeSet bool
 
 	// marshal the message body (assumes json format)
 	if body != nil {
 		if r, ok := body.(io.ReadCloser); ok {
-			req, err = http.NewRequest(method, u.String(), r)
+			req, err = http.NewRequest(method, u, r)
 			defer r.Close()
 			if v, ok := headers[headerKeyContentType]; ok {
 				req.Header.Set(headerKeyContentType, v)
@@ -375,7 +509,7 @@ func (c *client) DoAndGetResponseBody(
 			if err = enc.Encode(body); err != nil {
 				return nil, false, err
 			}
-			req, err = http.NewRequest(method, u.String(), buf)
+			req, err = http.NewRequest(method, u, buf)
 			if v, ok := headers[headerKeyContentType]; ok {
 				req.Header.Set(headerKeyContentType, v)
 			} else {
@@ -384,7 +518,7 @@ func (c *client) DoAndGetResponseBody(
 			isContentTypeSet = true
 		}
 	} else {
-		req, err = http.NewRequest(method, u.String(), nil)
+		req, err = http.NewRequest(method, u, nil)
 	}
 
 	if err != nil {
@@ -405,12 +539,11 @@ func (c *client) DoAndGetResponseBody(
 		}
 	}
 
-	// set the username and password
-	req.SetBasicAuth(c.username, c.password)
-
 	var (
 		isDebugLog bool
 		logReqBuf  = &bytes.Buffer{}
+		cancel     context.CancelFunc
+		orig       = req
 	)
 
 	if lvl, ok := ctx.Value(
@@ -418,19 +551,60 @@ func (c *client) DoAndGetResponseBody(
 		isDebugLog = true
 	}
 
-	logRequest(ctx, logReqBuf, req)
-	if isDebugLog {
-		log.Debug(ctx, logReqBuf.String())
-	}
+	for attempt := 0; ; attempt++ {
+		req = orig.Clone(ctx)
+		if attempt > 0 && orig.Body != nil {
+			if req.Body, err = orig.GetBody(); err != nil {
+				return nil, isDebugLog, err
+			}
+		}
 
-	// send the request
-	req = req.WithContext(ctx)
-	if res, err = c.http.Do(req); err != nil {
-		if !isDebugLog {
+		// authenticate the request, which may log in, within the request's
+		// time limit
+		actx, acancel := c.requestContext(ctx)
+		err = c.auth.Authenticate(actx, c.credentials(), req)
+		acancel()
+		if err != nil {
+			return nil, isDebugLog, err
+		}
+
+		logReqBuf.Reset()
+		c.logRequest(ctx, logReqBuf, req)
+		if isDebugLog {
 			log.Debug(ctx, logReqBuf.String())
 		}
-		return nil, isDebugLog, err
+
+		// send the request
+		if c.breaker != nil {
+			if err = c.breaker.allow(); err != nil {
+				return nil, isDebugLog, err
+			}
+		}
+		var rctx context.Context
+		rctx, cancel = c.requestContext(ctx)
+		req = req.WithContext(rctx)
+		res, err = c.http.Do(req)
+		if c.breaker != nil {
+			c.breaker.done(requestFailed(ctx, res, err))
+		}
+		if err != nil {
+			cancel()
+			if !isDebugLog {
+				log.Debug(ctx, logReqBuf.String())
+			}
+			return nil, isDebugLog, err
+		}
+
+		// retry once with new credentials if the authenticator's were
+		// rejected and the body can be sent again
+		if res.StatusCode != http.StatusUnauthorized || attempt > 0 ||
+			!c.auth.Reset() || (orig.Body != nil && orig.GetBody == nil) {
+			break
+		}
+		res.Body.Close()
+		cancel()
 	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
 
 	return res, isDebugLog, err
 }
@@ -455,14 +629,97 @@ func (c *client) VolumePath(volumeName string) string {
 	return path.Join(c.volumePath, volumeName)
 }
 
+func (c *client) Zone() string {
+	return c.zone
+}
+
+func (c *client) SetPassword(password string) {
+	c.password.set(password)
+}
+
+func (c *client) credentials() *Credentials {
+	return &Credentials{
+		Endpoint:   c.hostname,
+		Username:   c.username,
+		Password:   c.password.get(),
+		HTTPClient: c.http,
+	}
+}
+
+func (c *client) Stats() []EndpointStats {
+	return c.stats.snapshot()
+}
+
+func (c *client) ForZone(zone string) Client {
+	zc := *c
+	zc.zone = zone
+	zc.volumePath = c.configuredVolumesPath()
+	return &zc
+}
+
+func (c *client) ForZonePath(zone, zonePath string) Client {
+	zc := *c
+	zc.zone = zone
+	zc.volumePath = ZoneVolumesPath(zonePath, c.configuredVolumesPath())
+	return &zc
+}
+
+// configuredVolumesPath returns the volumes path the client was created
+// with, before any zone scoping.
+func (c *client) configuredVolumesPath() string {
+	if c.baseVolumePath != "" {
+		return c.baseVolumePath
+	}
+	return c.volumePath
+}
+
+// ZoneVolumesPath returns the volumes path scoped to an access zone's base
+// path. The volumes path is used as is if it is already under the base path,
+// and otherwise its path under /ifs is joined onto the base path.
+func ZoneVolumesPath(zonePath, volumesPath string) string {
+	zonePath = path.Clean(zonePath)
+	volumesPath = path.Clean(volumesPath)
+	if zonePath == "." || zonePath == "/" ||
+		volumesPath == zonePath ||
+		strings.HasPrefix(volumesPath, zonePath+"/") {
+		return volumesPath
+	}
+	if volumesPath == "/ifs" {
+		return zonePath
+	}
+	if strings.HasPrefix(volumesPath, "/ifs/") {
+		volumesPath = volumesPath[len("/ifs"):]
+	}
+	return path.Join(zonePath, volumesPath)
+}
+
+var zoneByteArr = []byte("zone")
+
+// ZoneQS returns the query string used to scope a call to an access zone. If
+// zone is empty the client's zone is used, and if that is also empty then nil
+// is returned so the call applies to the System zone.
+func ZoneQS(c Client, zone string) OrderedValues {
+	if zone == "" {
+		zone = c.Zone()
+	}
+	if zone == "" {
+		return nil
+	}
+	return OrderedValues{{zoneByteArr, []byte(zone)}}
+}
+
 func (err *JSONError) Error() string {
 	return err.Err[0].Message
 }
 
 func parseJSONError(r *http.Response) error {
 	jsonError := &JSONError{}
-	if err := json.NewDecoder(r.Body).Decode(jsonError); err != nil {
-		return err
+	if err := json.NewDecoder(r.Body).Decode(jsonError); err != nil ||
+		len(jsonError.Err) == 0 {
+
+		// responses from proxies and load balancers may not be JSON, but the
+		// status code is still needed to classify the error
+		jsonError.Err = []Error{{}}
 	}
 
 	jsonError.StatusCode = r.StatusCode
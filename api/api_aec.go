@@ -0,0 +1,59 @@
+package api
+
+// ErrorCode is a OneFS API error code, ex. "AEC_NOT_FOUND", as reported in
+// the body of an error response. A *JSONError is an ErrorCode for errors.Is
+// if any of its errors has the code, so callers can react to OneFS
+// conditions regardless of the HTTP status, which some releases report
+// differently for the same condition:
+//
+//	if errors.Is(err, api.ErrForbidden) { ... }
+//
+// Codes that are not listed here can be matched by converting them, ex.
+// api.ErrorCode("AEC_NOT_MODIFIED").
+type ErrorCode string
+
+// OneFS API error codes.
+const (
+	ErrNotFound       ErrorCode = "AEC_NOT_FOUND"
+	ErrExists         ErrorCode = "AEC_EXISTS"
+	ErrConflict       ErrorCode = "AEC_CONFLICT"
+	ErrForbidden      ErrorCode = "AEC_FORBIDDEN"
+	ErrUnauthorized   ErrorCode = "AEC_UNAUTHORIZED"
+	ErrBadRequest     ErrorCode = "AEC_BAD_REQUEST"
+	ErrArgRequired    ErrorCode = "AEC_ARG_REQUIRED"
+	ErrArgNotAllowed  ErrorCode = "AEC_ARG_NOT_ALLOWED"
+	ErrException      ErrorCode = "AEC_EXCEPTION"
+	ErrInternal       ErrorCode = "AEC_SYSTEM_INTERNAL_ERROR"
+	ErrTimeout        ErrorCode = "AEC_TIMEOUT"
+	ErrNotImplemented ErrorCode = "AEC_NOT_IMPLEMENTED"
+)
+
+func (c ErrorCode) Error() string {
+	return string(c)
+}
+
+// Is returns whether target is an ErrorCode that any of the response's
+// errors has.
+func (err *JSONError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	if !ok || code == "" {
+		return false
+	}
+	for _, e := range err.Err {
+		if e.Code == string(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Code returns the first error code of the response, or an empty code if
+// the response has none, ex. because it was not from OneFS.
+func (err *JSONError) Code() ErrorCode {
+	for _, e := range err.Err {
+		if e.Code != "" {
+			return ErrorCode(e.Code)
+		}
+	}
+	return ""
+}
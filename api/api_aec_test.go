@@ -0,0 +1,44 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONErrorCode(t *testing.T) {
+	res := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Status:     "500 Internal Server Error",
+		Body: io.NopCloser(strings.NewReader(`{"errors":[` +
+			`{"code":"AEC_EXCEPTION","message":"failed"},` +
+			`{"code":"AEC_FORBIDDEN","message":"denied"}]}`)),
+	}
+	err := fmt.Errorf("get share: %w", parseJSONError(res))
+
+	assert.True(t, errors.Is(err, ErrException))
+	assert.True(t, errors.Is(err, ErrForbidden))
+	assert.True(t, errors.Is(err, ErrorCode("AEC_FORBIDDEN")))
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var jerr *JSONError
+	assert.True(t, errors.As(err, &jerr))
+	assert.Equal(t, ErrException, jerr.Code())
+	assert.Equal(t, "AEC_EXCEPTION", ErrException.Error())
+
+	// responses that are not from OneFS have no code
+	res = &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Status:     "502 Bad Gateway",
+		Body:       io.NopCloser(strings.NewReader("<html></html>")),
+	}
+	err = parseJSONError(res)
+	assert.True(t, errors.As(err, &jerr))
+	assert.Equal(t, ErrorCode(""), jerr.Code())
+	assert.False(t, errors.Is(err, ErrorCode("")))
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+// Credentials are what an Authenticator needs to authenticate a client's
+// requests.
+type Credentials struct {
+
+	// Endpoint is the client's endpoint, ex. https://1.2.3.4:8080.
+	Endpoint string
+
+	// Username and Password are the client's credentials. The password may
+	// change when SetPassword is called.
+	Username string
+	Password string
+
+	// HTTPClient is the client's HTTP client, which an Authenticator may use
+	// to log in.
+	HTTPClient *http.Client
+}
+
+// Authenticator adds credentials to a client's requests. It is set with
+// ClientOptions.Authenticator and is shared by the clients returned by
+// ForZone, so it must be safe for concurrent use.
+type Authenticator interface {
+
+	// Authenticate adds credentials to a request before it is sent.
+	Authenticate(ctx context.Context, creds *Credentials, req *http.Request) error
+
+	// Reset is called when a request is rejected with 401 Unauthorized, to
+	// discard any cached credentials. It returns whether the request should
+	// be retried, with new credentials from Authenticate.
+	Reset() bool
+}
+
+// BasicAuth is an Authenticator that sends the user name and password with
+// every request. It is the default.
+type BasicAuth struct{}
+
+// Authenticate sets the request's basic authentication header.
+func (BasicAuth) Authenticate(
+	ctx context.Context, creds *Credentials, req *http.Request) error {
+
+	req.SetBasicAuth(creds.Username, creds.Password)
+	return nil
+}
+
+// Reset returns false, as the same credentials would be sent again.
+func (BasicAuth) Reset() bool {
+	return false
+}
+
+const sessionPath = "session/1/session"
+
+// SessionAuth is an Authenticator that logs in to the OneFS session service
+// once and sends the session cookie and CSRF token with each request, which
+// avoids authenticating the password on every call. The session is
+// recreated when it expires or the password changes.
+type SessionAuth struct {
+	mu       sync.Mutex
+	username string
+	password string
+	sessID   string
+	csrf     string
+}
+
+type sessionReq struct {
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	Services []string `json:"services"`
+}
+
+// Authenticate adds the session cookie and CSRF token to a request, logging
+// in first if there is no session.
+func (a *SessionAuth) Authenticate(
+	ctx context.Context, creds *Credentials, req *http.Request) error {
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.sessID == "" ||
+		a.username != creds.Username || a.password != creds.Password {
+		if err := a.login(ctx, creds); err != nil {
+			return err
+		}
+	}
+	req.AddCookie(&http.Cookie{Name: "isisessid", Value: a.sessID})
+	if a.csrf != "" {
+		req.Header.Set("X-CSRF-Token", a.csrf)
+		req.Header.Set("Referer", creds.Endpoint)
+	}
+	return nil
+}
+
+func (a *SessionAuth) login(ctx context.Context, creds *Credentials) error {
+	// PAPI call: POST https://1.2.3.4:8080/session/1/session
+	//            Content-Type: application/json
+	//            {username: "user", password: "pass",
+	//             services: ["platform", "namespace"]}
+	body, err := json.Marshal(&sessionReq{
+		Username: creds.Username,
+		Password: creds.Password,
+		Services: []string{"platform", "namespace"},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(
+		http.MethodPost,
+		strings.TrimSuffix(creds.Endpoint, "/")+"/"+sessionPath,
+		bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerKeyContentType, headerValContentTypeJSON)
+
+	res, err := creds.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		if err := parseJSONError(res); err != nil {
+			return fmt.Errorf("session login failed: %w", err)
+		}
+		return fmt.Errorf("session login failed: %s", res.Status)
+	}
+
+	a.sessID, a.csrf = "", ""
+	for _, c := range res.Cookies() {
+		switch c.Name {
+		case "isisessid":
+			a.sessID = c.Value
+		case "isicsrf":
+			a.csrf = c.Value
+		}
+	}
+	if a.sessID == "" {
+		return fmt.Errorf("session login failed: no session cookie")
+	}
+	a.username, a.password = creds.Username, creds.Password
+	return nil
+}
+
+// Reset discards the session and returns true, so the request is retried
+// with a new session.
+func (a *SessionAuth) Reset() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sessID, a.csrf = "", ""
+	return true
+}
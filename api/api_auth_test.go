@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicAuth(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			u, p, ok := r.BasicAuth()
+			if !ok || u != "admin" || p != "password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		username: "admin",
+		password: &secret{value: "password"},
+		auth:     BasicAuth{},
+	}
+	ctx := context.Background()
+
+	assertNoError(t, c.Get(ctx, "platform/1/test", "", nil, nil, nil))
+
+	// a rejected password is not retried
+	c.SetPassword("wrong")
+	assertError(t, c.Get(ctx, "platform/1/test", "", nil, nil, nil))
+	assert.EqualValues(t, 2, atomic.LoadInt32(&requests))
+}
+
+func TestSessionAuth(t *testing.T) {
+	var (
+		logins  int32
+		session atomic.Value
+	)
+	session.Store("s1")
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/"+sessionPath {
+				n := atomic.AddInt32(&logins, 1)
+				id := "s" + string(rune('0'+n))
+				session.Store(id)
+				http.SetCookie(w, &http.Cookie{Name: "isisessid", Value: id})
+				http.SetCookie(w, &http.Cookie{Name: "isicsrf", Value: "csrf-" + id})
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+			if _, _, ok := r.BasicAuth(); ok {
+				t.Error("unexpected basic authentication")
+			}
+			cookie, err := r.Cookie("isisessid")
+			if err != nil || cookie.Value != session.Load().(string) ||
+				r.Header.Get("X-CSRF-Token") != "csrf-"+cookie.Value {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			b, _ := io.ReadAll(r.Body)
+			w.Write(b)
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		username: "admin",
+		password: &secret{value: "password"},
+		auth:     &SessionAuth{},
+	}
+	ctx := context.Background()
+
+	var resp map[string]string
+	body := map[string]string{"name": "a"}
+	assertNoError(t, c.Put(ctx, "platform/1/test", "", nil, nil, body, &resp))
+	assert.Equal(t, body, resp)
+	assertNoError(t, c.Put(ctx, "platform/1/test", "", nil, nil, body, &resp))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&logins))
+
+	// an expired session is recreated and the request is retried with its
+	// body
+	session.Store("expired")
+	resp = nil
+	assertNoError(t, c.Put(ctx, "platform/1/test", "", nil, nil, body, &resp))
+	assert.Equal(t, body, resp)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&logins))
+
+	// a new password logs in again
+	c.SetPassword("new")
+	assertNoError(t, c.Get(ctx, "platform/1/test", "", nil, nil, nil))
+	assert.EqualValues(t, 3, atomic.LoadInt32(&logins))
+
+	// requests without a body are retried too
+	session.Store("expired")
+	assertNoError(t, c.Get(ctx, "platform/1/test", "", nil, nil, nil))
+	assert.EqualValues(t, 4, atomic.LoadInt32(&logins))
+	session.Store("expired")
+	assertNoError(t, c.Delete(ctx, "platform/1/test", "", nil, nil, nil))
+	assert.EqualValues(t, 5, atomic.LoadInt32(&logins))
+}
+
+func TestSessionAuthLoginFailed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errors":[{"code":"AEC_UNAUTHORIZED",` +
+				`"message":"bad credentials"}]}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		username: "admin",
+		password: &secret{value: "password"},
+		auth:     &SessionAuth{},
+	}
+	err := c.Get(context.Background(), "platform/1/test", "", nil, nil, nil)
+	assertError(t, err)
+	assert.Contains(t, err.Error(), "session login failed")
+	assert.Contains(t, err.Error(), "bad credentials")
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultBatchWorkers is the number of calls Batch runs concurrently if no
+// limit is given.
+const defaultBatchWorkers = 4
+
+// BatchError is returned by Batch when one or more calls fail. Errs has an
+// entry for each call, in the order the calls were given, which is nil for
+// the calls that succeeded.
+type BatchError struct {
+	Errs []error
+}
+
+func (e *BatchError) Error() string {
+	var msgs []string
+	for i, err := range e.Errs {
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("call %d: %v", i, err))
+		}
+	}
+	return fmt.Sprintf("%d of %d calls failed: %s",
+		len(msgs), len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the failed calls, so that errors.Is and
+// errors.As match any of them.
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errs {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Batch runs independent calls concurrently, at most workers at a time, and
+// waits for them all to finish. If workers is zero or less a default limit
+// of 4 is used. Calls that fail do not stop the others; if any fail a
+// *BatchError is returned. Calls that have not started when the context is
+// done fail with the context's error.
+func Batch(
+	ctx context.Context,
+	workers int, calls ...func(ctx context.Context) error) error {
+
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+	var (
+		errs   = make([]error, len(calls))
+		failed bool
+		sem    = make(chan struct{}, workers)
+		wg     sync.WaitGroup
+	)
+	for i, call := range calls {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, call func(ctx context.Context) error) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if errs[i] = ctx.Err(); errs[i] == nil {
+				errs[i] = call(ctx)
+			}
+		}(i, call)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		failed = failed || err != nil
+	}
+	if !failed {
+		return nil
+	}
+	return &BatchError{Errs: errs}
+}
+
+// BatchGet is a GET request issued by BatchGets. The response is decoded
+// into Resp.
+type BatchGet struct {
+	Path    string
+	ID      string
+	Params  OrderedValues
+	Headers map[string]string
+	Resp    interface{}
+}
+
+// BatchGets issues GET requests concurrently with Batch.
+func BatchGets(
+	ctx context.Context,
+	client Client, workers int, gets ...*BatchGet) error {
+
+	calls := make([]func(ctx context.Context) error, len(gets))
+	for i, g := range gets {
+		g := g
+		calls[i] = func(ctx context.Context) error {
+			return client.Get(ctx, g.Path, g.ID, g.Params, g.Headers, g.Resp)
+		}
+	}
+	return Batch(ctx, workers, calls...)
+}
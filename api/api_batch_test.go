@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	var running, maxRunning int32
+	results := make([]int, 6)
+	calls := make([]func(ctx context.Context) error, len(results))
+	errOdd := errors.New("odd")
+	for i := range calls {
+		i := i
+		calls[i] = func(ctx context.Context) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			results[i] = i * i
+			if i%2 == 1 {
+				return errOdd
+			}
+			return nil
+		}
+	}
+
+	err := Batch(context.Background(), 2, calls...)
+	assert.Equal(t, []int{0, 1, 4, 9, 16, 25}, results)
+	assert.True(t, atomic.LoadInt32(&maxRunning) <= 2)
+
+	var berr *BatchError
+	assert.True(t, errors.As(err, &berr))
+	assert.Equal(t, []error{nil, errOdd, nil, errOdd, nil, errOdd}, berr.Errs)
+	assert.True(t, errors.Is(err, errOdd))
+	assert.EqualError(t, err,
+		"3 of 6 calls failed: call 1: odd; call 3: odd; call 5: odd")
+
+	assertNoError(t, Batch(context.Background(), 0, calls[0], calls[2]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Batch(ctx, 1, calls[0])
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestBatchGets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.Write([]byte(`{"path":"` + r.URL.Path + `"}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+	}
+	var a, b struct {
+		Path string `json:"path"`
+	}
+	err := BatchGets(context.Background(), c, 2,
+		&BatchGet{Path: "platform/1/a", Resp: &a},
+		&BatchGet{Path: "platform/1", ID: "b", Resp: &b})
+	assertNoError(t, err)
+	assert.Equal(t, "/platform/1/a/", a.Path)
+	assert.Equal(t, "/platform/1/b", b.Path)
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, without a request being sent, while a
+// client's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerOptions configure a circuit breaker that stops a client
+// from sending requests to a cluster that is failing. The breaker opens
+// when the share of requests that fail within a window reaches ErrorRate.
+// While it is open requests fail with ErrCircuitOpen. After OpenTimeout a
+// probe request is let through; if it succeeds the breaker closes, and if it
+// fails the breaker opens again.
+//
+// A request fails if it cannot be sent or the response status is 429 or
+// 5xx. Other error responses, such as 404, count as successes since the
+// cluster handled them.
+type CircuitBreakerOptions struct {
+
+	// Window is the period over which the error rate is measured. It
+	// defaults to one minute.
+	Window time.Duration
+
+	// MinRequests is the number of requests in a window below which the
+	// breaker does not open. It defaults to 10.
+	MinRequests int
+
+	// ErrorRate is the share of failed requests, from 0 to 1, at which the
+	// breaker opens. It defaults to 0.5.
+	ErrorRate float64
+
+	// OpenTimeout is how long the breaker stays open before a probe is
+	// sent. It defaults to 30 seconds.
+	OpenTimeout time.Duration
+}
+
+// circuitState is the state of a circuit breaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker is shared by a client and the clients derived from it.
+type circuitBreaker struct {
+	sync.Mutex
+	opts        CircuitBreakerOptions
+	now         func() time.Time
+	state       circuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newCircuitBreaker(opts *CircuitBreakerOptions) *circuitBreaker {
+	b := &circuitBreaker{opts: *opts, now: time.Now}
+	if b.opts.Window <= 0 {
+		b.opts.Window = time.Minute
+	}
+	if b.opts.MinRequests <= 0 {
+		b.opts.MinRequests = 10
+	}
+	if b.opts.ErrorRate <= 0 {
+		b.opts.ErrorRate = 0.5
+	}
+	if b.opts.OpenTimeout <= 0 {
+		b.opts.OpenTimeout = 30 * time.Second
+	}
+	return b
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent. Otherwise the
+// caller must call done with the request's outcome.
+func (b *circuitBreaker) allow() error {
+	b.Lock()
+	defer b.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.opts.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		fallthrough
+	case circuitHalfOpen:
+		// only one probe is sent at a time
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// done records the outcome of a request that was allowed.
+func (b *circuitBreaker) done(failed bool) {
+	b.Lock()
+	defer b.Unlock()
+	now := b.now()
+	if b.state == circuitHalfOpen {
+		b.probing = false
+		if failed {
+			b.state, b.openedAt = circuitOpen, now
+			return
+		}
+		b.state = circuitClosed
+		b.windowStart, b.requests, b.failures = now, 0, 0
+		return
+	}
+	if b.state != circuitClosed {
+		return
+	}
+	if now.Sub(b.windowStart) >= b.opts.Window {
+		b.windowStart, b.requests, b.failures = now, 0, 0
+	}
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if b.requests >= b.opts.MinRequests &&
+		float64(b.failures) >= b.opts.ErrorRate*float64(b.requests) {
+		b.state, b.openedAt = circuitOpen, now
+	}
+}
+
+// requestFailed returns whether the outcome of a request counts as a failure
+// of the cluster. Requests canceled by the caller do not count.
+func requestFailed(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= 500
+}
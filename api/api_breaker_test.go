@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newCircuitBreaker(&CircuitBreakerOptions{
+		Window:      time.Minute,
+		MinRequests: 4,
+		ErrorRate:   0.5,
+		OpenTimeout: 10 * time.Second,
+	})
+	b.now = func() time.Time { return now }
+
+	// failures below the minimum number of requests do not open it
+	for i := 0; i < 3; i++ {
+		assertNoError(t, b.allow())
+		b.done(true)
+	}
+	// nor do failures in an earlier window
+	now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		assertNoError(t, b.allow())
+		b.done(i == 0)
+	}
+	assertNoError(t, b.allow())
+	b.done(true)
+	assert.Equal(t, circuitOpen, b.state)
+	assert.Equal(t, ErrCircuitOpen, b.allow())
+
+	// a single probe is let through after the timeout
+	now = now.Add(10 * time.Second)
+	assertNoError(t, b.allow())
+	assert.Equal(t, ErrCircuitOpen, b.allow())
+	b.done(true)
+	assert.Equal(t, ErrCircuitOpen, b.allow())
+
+	now = now.Add(10 * time.Second)
+	assertNoError(t, b.allow())
+	b.done(false)
+	assert.Equal(t, circuitClosed, b.state)
+	assertNoError(t, b.allow())
+}
+
+func TestCircuitBreakerClient(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors":[{"code":"AEC_SYSTEM_INTERNAL_ERROR",` +
+				`"message":"unavailable"}]}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+		breaker:  newCircuitBreaker(&CircuitBreakerOptions{MinRequests: 2}),
+	}
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		err := c.Get(ctx, "platform/1/test", "", nil, nil, nil)
+		assert.EqualError(t, err, "unavailable")
+	}
+	err := c.ForZone("zone1").Get(ctx, "platform/1/test", "", nil, nil, nil)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestRequestFailed(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, requestFailed(ctx, &http.Response{StatusCode: 404}, nil))
+	assert.True(t, requestFailed(ctx, &http.Response{StatusCode: 429}, nil))
+	assert.True(t, requestFailed(ctx, &http.Response{StatusCode: 503}, nil))
+	assert.True(t, requestFailed(ctx, nil, errors.New("refused")))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, requestFailed(canceled, nil, context.Canceled))
+}
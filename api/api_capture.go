@@ -0,0 +1,389 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+// Exchange is a captured HTTP request and its response. Credentials are
+// redacted from the headers and bodies before it is captured.
+type Exchange struct {
+	Start          time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    []byte
+	StatusCode     int
+	Status         string
+	ResponseHeader http.Header
+	ResponseBody   []byte
+
+	// Err is the error if no response was received.
+	Err error
+}
+
+// Capturer records the HTTP exchanges of a client, ex. to attach them to a
+// support case. Capture may be called concurrently.
+type Capturer interface {
+	Capture(e *Exchange)
+}
+
+// omittedBody replaces bodies that are not JSON or text, such as file
+// contents, which are not captured.
+var omittedBody = []byte("[binary body omitted]")
+
+// captureTransport is an http.RoundTripper that passes exchanges to a
+// Capturer.
+type captureTransport struct {
+	next     http.RoundTripper
+	capturer Capturer
+	redactor Redactor
+}
+
+func (t *captureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e := &Exchange{
+		Start:         time.Now(),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeader(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		if !isTextBody(req.Header) {
+			e.RequestBody = omittedBody
+		} else {
+			b, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = io.NopCloser(bytes.NewReader(b))
+			e.RequestBody = t.redactBody(b)
+		}
+	}
+
+	res, err := t.next.RoundTrip(req)
+	e.Duration = time.Since(e.Start)
+	if err != nil {
+		e.Err = err
+		t.capturer.Capture(e)
+		return nil, err
+	}
+
+	e.StatusCode, e.Status = res.StatusCode, res.Status
+	e.ResponseHeader = redactHeader(res.Header)
+	if !isTextBody(res.Header) {
+		e.ResponseBody = omittedBody
+	} else {
+		b, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			e.Err = err
+		}
+		e.ResponseBody = t.redactBody(b)
+	}
+	t.capturer.Capture(e)
+	return res, nil
+}
+
+func (t *captureTransport) redactBody(b []byte) []byte {
+	b = redactedFieldRX.ReplaceAll(b, []byte(`${1}"`+redacted+`"`))
+	if t.redactor != nil {
+		b = t.redactor(b)
+	}
+	return b
+}
+
+// isTextBody returns whether a body with the given headers is JSON or text.
+func isTextBody(h http.Header) bool {
+	ct := h.Get(headerKeyContentType)
+	return ct == "" || strings.Contains(ct, "json") ||
+		strings.HasPrefix(ct, "text/")
+}
+
+// redactHeader returns a copy of h with the values of the headers that hold
+// credentials redacted.
+func redactHeader(h http.Header) http.Header {
+	c := h.Clone()
+	for k := range c {
+		if redactedHeaders[strings.ToLower(k)] {
+			c[k] = []string{redacted}
+		}
+	}
+	return c
+}
+
+// HARCapture is a Capturer that keeps exchanges in memory and writes them as
+// an HTTP Archive (HAR) 1.2 file, which browsers and many HTTP tools can
+// open.
+type HARCapture struct {
+	mu      sync.Mutex
+	entries []*Exchange
+}
+
+// NewHARCapture returns a new HARCapture.
+func NewHARCapture() *HARCapture {
+	return &HARCapture{}
+}
+
+// Capture records an exchange.
+func (h *HARCapture) Capture(e *Exchange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, e)
+}
+
+// Reset discards the captured exchanges.
+func (h *HARCapture) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = nil
+}
+
+type harLog struct {
+	Log harLogBody `json:"log"`
+}
+
+type harLogBody struct {
+	Version string      `json:"version"`
+	Creator harNameVer  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harNameVer struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harContent    `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	nvs := []harNameValue{}
+	for k, vs := range h {
+		for _, v := range vs {
+			nvs = append(nvs, harNameValue{Name: k, Value: v})
+		}
+	}
+	return nvs
+}
+
+func newHAREntry(e *Exchange) *harEntry {
+	ms := float64(e.Duration) / float64(time.Millisecond)
+	entry := &harEntry{
+		StartedDateTime: e.Start.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      e.Method,
+			URL:         e.URL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.RequestHeader),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(e.RequestBody),
+		},
+		Response: harResponse{
+			Status:      e.StatusCode,
+			StatusText:  http.StatusText(e.StatusCode),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.ResponseHeader),
+			Content: harContent{
+				Size:     len(e.ResponseBody),
+				MimeType: e.ResponseHeader.Get(headerKeyContentType),
+				Text:     string(e.ResponseBody),
+			},
+			HeadersSize: -1,
+			BodySize:    len(e.ResponseBody),
+		},
+		Timings: harTimings{Send: 0, Wait: ms, Receive: 0},
+	}
+	if e.RequestBody != nil {
+		entry.Request.PostData = &harContent{
+			Size:     len(e.RequestBody),
+			MimeType: e.RequestHeader.Get(headerKeyContentType),
+			Text:     string(e.RequestBody),
+		}
+	}
+	if e.Err != nil {
+		entry.Comment = e.Err.Error()
+	}
+	return entry
+}
+
+// WriteTo writes the captured exchanges to w as a HAR file.
+func (h *HARCapture) WriteTo(w io.Writer) (int64, error) {
+	h.mu.Lock()
+	l := harLog{Log: harLogBody{
+		Version: "1.2",
+		Creator: harNameVer{Name: "goisilon", Version: "1"},
+		Entries: make([]*harEntry, len(h.entries)),
+	}}
+	for i, e := range h.entries {
+		l.Log.Entries[i] = newHAREntry(e)
+	}
+	h.mu.Unlock()
+
+	b, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// FileCapture is a Capturer that appends exchanges as text to a file. When
+// the file reaches MaxSize it is rotated: name is renamed to name.1, name.1
+// to name.2, and so on, keeping at most MaxFiles old files.
+type FileCapture struct {
+	mu       sync.Mutex
+	name     string
+	maxSize  int64
+	maxFiles int
+	f        *os.File
+	size     int64
+}
+
+// NewFileCapture returns a FileCapture that writes to the named file. A
+// maxSize of zero or less disables rotation.
+func NewFileCapture(
+	name string, maxSize int64, maxFiles int) (*FileCapture, error) {
+
+	c := &FileCapture{name: name, maxSize: maxSize, maxFiles: maxFiles}
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *FileCapture) open() error {
+	f, err := os.OpenFile(c.name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	c.f, c.size = f, fi.Size()
+	return nil
+}
+
+func (c *FileCapture) rotate() error {
+	if err := c.f.Close(); err != nil {
+		return err
+	}
+	for i := c.maxFiles - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", c.name, i),
+			fmt.Sprintf("%s.%d", c.name, i+1))
+	}
+	if c.maxFiles > 0 {
+		os.Rename(c.name, c.name+".1")
+	} else {
+		os.Remove(c.name)
+	}
+	return c.open()
+}
+
+// Capture appends an exchange to the file. Errors writing the file are
+// ignored so that capturing never fails a request.
+func (c *FileCapture) Capture(e *Exchange) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "=== %s %s %s (%s)\n",
+		e.Start.Format(time.RFC3339Nano), e.Method, e.URL, e.Duration)
+	e.RequestHeader.Write(&b)
+	fmt.Fprintf(&b, "\n%s\n", e.RequestBody)
+	if e.Err != nil {
+		fmt.Fprintf(&b, "--- error: %v\n\n", e.Err)
+	} else {
+		fmt.Fprintf(&b, "--- %s\n", e.Status)
+		e.ResponseHeader.Write(&b)
+		fmt.Fprintf(&b, "\n%s\n\n", e.ResponseBody)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return
+	}
+	if c.maxSize > 0 && c.size > 0 && c.size+int64(b.Len()) > c.maxSize {
+		if err := c.rotate(); err != nil {
+			c.f = nil
+			return
+		}
+	}
+	n, _ := c.f.Write(b.Bytes())
+	c.size += int64(n)
+}
+
+// Close closes the file.
+func (c *FileCapture) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.f == nil {
+		return nil
+	}
+	err := c.f.Close()
+	c.f = nil
+	return err
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+func newCaptureTestClient(t *testing.T, capturer Capturer) (*client, func()) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.Write([]byte(`{"user":"admin","password":"secret1"}`))
+		}))
+	c := &client{
+		http: &http.Client{Transport: &captureTransport{
+			next:     srv.Client().Transport,
+			capturer: capturer,
+		}},
+		hostname: srv.URL,
+		username: "admin",
+		password: &secret{value: "hunter2"},
+		auth:     BasicAuth{},
+	}
+	return c, srv.Close
+}
+
+func TestHARCapture(t *testing.T) {
+	har := NewHARCapture()
+	c, done := newCaptureTestClient(t, har)
+	defer done()
+
+	var resp map[string]string
+	assertNoError(t, c.Put(context.Background(), "platform/1/test", "", nil,
+		nil, map[string]string{"smtp_auth_passwd": "hunter2"}, &resp))
+	// the client still sees the response body
+	assert.Equal(t, "secret1", resp["password"])
+
+	var buf bytes.Buffer
+	_, err := har.WriteTo(&buf)
+	assertNoError(t, err)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "secret1")
+
+	var l struct {
+		Log struct {
+			Version string `json:"version"`
+			Entries []struct {
+				Request struct {
+					Method   string `json:"method"`
+					URL      string `json:"url"`
+					PostData struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+				Response struct {
+					Status  int `json:"status"`
+					Content struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"response"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	assertNoError(t, json.Unmarshal(buf.Bytes(), &l))
+	assert.Equal(t, "1.2", l.Log.Version)
+	assertLen(t, l.Log.Entries, 1)
+	e := l.Log.Entries[0]
+	assert.Equal(t, http.MethodPut, e.Request.Method)
+	assert.True(t, strings.HasSuffix(e.Request.URL, "/platform/1/test/"))
+	assert.Contains(t, e.Request.PostData.Text, `"smtp_auth_passwd":"[REDACTED]"`)
+	assert.Equal(t, http.StatusOK, e.Response.Status)
+	assert.Equal(t,
+		`{"user":"admin","password":"[REDACTED]"}`, e.Response.Content.Text)
+
+	har.Reset()
+	buf.Reset()
+	har.WriteTo(&buf)
+	assertNoError(t, json.Unmarshal(buf.Bytes(), &l))
+	assert.Empty(t, l.Log.Entries)
+}
+
+func TestFileCapture(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "capture.log")
+	fc, err := NewFileCapture(name, 100, 2)
+	assertNoError(t, err)
+	c, done := newCaptureTestClient(t, fc)
+	defer done()
+
+	for i := 0; i < 4; i++ {
+		assertNoError(t, c.Get(
+			context.Background(), "platform/1/test", "", nil, nil, nil))
+	}
+	assertNoError(t, fc.Close())
+
+	b, err := os.ReadFile(name)
+	assertNoError(t, err)
+	assert.Contains(t, string(b), "GET ")
+	assert.Contains(t, string(b), "Authorization: [REDACTED]")
+	assert.NotContains(t, string(b), "secret1")
+	for _, rotated := range []string{name + ".1", name + ".2"} {
+		_, err = os.Stat(rotated)
+		assertNoError(t, err)
+	}
+	_, err = os.Stat(name + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"regexp"
+	"strings"
 
 	log "github.com/akutz/gournal"
 )
@@ -16,7 +18,59 @@ func isBinOctetBody(h http.Header) bool {
 	return h.Get(headerKeyContentType) == headerValContentTypeBinaryOctetStream
 }
 
-func logRequest(ctx context.Context, w io.Writer, req *http.Request) {
+// Redactor rewrites a dumped HTTP request or response before it is logged.
+type Redactor func(dump []byte) []byte
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders are the headers whose values are never logged.
+var redactedHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-csrf-token":        true,
+}
+
+// redactedFieldRX matches JSON string fields whose names suggest they hold
+// credentials, such as "password", "smtp_auth_passwd", or "secret_key".
+var redactedFieldRX = regexp.MustCompile(
+	`("[^"]*(?i:password|passwd|secret|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// RedactCredentials returns a copy of an HTTP dump with the values of
+// authentication and cookie headers, and of JSON fields that hold passwords
+// or secrets, replaced with [REDACTED].
+func RedactCredentials(dump []byte) []byte {
+	s := string(dump)
+	head, body := s, ""
+	if i := strings.Index(s, "\r\n\r\n"); i >= 0 {
+		head, body = s[:i], s[i:]
+	}
+
+	lines := strings.Split(head, "\r\n")
+	for i, l := range lines {
+		if i == 0 {
+			continue
+		}
+		if k := strings.IndexByte(l, ':'); k > 0 &&
+			redactedHeaders[strings.ToLower(l[:k])] {
+			lines[i] = l[:k] + ": " + redacted
+		}
+	}
+
+	body = redactedFieldRX.ReplaceAllString(body, `${1}"`+redacted+`"`)
+	return []byte(strings.Join(lines, "\r\n") + body)
+}
+
+func (c *client) redact(dump []byte) []byte {
+	dump = RedactCredentials(dump)
+	if c.redactor != nil {
+		dump = c.redactor(dump)
+	}
+	return dump
+}
+
+func (c *client) logRequest(ctx context.Context, w io.Writer, req *http.Request) {
 	fmt.Fprintln(w, "")
 	fmt.Fprint(w, "    -------------------------- ")
 	fmt.Fprint(w, "GOISILON HTTP REQUEST")
@@ -25,11 +79,11 @@ func logRequest(ctx context.Context, w io.Writer, req *http.Request) {
 	if err != nil {
 		return
 	}
-	WriteIndented(w, buf)
+	WriteIndented(w, c.redact(buf))
 	fmt.Fprintln(w)
 }
 
-func logResponse(ctx context.Context, res *http.Response) {
+func (c *client) logResponse(ctx context.Context, res *http.Response) {
 	w := &bytes.Buffer{}
 
 	fmt.Fprintln(w)
@@ -43,7 +97,7 @@ func logResponse(ctx context.Context, res *http.Response) {
 	}
 
 	bw := &bytes.Buffer{}
-	WriteIndented(bw, buf)
+	WriteIndented(bw, c.redact(buf))
 
 	scanner := bufio.NewScanner(bw)
 	for {
@@ -5,18 +5,11 @@ import (
 	"io"
 	"net/url"
 	"strings"
+	"sync"
 )
 
 var chrs = []byte(`,=&+%`)
 
-const (
-	chComa = iota
-	chEqul
-	chAmps
-	chPlus
-	chPrct
-)
-
 // OrderedValues maps a string key to a list of values and preserves insertion
 // order of the keys. It is typically used for query parameters and form values.
 // Unlike in the http.Header map, the keys in a Values map are case-sensitive.
@@ -180,50 +173,75 @@ func (v *OrderedValues) Del(key []byte) {
 	*v = (*v)[:len(*v)-1]
 }
 
+// bufPool holds buffers used to encode query strings and build URLs, so
+// that high request rates do not allocate a buffer per call.
+var bufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+func getBuf() *[]byte {
+	return bufPool.Get().(*[]byte)
+}
+
+func putBuf(b *[]byte) {
+	// large buffers are not pooled so that a single huge query does not pin
+	// its memory for the life of the process
+	if cap(*b) > 64<<10 {
+		return
+	}
+	*b = (*b)[:0]
+	bufPool.Put(b)
+}
+
 // Encode encodes the values into “URL encoded” form ("bar=baz&foo=quux")
 // using insertion order.
 func (v *OrderedValues) Encode() string {
-	buf := &bytes.Buffer{}
-	v.EncodeTo(buf)
-	return buf.String()
+	b := getBuf()
+	*b = v.AppendEncoded(*b)
+	s := string(*b)
+	putBuf(b)
+	return s
 }
 
 // EncodeTo encodes the values into “URL encoded” form ("bar=baz&foo=quux")
 // using insertion order.
 func (v *OrderedValues) EncodeTo(w io.Writer) error {
+	b := getBuf()
+	defer putBuf(b)
+	*b = v.AppendEncoded(*b)
+	_, err := w.Write(*b)
+	return err
+}
+
+// AppendEncoded appends the “URL encoded” form ("bar=baz&foo=quux") of the
+// values to dst, using insertion order, and returns the extended buffer.
+func (v *OrderedValues) AppendEncoded(dst []byte) []byte {
 	first := true
 	for _, j := range *v {
 		if len(j) == 0 {
 			continue
 		}
 		if !first {
-			if _, err := w.Write(chrs[chAmps : chAmps+1]); err != nil {
-				return err
-			}
+			dst = append(dst, '&')
 		} else {
 			first = false
 		}
-		if _, err := w.Write(j[0]); err != nil {
-			return err
-		}
+		dst = append(dst, j[0]...)
 		if len(j) == 1 {
 			continue
 		}
-		if _, err := w.Write(chrs[chEqul : chEqul+1]); err != nil {
-			return err
-		}
+		dst = append(dst, '=')
 		for e := 1; e < len(j); e++ {
 			if e > 1 {
-				if _, err := w.Write(chrs[chComa : chComa+1]); err != nil {
-					return err
-				}
-			}
-			if err := escapeTo(w, j[e]); err != nil {
-				return err
+				dst = append(dst, ',')
 			}
+			dst = appendEscaped(dst, j[e])
 		}
 	}
-	return nil
+	return dst
 }
 
 func (v *OrderedValues) String() string {
@@ -275,54 +293,27 @@ var hexChars = []byte{
 	'8', '9', 'A', 'B', 'C', 'D', 'E', 'F',
 }
 
-func escapeTo(w io.Writer, s []byte) error {
-
-	var (
-		hexCount   = 0
-		spaceCount = 0
-	)
-
+func appendEscaped(dst, s []byte) []byte {
 	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if shouldEscape(c) {
-			if c == ' ' {
-				spaceCount++
-			} else {
-				hexCount++
-			}
+		if shouldEscape(s[i]) {
+			return appendEscapedSlow(dst, s)
 		}
 	}
-	if spaceCount == 0 && hexCount == 0 {
-		if _, err := w.Write(s); err != nil {
-			return err
-		}
-		return nil
-	}
+	return append(dst, s...)
+}
+
+func appendEscapedSlow(dst, s []byte) []byte {
 	for i := 0; i < len(s); i++ {
 		switch c := s[i]; {
 		case c == ' ':
-			if _, err := w.Write(chrs[chPlus : chPlus+1]); err != nil {
-				return err
-			}
+			dst = append(dst, '+')
 		case shouldEscape(c):
-			if _, err := w.Write(chrs[chPrct : chPrct+1]); err != nil {
-				return err
-			}
-			c4, c15 := c>>4, c&15
-			if _, err := w.Write(hexChars[c4 : c4+1]); err != nil {
-				return err
-			}
-			if _, err := w.Write(hexChars[c15 : c15+1]); err != nil {
-				return err
-			}
+			dst = append(dst, '%', hexChars[c>>4], hexChars[c&15])
 		default:
-			if _, err := w.Write(s[i : i+1]); err != nil {
-				return err
-			}
+			dst = append(dst, c)
 		}
 	}
-
-	return nil
+	return dst
 }
 
 // shouldEscape returns true if the specified character should be escaped when
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -205,3 +206,31 @@ func TestParseQuery(t *testing.T) {
 	tf("name=Andrew%20Kutz&active&alias=akutz")
 	tf("name=Andrew+Kutz&active&alias=akutz")
 }
+
+func benchmarkValues() OrderedValues {
+	return OrderedValues{
+		{[]byte("query")},
+		{[]byte("path"), []byte("/ifs/data/volumes/vol 1")},
+		{[]byte("detail"), []byte("owner"), []byte("group")},
+		{[]byte("zone"), []byte("System")},
+		{[]byte("resume"), []byte("eyJ0b2tlbiI6IjEyMyJ9==")},
+	}
+}
+
+func BenchmarkOrderedValuesEncodeString(b *testing.B) {
+	v := benchmarkValues()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = v.Encode()
+	}
+}
+
+func BenchmarkOrderedValuesEncodeToDiscard(b *testing.B) {
+	v := benchmarkValues()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.EncodeTo(ioutil.Discard)
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+// RawResponse is the undecoded response to a request sent with DoRaw.
+type RawResponse struct {
+
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Header holds the response headers.
+	Header http.Header
+
+	// Body is the response body, which is usually JSON.
+	Body json.RawMessage
+}
+
+func (c *client) DoRaw(
+	ctx context.Context,
+	method, path, id string,
+	params OrderedValues, headers map[string]string,
+	body interface{}) (raw *RawResponse, err error) {
+
+	start := time.Now()
+	defer func() {
+		failed := err
+		if err == nil && raw.StatusCode >= 400 {
+			failed = statusError(raw.StatusCode)
+		}
+		c.stats.record(method, path, time.Since(start), failed)
+	}()
+
+	res, isDebugLog, err := c.DoAndGetResponseBody(
+		ctx, method, path, id, params, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if isDebugLog {
+		c.logResponse(ctx, res)
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &RawResponse{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       b,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoRaw(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.Header().Set("X-Test", r.Method+" "+r.URL.String())
+			if r.URL.Path == "/platform/1/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[{"code":"AEC_NOT_FOUND"}]}`))
+				return
+			}
+			w.Write([]byte(`{"settings":{"enabled":true}}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+	}
+	ctx := context.Background()
+
+	res, err := c.DoRaw(ctx, http.MethodPut, "platform/1/test", "",
+		OrderedValues{{[]byte("zone"), []byte("z1")}}, nil,
+		map[string]bool{"enabled": true})
+	assertNoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "PUT /platform/1/test/?zone=z1", res.Header.Get("X-Test"))
+	assert.JSONEq(t, `{"settings":{"enabled":true}}`, string(res.Body))
+
+	// error responses are returned, not converted to errors
+	res, err = c.DoRaw(ctx, http.MethodGet, "platform/1", "missing",
+		nil, nil, nil)
+	assertNoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.JSONEq(t, `{"errors":[{"code":"AEC_NOT_FOUND"}]}`, string(res.Body))
+}
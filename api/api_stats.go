@@ -0,0 +1,144 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// statsLatencySamples is the number of recent latencies per endpoint
+	// from which percentiles are computed.
+	statsLatencySamples = 128
+
+	// statsMaxEndpoints bounds the number of endpoints tracked, as paths
+	// may include names; requests to other endpoints are counted under
+	// statsOtherEndpoint.
+	statsMaxEndpoints  = 512
+	statsOtherEndpoint = "other"
+)
+
+// EndpointStats are the statistics of the requests a client made to an
+// endpoint, which is a method and path, ex. "GET platform/1/quota/quotas".
+type EndpointStats struct {
+	Endpoint string
+
+	// Requests is the number of requests made.
+	Requests int64
+
+	// Errors is the number of requests that failed or returned an error
+	// status.
+	Errors int64
+
+	// LastError is the error of the most recent failed request, and
+	// LastErrorTime when it failed.
+	LastError     string
+	LastErrorTime time.Time
+
+	// P50Latency and P95Latency are the median and 95th percentile latency
+	// of the most recent requests.
+	P50Latency time.Duration
+	P95Latency time.Duration
+}
+
+// endpointStats accumulates the statistics of an endpoint.
+type endpointStats struct {
+	EndpointStats
+	latencies []time.Duration // ring buffer of recent latencies
+	next      int
+}
+
+// clientStats is shared by a client and the clients derived from it.
+type clientStats struct {
+	sync.Mutex
+	endpoints map[string]*endpointStats
+}
+
+func newClientStats() *clientStats {
+	return &clientStats{endpoints: map[string]*endpointStats{}}
+}
+
+// endpointKey returns the endpoint of a request. The ID is not included, so
+// requests for different objects of a collection are counted together.
+func endpointKey(method, uri string) string {
+	return method + " " + strings.Trim(uri, "/")
+}
+
+func (s *clientStats) record(
+	method, uri string, latency time.Duration, err error) {
+
+	if s == nil {
+		return
+	}
+	key := endpointKey(method, uri)
+
+	s.Lock()
+	defer s.Unlock()
+	e, ok := s.endpoints[key]
+	if !ok {
+		if len(s.endpoints) >= statsMaxEndpoints {
+			key = statsOtherEndpoint
+			e = s.endpoints[key]
+		}
+		if e == nil {
+			e = &endpointStats{EndpointStats: EndpointStats{Endpoint: key}}
+			s.endpoints[key] = e
+		}
+	}
+
+	e.Requests++
+	if err != nil {
+		e.Errors++
+		e.LastError, e.LastErrorTime = err.Error(), time.Now()
+	}
+	if len(e.latencies) < statsLatencySamples {
+		e.latencies = append(e.latencies, latency)
+	} else {
+		e.latencies[e.next] = latency
+		e.next = (e.next + 1) % statsLatencySamples
+	}
+}
+
+// snapshot returns the statistics of each endpoint, sorted by endpoint.
+func (s *clientStats) snapshot() []EndpointStats {
+	if s == nil {
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	stats := make([]EndpointStats, 0, len(s.endpoints))
+	for _, e := range s.endpoints {
+		st := e.EndpointStats
+		sorted := append([]time.Duration(nil), e.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		st.P50Latency = percentile(sorted, 50)
+		st.P95Latency = percentile(sorted, 95)
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Endpoint < stats[j].Endpoint
+	})
+	return stats
+}
+
+// percentile returns the pth percentile of sorted latencies by the nearest
+// rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := (p*len(sorted)+99)/100 - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+// statusError is recorded for raw requests that return an error status.
+type statusError int
+
+func (e statusError) Error() string {
+	return http.StatusText(int(e))
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			if r.URL.Path == "/platform/1/test/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[{"code":"AEC_NOT_FOUND",` +
+					`"message":"not found"}]}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+		stats:    newClientStats(),
+	}
+	ctx := context.Background()
+	c.Get(ctx, "platform/1/test", "a", nil, nil, nil)
+	c.ForZone("zone1").Get(ctx, "/platform/1/test/", "b", nil, nil, nil)
+	c.Get(ctx, "platform/1/test", "missing", nil, nil, nil)
+	c.DoRaw(ctx, http.MethodGet, "platform/1/test", "missing", nil, nil, nil)
+	c.Put(ctx, "platform/1/test", "a", nil, nil, nil, nil)
+
+	stats := c.Stats()
+	assertLen(t, stats, 2)
+	get, put := stats[0], stats[1]
+	assert.Equal(t, "GET platform/1/test", get.Endpoint)
+	assert.Equal(t, int64(4), get.Requests)
+	assert.Equal(t, int64(2), get.Errors)
+	assert.Equal(t, "Not Found", get.LastError)
+	assert.False(t, get.LastErrorTime.IsZero())
+	assert.True(t, get.P95Latency >= get.P50Latency)
+	assert.Equal(t, "PUT platform/1/test", put.Endpoint)
+	assert.Equal(t, int64(0), put.Errors)
+}
+
+func TestClientStatsBounds(t *testing.T) {
+	s := newClientStats()
+	for i := 1; i <= 200; i++ {
+		s.record(http.MethodGet, "a", time.Duration(i)*time.Millisecond, nil)
+	}
+	stats := s.snapshot()
+	// percentiles are of the most recent 128 requests, 73 to 200ms
+	assert.Equal(t, 136*time.Millisecond, stats[0].P50Latency)
+	assert.Equal(t, 194*time.Millisecond, stats[0].P95Latency)
+
+	for i := 0; i < statsMaxEndpoints+10; i++ {
+		s.record(http.MethodGet, fmt.Sprint("path", i), 0, nil)
+	}
+	stats = s.snapshot()
+	assertLen(t, stats, statsMaxEndpoints+1)
+	var other EndpointStats
+	for _, st := range stats {
+		if st.Endpoint == statsOtherEndpoint {
+			other = st
+		}
+	}
+	assert.Equal(t, int64(11), other.Requests)
+
+	var nilStats *clientStats
+	nilStats.record(http.MethodGet, "a", 0, nil)
+	assert.Nil(t, nilStats.snapshot())
+}
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
 )
 
 func assertLen(t *testing.T, obj interface{}, expLen int) {
@@ -35,3 +40,117 @@ func assertNotNil(t *testing.T, i interface{}) {
 		t.FailNow()
 	}
 }
+
+func TestZoneQS(t *testing.T) {
+	var c Client = &client{}
+	assertNil(t, ZoneQS(c, ""))
+	qs := ZoneQS(c, "zone1")
+	assert.Equal(t, "zone=zone1", qs.Encode())
+
+	zc := c.ForZone("zone2")
+	assert.Equal(t, "zone2", zc.Zone())
+	assert.Equal(t, "", c.Zone())
+	qs = ZoneQS(zc, "")
+	assert.Equal(t, "zone=zone2", qs.Encode())
+	qs = ZoneQS(zc, "zone1")
+	assert.Equal(t, "zone=zone1", qs.Encode())
+}
+
+func TestSetPasswordSharedWithZoneClients(t *testing.T) {
+	c := &client{password: &secret{value: "old"}}
+	zc := c.ForZone("zone1")
+	zc.SetPassword("new")
+	assert.Equal(t, "new", c.password.get())
+}
+
+func TestBuildURL(t *testing.T) {
+	c := &client{hostname: "https://1.2.3.4:8080"}
+	assert.Equal(t,
+		"https://1.2.3.4:8080/platform/1/quota/quotas/",
+		c.buildURL("/platform/1/quota/quotas", "", nil))
+	assert.Equal(t,
+		"https://1.2.3.4:8080/platform/3/job/jobs/12",
+		c.buildURL("platform/3/job/jobs", "12", nil))
+	assert.Equal(t,
+		"https://1.2.3.4:8080/namespace/ifs/vol/?acl&zone=z+1",
+		c.buildURL("namespace/ifs/vol/", "",
+			OrderedValues{{[]byte("acl")}, {[]byte("zone"), []byte("z 1")}}))
+
+	c.hostname = "https://1.2.3.4:8080/"
+	assert.Equal(t, "https://1.2.3.4:8080/", c.buildURL("", "", nil))
+}
+
+func BenchmarkBuildURL(b *testing.B) {
+	c := &client{hostname: "https://1.2.3.4:8080"}
+	params := OrderedValues{
+		{[]byte("path"), []byte("/ifs/data/volumes/vol 1")},
+		{[]byte("zone"), []byte("System")},
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = c.buildURL("platform/1/quota/quotas", "", params)
+		}
+	})
+}
+
+func TestDecodeLargeNumbers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.Write([]byte(`{"id":9007199254740993,` +
+				`"size":9223372036854775807,"value":9007199254740993}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+	}
+	var resp struct {
+		ID    int64       `json:"id"`
+		Size  int64       `json:"size"`
+		Value interface{} `json:"value"`
+	}
+	assertNoError(t, c.Get(
+		context.Background(), "platform/1/test", "", nil, nil, &resp))
+	assert.Equal(t, int64(9007199254740993), resp.ID)
+	assert.Equal(t, int64(9223372036854775807), resp.Size)
+	assert.Equal(t, json.Number("9007199254740993"), resp.Value)
+}
+
+func TestZoneVolumesPath(t *testing.T) {
+	tests := []struct {
+		zonePath, volumesPath, want string
+	}{
+		{"/ifs", "/ifs/volumes", "/ifs/volumes"},
+		{"/ifs/zone1", "/ifs/volumes", "/ifs/zone1/volumes"},
+		{"/ifs/zone1/", "/ifs/zone1/csi", "/ifs/zone1/csi"},
+		{"/ifs/zone1", "/ifs/zone1", "/ifs/zone1"},
+		{"/ifs/zone1", "/ifs/zone10/volumes", "/ifs/zone1/zone10/volumes"},
+		{"/ifs/zone1", "/ifs", "/ifs/zone1"},
+		{"", "/ifs/volumes", "/ifs/volumes"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want,
+			ZoneVolumesPath(tt.zonePath, tt.volumesPath), "%v", tt)
+	}
+}
+
+func TestForZonePath(t *testing.T) {
+	c := &client{volumePath: "/ifs/volumes", baseVolumePath: "/ifs/volumes"}
+	zc := c.ForZonePath("zone1", "/ifs/zone1")
+	assert.Equal(t, "zone1", zc.Zone())
+	assert.Equal(t, "/ifs/zone1/volumes", zc.VolumesPath())
+	assert.Equal(t, "/ifs/zone1/volumes/v", zc.VolumePath("v"))
+	assert.Equal(t, "/ifs/volumes", c.VolumesPath())
+
+	// deriving from a zone client starts from the configured path
+	zc = zc.ForZonePath("zone2", "/ifs/zone2")
+	assert.Equal(t, "/ifs/zone2/volumes", zc.VolumesPath())
+	zc = zc.ForZone("System")
+	assert.Equal(t, "/ifs/volumes", zc.VolumesPath())
+}
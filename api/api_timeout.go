@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type timeoutKey struct{}
+
+// WithTimeout returns a copy of ctx that sets the time limit of the requests
+// made with it to d, overriding the client's Timeout, ex. to allow a
+// snapshot copy to run for hours while other calls fail in seconds. A zero
+// d means no limit. The limit applies to each request, including reading its
+// response.
+func WithTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// requestContext returns the context of a request, which is limited by the
+// timeout set with WithTimeout or, if there is none, the client's timeout,
+// as well as by any deadline of ctx, whichever is earlier.
+func (c *client) requestContext(
+	ctx context.Context) (context.Context, context.CancelFunc) {
+
+	d, ok := ctx.Value(timeoutKey{}).(time.Duration)
+	if !ok {
+		d = c.timeout
+	}
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// cancelBody is a response body that cancels the request's context once it
+// is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
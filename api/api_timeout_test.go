@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+			w.Header().Set(headerKeyContentType, headerValContentTypeJSON)
+			w.Write([]byte(`{}`))
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		password: &secret{},
+		auth:     BasicAuth{},
+		timeout:  10 * time.Millisecond,
+	}
+	ctx := context.Background()
+	var resp struct{}
+
+	err := c.Get(ctx, "platform/1/test", "", nil, nil, &resp)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// the per-call timeout overrides the client's
+	err = c.Get(WithTimeout(ctx, time.Second),
+		"platform/1/test", "", nil, nil, &resp)
+	assertNoError(t, err)
+	err = c.Get(WithTimeout(ctx, 0), "platform/1/test", "", nil, nil, &resp)
+	assertNoError(t, err)
+
+	// a later deadline on the context does not lift the client's timeout
+	dctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	err = c.Get(dctx, "platform/1/test", "", nil, nil, &resp)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// but an earlier one applies
+	c.timeout = time.Second
+	dctx, cancel = context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = c.Get(dctx, "platform/1/test", "", nil, nil, &resp)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// a shorter per-call timeout also applies
+	c.timeout = 0
+	err = c.Get(WithTimeout(ctx, 10*time.Millisecond),
+		"platform/1/test", "", nil, nil, &resp)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestSessionLoginTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusCreated)
+		}))
+	defer srv.Close()
+
+	c := &client{
+		http:     srv.Client(),
+		hostname: srv.URL,
+		username: "admin",
+		password: &secret{value: "password"},
+		auth:     &SessionAuth{},
+		timeout:  10 * time.Millisecond,
+	}
+	err := c.Get(context.Background(), "platform/1/test", "", nil, nil, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+}
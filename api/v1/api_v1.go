@@ -10,17 +10,40 @@ import (
 )
 
 const (
-	namespacePath       = "namespace"
-	exportsPath         = "platform/1/protocols/nfs/exports"
-	quotaPath           = "platform/1/quota/quotas"
-	snapshotsPath       = "platform/1/snapshot/snapshots"
-	volumesnapshotsPath = "/ifs/.snapshot"
+	namespacePath        = "namespace"
+	exportsPath          = "platform/1/protocols/nfs/exports"
+	quotaPath            = "platform/1/quota/quotas"
+	snapshotsPath        = "platform/1/snapshot/snapshots"
+	changelistsPath      = "platform/1/snapshot/changelists"
+	snapshotSettingsPath = "platform/1/snapshot/settings"
+	snapshotSummaryPath  = "platform/1/snapshot/snapshots-summary"
+	volumesnapshotsPath  = "/ifs/.snapshot"
+	dedupeSettingsPath   = "platform/1/dedupe/settings"
+	dedupeReportsPath    = "platform/1/dedupe/reports"
+	dedupeSummaryPath    = "platform/1/dedupe/dedupe-summary"
+	clusterEmailPath     = "platform/1/cluster/email"
+	clusterOwnerPath     = "platform/1/cluster/owner"
+	accessTimePath       = "platform/1/filesystem/settings/access-time"
+	filePoolPath         = "platform/1/filepool/policies"
+	statsCurrentPath     = "platform/1/statistics/current"
+	licensesPath         = "platform/1/license/licenses"
 )
 
 var (
 	debug, _ = strconv.ParseBool(os.Getenv("GOISILON_DEBUG"))
+
+	byteArrResume = []byte("resume")
 )
 
+// resumeQS returns the query string used to request the page of a
+// collection identified by a resume token.
+func resumeQS(resume string) api.OrderedValues {
+	if resume == "" {
+		return nil
+	}
+	return api.OrderedValues{{byteArrResume, []byte(resume)}}
+}
+
 func realNamespacePath(client api.Client) string {
 	return path.Join(namespacePath, client.VolumesPath())
 }
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiChangelist queries an individual changelist. The ID of the
+// changelist of two snapshots is "older_newer", ex. "12_34".
+func GetIsiChangelist(
+	ctx context.Context,
+	client api.Client,
+	id string) (*IsiChangelist, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/changelists/12_34
+	var resp IsiChangelistsResp
+	if err := client.Get(ctx, changelistsPath, id, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Changelists) == 0 {
+		return nil, errors.New("changelist missing from response")
+	}
+	return resp.Changelists[0], nil
+}
+
+// GetIsiChangelistEntriesPage queries a page of the entries of a changelist.
+// An empty resume token queries the first page, and the returned resume
+// token is empty after the last page.
+func GetIsiChangelistEntriesPage(
+	ctx context.Context,
+	client api.Client,
+	id, resume string) ([]*IsiChangelistEntry, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/changelists/12_34/lins?resume=token
+	var resp IsiChangelistEntriesResp
+	err := client.Get(
+		ctx, path.Join(changelistsPath, id, "lins"), "",
+		resumeQS(resume), nil, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Entries, resp.Resume, nil
+}
+
+// DeleteIsiChangelist deletes a changelist
+func DeleteIsiChangelist(
+	ctx context.Context,
+	client api.Client,
+	id string) error {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/1/snapshot/changelists/12_34
+	return client.Delete(ctx, changelistsPath, id, nil, nil, nil)
+}
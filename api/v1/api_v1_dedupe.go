@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiDedupeSettings queries the SmartDedupe settings
+func GetIsiDedupeSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiDedupeSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/dedupe/settings
+	var resp IsiDedupeSettingsResp
+	err = client.Get(ctx, dedupeSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("dedupe settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiDedupeSettings modifies the SmartDedupe settings
+func UpdateIsiDedupeSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiDedupeSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/dedupe/settings
+	//            Content-Type: application/json
+	//            {paths: ["/path/to/volume"], assess_paths: ["/path/to/volume"]}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, dedupeSettingsPath, "", nil, nil, settings, nil)
+}
+
+// GetIsiDedupeReports queries a list of all SmartDedupe reports
+func GetIsiDedupeReports(
+	ctx context.Context,
+	client api.Client) (reports []*IsiDedupeReport, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/dedupe/reports
+	var resp IsiDedupeReportsResp
+	err = client.Get(ctx, dedupeReportsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Reports, nil
+}
+
+// GetIsiDedupeReport queries an individual SmartDedupe report
+func GetIsiDedupeReport(
+	ctx context.Context,
+	client api.Client,
+	id string) (report *IsiDedupeReport, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/dedupe/reports/id
+	var resp IsiDedupeReportsResp
+	err = client.Get(ctx, dedupeReportsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Reports) == 0 {
+		return nil, errors.New("dedupe report missing from response")
+	}
+	return resp.Reports[0], nil
+}
+
+// GetIsiDedupeSummary queries the cluster-wide SmartDedupe savings
+func GetIsiDedupeSummary(
+	ctx context.Context,
+	client api.Client) (summary *IsiDedupeSummary, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/dedupe/dedupe-summary
+	var resp IsiDedupeSummaryResp
+	err = client.Get(ctx, dedupeSummaryPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Summary == nil {
+		return nil, errors.New("dedupe summary missing from response")
+	}
+	return resp.Summary, nil
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiEmailSettings queries the cluster email settings
+func GetIsiEmailSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiEmailSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/cluster/email
+	var resp IsiEmailSettingsResp
+	err = client.Get(ctx, clusterEmailPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("email settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiEmailSettings modifies the cluster email settings
+func UpdateIsiEmailSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiEmailSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/cluster/email
+	//            Content-Type: application/json
+	//            {mail_relay: "smtp.example.com", mail_sender: "isilon@example.com"}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, clusterEmailPath, "", nil, nil, settings, nil)
+}
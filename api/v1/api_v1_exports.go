@@ -28,7 +28,7 @@ func Export(
 	if group := client.Group(); group != "" {
 		data.MapAll.Groups = append(data.MapAll.Groups, group)
 	}
-	var resp *postIsiExportResp
+	var resp *IsiExportCreateResp
 
 	err = client.Post(ctx, exportsPath, "", nil, nil, data, &resp)
 
@@ -49,7 +49,7 @@ func SetExportClients(
 	//            {clients: ["client_ip_address"]}
 
 	var data = &ExportClientList{Clients: clients}
-	var resp *postIsiExportResp
+	var resp *IsiExportCreateResp
 
 	err = client.Put(ctx, exportsPath, strconv.Itoa(Id), nil, nil, data, &resp)
 
@@ -69,7 +69,7 @@ func Unexport(
 
 	exportPath := fmt.Sprintf("%s/%d", exportsPath, Id)
 
-	var resp postIsiExportResp
+	var resp IsiExportCreateResp
 	err = client.Delete(ctx, exportPath, "", nil, nil, &resp)
 
 	return err
@@ -78,7 +78,7 @@ func Unexport(
 // GetIsiExports queries a list of all exports on the cluster
 func GetIsiExports(
 	ctx context.Context,
-	client api.Client) (resp *getIsiExportsResp, err error) {
+	client api.Client) (resp *IsiExportsResp, err error) {
 
 	// PAPI call: GET https://1.2.3.4:8080/platform/1/protocols/nfs/exports
 	err = client.Get(ctx, exportsPath, "", nil, nil, &resp)
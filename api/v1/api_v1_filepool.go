@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiFilePoolPolicies queries all file pool policies
+func GetIsiFilePoolPolicies(
+	ctx context.Context,
+	client api.Client) (policies []*IsiFilePoolPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/filepool/policies
+	var resp IsiFilePoolPoliciesResp
+	err = client.Get(ctx, filePoolPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Policies, nil
+}
+
+// GetIsiFilePoolPolicy queries an individual file pool policy by name
+func GetIsiFilePoolPolicy(
+	ctx context.Context,
+	client api.Client,
+	name string) (policy *IsiFilePoolPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/filepool/policies/name
+	if name == "" {
+		return nil, errors.New("no policy name set")
+	}
+
+	var resp IsiFilePoolPoliciesResp
+	err = client.Get(ctx, filePoolPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Policies) == 0 {
+		return nil, errors.New("policy missing from response")
+	}
+	return resp.Policies[0], nil
+}
+
+// CreateIsiFilePoolPolicy creates a file pool policy
+func CreateIsiFilePoolPolicy(
+	ctx context.Context,
+	client api.Client,
+	policy *IsiFilePoolPolicy) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/1/filepool/policies
+	//            Content-Type: application/json
+	//            {name: "policy1",
+	//             file_matching_pattern: {or_criteria: [{and_criteria: [
+	//                 {type: "path", operator: "==", value: "/ifs/data",
+	//                  begins_with: true}]}]},
+	//             actions: [{action_type: "set_requested_protection",
+	//                        action_param: "+2d:1n"}]}
+	if policy == nil || policy.Name == nil || *policy.Name == "" {
+		return errors.New("no policy name set")
+	}
+	return client.Post(ctx, filePoolPath, "", nil, nil, policy, nil)
+}
+
+// UpdateIsiFilePoolPolicy modifies a file pool policy. Only the fields that
+// are set are changed.
+func UpdateIsiFilePoolPolicy(
+	ctx context.Context,
+	client api.Client,
+	name string, policy *IsiFilePoolPolicy) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/filepool/policies/name
+	//            Content-Type: application/json
+	//            {actions: [{action_type: "set_requested_protection",
+	//                        action_param: "+3"}]}
+	if name == "" {
+		return errors.New("no policy name set")
+	}
+	if policy == nil {
+		return errors.New("no policy set")
+	}
+	return client.Put(ctx, filePoolPath, name, nil, nil, policy, nil)
+}
+
+// DeleteIsiFilePoolPolicy deletes a file pool policy
+func DeleteIsiFilePoolPolicy(
+	ctx context.Context,
+	client api.Client,
+	name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/1/filepool/policies/name
+	if name == "" {
+		return errors.New("no policy name set")
+	}
+	return client.Delete(ctx, filePoolPath, name, nil, nil, nil)
+}
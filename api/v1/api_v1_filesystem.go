@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiAccessTimeSettings queries the access time tracking settings
+func GetIsiAccessTimeSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiAccessTimeSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/filesystem/settings/access-time
+	var resp IsiAccessTimeSettingsResp
+	err = client.Get(ctx, accessTimePath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("access time settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiAccessTimeSettings modifies the access time tracking settings
+func UpdateIsiAccessTimeSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiAccessTimeSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/filesystem/settings/access-time
+	//            Content-Type: application/json
+	//            {enabled: true, precision: 86400}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, accessTimePath, "", nil, nil, settings, nil)
+}
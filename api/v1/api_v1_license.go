@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiLicenses queries a list of all licenses and their status
+func GetIsiLicenses(
+	ctx context.Context,
+	client api.Client) (licenses []*IsiLicense, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/license/licenses
+	var resp IsiLicensesResp
+	err = client.Get(ctx, licensesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Licenses, nil
+}
+
+// GetIsiLicense queries an individual license by name
+func GetIsiLicense(
+	ctx context.Context,
+	client api.Client,
+	name string) (license *IsiLicense, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/license/licenses/name
+	var resp IsiLicensesResp
+	err = client.Get(ctx, licensesPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Licenses) == 0 {
+		return nil, errors.New("license missing from response")
+	}
+	return resp.Licenses[0], nil
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiClusterOwner queries the cluster owner and contact information
+func GetIsiClusterOwner(
+	ctx context.Context,
+	client api.Client) (owner *IsiClusterOwner, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/cluster/owner
+	var resp IsiClusterOwnerResp
+	err = client.Get(ctx, clusterOwnerPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Owner == nil {
+		return nil, errors.New("cluster owner missing from response")
+	}
+	return resp.Owner, nil
+}
+
+// UpdateIsiClusterOwner modifies the cluster owner and contact information
+func UpdateIsiClusterOwner(
+	ctx context.Context,
+	client api.Client,
+	owner *IsiClusterOwner) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/cluster/owner
+	//            Content-Type: application/json
+	//            {company: "Example Inc.", primary_email: "storage@example.com"}
+	if owner == nil {
+		return errors.New("no owner set")
+	}
+	return client.Put(ctx, clusterOwnerPath, "", nil, nil, owner, nil)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/tenortim/goisilon/api"
 )
 
-// GetIsiQuota queries the quota for a directory
+// GetIsiQuota queries the directory quota for a directory that excludes
+// snapshots, as created by CreateIsiQuota. A directory may also have a quota
+// that includes snapshots, which is queried by GetIsiQuotaWithSnapshots.
 func GetIsiQuota(
 	ctx context.Context,
 	client api.Client,
@@ -16,21 +19,150 @@ func GetIsiQuota(
 
 	// PAPI call: GET https://1.2.3.4:8080/platform/1/quota/quotas?path=path
 	// This will list quotas matching path on the cluster
+	return GetIsiQuotaWithSnapshots(ctx, client, path, false)
+}
+
+func quotaNotFound(path string) error {
+	return &api.JSONError{
+		StatusCode: http.StatusNotFound,
+		Err: []api.Error{{
+			Code:    "AEC_NOT_FOUND",
+			Message: fmt.Sprintf("Quota not found: %s", path),
+		}},
+	}
+}
 
-	var quotaResp isiQuotaListResp
-	err = client.Get(ctx, quotaPath, "", api.OrderedValues{{byteArrPath, []byte(path)}}, nil, &quotaResp)
+// GetIsiQuotas queries all directory quotas for a directory, such as a quota
+// that includes snapshots and one that does not
+func GetIsiQuotas(
+	ctx context.Context,
+	client api.Client,
+	path string) ([]*IsiQuota, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/quota/quotas?path=path
+
+	var quotaResp IsiQuotasResp
+	err := client.Get(ctx, quotaPath, "", api.OrderedValues{{byteArrPath, []byte(path)}}, nil, &quotaResp)
 	if err != nil {
 		return nil, err
 	}
 
-	// find the specific quota we are looking for
-	for _, quota := range quotaResp.Quotas {
-		if quota.Path == path {
-			return &quota, nil
+	var quotas []*IsiQuota
+	for i, quota := range quotaResp.Quotas {
+		if quota.Path == path && quota.Type == "directory" {
+			quotas = append(quotas, &quotaResp.Quotas[i])
+		}
+	}
+	if len(quotas) == 0 {
+		return nil, quotaNotFound(path)
+	}
+	return quotas, nil
+}
+
+// GetIsiQuotaWithSnapshots queries the quota for a directory that includes
+// or excludes snapshots
+func GetIsiQuotaWithSnapshots(
+	ctx context.Context,
+	client api.Client,
+	path string, includeSnapshots bool) (*IsiQuota, error) {
+
+	quotas, err := GetIsiQuotas(ctx, client, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		if quota.IncludeSnapshots == includeSnapshots {
+			return quota, nil
+		}
+	}
+	return nil, quotaNotFound(path)
+}
+
+// GetIsiQuotasPage queries a page of quotas on the cluster. An empty resume
+// token queries the first page, and the returned resume token is empty after
+// the last page.
+func GetIsiQuotasPage(
+	ctx context.Context,
+	client api.Client,
+	resume string) ([]*IsiQuota, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/quota/quotas?resume=token
+
+	var resp IsiQuotasResp
+	err := client.Get(ctx, quotaPath, "", resumeQS(resume), nil, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	quotas := make([]*IsiQuota, len(resp.Quotas))
+	for i := range resp.Quotas {
+		quotas[i] = &resp.Quotas[i]
+	}
+	return quotas, resp.Resume, nil
+}
+
+var (
+	byteArrRecursePathChildren = []byte("recurse_path_children")
+	byteArrType                = []byte("type")
+	byteArrTrue                = []byte("true")
+	byteArrDirectory           = []byte("directory")
+)
+
+// GetIsiDirectoryQuotasPage queries a page of the directory quotas on a path
+// and its descendants. An empty resume token queries the first page, and the
+// returned resume token is empty after the last page.
+func GetIsiDirectoryQuotasPage(
+	ctx context.Context,
+	client api.Client,
+	path, resume string) ([]*IsiQuota, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/quota/quotas?path=path&recurse_path_children=true&type=directory
+
+	qs := resumeQS(resume)
+	if resume == "" {
+		qs = api.OrderedValues{
+			{byteArrPath, []byte(path)},
+			{byteArrRecursePathChildren, byteArrTrue},
+			{byteArrType, byteArrDirectory},
 		}
 	}
 
-	return nil, errors.New(fmt.Sprintf("Quota not found: %s", path))
+	var resp IsiQuotasResp
+	if err := client.Get(ctx, quotaPath, "", qs, nil, &resp); err != nil {
+		return nil, "", err
+	}
+	quotas := make([]*IsiQuota, len(resp.Quotas))
+	for i := range resp.Quotas {
+		quotas[i] = &resp.Quotas[i]
+	}
+	return quotas, resp.Resume, nil
+}
+
+var byteArrExceeded = []byte("exceeded")
+
+// GetIsiExceededQuotasPage queries a page of the quotas with an exceeded
+// threshold. An empty resume token queries the first page, and the returned
+// resume token is empty after the last page.
+func GetIsiExceededQuotasPage(
+	ctx context.Context,
+	client api.Client,
+	resume string) ([]*IsiQuota, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/quota/quotas?exceeded=true
+
+	qs := resumeQS(resume)
+	if resume == "" {
+		qs = api.OrderedValues{{byteArrExceeded, byteArrTrue}}
+	}
+
+	var resp IsiQuotasResp
+	if err := client.Get(ctx, quotaPath, "", qs, nil, &resp); err != nil {
+		return nil, "", err
+	}
+	quotas := make([]*IsiQuota, len(resp.Quotas))
+	for i := range resp.Quotas {
+		quotas[i] = &resp.Quotas[i]
+	}
+	return quotas, resp.Resume, nil
 }
 
 // TODO: Add a means to set/update more than just the hard threshold
@@ -41,6 +173,22 @@ func CreateIsiQuota(
 	client api.Client,
 	path string, container bool, size int64) (err error) {
 
+	return CreateIsiQuotaWithOptions(ctx, client, path, container, size, nil)
+}
+
+// CreateIsiQuotaWithOptions creates a hard directory quota on given path
+// with the given accounting options. Nil options create a quota that
+// excludes snapshots and overhead.
+func CreateIsiQuotaWithOptions(
+	ctx context.Context,
+	client api.Client,
+	path string, container bool, size int64,
+	opts *IsiQuotaOptions) (err error) {
+
+	if opts == nil {
+		opts = &IsiQuotaOptions{}
+	}
+
 	// PAPI call: POST https://1.2.3.4:8080/platform/1/quota/quotas
 	//             { "enforced" : true,
 	//               "include_snapshots" : false,
@@ -55,12 +203,12 @@ func CreateIsiQuota(
 	//             }
 	var data = &IsiQuotaReq{
 		Enforced:                  true,
-		IncludeSnapshots:          false,
+		IncludeSnapshots:          opts.IncludeSnapshots,
 		Path:                      path,
 		Container:                 container,
-		ThresholdsIncludeOverhead: false,
+		ThresholdsIncludeOverhead: opts.ThresholdsIncludeOverhead,
 		Type:                      "directory",
-		Thresholds:                isiThresholdsReq{Advisory: nil, Hard: size, Soft: nil},
+		Thresholds:                IsiThresholdsReq{Advisory: nil, Hard: size, Soft: nil},
 	}
 
 	var quotaResp IsiQuota
@@ -68,6 +216,21 @@ func CreateIsiQuota(
 	return err
 }
 
+// CreateIsiQuotaFromReq creates a quota as described by a request, ex. to
+// recreate a quota with all of its thresholds
+func CreateIsiQuotaFromReq(
+	ctx context.Context,
+	client api.Client,
+	data *IsiQuotaReq) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/1/quota/quotas
+	if data == nil || data.Path == "" {
+		return errors.New("no quota path set")
+	}
+	var quotaResp IsiQuota
+	return client.Post(ctx, quotaPath, "", nil, nil, data, &quotaResp)
+}
+
 // SetIsiQuotaHardThreshold sets the hard threshold of a quota for a directory
 // This is really just CreateIsiQuota() with container set to false
 func SetIsiQuotaHardThreshold(
@@ -92,38 +255,113 @@ func UpdateIsiQuotaHardThreshold(
 	//                                "soft" : null
 	//                              }
 	//             }
+	quota, err := GetIsiQuota(ctx, client, path)
+	if err != nil {
+		return err
+	}
+
+	// the quota's accounting is kept
 	var data = &IsiUpdateQuotaReq{
 		Enforced:                  true,
-		ThresholdsIncludeOverhead: false,
-		Thresholds:                isiThresholdsReq{Advisory: nil, Hard: size, Soft: nil},
+		ThresholdsIncludeOverhead: quota.ThresholdsIncludeOverhead,
+		Thresholds:                IsiThresholdsReq{Advisory: nil, Hard: size, Soft: nil},
 	}
 
+	var quotaResp IsiQuota
+	err = client.Put(ctx, quotaPath, quota.Id, nil, nil, data, &quotaResp)
+	return err
+}
+
+// UpdateIsiQuotaThresholds modifies the thresholds of a quota for a
+// directory. The quota's enforcement and accounting are kept.
+func UpdateIsiQuotaThresholds(
+	ctx context.Context,
+	client api.Client,
+	path string, thresholds IsiThresholdsReq) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/quota/quotas/Id
+	//             { "enforced" : true,
+	//               "thresholds_include_overhead" : false,
+	//               "thresholds" : { "advisory" : 1000000000,
+	//                                "hard" : 1234567890,
+	//                                "soft" : 1100000000,
+	//                                "soft_grace" : 604800
+	//                              }
+	//             }
 	quota, err := GetIsiQuota(ctx, client, path)
 	if err != nil {
 		return err
 	}
 
-	var quotaResp IsiQuota
-	err = client.Put(ctx, quotaPath, quota.Id, nil, nil, data, &quotaResp)
-	return err
+	var data = &IsiUpdateQuotaReq{
+		Enforced:                  quota.Enforced,
+		ThresholdsIncludeOverhead: quota.ThresholdsIncludeOverhead,
+		Thresholds:                thresholds,
+	}
+	return client.Put(ctx, quotaPath, quota.Id, nil, nil, data, nil)
+}
+
+// UpdateIsiQuotaAccounting sets whether the thresholds of a quota apply to
+// its physical usage, including protection overhead
+func UpdateIsiQuotaAccounting(
+	ctx context.Context,
+	client api.Client,
+	id string, includeOverhead bool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/quota/quotas/Id
+	//             { "thresholds_include_overhead" : true }
+	var data = &IsiUpdateQuotaAccountingReq{
+		ThresholdsIncludeOverhead: includeOverhead,
+	}
+	return client.Put(ctx, quotaPath, id, nil, nil, data, nil)
+}
+
+// UpdateIsiQuotaFlags sets the container and enforced flags of a quota in
+// place. Nil flags are not changed.
+func UpdateIsiQuotaFlags(
+	ctx context.Context,
+	client api.Client,
+	id string, container, enforced *bool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/quota/quotas/Id
+	//             { "container" : true,
+	//               "enforced" : true
+	//             }
+	if container == nil && enforced == nil {
+		return errors.New("no quota flags set")
+	}
+	var data = &IsiUpdateQuotaFlagsReq{
+		Container: container,
+		Enforced:  enforced,
+	}
+	return client.Put(ctx, quotaPath, id, nil, nil, data, nil)
 }
 
 var byteArrPath = []byte("path")
 
-// DeleteIsiQuota removes the quota for a directory
+// DeleteIsiQuota removes the directory quota for a directory that excludes
+// snapshots. A quota that includes snapshots is kept.
 func DeleteIsiQuota(
 	ctx context.Context,
 	client api.Client,
 	path string) (err error) {
 
-	// PAPI call: DELETE https://1.2.3.4:8080/platform/1/quota/quotas?path=/path/to/volume
-	// This will remove a the quota on a volume
+	quota, err := GetIsiQuota(ctx, client, path)
+	if err != nil {
+		return err
+	}
+	return DeleteIsiQuotaByID(ctx, client, quota.Id)
+}
+
+// DeleteIsiQuotaByID removes a quota by its id
+func DeleteIsiQuotaByID(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
 
-	return client.Delete(
-		ctx,
-		quotaPath,
-		"",
-		api.OrderedValues{{byteArrPath, []byte(path)}},
-		nil,
-		nil)
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/1/quota/quotas/Id
+	if id == "" {
+		return errors.New("no quota id set")
+	}
+	return client.Delete(ctx, quotaPath, id, nil, nil, nil)
 }
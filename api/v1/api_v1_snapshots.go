@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"path"
 
 	"github.com/tenortim/goisilon/api"
@@ -12,7 +14,7 @@ import (
 // GetIsiSnapshots queries a list of all snapshots on the cluster
 func GetIsiSnapshots(
 	ctx context.Context,
-	client api.Client) (resp *getIsiSnapshotsResp, err error) {
+	client api.Client) (resp *IsiSnapshotsResp, err error) {
 	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/snapshots
 	err = client.Get(ctx, snapshotsPath, "", nil, nil, &resp)
 	if err != nil {
@@ -21,6 +23,22 @@ func GetIsiSnapshots(
 	return resp, nil
 }
 
+// GetIsiSnapshotsPage queries a page of snapshots on the cluster. An empty
+// resume token queries the first page, and the returned resume token is
+// empty after the last page.
+func GetIsiSnapshotsPage(
+	ctx context.Context,
+	client api.Client,
+	resume string) ([]*IsiSnapshot, string, error) {
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/snapshots?resume=token
+	var resp IsiSnapshotsResp
+	err := client.Get(ctx, snapshotsPath, "", resumeQS(resume), nil, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.SnapshotList, resp.Resume, nil
+}
+
 // GetIsiSnapshot queries an individual snapshot on the cluster
 func GetIsiSnapshot(
 	ctx context.Context,
@@ -28,7 +46,7 @@ func GetIsiSnapshot(
 	id int64) (*IsiSnapshot, error) {
 	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/snapshots/123
 	snapshotUrl := fmt.Sprintf("%s/%d", snapshotsPath, id)
-	var resp *getIsiSnapshotsResp
+	var resp *IsiSnapshotsResp
 	err := client.Get(ctx, snapshotUrl, "", nil, nil, &resp)
 	if err != nil {
 		return nil, err
@@ -39,6 +57,55 @@ func GetIsiSnapshot(
 	return resp.SnapshotList[0], nil
 }
 
+// GetIsiSnapshotByName queries an individual snapshot on the cluster by name
+func GetIsiSnapshotByName(
+	ctx context.Context,
+	client api.Client,
+	name string) (*IsiSnapshot, error) {
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/snapshots/name
+	if name == "" {
+		return nil, errors.New("no snapshot name set")
+	}
+	var resp *IsiSnapshotsResp
+	err := client.Get(
+		ctx, snapshotsPath, url.PathEscape(name), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.SnapshotList) > 0 && resp.SnapshotList[0].Name == name {
+		return resp.SnapshotList[0], nil
+	}
+
+	// a numeric name is looked up as an id, so the snapshot found may not be
+	// the one with the name; fall back to searching the snapshots for it
+	var resume string
+	for {
+		snapshots, next, err := GetIsiSnapshotsPage(ctx, client, resume)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range snapshots {
+			if s.Name == name {
+				return s, nil
+			}
+		}
+		if next == "" {
+			return nil, snapshotNotFound(name)
+		}
+		resume = next
+	}
+}
+
+func snapshotNotFound(name string) error {
+	return &api.JSONError{
+		StatusCode: http.StatusNotFound,
+		Err: []api.Error{{
+			Code:    "AEC_NOT_FOUND",
+			Message: fmt.Sprintf("Snapshot not found: %s", name),
+		}},
+	}
+}
+
 // CreateIsiSnapshot makes a new snapshot on the cluster
 func CreateIsiSnapshot(
 	ctx context.Context,
@@ -97,3 +164,53 @@ func RemoveIsiSnapshot(
 
 	return err
 }
+
+// GetIsiSnapshotSettings queries the cluster's snapshot settings
+func GetIsiSnapshotSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiSnapshotSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/settings
+	var resp IsiSnapshotSettingsResp
+	err = client.Get(ctx, snapshotSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("snapshot settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiSnapshotSettings modifies the cluster's snapshot settings
+func UpdateIsiSnapshotSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiSnapshotSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/1/snapshot/settings
+	//            Content-Type: application/json
+	//            {reserve: 10}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, snapshotSettingsPath, "", nil, nil, settings, nil)
+}
+
+// GetIsiSnapshotSummary queries the number and size of the cluster's
+// snapshots
+func GetIsiSnapshotSummary(
+	ctx context.Context,
+	client api.Client) (summary *IsiSnapshotSummary, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/snapshot/snapshots-summary
+	var resp IsiSnapshotSummaryResp
+	err = client.Get(ctx, snapshotSummaryPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Summary == nil {
+		return nil, errors.New("snapshot summary missing from response")
+	}
+	return resp.Summary, nil
+}
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// GetIsiStatsCurrent queries the current values of statistics keys. Devid
+// selects the nodes, ex. "all", "0" for the cluster, or "1,2"; if empty the
+// cluster values are returned.
+func GetIsiStatsCurrent(
+	ctx context.Context,
+	client api.Client,
+	keys []string, devid string) (stats []*IsiStat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/1/statistics/current?keys=key1,key2&devid=all
+	if len(keys) == 0 {
+		return nil, errors.New("no keys set")
+	}
+	qs := api.OrderedValues{{[]byte("keys"), []byte(strings.Join(keys, ","))}}
+	if devid != "" {
+		qs = append(qs, [][]byte{[]byte("devid"), []byte(devid)})
+	}
+
+	var resp IsiStatsResp
+	err = client.Get(ctx, statsCurrentPath, "", qs, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Stats, nil
+}
@@ -1,11 +1,15 @@
 package v1
 
+// IsiVolume is a volume and its attributes.
 type IsiVolume struct {
-	Name         string `json:"name"`
-	AttributeMap []struct {
-		Name  string      `json:"name"`
-		Value interface{} `json:"value"`
-	} `json:"attrs"`
+	Name         string               `json:"name"`
+	AttributeMap []IsiVolumeAttribute `json:"attrs"`
+}
+
+// IsiVolumeAttribute is a namespace attribute of a volume.
+type IsiVolumeAttribute struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
 }
 
 // Isi PAPI volume JSON structs
@@ -13,8 +17,11 @@ type VolumeName struct {
 	Name string `json:"name"`
 }
 
-type getIsiVolumesResp struct {
+// IsiVolumesResp is the response to a volume listing. Resume is set if there
+// are more volumes to list.
+type IsiVolumesResp struct {
 	Children []*VolumeName `json:"children"`
+	Resume   string        `json:"resume,omitempty"`
 }
 
 // Isi PAPI Volume ACL JSON structs
@@ -31,11 +38,9 @@ type AclRequest struct {
 }
 
 // Isi PAPI volume attributes JSON struct
-type getIsiVolumeAttributesResp struct {
-	AttributeMap []struct {
-		Name  string      `json:"name"`
-		Value interface{} `json:"value"`
-	} `json:"attrs"`
+// IsiVolumeAttributesResp is the response to a volume attributes query.
+type IsiVolumeAttributesResp struct {
+	AttributeMap []IsiVolumeAttribute `json:"attrs"`
 }
 
 // Isi PAPI export path JSON struct
@@ -53,7 +58,8 @@ type ExportClientList struct {
 }
 
 // Isi PAPI export Id JSON struct
-type postIsiExportResp struct {
+// IsiExportCreateResp is the response to an export creation.
+type IsiExportCreateResp struct {
 	Id int `json:"id"`
 }
 
@@ -64,7 +70,8 @@ type IsiExport struct {
 	Clients []string `json:"clients"`
 }
 
-type getIsiExportsResp struct {
+// IsiExportsResp is the response to an export listing.
+type IsiExportsResp struct {
 	ExportList []*IsiExport `json:"exports"`
 }
 
@@ -88,17 +95,105 @@ type IsiSnapshot struct {
 	ShadowBytes   int64   `json:"shadow_bytes"`
 	Size          int64   `json:"size"`
 	State         string  `json:"state"`
-	TargetId      int64   `json:"target_it"`
+	TargetId      int64   `json:"target_id"`
 	TargetName    string  `json:"target_name"`
 }
 
-type getIsiSnapshotsResp struct {
+// IsiSnapshotsResp is the response to a snapshot listing. Resume is set if
+// there are more snapshots to list.
+type IsiSnapshotsResp struct {
 	SnapshotList []*IsiSnapshot `json:"snapshots"`
 	Total        int64          `json:"total"`
 	Resume       string         `json:"resume"`
 }
 
-type isiThresholds struct {
+// IsiSnapshotSettings are the cluster's snapshot settings. Reserve is the
+// percentage of the file system reserved for snapshots.
+type IsiSnapshotSettings struct {
+	Reserve    *int  `json:"reserve,omitempty"`
+	Service    *bool `json:"service,omitempty"`
+	AutoCreate *bool `json:"autocreate,omitempty"`
+	AutoDelete *bool `json:"autodelete,omitempty"`
+}
+
+// IsiSnapshotSettingsResp is the response to a snapshot settings query.
+type IsiSnapshotSettingsResp struct {
+	Settings *IsiSnapshotSettings `json:"settings"`
+}
+
+// IsiSnapshotSummary is the number and size of the cluster's snapshots.
+// Sizes are in bytes.
+type IsiSnapshotSummary struct {
+	Count         int64 `json:"count"`
+	ActiveCount   int64 `json:"active_count"`
+	ActiveSize    int64 `json:"active_size"`
+	DeletingCount int64 `json:"deleting_count"`
+	DeletingSize  int64 `json:"deleting_size"`
+	AliasesCount  int64 `json:"aliases_count"`
+	ShadowBytes   int64 `json:"shadow_bytes"`
+	Size          int64 `json:"size"`
+}
+
+// IsiSnapshotSummaryResp is the response to a snapshot summary query.
+type IsiSnapshotSummaryResp struct {
+	Summary *IsiSnapshotSummary `json:"summary"`
+}
+
+// IsiChangelist is the list of the differences between two snapshots, which
+// is created by a ChangelistCreate job.
+type IsiChangelist struct {
+	ID         string `json:"id"`
+	JobID      int64  `json:"job_id"`
+	NumEntries int64  `json:"num_entries"`
+	RootPath   string `json:"root_path"`
+	Snap1      int64  `json:"snap1"`
+	Snap2      int64  `json:"snap2"`
+	Status     string `json:"status"`
+}
+
+// IsiChangelistsResp is the response to a changelist query.
+type IsiChangelistsResp struct {
+	Changelists []*IsiChangelist `json:"changelists"`
+	Total       int64            `json:"total"`
+	Resume      string           `json:"resume"`
+}
+
+// IsiChangelistEntry is a file or directory that changed between the
+// snapshots of a changelist. Times are in seconds and nanoseconds since the
+// epoch.
+type IsiChangelistEntry struct {
+	ID           int64    `json:"id"`
+	LIN          int64    `json:"lin"`
+	ParentLIN    int64    `json:"parent_lin"`
+	Path         string   `json:"path"`
+	Type         string   `json:"type"`
+	ChangeTypes  []string `json:"change_types"`
+	Size         int64    `json:"size"`
+	DataSize     int64    `json:"data_size"`
+	PhysicalSize int64    `json:"physical_size"`
+	Mode         string   `json:"mode"`
+	User         int64    `json:"user"`
+	Group        int64    `json:"group"`
+	Atime        int64    `json:"atime"`
+	Atimensec    int64    `json:"atimensec"`
+	Btime        int64    `json:"btime"`
+	Btimensec    int64    `json:"btimensec"`
+	Ctime        int64    `json:"ctime"`
+	Ctimensec    int64    `json:"ctimensec"`
+	Mtime        int64    `json:"mtime"`
+	Mtimensec    int64    `json:"mtimensec"`
+}
+
+// IsiChangelistEntriesResp is the response to a changelist entry listing.
+// Resume is set if there are more entries to list.
+type IsiChangelistEntriesResp struct {
+	Entries []*IsiChangelistEntry `json:"lins"`
+	Total   int64                 `json:"total"`
+	Resume  string                `json:"resume"`
+}
+
+// IsiThresholds are the thresholds of a quota and whether they are exceeded.
+type IsiThresholds struct {
 	Advisory             int64       `json:"advisory"`
 	AdvisoryExceeded     bool        `json:"advisory_exceeded"`
 	AdvisoryLastExceeded interface{} `json:"advisory_last_exceeded"`
@@ -107,9 +202,11 @@ type isiThresholds struct {
 	HardLastExceeded     interface{} `json:"hard_last_exceeded"`
 	Soft                 int64       `json:"soft"`
 	SoftExceeded         bool        `json:"soft_exceeded"`
+	SoftGrace            int64       `json:"soft_grace"`
 	SoftLastExceeded     interface{} `json:"soft_last_exceeded"`
 }
 
+// IsiQuota is a filesystem quota.
 type IsiQuota struct {
 	Container                 bool          `json:"container"`
 	Enforced                  bool          `json:"enforced"`
@@ -120,38 +217,257 @@ type IsiQuota struct {
 	Path                      string        `json:"path"`
 	Persona                   interface{}   `json:"persona"`
 	Ready                     bool          `json:"ready"`
-	Thresholds                isiThresholds `json:"thresholds"`
+	Thresholds                IsiThresholds `json:"thresholds"`
 	ThresholdsIncludeOverhead bool          `json:"thresholds_include_overhead"`
 	Type                      string        `json:"type"`
-	Usage                     struct {
-		Inodes   int64 `json:"inodes"`
-		Logical  int64 `json:"logical"`
-		Physical int64 `json:"physical"`
-	} `json:"usage"`
+	Usage                     IsiQuotaUsage `json:"usage"`
+}
+
+// IsiQuotaUsage is the usage accounted for by a quota.
+type IsiQuotaUsage struct {
+	Inodes   int64 `json:"inodes"`
+	Logical  int64 `json:"logical"`
+	Physical int64 `json:"physical"`
 }
 
-type isiThresholdsReq struct {
+// IsiThresholdsReq are the thresholds set when creating or updating a quota.
+type IsiThresholdsReq struct {
 	Advisory interface{} `json:"advisory"`
 	Hard     interface{} `json:"hard"`
 	Soft     interface{} `json:"soft"`
+
+	// SoftGrace is the time in seconds that the soft threshold may be
+	// exceeded for. It must be set with the soft threshold.
+	SoftGrace interface{} `json:"soft_grace,omitempty"`
 }
 
+// IsiQuotaReq is used to create a quota.
 type IsiQuotaReq struct {
 	Enforced                  bool             `json:"enforced"`
 	IncludeSnapshots          bool             `json:"include_snapshots"`
 	Path                      string           `json:"path"`
-	Thresholds                isiThresholdsReq `json:"thresholds"`
+	Thresholds                IsiThresholdsReq `json:"thresholds"`
 	ThresholdsIncludeOverhead bool             `json:"thresholds_include_overhead"`
 	Type                      string           `json:"type"`
 	Container                 bool             `json:"container"`
 }
 
+// IsiUpdateQuotaReq is used to update a quota.
 type IsiUpdateQuotaReq struct {
 	Enforced                  bool             `json:"enforced"`
-	Thresholds                isiThresholdsReq `json:"thresholds"`
+	Thresholds                IsiThresholdsReq `json:"thresholds"`
 	ThresholdsIncludeOverhead bool             `json:"thresholds_include_overhead"`
 }
 
-type isiQuotaListResp struct {
+// IsiQuotaOptions are the accounting options of a quota.
+type IsiQuotaOptions struct {
+
+	// IncludeSnapshots counts the data held in snapshots against the quota.
+	// It cannot be changed once the quota is created.
+	IncludeSnapshots bool
+
+	// ThresholdsIncludeOverhead applies the thresholds to the physical
+	// usage, which includes protection overhead, rather than the logical
+	// usage.
+	ThresholdsIncludeOverhead bool
+}
+
+// IsiUpdateQuotaAccountingReq is used to update the accounting of a quota.
+type IsiUpdateQuotaAccountingReq struct {
+	ThresholdsIncludeOverhead bool `json:"thresholds_include_overhead"`
+}
+
+// IsiUpdateQuotaFlagsReq is used to update the container and enforced flags
+// of a quota. Unset flags are not changed.
+type IsiUpdateQuotaFlagsReq struct {
+	Container *bool `json:"container,omitempty"`
+	Enforced  *bool `json:"enforced,omitempty"`
+}
+
+// IsiQuotasResp is the response to a quota listing. Resume is set if there
+// are more quotas to list.
+type IsiQuotasResp struct {
 	Quotas []IsiQuota `json:"quotas"`
+	Resume string     `json:"resume,omitempty"`
+}
+
+// Isi PAPI dedupe settings JSON structs
+type IsiDedupeSettings struct {
+	AssessPaths *[]string `json:"assess_paths,omitempty"`
+	Paths       *[]string `json:"paths,omitempty"`
+}
+
+// IsiDedupeSettingsResp is the response to a dedupe settings query.
+type IsiDedupeSettingsResp struct {
+	Settings *IsiDedupeSettings `json:"settings"`
+}
+
+// Isi PAPI dedupe report JSON structs
+type IsiDedupeReport struct {
+	Id      string `json:"id"`
+	JobId   int64  `json:"job_id"`
+	JobType string `json:"job_type"`
+	Reports []struct {
+		Description string `json:"description"`
+		Name        string `json:"name"`
+		Value       int64  `json:"value"`
+	} `json:"reports"`
+	Time int64 `json:"time"`
+}
+
+// IsiDedupeReportsResp is the response to a dedupe report listing.
+type IsiDedupeReportsResp struct {
+	Reports []*IsiDedupeReport `json:"reports"`
+}
+
+// Isi PAPI dedupe summary JSON structs
+type IsiDedupeSummary struct {
+	BlockSize               int64 `json:"block_size"`
+	EstimatedPhysicalBlocks int64 `json:"estimated_physical_blocks"`
+	EstimatedSavedBlocks    int64 `json:"estimated_saved_blocks"`
+	LogicalBlocks           int64 `json:"logical_blocks"`
+	SavedLogicalBlocks      int64 `json:"saved_logical_blocks"`
+	TotalBlocks             int64 `json:"total_blocks"`
+	UsedBlocks              int64 `json:"used_blocks"`
+}
+
+// IsiDedupeSummaryResp is the response to a dedupe summary query.
+type IsiDedupeSummaryResp struct {
+	Summary *IsiDedupeSummary `json:"summary"`
+}
+
+// Isi PAPI cluster email settings JSON structs
+type IsiEmailSettings struct {
+	BatchMode            *string `json:"batch_mode,omitempty"`
+	MailRelay            *string `json:"mail_relay,omitempty"`
+	MailSender           *string `json:"mail_sender,omitempty"`
+	MailSubject          *string `json:"mail_subject,omitempty"`
+	SmtpAuthPasswd       *string `json:"smtp_auth_passwd,omitempty"`
+	SmtpAuthSecurity     *string `json:"smtp_auth_security,omitempty"`
+	SmtpAuthUsername     *string `json:"smtp_auth_username,omitempty"`
+	SmtpPort             *int    `json:"smtp_port,omitempty"`
+	UseSmtpAuth          *bool   `json:"use_smtp_auth,omitempty"`
+	UserTemplateLocation *string `json:"user_template_location,omitempty"`
+}
+
+// IsiEmailSettingsResp is the response to an email settings query.
+type IsiEmailSettingsResp struct {
+	Settings *IsiEmailSettings `json:"settings"`
+}
+
+// IsiClusterOwner is the cluster's owner and contact information, used by
+// support to reach its administrators.
+type IsiClusterOwner struct {
+	Company         *string `json:"company,omitempty"`
+	Location        *string `json:"location,omitempty"`
+	PrimaryName     *string `json:"primary_name,omitempty"`
+	PrimaryEmail    *string `json:"primary_email,omitempty"`
+	PrimaryPhone1   *string `json:"primary_phone1,omitempty"`
+	PrimaryPhone2   *string `json:"primary_phone2,omitempty"`
+	SecondaryName   *string `json:"secondary_name,omitempty"`
+	SecondaryEmail  *string `json:"secondary_email,omitempty"`
+	SecondaryPhone1 *string `json:"secondary_phone1,omitempty"`
+	SecondaryPhone2 *string `json:"secondary_phone2,omitempty"`
+}
+
+// IsiClusterOwnerResp is the response to a cluster owner query.
+type IsiClusterOwnerResp struct {
+	Owner *IsiClusterOwner `json:"owner"`
+}
+
+// IsiAccessTimeSettings are the cluster's access time (atime) tracking
+// settings. Precision is the number of seconds an atime may lag behind the
+// last access before it is updated.
+type IsiAccessTimeSettings struct {
+	Enabled   *bool  `json:"enabled,omitempty"`
+	Precision *int64 `json:"precision,omitempty"`
+}
+
+// IsiAccessTimeSettingsResp is the response to an access time settings
+// query.
+type IsiAccessTimeSettingsResp struct {
+	Settings *IsiAccessTimeSettings `json:"settings"`
+}
+
+// IsiFilePoolPolicy is a file pool policy, which applies actions such as
+// setting the requested protection to the files matching its pattern.
+type IsiFilePoolPolicy struct {
+	Name                *string               `json:"name,omitempty"`
+	Description         *string               `json:"description,omitempty"`
+	ApplyOrder          *int                  `json:"apply_order,omitempty"`
+	FileMatchingPattern *IsiFilePoolPattern   `json:"file_matching_pattern,omitempty"`
+	Actions             *[]*IsiFilePoolAction `json:"actions,omitempty"`
+}
+
+// IsiFilePoolPattern matches the files that satisfy all the criteria of any
+// of its OrCriteria.
+type IsiFilePoolPattern struct {
+	OrCriteria []*IsiFilePoolOrCriteria `json:"or_criteria"`
+}
+
+// IsiFilePoolOrCriteria is a set of criteria that must all be satisfied.
+type IsiFilePoolOrCriteria struct {
+	AndCriteria []*IsiFilePoolCriterion `json:"and_criteria"`
+}
+
+// IsiFilePoolCriterion compares a file attribute, ex. "path", to a value.
+type IsiFilePoolCriterion struct {
+	Type       string `json:"type"`
+	Operator   string `json:"operator,omitempty"`
+	Value      string `json:"value,omitempty"`
+	BeginsWith bool   `json:"begins_with,omitempty"`
+}
+
+// IsiFilePoolAction is an action of a file pool policy. The type of the
+// parameter depends on the action: the "set_requested_protection" action
+// takes a protection level such as "+2d:1n", and the
+// "apply_data_storage_policy" action takes an IsiFilePoolStoragePolicy,
+// which is decoded as a map.
+type IsiFilePoolAction struct {
+	ActionType  string      `json:"action_type"`
+	ActionParam interface{} `json:"action_param,omitempty"`
+}
+
+// IsiFilePoolStoragePolicy is the parameter of the
+// "apply_data_storage_policy" action.
+type IsiFilePoolStoragePolicy struct {
+	SSDStrategy string `json:"ssd_strategy"`
+	StoragePool string `json:"storagepool"`
+}
+
+// IsiFilePoolPoliciesResp is the response to a file pool policy query.
+type IsiFilePoolPoliciesResp struct {
+	Policies []*IsiFilePoolPolicy `json:"policies"`
+}
+
+// IsiStat is the current value of a statistics key on a node, or on the
+// cluster if DevID is zero. The type of the value depends on the key; numbers
+// are decoded as json.Number.
+type IsiStat struct {
+	Key       string      `json:"key"`
+	DevID     int         `json:"devid"`
+	Time      int64       `json:"time"`
+	Value     interface{} `json:"value"`
+	Error     *string     `json:"error"`
+	ErrorCode *int        `json:"error_code"`
+}
+
+// IsiStatsResp is the response to a statistics query.
+type IsiStatsResp struct {
+	Stats []*IsiStat `json:"stats"`
+}
+
+// IsiLicense is a OneFS feature license as reported by releases before
+// 8.1, which do not have the version 5 license API.
+type IsiLicense struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Expiration string `json:"expiration"`
+	Duration   int    `json:"duration"`
+}
+
+// IsiLicensesResp is the response to a license query.
+type IsiLicensesResp struct {
+	Licenses []*IsiLicense `json:"licenses"`
 }
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+func TestQuotasRespDecodeJSON(t *testing.T) {
+	j := `{"quotas":[{"id":"abc","path":"/ifs/volumes/v1","type":"directory",` +
+		`"ready":true,"container":true,` +
+		`"thresholds":{"hard":1073741824,"hard_exceeded":false},` +
+		`"usage":{"inodes":3,"logical":2048,"physical":6144}}],` +
+		`"resume":"token"}`
+
+	var resp IsiQuotasResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.Quotas, 1) {
+		t.FailNow()
+	}
+	q := resp.Quotas[0]
+	assert.Equal(t, "/ifs/volumes/v1", q.Path)
+	assert.True(t, q.Ready)
+	assert.Equal(t, int64(1073741824), q.Thresholds.Hard)
+	assert.Equal(t, IsiQuotaUsage{Inodes: 3, Logical: 2048, Physical: 6144}, q.Usage)
+	assert.Equal(t, "token", resp.Resume)
+}
+
+func TestVolumesRespDecodeJSON(t *testing.T) {
+	j := `{"children":[{"name":"v1"},{"name":"v2"}]}`
+
+	var resp IsiVolumesResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []*VolumeName{{Name: "v1"}, {Name: "v2"}}, resp.Children)
+	assert.Equal(t, "", resp.Resume)
+
+	j = `{"attrs":[{"name":"is_hidden","value":false},{"name":"owner","value":"root"}]}`
+	var attrs IsiVolumeAttributesResp
+	if err := json.Unmarshal([]byte(j), &attrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []IsiVolumeAttribute{
+		{Name: "is_hidden", Value: false},
+		{Name: "owner", Value: "root"},
+	}, attrs.AttributeMap)
+}
+
+func TestSnapshotsRespDecodeLargeNumbers(t *testing.T) {
+	j := `{"snapshots":[{"id":9007199254740993,"size":9223372036854775807,` +
+		`"target_id":9007199254740995,"created":1500000000}],"total":1}`
+
+	var resp IsiSnapshotsResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.SnapshotList, 1) {
+		t.FailNow()
+	}
+	s := resp.SnapshotList[0]
+	assert.Equal(t, int64(9007199254740993), s.Id)
+	assert.Equal(t, int64(9223372036854775807), s.Size)
+	assert.Equal(t, int64(9007199254740995), s.TargetId)
+}
+
+func TestQuotaDecodeLargeThresholds(t *testing.T) {
+	j := `{"quotas":[{"path":"/ifs/big","thresholds":` +
+		`{"hard":9223372036854775807,"soft":9007199254740993},` +
+		`"usage":{"inodes":9007199254740993,"logical":9007199254740995,` +
+		`"physical":9223372036854775806}}]}`
+
+	var resp IsiQuotasResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	q := resp.Quotas[0]
+	assert.Equal(t, int64(9223372036854775807), q.Thresholds.Hard)
+	assert.Equal(t, int64(9007199254740993), q.Thresholds.Soft)
+	assert.Equal(t, IsiQuotaUsage{
+		Inodes:   9007199254740993,
+		Logical:  9007199254740995,
+		Physical: 9223372036854775806,
+	}, q.Usage)
+
+	// request thresholds are interface{} values and must encode exactly
+	b, err := json.Marshal(&IsiThresholdsReq{Hard: q.Thresholds.Hard})
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"hard":9223372036854775807`)
+}
+
+func TestChangelistEntriesRespDecodeJSON(t *testing.T) {
+	j := `{"lins":[{"id":2,"lin":4300276353,"parent_lin":4300276352,` +
+		`"path":"/ifs/volumes/v1/f","type":"regular",` +
+		`"change_types":["ENTRY_ADDED"],"size":4096,` +
+		`"mtime":1600000000,"mtimensec":500}],` +
+		`"resume":"token","total":1}`
+
+	var resp IsiChangelistEntriesResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.Entries, 1) {
+		t.FailNow()
+	}
+	e := resp.Entries[0]
+	assert.Equal(t, int64(4300276353), e.LIN)
+	assert.Equal(t, "/ifs/volumes/v1/f", e.Path)
+	assert.Equal(t, []string{"ENTRY_ADDED"}, e.ChangeTypes)
+	assert.Equal(t, int64(4096), e.Size)
+	assert.Equal(t, int64(500), e.Mtimensec)
+	assert.Equal(t, "token", resp.Resume)
+}
@@ -20,18 +20,36 @@ var (
 // GetIsiVolumes queries a list of all volumes on the cluster
 func GetIsiVolumes(
 	ctx context.Context,
-	client api.Client) (resp *getIsiVolumesResp, err error) {
+	client api.Client) (resp *IsiVolumesResp, err error) {
 
 	// PAPI call: GET https://1.2.3.4:8080/namespace/path/to/volumes/
 	err = client.Get(ctx, realNamespacePath(client), "", nil, nil, &resp)
 	return resp, err
 }
 
+// GetIsiVolumesPage queries a page of volumes on the cluster. An empty resume
+// token queries the first page, and the returned resume token is empty after
+// the last page.
+func GetIsiVolumesPage(
+	ctx context.Context,
+	client api.Client,
+	resume string) ([]*VolumeName, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/path/to/volumes/?resume=token
+	var resp IsiVolumesResp
+	err := client.Get(
+		ctx, realNamespacePath(client), "", resumeQS(resume), nil, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Children, resp.Resume, nil
+}
+
 // CreateIsiVolume makes a new volume on the cluster
 func CreateIsiVolume(
 	ctx context.Context,
 	client api.Client,
-	name string) (resp *getIsiVolumesResp, err error) {
+	name string) (resp *IsiVolumesResp, err error) {
 
 	return CreateIsiVolumeWithACL(ctx, client, name, defaultACL)
 }
@@ -40,7 +58,7 @@ func CreateIsiVolume(
 func CreateIsiVolumeWithACL(
 	ctx context.Context,
 	client api.Client,
-	name, ACL string) (resp *getIsiVolumesResp, err error) {
+	name, ACL string) (resp *IsiVolumesResp, err error) {
 
 	// PAPI calls: PUT https://1.2.3.4:8080/namespace/path/to/volumes/volume_name
 	//             x-isi-ifs-target-type: container
@@ -105,7 +123,7 @@ func CreateIsiVolumeWithACL(
 func GetIsiVolume(
 	ctx context.Context,
 	client api.Client,
-	name string) (resp *getIsiVolumeAttributesResp, err error) {
+	name string) (resp *IsiVolumeAttributesResp, err error) {
 
 	// PAPI call: GET https://1.2.3.4:8080/namespace/path/to/volume/?metadata
 	err = client.Get(
@@ -122,7 +140,7 @@ func GetIsiVolume(
 func DeleteIsiVolume(
 	ctx context.Context,
 	client api.Client,
-	name string) (resp *getIsiVolumesResp, err error) {
+	name string) (resp *IsiVolumesResp, err error) {
 
 	err = client.Delete(
 		ctx,
@@ -138,7 +156,7 @@ func DeleteIsiVolume(
 func CopyIsiVolume(
 	ctx context.Context,
 	client api.Client,
-	sourceName, destinationName string) (resp *getIsiVolumesResp, err error) {
+	sourceName, destinationName string) (resp *IsiVolumesResp, err error) {
 	// PAPI calls: PUT https://1.2.3.4:8080/namespace/path/to/volumes/destination_volume_name
 	//             x-isi-ifs-copy-source: /path/to/volumes/source_volume_name
 
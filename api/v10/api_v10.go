@@ -0,0 +1,6 @@
+package v10
+
+const (
+	certificateSettingsPath = "platform/10/certificate/settings"
+	s3BucketsPath           = "platform/10/protocols/s3/buckets"
+)
@@ -0,0 +1,52 @@
+package v10
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiCertificateSettings are the cluster's certificate settings.
+type IsiCertificateSettings struct {
+	DefaultHTTPSCertificate *string `json:"default_https_certificate,omitempty"`
+}
+
+type getIsiCertificateSettingsResp struct {
+	Settings *IsiCertificateSettings `json:"settings"`
+}
+
+// GetIsiCertificateSettings queries the cluster's certificate settings
+func GetIsiCertificateSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiCertificateSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/10/certificate/settings
+	var resp getIsiCertificateSettingsResp
+	err = client.Get(ctx, certificateSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("certificate settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// SetIsiDefaultHTTPSCertificate sets the server certificate used by the
+// cluster's HTTPS services
+func SetIsiDefaultHTTPSCertificate(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/10/certificate/settings
+	//            Content-Type: application/json
+	//            {default_https_certificate: "certificate_id"}
+	if id == "" {
+		return errors.New("no certificate id set")
+	}
+	return client.Put(
+		ctx, certificateSettingsPath, "", nil, nil,
+		&IsiCertificateSettings{DefaultHTTPSCertificate: &id}, nil)
+}
@@ -0,0 +1,160 @@
+package v10
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// S3 bucket permissions.
+const (
+	S3PermissionRead        = "READ"
+	S3PermissionWrite       = "WRITE"
+	S3PermissionReadACP     = "READ_ACP"
+	S3PermissionWriteACP    = "WRITE_ACP"
+	S3PermissionFullControl = "FULL_CONTROL"
+)
+
+// S3 object ACL policies, which set what happens when a client modifies the
+// ACL of an object in a bucket.
+const (
+	S3ObjectACLPolicyReplace = "replace"
+	S3ObjectACLPolicyDeny    = "deny"
+)
+
+// IsiS3BucketGrant grants a grantee a permission on an S3 bucket.
+type IsiS3BucketGrant struct {
+	Grantee    *apiv2.Persona `json:"grantee"`
+	Permission string         `json:"permission"`
+}
+
+// IsiS3Bucket is an S3 bucket. The ID is the bucket's name. CreatePath only
+// applies when a bucket is created.
+type IsiS3Bucket struct {
+	ID              string               `json:"id,omitmarshal"`
+	Zid             int                  `json:"zid,omitmarshal"`
+	Name            *string              `json:"name,omitempty"`
+	Path            *string              `json:"path,omitempty"`
+	Owner           *string              `json:"owner,omitempty"`
+	Description     *string              `json:"description,omitempty"`
+	ACL             *[]*IsiS3BucketGrant `json:"acl,omitempty"`
+	ObjectACLPolicy *string              `json:"object_acl_policy,omitempty"`
+	CreatePath      *bool                `json:"create_path,omitempty"`
+}
+
+type getIsiS3BucketsResp struct {
+	Buckets []*IsiS3Bucket `json:"buckets"`
+	Resume  string         `json:"resume,omitempty"`
+}
+
+type postIsiS3BucketResp struct {
+	ID string `json:"id"`
+}
+
+var byteArrResume = []byte("resume")
+
+// GetIsiS3BucketsPage queries a page of the S3 buckets in an access zone. An
+// empty resume token queries the first page, and the returned resume token
+// is empty after the last page.
+func GetIsiS3BucketsPage(
+	ctx context.Context,
+	client api.Client,
+	zone, resume string) ([]*IsiS3Bucket, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/10/protocols/s3/buckets?zone=zone
+	// a resume token encodes the original query, so no other arguments may be
+	// sent with it
+	qs := api.ZoneQS(client, zone)
+	if resume != "" {
+		qs = api.OrderedValues{{byteArrResume, []byte(resume)}}
+	}
+
+	var resp getIsiS3BucketsResp
+	if err := client.Get(ctx, s3BucketsPath, "", qs, nil, &resp); err != nil {
+		return nil, "", err
+	}
+	return resp.Buckets, resp.Resume, nil
+}
+
+// GetIsiS3Bucket queries an S3 bucket in an access zone by name
+func GetIsiS3Bucket(
+	ctx context.Context,
+	client api.Client,
+	zone, name string) (bucket *IsiS3Bucket, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/10/protocols/s3/buckets/name?zone=zone
+	if name == "" {
+		return nil, errors.New("no bucket name set")
+	}
+
+	var resp getIsiS3BucketsResp
+	err = client.Get(
+		ctx, s3BucketsPath, name, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Buckets) == 0 {
+		return nil, errors.New("bucket missing from response")
+	}
+	return resp.Buckets[0], nil
+}
+
+// CreateIsiS3Bucket creates an S3 bucket in an access zone and returns its
+// ID
+func CreateIsiS3Bucket(
+	ctx context.Context,
+	client api.Client,
+	zone string, bucket *IsiS3Bucket) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/10/protocols/s3/buckets?zone=zone
+	//            Content-Type: application/json
+	//            {name: "bucket", path: "/ifs/data/bucket"}
+	if bucket == nil || bucket.Name == nil || bucket.Path == nil {
+		return "", errors.New("no bucket name or path set")
+	}
+
+	var resp postIsiS3BucketResp
+	err = client.Post(
+		ctx, s3BucketsPath, "", api.ZoneQS(client, zone), nil, bucket, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiS3Bucket modifies an S3 bucket in an access zone. Only the fields
+// that are set are changed.
+func UpdateIsiS3Bucket(
+	ctx context.Context,
+	client api.Client,
+	zone, name string, bucket *IsiS3Bucket) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/10/protocols/s3/buckets/name?zone=zone
+	//            Content-Type: application/json
+	//            {object_acl_policy: "deny"}
+	if name == "" {
+		return errors.New("no bucket name set")
+	}
+	if bucket == nil {
+		return errors.New("no bucket settings set")
+	}
+	return client.Put(
+		ctx, s3BucketsPath, name, api.ZoneQS(client, zone), nil, bucket, nil)
+}
+
+// DeleteIsiS3Bucket removes an S3 bucket from an access zone. The bucket's
+// directory is not removed.
+func DeleteIsiS3Bucket(
+	ctx context.Context,
+	client api.Client,
+	zone, name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/10/protocols/s3/buckets/name?zone=zone
+	if name == "" {
+		return errors.New("no bucket name set")
+	}
+	return client.Delete(
+		ctx, s3BucketsPath, name, api.ZoneQS(client, zone), nil, nil)
+}
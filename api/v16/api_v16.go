@@ -0,0 +1,8 @@
+package v16
+
+const (
+	supportAssistSettingsPath  = "platform/16/supportassist/settings"
+	supportAssistProvisionPath = "platform/16/supportassist/provision"
+	firewallPoliciesPath       = "platform/16/network/firewall/policies"
+	firewallSettingsPath       = "platform/16/network/firewall/settings"
+)
@@ -0,0 +1,261 @@
+package v16
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiFirewallPolicy is a firewall policy, which is applied to the network
+// pools and subnets it lists.
+type IsiFirewallPolicy struct {
+	ID            string    `json:"id,omitmarshal"`
+	Name          *string   `json:"name,omitempty"`
+	Description   *string   `json:"description,omitempty"`
+	DefaultAction *string   `json:"default_action,omitempty"`
+	MaxRules      *int      `json:"max_rules,omitempty"`
+	Pools         *[]string `json:"pools,omitempty"`
+	Subnets       *[]string `json:"subnets,omitempty"`
+	RuleOrder     *[]string `json:"rule_order,omitempty"`
+	Rules         []string  `json:"rules,omitmarshal"`
+}
+
+// IsiFirewallRule is a rule of a firewall policy.
+type IsiFirewallRule struct {
+	ID          string    `json:"id,omitmarshal"`
+	Name        *string   `json:"name,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Action      *string   `json:"action,omitempty"`
+	Index       *int      `json:"index,omitempty"`
+	Protocol    *string   `json:"protocol,omitempty"`
+	SrcNetworks *[]string `json:"src_networks,omitempty"`
+	SrcPorts    *[]string `json:"src_ports,omitempty"`
+	DstPorts    *[]string `json:"dst_ports,omitempty"`
+}
+
+// IsiFirewallSettings are the cluster firewall settings.
+type IsiFirewallSettings struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type getIsiFirewallPoliciesResp struct {
+	Policies []*IsiFirewallPolicy `json:"policies"`
+}
+
+type getIsiFirewallRulesResp struct {
+	Rules []*IsiFirewallRule `json:"rules"`
+}
+
+type getIsiFirewallSettingsResp struct {
+	Settings *IsiFirewallSettings `json:"settings"`
+}
+
+type postIsiFirewallResp struct {
+	ID string `json:"id"`
+}
+
+func firewallRulesPath(policy string) string {
+	return path.Join(firewallPoliciesPath, policy, "rules")
+}
+
+// GetIsiFirewallPolicies queries all firewall policies
+func GetIsiFirewallPolicies(
+	ctx context.Context,
+	client api.Client) (policies []*IsiFirewallPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/16/network/firewall/policies
+	var resp getIsiFirewallPoliciesResp
+	err = client.Get(ctx, firewallPoliciesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Policies, nil
+}
+
+// GetIsiFirewallPolicy queries an individual firewall policy by id or name
+func GetIsiFirewallPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (policy *IsiFirewallPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/16/network/firewall/policies/id
+	if id == "" {
+		return nil, errors.New("no policy set")
+	}
+
+	var resp getIsiFirewallPoliciesResp
+	err = client.Get(ctx, firewallPoliciesPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Policies) == 0 {
+		return nil, errors.New("policy missing from response")
+	}
+	return resp.Policies[0], nil
+}
+
+// CreateIsiFirewallPolicy creates a firewall policy and returns its id
+func CreateIsiFirewallPolicy(
+	ctx context.Context,
+	client api.Client,
+	policy *IsiFirewallPolicy) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/16/network/firewall/policies
+	//            Content-Type: application/json
+	//            {name: "policy1", default_action: "deny",
+	//             pools: ["groupnet0.subnet0.pool0"]}
+	if policy == nil || policy.Name == nil || *policy.Name == "" {
+		return "", errors.New("no policy name set")
+	}
+
+	var resp postIsiFirewallResp
+	err = client.Post(ctx, firewallPoliciesPath, "", nil, nil, policy, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiFirewallPolicy modifies a firewall policy. Only the fields that
+// are set are changed.
+func UpdateIsiFirewallPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string, policy *IsiFirewallPolicy) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/16/network/firewall/policies/id
+	//            Content-Type: application/json
+	//            {pools: ["groupnet0.subnet0.pool0"]}
+	if id == "" {
+		return errors.New("no policy set")
+	}
+	if policy == nil {
+		return errors.New("no policy set")
+	}
+	return client.Put(ctx, firewallPoliciesPath, id, nil, nil, policy, nil)
+}
+
+// DeleteIsiFirewallPolicy deletes a firewall policy
+func DeleteIsiFirewallPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/16/network/firewall/policies/id
+	if id == "" {
+		return errors.New("no policy set")
+	}
+	return client.Delete(ctx, firewallPoliciesPath, id, nil, nil, nil)
+}
+
+// GetIsiFirewallRules queries the rules of a firewall policy
+func GetIsiFirewallRules(
+	ctx context.Context,
+	client api.Client,
+	policy string) (rules []*IsiFirewallRule, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/16/network/firewall/policies/policy/rules
+	if policy == "" {
+		return nil, errors.New("no policy set")
+	}
+
+	var resp getIsiFirewallRulesResp
+	err = client.Get(ctx, firewallRulesPath(policy), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Rules, nil
+}
+
+// CreateIsiFirewallRule creates a rule of a firewall policy and returns its
+// id
+func CreateIsiFirewallRule(
+	ctx context.Context,
+	client api.Client,
+	policy string, rule *IsiFirewallRule) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/16/network/firewall/policies/policy/rules
+	//            Content-Type: application/json
+	//            {name: "allow_ssh", action: "allow", protocol: "TCP",
+	//             src_networks: ["10.0.0.0/8"], dst_ports: ["22"]}
+	if policy == "" {
+		return "", errors.New("no policy set")
+	}
+	if rule == nil || rule.Name == nil || *rule.Name == "" {
+		return "", errors.New("no rule name set")
+	}
+
+	var resp postIsiFirewallResp
+	err = client.Post(
+		ctx, firewallRulesPath(policy), "", nil, nil, rule, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiFirewallRule modifies a rule of a firewall policy. Only the
+// fields that are set are changed.
+func UpdateIsiFirewallRule(
+	ctx context.Context,
+	client api.Client,
+	policy, id string, rule *IsiFirewallRule) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/16/network/firewall/policies/policy/rules/id
+	//            Content-Type: application/json
+	//            {src_networks: ["10.1.0.0/16"]}
+	if policy == "" || id == "" {
+		return errors.New("no policy or rule set")
+	}
+	if rule == nil {
+		return errors.New("no rule set")
+	}
+	return client.Put(ctx, firewallRulesPath(policy), id, nil, nil, rule, nil)
+}
+
+// DeleteIsiFirewallRule deletes a rule of a firewall policy
+func DeleteIsiFirewallRule(
+	ctx context.Context,
+	client api.Client,
+	policy, id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/16/network/firewall/policies/policy/rules/id
+	if policy == "" || id == "" {
+		return errors.New("no policy or rule set")
+	}
+	return client.Delete(ctx, firewallRulesPath(policy), id, nil, nil, nil)
+}
+
+// GetIsiFirewallSettings queries the cluster firewall settings
+func GetIsiFirewallSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiFirewallSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/16/network/firewall/settings
+	var resp getIsiFirewallSettingsResp
+	err = client.Get(ctx, firewallSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("firewall settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiFirewallSettings modifies the cluster firewall settings
+func UpdateIsiFirewallSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiFirewallSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/16/network/firewall/settings
+	//            Content-Type: application/json
+	//            {enabled: true}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, firewallSettingsPath, "", nil, nil, settings, nil)
+}
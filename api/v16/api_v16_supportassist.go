@@ -0,0 +1,107 @@
+package v16
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiSupportAssistGateway is a Secure Connect Gateway used by SupportAssist.
+type IsiSupportAssistGateway struct {
+	Host        string `json:"host"`
+	Port        int    `json:"port,omitempty"`
+	Priority    int    `json:"priority,omitempty"`
+	Enabled     *bool  `json:"enabled,omitempty"`
+	UseProxy    *bool  `json:"use_proxy,omitempty"`
+	ValidateSSL *bool  `json:"validate_ssl,omitempty"`
+}
+
+// IsiSupportAssistConnection describes how the cluster reaches Dell support.
+type IsiSupportAssistConnection struct {
+	Mode             *string                     `json:"mode,omitempty"`
+	GatewayEndpoints *[]*IsiSupportAssistGateway `json:"gateway_endpoints,omitempty"`
+	NetworkPools     *[]string                   `json:"network_pools,omitempty"`
+}
+
+// IsiSupportAssistTelemetry are the telemetry collection settings.
+type IsiSupportAssistTelemetry struct {
+	TelemetryEnabled        *bool `json:"telemetry_enabled,omitempty"`
+	TelemetryPersist        *bool `json:"telemetry_persist,omitempty"`
+	TelemetryThreads        *int  `json:"telemetry_threads,omitempty"`
+	OfflineCollectionPeriod *int  `json:"offline_collection_period,omitempty"`
+}
+
+// IsiSupportAssistSettings are the SupportAssist settings.
+type IsiSupportAssistSettings struct {
+	SupportAssistEnabled  *bool                       `json:"supportassist_enabled,omitempty"`
+	AutomaticCaseCreation *bool                       `json:"automatic_case_creation,omitempty"`
+	EnableDownload        *bool                       `json:"enable_download,omitempty"`
+	EnableRemoteSupport   *bool                       `json:"enable_remote_support,omitempty"`
+	AcceptTerms           *bool                       `json:"accepted_terms,omitempty"`
+	Connection            *IsiSupportAssistConnection `json:"connection,omitempty"`
+	Telemetry             *IsiSupportAssistTelemetry  `json:"telemetry,omitempty"`
+	ConnectionState       string                      `json:"connection_state,omitmarshal"`
+}
+
+type getIsiSupportAssistSettingsResp struct {
+	Settings *IsiSupportAssistSettings `json:"settings"`
+}
+
+type isiSupportAssistProvisionReq struct {
+	AccessKey string `json:"access_key"`
+	PIN       string `json:"pin"`
+}
+
+// GetIsiSupportAssistSettings queries the SupportAssist settings
+func GetIsiSupportAssistSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiSupportAssistSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/16/supportassist/settings
+	var resp getIsiSupportAssistSettingsResp
+	err = client.Get(ctx, supportAssistSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("supportassist settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiSupportAssistSettings modifies the SupportAssist settings. Only
+// the fields that are set are changed.
+func UpdateIsiSupportAssistSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiSupportAssistSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/16/supportassist/settings
+	//            Content-Type: application/json
+	//            {supportassist_enabled: true,
+	//             telemetry: {telemetry_enabled: true}}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, supportAssistSettingsPath, "", nil, nil, settings, nil)
+}
+
+// ProvisionIsiSupportAssist enrolls the cluster with Dell support using an
+// access key and PIN
+func ProvisionIsiSupportAssist(
+	ctx context.Context,
+	client api.Client,
+	accessKey, pin string) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/16/supportassist/provision
+	//            Content-Type: application/json
+	//            {access_key: "key", pin: "1234"}
+	if accessKey == "" || pin == "" {
+		return errors.New("no access key or pin set")
+	}
+	return client.Post(
+		ctx, supportAssistProvisionPath, "", nil, nil,
+		&isiSupportAssistProvisionReq{AccessKey: accessKey, PIN: pin}, nil)
+}
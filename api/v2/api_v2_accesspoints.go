@@ -0,0 +1,100 @@
+package v2
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// AccessPoint is a namespace access point, a name under which a directory
+// is exposed by the namespace API.
+type AccessPoint struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path"`
+}
+
+type accessPointList struct {
+	Namespaces []*AccessPoint `json:"namespaces"`
+}
+
+var nsaccessACLQueryString = api.NewOrderedValues([][]string{
+	{"acl"},
+	{"nsaccess", "true"},
+})
+
+// AccessPointsGet GETs the namespace access points.
+func AccessPointsGet(
+	ctx context.Context,
+	client api.Client) ([]*AccessPoint, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/
+	var resp accessPointList
+	if err := client.Get(
+		ctx, namespacePath, "", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Namespaces, nil
+}
+
+// AccessPointCreate creates a namespace access point for a directory.
+func AccessPointCreate(
+	ctx context.Context,
+	client api.Client,
+	name, path string) error {
+
+	if name == "" || path == "" {
+		return errors.New("no access point name or path set")
+	}
+
+	// PAPI call: PUT https://1.2.3.4:8080/namespace/<name>
+	//            {"path": "/ifs/data/tenant"}
+	return client.Put(
+		ctx,
+		namespacePath,
+		name,
+		nil,
+		nil,
+		&AccessPoint{Path: path},
+		nil)
+}
+
+// AccessPointDelete deletes a namespace access point. The directory it
+// exposes is not deleted.
+func AccessPointDelete(
+	ctx context.Context,
+	client api.Client,
+	name string) error {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/namespace/<name>
+	return client.Delete(ctx, namespacePath, name, nil, nil, nil)
+}
+
+// AccessPointACLInspect GETs the ACL of a namespace access point, which
+// controls who may use it.
+func AccessPointACLInspect(
+	ctx context.Context,
+	client api.Client,
+	name string) (*ACL, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/<name>?acl&nsaccess=true
+	var resp ACL
+	if err := client.Get(
+		ctx, namespacePath, name, nsaccessACLQueryString, nil,
+		&resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// AccessPointACLUpdate PUTs the ACL of a namespace access point.
+func AccessPointACLUpdate(
+	ctx context.Context,
+	client api.Client,
+	name string,
+	acl *ACL) error {
+
+	// PAPI call: PUT https://1.2.3.4:8080/namespace/<name>?acl&nsaccess=true
+	return client.Put(
+		ctx, namespacePath, name, nsaccessACLQueryString, nil, acl, nil)
+}
@@ -0,0 +1,220 @@
+package v2
+
+// ACE is an access control entry of an ACL.
+type ACE struct {
+	Trustee      *Persona `json:"trustee,omitempty"`
+	AccessType   string   `json:"accesstype,omitempty"`
+	AccessRights []string `json:"accessrights,omitempty"`
+	InheritFlags []string `json:"inherit_flags,omitempty"`
+	Op           string   `json:"op,omitempty"`
+}
+
+// ACE access types.
+const (
+	AccessTypeAllow = "allow"
+	AccessTypeDeny  = "deny"
+)
+
+// ACE access rights used to express POSIX permission bits.
+const (
+	AccessRightFileGenRead    = "file_gen_read"
+	AccessRightFileGenWrite   = "file_gen_write"
+	AccessRightFileGenExecute = "file_gen_execute"
+	AccessRightFileGenAll     = "file_gen_all"
+	AccessRightDirGenRead     = "dir_gen_read"
+	AccessRightDirGenWrite    = "dir_gen_write"
+	AccessRightDirGenExecute  = "dir_gen_execute"
+	AccessRightDirGenAll      = "dir_gen_all"
+)
+
+// ACE inherit flags.
+const (
+	InheritFlagObject    = "object_inherit"
+	InheritFlagContainer = "container_inherit"
+)
+
+// ACE operations used when updating an ACL.
+const (
+	ACEOpAdd    = "add"
+	ACEOpDelete = "delete"
+)
+
+// EveryoneSID is the SID of the well-known Everyone trustee, which synthetic
+// ACLs use for the "other" permission bits.
+const EveryoneSID = "S-1-1-0"
+
+// EveryonePersona returns the well-known Everyone trustee.
+func EveryonePersona() *Persona {
+	return &Persona{ID: &PersonaID{ID: EveryoneSID, Type: PersonaIDTypeSID}}
+}
+
+// UserPersona returns the trustee of the user with the given name.
+func UserPersona(name string) *Persona {
+	return &Persona{ID: &PersonaID{ID: name, Type: PersonaIDTypeUser}}
+}
+
+// GroupPersona returns the trustee of the group with the given name.
+func GroupPersona(name string) *Persona {
+	return &Persona{ID: &PersonaID{ID: name, Type: PersonaIDTypeGroup}}
+}
+
+// permission bits of one class of a FileMode
+const (
+	permRead    = 04
+	permWrite   = 02
+	permExecute = 01
+)
+
+func genRights(perm FileMode, dir bool) []string {
+	r, w, x := AccessRightFileGenRead, AccessRightFileGenWrite,
+		AccessRightFileGenExecute
+	if dir {
+		r, w, x = AccessRightDirGenRead, AccessRightDirGenWrite,
+			AccessRightDirGenExecute
+	}
+	var rights []string
+	if perm&permRead != 0 {
+		rights = append(rights, r)
+	}
+	if perm&permWrite != 0 {
+		rights = append(rights, w)
+	}
+	if perm&permExecute != 0 {
+		rights = append(rights, x)
+	}
+	return rights
+}
+
+func rightsPerm(rights []string) FileMode {
+	var perm FileMode
+	for _, r := range rights {
+		switch r {
+		case AccessRightFileGenAll, AccessRightDirGenAll:
+			perm |= permRead | permWrite | permExecute
+		case AccessRightFileGenRead, AccessRightDirGenRead:
+			perm |= permRead
+		case AccessRightFileGenWrite, AccessRightDirGenWrite:
+			perm |= permWrite
+		case AccessRightFileGenExecute, AccessRightDirGenExecute:
+			perm |= permExecute
+		}
+	}
+	return perm
+}
+
+// ModeToACEs returns the allow ACEs that are equivalent to the permission
+// bits of mode, granting the owner, group and Everyone trustees the rights
+// of the user, group and other classes. Classes without permissions get no
+// ACE. The directory rights are used if dir is set.
+func ModeToACEs(mode FileMode, owner, group *Persona, dir bool) []*ACE {
+	var aces []*ACE
+	for _, c := range []struct {
+		trustee *Persona
+		perm    FileMode
+	}{
+		{owner, mode >> 6 & 07},
+		{group, mode >> 3 & 07},
+		{EveryonePersona(), mode & 07},
+	} {
+		if c.trustee == nil || c.perm == 0 {
+			continue
+		}
+		aces = append(aces, &ACE{
+			Trustee:      c.trustee,
+			AccessType:   AccessTypeAllow,
+			AccessRights: genRights(c.perm, dir),
+		})
+	}
+	return aces
+}
+
+// ACEsToMode returns the permission bits that approximate aces, which is
+// the inverse of ModeToACEs. Rights allowed to the owner, group and Everyone
+// trustees set the user, group and other bits unless an earlier ACE denied
+// them. ACEs for other trustees and rights with no POSIX equivalent are
+// ignored.
+func ACEsToMode(aces []*ACE, owner, group *Persona) FileMode {
+	var allowed, denied FileMode
+	everyone := EveryonePersona()
+	for _, ace := range aces {
+		var shift uint
+		switch {
+		case samePersona(ace.Trustee, owner):
+			shift = 6
+		case samePersona(ace.Trustee, group):
+			shift = 3
+		case samePersona(ace.Trustee, everyone):
+			shift = 0
+		default:
+			continue
+		}
+		perm := rightsPerm(ace.AccessRights) << shift
+		switch ace.AccessType {
+		case AccessTypeAllow:
+			allowed |= perm &^ denied
+		case AccessTypeDeny:
+			denied |= perm &^ allowed
+		}
+	}
+	return allowed
+}
+
+// samePersona returns whether two personas identify the same trustee by ID
+// or, if either has no ID, by name.
+func samePersona(a, b *Persona) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a.ID != nil && b.ID != nil {
+		return a.ID.ID == b.ID.ID && (a.ID.Type == b.ID.Type ||
+			a.ID.Type == PersonaIDTypeUnknown ||
+			b.ID.Type == PersonaIDTypeUnknown)
+	}
+	return a.Name != nil && b.Name != nil && *a.Name == *b.Name
+}
+
+func grantACE(trustee *Persona, dir bool, perm FileMode) *ACE {
+	ace := &ACE{
+		Trustee:      trustee,
+		AccessType:   AccessTypeAllow,
+		AccessRights: genRights(perm, dir),
+		Op:           ACEOpAdd,
+	}
+	if dir {
+		ace.InheritFlags = []string{InheritFlagObject, InheritFlagContainer}
+	}
+	return ace
+}
+
+// ReadOnlyACE returns an ACE that grants trustee read access. If dir is set
+// the ACE is inherited by the files and directories created beneath it.
+func ReadOnlyACE(trustee *Persona, dir bool) *ACE {
+	return grantACE(trustee, dir, permRead|permExecute)
+}
+
+// ReadWriteACE returns an ACE that grants trustee read and write access. If
+// dir is set the ACE is inherited by the files and directories created
+// beneath it.
+func ReadWriteACE(trustee *Persona, dir bool) *ACE {
+	return grantACE(trustee, dir, permRead|permWrite|permExecute)
+}
+
+// FullControlACE returns an ACE that grants trustee all rights, including
+// changing the ACL. If dir is set the ACE is inherited by the files and
+// directories created beneath it.
+func FullControlACE(trustee *Persona, dir bool) *ACE {
+	ace := grantACE(trustee, dir, 0)
+	ace.AccessRights = []string{AccessRightFileGenAll}
+	if dir {
+		ace.AccessRights = []string{AccessRightDirGenAll}
+	}
+	return ace
+}
+
+// RevokeACE returns an ACE that removes the given ACE when it is used to
+// update an ACL.
+func RevokeACE(ace *ACE) *ACE {
+	revoke := *ace
+	revoke.Op = ACEOpDelete
+	return &revoke
+}
@@ -0,0 +1,85 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+func TestModeToACEs(t *testing.T) {
+	owner, group := UserPersona("alice"), GroupPersona("staff")
+
+	aces := ModeToACEs(0750, owner, group, true)
+	if !assert.Len(t, aces, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, owner, aces[0].Trustee)
+	assert.Equal(t, []string{
+		AccessRightDirGenRead, AccessRightDirGenWrite, AccessRightDirGenExecute,
+	}, aces[0].AccessRights)
+	assert.Equal(t, group, aces[1].Trustee)
+	assert.Equal(t, []string{
+		AccessRightDirGenRead, AccessRightDirGenExecute,
+	}, aces[1].AccessRights)
+
+	aces = ModeToACEs(0604, owner, group, false)
+	if !assert.Len(t, aces, 2) {
+		t.FailNow()
+	}
+	assert.Equal(t, EveryonePersona(), aces[1].Trustee)
+	assert.Equal(t, []string{AccessRightFileGenRead}, aces[1].AccessRights)
+}
+
+func TestACEsToMode(t *testing.T) {
+	owner, group := UserPersona("alice"), GroupPersona("staff")
+
+	for _, mode := range []FileMode{0, 0777, 0755, 0640, 0604, 0111} {
+		for _, dir := range []bool{false, true} {
+			aces := ModeToACEs(mode, owner, group, dir)
+			assert.Equal(t, mode, ACEsToMode(aces, owner, group),
+				"%s dir=%t", mode, dir)
+		}
+	}
+
+	// earlier deny ACEs take precedence and unknown trustees are ignored
+	aces := []*ACE{
+		{Trustee: group, AccessType: AccessTypeDeny,
+			AccessRights: []string{AccessRightFileGenWrite}},
+		{Trustee: GroupPersona("other"), AccessType: AccessTypeAllow,
+			AccessRights: []string{AccessRightFileGenAll}},
+		{Trustee: group, AccessType: AccessTypeAllow,
+			AccessRights: []string{AccessRightFileGenAll}},
+	}
+	assert.Equal(t, FileMode(0050), ACEsToMode(aces, owner, group))
+}
+
+func TestGrantACEs(t *testing.T) {
+	group := GroupPersona("staff")
+
+	ace := ReadOnlyACE(group, true)
+	assert.Equal(t, []string{
+		AccessRightDirGenRead, AccessRightDirGenExecute,
+	}, ace.AccessRights)
+	assert.Equal(t, []string{
+		InheritFlagObject, InheritFlagContainer,
+	}, ace.InheritFlags)
+	assert.Equal(t, ACEOpAdd, ace.Op)
+
+	ace = FullControlACE(group, false)
+	assert.Equal(t, []string{AccessRightFileGenAll}, ace.AccessRights)
+	assert.Nil(t, ace.InheritFlags)
+	assert.Equal(t, ACEOpDelete, RevokeACE(ace).Op)
+	assert.Equal(t, ACEOpAdd, ace.Op)
+
+	buf, err := json.Marshal(ReadWriteACE(group, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t,
+		`{"trustee":{"id":"group:staff"},"accesstype":"allow",`+
+			`"accessrights":["file_gen_read","file_gen_write","file_gen_execute"],`+
+			`"op":"add"}`,
+		string(buf))
+}
@@ -199,6 +199,7 @@ type ACL struct {
 	Owner         *Persona           `json:"owner,omitempty"`
 	Group         *Persona           `json:"group,omitempty"`
 	Mode          *FileMode          `json:"mode,omitempty"`
+	ACEs          []*ACE             `json:"acl,omitempty"`
 }
 
 var aclQueryString = api.OrderedValues{{[]byte("acl")}}
@@ -225,6 +226,29 @@ func ACLInspect(
 	return &resp, nil
 }
 
+// ACLInspectPath GETs the ACL of a file or directory by its absolute path,
+// ex. /ifs/data/dir, rather than its path in the volumes path.
+func ACLInspectPath(
+	ctx context.Context,
+	client api.Client,
+	absPath string) (*ACL, error) {
+
+	var resp ACL
+
+	if err := client.Get(
+		ctx,
+		namespacePath,
+		strings.TrimPrefix(absPath, "/"),
+		aclQueryString,
+		nil,
+		&resp); err != nil {
+
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
 // ACLUpdate PUTs an ACL.
 func ACLUpdate(
 	ctx context.Context,
@@ -246,3 +270,21 @@ func ACLUpdate(
 
 	return nil
 }
+
+// ACLUpdatePath PUTs the ACL of a file or directory by its absolute path,
+// ex. /ifs/data/dir, rather than its path in the volumes path.
+func ACLUpdatePath(
+	ctx context.Context,
+	client api.Client,
+	absPath string,
+	acl *ACL) error {
+
+	return client.Put(
+		ctx,
+		namespacePath,
+		strings.TrimPrefix(absPath, "/"),
+		aclQueryString,
+		nil,
+		acl,
+		nil)
+}
@@ -56,7 +56,7 @@ func ExportsList(
 		ctx,
 		exportsPath,
 		"",
-		nil,
+		api.ZoneQS(client, ""),
 		nil,
 		&resp); err != nil {
 
@@ -77,9 +77,7 @@ func ExportsListWithZone(
 		ctx,
 		exportsPath,
 		"",
-		api.OrderedValues{
-			{[]byte("zone"), []byte(zone)},
-		},
+		api.ZoneQS(client, zone),
 		nil,
 		&resp); err != nil {
 
@@ -89,6 +87,40 @@ func ExportsListWithZone(
 	return resp, nil
 }
 
+// ExportsListPage GETs a page of exports in the specified zone. An empty
+// resume token GETs the first page, and the returned resume token is empty
+// after the last page.
+func ExportsListPage(
+	ctx context.Context,
+	client api.Client,
+	zone, resume string) ([]*Export, string, error) {
+
+	var resp struct {
+		Exports []*Export `json:"exports,omitempty"`
+		Resume  string    `json:"resume,omitempty"`
+	}
+
+	// a resume token encodes the original query, so no other arguments may be
+	// sent with it
+	qs := api.ZoneQS(client, zone)
+	if resume != "" {
+		qs = api.OrderedValues{{resumeByteArr, []byte(resume)}}
+	}
+
+	if err := client.Get(
+		ctx,
+		exportsPath,
+		"",
+		qs,
+		nil,
+		&resp); err != nil {
+
+		return nil, "", err
+	}
+
+	return resp.Exports, resp.Resume, nil
+}
+
 // ExportInspect GETs an export.
 func ExportInspect(
 	ctx context.Context,
@@ -101,7 +133,7 @@ func ExportInspect(
 		ctx,
 		exportsPath,
 		strconv.Itoa(id),
-		nil,
+		api.ZoneQS(client, ""),
 		nil,
 		&resp); err != nil {
 
@@ -131,7 +163,7 @@ func ExportCreate(
 		ctx,
 		exportsPath,
 		"",
-		nil,
+		api.ZoneQS(client, ""),
 		nil,
 		export,
 		&resp); err != nil {
@@ -161,9 +193,7 @@ func ExportCreateWithZone(
 		ctx,
 		exportsPath,
 		"",
-		api.OrderedValues{
-			{[]byte("zone"), []byte(zone)},
-		},
+		api.ZoneQS(client, zone),
 		nil,
 		export,
 		&resp); err != nil {
@@ -184,7 +214,7 @@ func ExportUpdate(
 		ctx,
 		exportsPath,
 		strconv.Itoa(export.ID),
-		nil,
+		api.ZoneQS(client, ""),
 		nil,
 		export,
 		nil)
@@ -200,7 +230,7 @@ func ExportDelete(
 		ctx,
 		exportsPath,
 		strconv.Itoa(id),
-		nil,
+		api.ZoneQS(client, ""),
 		nil,
 		nil)
 }
@@ -215,9 +245,7 @@ func ExportDeleteWithZone(
 		ctx,
 		exportsPath,
 		strconv.Itoa(id),
-		api.OrderedValues{
-			{[]byte("zone"), []byte(zone)},
-		},
+		api.ZoneQS(client, zone),
 		nil,
 		nil)
 }
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"path"
@@ -20,7 +21,7 @@ type ContainerChild struct {
 	Owner *string   `json:"owner,omitempty"`
 	Group *string   `json:"group,omitempty"`
 	Mode  *FileMode `json:"mode,omitempty"`
-	Size  *int      `json:"size,omitempty"`
+	Size  *int64    `json:"size,omitempty"`
 }
 
 type resumeableContainerChildList struct {
@@ -358,6 +359,26 @@ func ContainerCreateFile(
 		nil)
 }
 
+// ContainerGetFile streams the contents of a file in a container to w.
+func ContainerGetFile(
+	ctx context.Context,
+	client api.Client,
+	filePath string,
+	w io.Writer) error {
+
+	if w == nil {
+		return errors.New("no writer set")
+	}
+
+	return client.Get(
+		ctx,
+		realNamespacePath(client),
+		filePath,
+		nil,
+		nil,
+		w)
+}
+
 // ContainerChildDelete deletes a child of a container.
 func ContainerChildDelete(
 	ctx context.Context,
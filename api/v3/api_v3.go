@@ -0,0 +1,74 @@
+package v3
+
+const (
+	namespacePath             = "namespace"
+	diagnosticsGatherPath     = "platform/3/cluster/diagnostics/gather"
+	diagnosticsBundlesDirPath = "/ifs/data/Isilon_Support/pkg"
+	auditGlobalSettingsPath   = "platform/3/audit/settings/global"
+	auditSettingsPath         = "platform/3/audit/settings"
+	auditTopicsPath           = "platform/3/audit/topics"
+	antivirusServersPath      = "platform/3/antivirus/servers"
+	antivirusPoliciesPath     = "platform/3/antivirus/policies"
+	antivirusSettingsPath     = "platform/3/antivirus/settings"
+	antivirusScanPath         = "platform/3/antivirus/scan"
+	antivirusScanReportsPath  = "platform/3/antivirus/reports/scans"
+	antivirusThreatsPath      = "platform/3/antivirus/reports/threats"
+	antivirusQuarantinePath   = "platform/3/antivirus/quarantine"
+	jobsPath                  = "platform/3/job/jobs"
+	jobPoliciesPath           = "platform/3/job/policies"
+	cloudAccountsPath         = "platform/3/cloud/accounts"
+	cloudPoolsPath            = "platform/3/cloud/pools"
+	cloudJobsPath             = "platform/3/cloud/jobs"
+	storagePoolsPath          = "platform/3/storagepool/storagepools"
+	nodePoolsPath             = "platform/3/storagepool/nodepools"
+	tiersPath                 = "platform/3/storagepool/tiers"
+	storagePoolSettingsPath   = "platform/3/storagepool/settings"
+	certificateServerPath     = "platform/3/certificate/server"
+	certificateAuthorityPath  = "platform/3/certificate/authority"
+	esrsSettingsPath          = "platform/3/esrs/settings"
+	snmpSettingsPath          = "platform/3/protocols/snmp/settings"
+	eventChannelsPath         = "platform/3/event/channels"
+	eventEventsPath           = "platform/3/event/events"
+	eventSettingsPath         = "platform/3/event/settings"
+	groupnetsPath             = "platform/3/network/groupnets"
+	subnetsPathSegment        = "subnets"
+	poolsPathSegment          = "pools"
+	networkPoolsPath          = "platform/3/network/pools"
+	networkExternalPath       = "platform/3/network/external"
+	networkInterfacesPath     = "platform/3/network/interfaces"
+	dnsCacheFlushPath         = "platform/3/network/dnscache/flush"
+	authUsersPath             = "platform/3/auth/users"
+	authGroupsPath            = "platform/3/auth/groups"
+	membersPathSegment        = "members"
+	authRolesPath             = "platform/3/auth/roles"
+	authPrivilegesPath        = "platform/3/auth/privileges"
+	privilegesPathSegment     = "privileges"
+	authADSProvidersPath      = "platform/3/auth/providers/ads"
+	zonesPath                 = "platform/3/zones"
+	authKrb5ProvidersPath     = "platform/3/auth/providers/krb5"
+	krb5RealmsPath            = "platform/3/auth/settings/krb5/realms"
+	krb5DomainsPath           = "platform/3/auth/settings/krb5/domains"
+	mappingUserRulesPath      = "platform/3/auth/mapping/users/rules"
+	mappingIdentitiesPath     = "platform/3/auth/mapping/identities"
+	mappingDumpPath           = "platform/3/auth/mapping/dump"
+	mappingUserLookupPath     = "platform/3/auth/mapping/users/lookup"
+	authIDPath                = "platform/3/auth/id"
+	aclSettingsPath           = "platform/3/auth/settings/acls"
+	fileFilterSettingsPath    = "platform/3/file-filter/settings"
+	smbSharesPath             = "platform/3/protocols/smb/shares"
+	clusterNodesPath          = "platform/3/cluster/nodes"
+	internalNetworksPath      = "platform/3/cluster/internal-networks"
+	authLocalProvidersPath    = "platform/3/auth/providers/local"
+	changePasswordPathSegment = "change-password"
+	wormDomainsPath           = "platform/3/worm/domains"
+	statsSummaryProtocolPath  = "platform/3/statistics/summary/protocol"
+	statsSummaryClientPath    = "platform/3/statistics/summary/client"
+	fsaResultsPath            = "platform/3/fsa/results"
+	directoriesPathSegment    = "directories"
+	syncSettingsPath          = "platform/3/sync/settings"
+	syncReportsPath           = "platform/3/sync/reports"
+	syncReportsRotatePath     = "platform/3/sync/reports-rotate"
+	syncPoliciesPath          = "platform/3/sync/policies"
+	syncJobsPath              = "platform/3/sync/jobs"
+	resetPathSegment          = "reset"
+)
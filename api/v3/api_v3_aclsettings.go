@@ -0,0 +1,84 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiACLPolicySettings are the cluster's ACL policy settings, which control
+// how permissions behave when files are accessed over several protocols.
+type IsiACLPolicySettings struct {
+	// Access is the permission model used by access checks, "unix" or
+	// "windows".
+	Access *string `json:"access,omitempty"`
+
+	// CalcMode is how the mode bits of ACL'd files are approximated,
+	// "approx" or "777".
+	CalcMode         *string `json:"calcmode,omitempty"`
+	CalcModeGroup    *string `json:"calcmode_group,omitempty"`
+	CalcModeOwner    *string `json:"calcmode_owner,omitempty"`
+	CalcModeTraverse *string `json:"calcmode_traverse,omitempty"`
+
+	// Chmod is what a chmod does to the ACL of a file, ex. "remove",
+	// "replace", "merge" or "ignore".
+	Chmod            *string `json:"chmod,omitempty"`
+	Chmod007         *string `json:"chmod_007,omitempty"`
+	ChmodInheritable *string `json:"chmod_inheritable,omitempty"`
+	Chown            *string `json:"chown,omitempty"`
+
+	CreateOverSMB *string `json:"create_over_smb,omitempty"`
+	DOSAttr       *string `json:"dos_attr,omitempty"`
+
+	// GroupOwnerInheritance is how the group of new files is chosen,
+	// "native", "parent" or "creator".
+	GroupOwnerInheritance *string `json:"group_owner_inheritance,omitempty"`
+
+	// RWX is how rwx permissions appear in synthetic ACLs, "retain" or
+	// "full_control".
+	RWX *string `json:"rwx,omitempty"`
+
+	// SyntheticDenies is whether synthetic ACLs include deny ACEs, "none"
+	// or "remove".
+	SyntheticDenies *string `json:"synthetic_denies,omitempty"`
+
+	Utimes *string `json:"utimes,omitempty"`
+}
+
+type getIsiACLPolicySettingsResp struct {
+	Settings *IsiACLPolicySettings `json:"settings"`
+}
+
+// GetIsiACLPolicySettings queries the cluster's ACL policy settings
+func GetIsiACLPolicySettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiACLPolicySettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/settings/acls
+	var resp getIsiACLPolicySettingsResp
+	err = client.Get(ctx, aclSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("acl policy settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiACLPolicySettings modifies the cluster's ACL policy settings.
+// Only the fields that are set are changed.
+func UpdateIsiACLPolicySettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiACLPolicySettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/settings/acls
+	//            Content-Type: application/json
+	//            {chmod: "merge", synthetic_denies: "remove"}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, aclSettingsPath, "", nil, nil, settings, nil)
+}
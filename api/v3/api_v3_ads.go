@@ -0,0 +1,158 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiADSProvider is an Active Directory authentication provider. The
+// pointer fields are settings that may be modified after the cluster has
+// joined the domain.
+type IsiADSProvider struct {
+	ID                      string    `json:"id,omitmarshal"`
+	Name                    string    `json:"name,omitmarshal"`
+	Hostname                string    `json:"hostname,omitmarshal"`
+	MachineAccount          string    `json:"machine_account,omitmarshal"`
+	Forest                  string    `json:"forest,omitmarshal"`
+	PrimaryDomain           string    `json:"primary_domain,omitmarshal"`
+	NetBIOSDomain           string    `json:"netbios_domain,omitmarshal"`
+	Site                    string    `json:"site,omitmarshal"`
+	Groupnet                string    `json:"groupnet,omitmarshal"`
+	Status                  string    `json:"status,omitmarshal"`
+	System                  bool      `json:"system,omitmarshal"`
+	AllocateGIDs            *bool     `json:"allocate_gids,omitempty"`
+	AllocateUIDs            *bool     `json:"allocate_uids,omitempty"`
+	AssumeDefaultDomain     *bool     `json:"assume_default_domain,omitempty"`
+	CheckOnlineInterval     *int      `json:"check_online_interval,omitempty"`
+	CreateHomeDirectory     *bool     `json:"create_home_directory,omitempty"`
+	ExtraExpectedSPNs       *[]string `json:"extra_expected_spns,omitempty"`
+	HomeDirectoryTemplate   *string   `json:"home_directory_template,omitempty"`
+	LoginShell              *string   `json:"login_shell,omitempty"`
+	LookupDomains           *[]string `json:"lookup_domains,omitempty"`
+	LookupGroups            *bool     `json:"lookup_groups,omitempty"`
+	LookupNormalizeGroups   *bool     `json:"lookup_normalize_groups,omitempty"`
+	LookupNormalizeUsers    *bool     `json:"lookup_normalize_users,omitempty"`
+	LookupUsers             *bool     `json:"lookup_users,omitempty"`
+	MachinePasswordChanges  *bool     `json:"machine_password_changes,omitempty"`
+	MachinePasswordLifespan *int      `json:"machine_password_lifespan,omitempty"`
+	NodeDCAffinity          *string   `json:"node_dc_affinity,omitempty"`
+	NodeDCAffinityTimeout   *int      `json:"node_dc_affinity_timeout,omitempty"`
+	SFUSupport              *string   `json:"sfu_support,omitempty"`
+	SPNs                    *[]string `json:"spns,omitempty"`
+	StoreSFUMappings        *bool     `json:"store_sfu_mappings,omitempty"`
+}
+
+type getIsiADSProvidersResp struct {
+	ADS []*IsiADSProvider `json:"ads"`
+}
+
+// IsiADSJoinReq is used to join the cluster to an Active Directory domain.
+type IsiADSJoinReq struct {
+	Name               string  `json:"name"`
+	User               string  `json:"user"`
+	Password           string  `json:"password"`
+	MachineAccount     *string `json:"machine_account,omitempty"`
+	OrganizationalUnit *string `json:"organizational_unit,omitempty"`
+	Groupnet           *string `json:"groupnet,omitempty"`
+	DNSDomain          *string `json:"dns_domain,omitempty"`
+	Instance           *string `json:"instance,omitempty"`
+}
+
+// GetIsiADSProviders queries a list of all Active Directory providers,
+// limited to those used by an access zone if zone is set
+func GetIsiADSProviders(
+	ctx context.Context,
+	client api.Client,
+	zone string) (providers []*IsiADSProvider, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/providers/ads?zone=zone
+	var resp getIsiADSProvidersResp
+	err = client.Get(
+		ctx, authADSProvidersPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.ADS, nil
+}
+
+// GetIsiADSProvider queries an individual Active Directory provider by
+// domain name
+func GetIsiADSProvider(
+	ctx context.Context,
+	client api.Client,
+	id string) (provider *IsiADSProvider, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/providers/ads/id
+	if id == "" {
+		return nil, errors.New("no provider id set")
+	}
+
+	var resp getIsiADSProvidersResp
+	err = client.Get(ctx, authADSProvidersPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.ADS) == 0 {
+		return nil, errors.New("ads provider missing from response")
+	}
+	return resp.ADS[0], nil
+}
+
+// JoinIsiADSDomain joins the cluster to an Active Directory domain and
+// returns the id of the new provider
+func JoinIsiADSDomain(
+	ctx context.Context,
+	client api.Client,
+	req *IsiADSJoinReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/providers/ads
+	//            Content-Type: application/json
+	//            {name: "ad.example.com", user: "administrator",
+	//             password: "secret"}
+	if req == nil || req.Name == "" {
+		return "", errors.New("no domain name set")
+	}
+	if req.User == "" {
+		return "", errors.New("no domain user set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(ctx, authADSProvidersPath, "", nil, nil, req, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiADSProvider modifies the settings of an Active Directory provider
+func UpdateIsiADSProvider(
+	ctx context.Context,
+	client api.Client,
+	id string, provider *IsiADSProvider) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/providers/ads/id
+	//            Content-Type: application/json
+	//            {lookup_users: true, lookup_domains: ["ad.example.com"]}
+	if id == "" {
+		return errors.New("no provider id set")
+	}
+	if provider == nil {
+		return errors.New("no provider settings set")
+	}
+	return client.Put(ctx, authADSProvidersPath, id, nil, nil, provider, nil)
+}
+
+// LeaveIsiADSDomain removes an Active Directory provider, leaving the domain
+func LeaveIsiADSDomain(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/providers/ads/id
+	if id == "" {
+		return errors.New("no provider id set")
+	}
+	return client.Delete(ctx, authADSProvidersPath, id, nil, nil, nil)
+}
@@ -0,0 +1,453 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiAntivirusServer is an ICAP server used for antivirus scanning.
+type IsiAntivirusServer struct {
+	ID          string  `json:"id,omitmarshal"`
+	URL         *string `json:"url,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Enabled     *bool   `json:"enabled,omitempty"`
+}
+
+type getIsiAntivirusServersResp struct {
+	Servers []*IsiAntivirusServer `json:"servers"`
+}
+
+// IsiAntivirusPolicy is a scheduled or on-demand antivirus scan policy.
+type IsiAntivirusPolicy struct {
+	ID             string    `json:"id,omitmarshal"`
+	Name           *string   `json:"name,omitempty"`
+	Description    *string   `json:"description,omitempty"`
+	Enabled        *bool     `json:"enabled,omitempty"`
+	ForceRun       *bool     `json:"force_run,omitempty"`
+	Impact         *string   `json:"impact,omitempty"`
+	Paths          *[]string `json:"paths,omitempty"`
+	RecursionDepth *int      `json:"recursion_depth,omitempty"`
+	Schedule       *string   `json:"schedule,omitempty"`
+}
+
+type getIsiAntivirusPoliciesResp struct {
+	Policies []*IsiAntivirusPolicy `json:"policies"`
+}
+
+type postIsiAntivirusResp struct {
+	ID string `json:"id"`
+}
+
+// IsiAntivirusSettings are the global antivirus settings.
+type IsiAntivirusSettings struct {
+	FailOpen           *bool     `json:"fail_open,omitempty"`
+	GlobFilters        *[]string `json:"glob_filters,omitempty"`
+	GlobFiltersEnabled *bool     `json:"glob_filters_enabled,omitempty"`
+	GlobFiltersInclude *bool     `json:"glob_filters_include,omitempty"`
+	PathPrefixes       *[]string `json:"path_prefixes,omitempty"`
+	Quarantine         *bool     `json:"quarantine,omitempty"`
+	Repair             *bool     `json:"repair,omitempty"`
+	ReportExpiry       *int64    `json:"report_expiry,omitempty"`
+	ScanOnClose        *bool     `json:"scan_on_close,omitempty"`
+	ScanOnOpen         *bool     `json:"scan_on_open,omitempty"`
+	ScanSizeMaximum    *int64    `json:"scan_size_maximum,omitempty"`
+	Service            *bool     `json:"service,omitempty"`
+}
+
+type getIsiAntivirusSettingsResp struct {
+	Settings *IsiAntivirusSettings `json:"settings"`
+}
+
+type isiAntivirusScanReq struct {
+	File string `json:"file"`
+}
+
+// IsiAntivirusScanResult is the result of scanning a single file.
+type IsiAntivirusScanResult struct {
+	File     string `json:"file"`
+	ReportID string `json:"report_id"`
+	Result   string `json:"result"`
+}
+
+// IsiAntivirusScanReport is the report of an antivirus scan.
+type IsiAntivirusScanReport struct {
+	ID         string   `json:"id"`
+	Policy     string   `json:"policy"`
+	JobID      int64    `json:"job_id"`
+	Status     string   `json:"status"`
+	Start      int64    `json:"start"`
+	End        int64    `json:"end"`
+	Duration   int64    `json:"duration"`
+	Files      int64    `json:"files"`
+	Infections int64    `json:"infections"`
+	TotalSize  int64    `json:"total_size"`
+	Paths      []string `json:"paths"`
+	ReportID   string   `json:"report_id"`
+}
+
+type getIsiAntivirusScanReportsResp struct {
+	Reports []*IsiAntivirusScanReport `json:"reports"`
+}
+
+// IsiAntivirusThreat is a threat found by an antivirus scan.
+type IsiAntivirusThreat struct {
+	ID          string   `json:"id"`
+	File        string   `json:"file"`
+	Remediation string   `json:"remediation"`
+	ReportID    string   `json:"report_id"`
+	Threat      string   `json:"threat"`
+	Time        int64    `json:"time"`
+	Policies    []string `json:"policies"`
+}
+
+type getIsiAntivirusThreatsResp struct {
+	Reports []*IsiAntivirusThreat `json:"reports"`
+}
+
+// IsiAntivirusQuarantine is the quarantine state of a file.
+type IsiAntivirusQuarantine struct {
+	File        string `json:"file"`
+	Quarantined bool   `json:"quarantined"`
+	LastISTag   string `json:"last_istag"`
+	LastScan    int64  `json:"last_scan"`
+}
+
+type isiAntivirusQuarantineReq struct {
+	Quarantined bool `json:"quarantined"`
+}
+
+var byteArrReportID = []byte("report_id")
+
+// GetIsiAntivirusServers queries a list of all antivirus servers
+func GetIsiAntivirusServers(
+	ctx context.Context,
+	client api.Client) (servers []*IsiAntivirusServer, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/servers
+	var resp getIsiAntivirusServersResp
+	err = client.Get(ctx, antivirusServersPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Servers, nil
+}
+
+// CreateIsiAntivirusServer adds an antivirus server and returns its id
+func CreateIsiAntivirusServer(
+	ctx context.Context,
+	client api.Client,
+	server *IsiAntivirusServer) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/antivirus/servers
+	//            Content-Type: application/json
+	//            {url: "icap://10.0.0.1", enabled: true}
+	if server == nil || server.URL == nil || *server.URL == "" {
+		return "", errors.New("no server url set")
+	}
+
+	var resp postIsiAntivirusResp
+	err = client.Post(
+		ctx, antivirusServersPath, "", nil, nil, server, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiAntivirusServer modifies an antivirus server
+func UpdateIsiAntivirusServer(
+	ctx context.Context,
+	client api.Client,
+	server *IsiAntivirusServer) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/antivirus/servers/id
+	if server == nil || server.ID == "" {
+		return errors.New("no server id set")
+	}
+	return client.Put(
+		ctx, antivirusServersPath, server.ID, nil, nil, server, nil)
+}
+
+// DeleteIsiAntivirusServer removes an antivirus server
+func DeleteIsiAntivirusServer(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/antivirus/servers/id
+	if id == "" {
+		return errors.New("no server id set")
+	}
+	return client.Delete(ctx, antivirusServersPath, id, nil, nil, nil)
+}
+
+// GetIsiAntivirusPolicies queries a list of all antivirus policies
+func GetIsiAntivirusPolicies(
+	ctx context.Context,
+	client api.Client) (policies []*IsiAntivirusPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/policies
+	var resp getIsiAntivirusPoliciesResp
+	err = client.Get(ctx, antivirusPoliciesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Policies, nil
+}
+
+// GetIsiAntivirusPolicy queries an individual antivirus policy
+func GetIsiAntivirusPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (policy *IsiAntivirusPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/policies/id
+	var resp getIsiAntivirusPoliciesResp
+	err = client.Get(ctx, antivirusPoliciesPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Policies) == 0 {
+		return nil, errors.New("antivirus policy missing from response")
+	}
+	return resp.Policies[0], nil
+}
+
+// CreateIsiAntivirusPolicy creates an antivirus policy and returns its id
+func CreateIsiAntivirusPolicy(
+	ctx context.Context,
+	client api.Client,
+	policy *IsiAntivirusPolicy) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/antivirus/policies
+	//            Content-Type: application/json
+	//            {name: "policy_name", paths: ["/path/to/volume"]}
+	if policy == nil || policy.Name == nil || *policy.Name == "" {
+		return "", errors.New("no policy name set")
+	}
+
+	var resp postIsiAntivirusResp
+	err = client.Post(
+		ctx, antivirusPoliciesPath, "", nil, nil, policy, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiAntivirusPolicy modifies an antivirus policy
+func UpdateIsiAntivirusPolicy(
+	ctx context.Context,
+	client api.Client,
+	policy *IsiAntivirusPolicy) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/antivirus/policies/id
+	if policy == nil || policy.ID == "" {
+		return errors.New("no policy id set")
+	}
+	return client.Put(
+		ctx, antivirusPoliciesPath, policy.ID, nil, nil, policy, nil)
+}
+
+// DeleteIsiAntivirusPolicy removes an antivirus policy
+func DeleteIsiAntivirusPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/antivirus/policies/id
+	if id == "" {
+		return errors.New("no policy id set")
+	}
+	return client.Delete(ctx, antivirusPoliciesPath, id, nil, nil, nil)
+}
+
+// GetIsiAntivirusSettings queries the global antivirus settings
+func GetIsiAntivirusSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiAntivirusSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/settings
+	var resp getIsiAntivirusSettingsResp
+	err = client.Get(ctx, antivirusSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("antivirus settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiAntivirusSettings modifies the global antivirus settings. Only the
+// fields that are set are changed.
+func UpdateIsiAntivirusSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiAntivirusSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/antivirus/settings
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, antivirusSettingsPath, "", nil, nil, settings, nil)
+}
+
+// ScanIsiAntivirusFile scans a single file immediately
+func ScanIsiAntivirusFile(
+	ctx context.Context,
+	client api.Client,
+	file string) (result *IsiAntivirusScanResult, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/antivirus/scan
+	//            Content-Type: application/json
+	//            {file: "/path/to/file"}
+	if file == "" {
+		return nil, errors.New("no file set")
+	}
+
+	err = client.Post(
+		ctx, antivirusScanPath, "", nil, nil,
+		&isiAntivirusScanReq{File: file}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StartIsiAntivirusPolicyScan runs an antivirus policy as an AVScan job and
+// returns the job id
+func StartIsiAntivirusPolicyScan(
+	ctx context.Context,
+	client api.Client,
+	policyName string) (jobID int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/job/jobs
+	//            Content-Type: application/json
+	//            {type: "AVScan", avscan_params: {policy: "policy_name"}}
+	if policyName == "" {
+		return 0, errors.New("no policy name set")
+	}
+
+	return StartIsiJob(ctx, client, &IsiJobReq{
+		Type:         "AVScan",
+		AVScanParams: &IsiJobAVScanParams{Policy: policyName},
+	})
+}
+
+// GetIsiAntivirusScanReports queries a list of antivirus scan reports
+func GetIsiAntivirusScanReports(
+	ctx context.Context,
+	client api.Client) (reports []*IsiAntivirusScanReport, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/reports/scans
+	var resp getIsiAntivirusScanReportsResp
+	err = client.Get(ctx, antivirusScanReportsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Reports, nil
+}
+
+// GetIsiAntivirusScanReport queries an individual antivirus scan report
+func GetIsiAntivirusScanReport(
+	ctx context.Context,
+	client api.Client,
+	id string) (report *IsiAntivirusScanReport, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/reports/scans/id
+	if id == "" {
+		return nil, errors.New("no report id set")
+	}
+	var resp getIsiAntivirusScanReportsResp
+	err = client.Get(ctx, antivirusScanReportsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Reports) == 0 {
+		return nil, errors.New("antivirus scan report missing from response")
+	}
+	return resp.Reports[0], nil
+}
+
+// GetIsiAntivirusThreats queries a list of threats found by antivirus scans
+func GetIsiAntivirusThreats(
+	ctx context.Context,
+	client api.Client) (threats []*IsiAntivirusThreat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/reports/threats
+	var resp getIsiAntivirusThreatsResp
+	err = client.Get(ctx, antivirusThreatsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Reports, nil
+}
+
+// GetIsiAntivirusReportThreats queries a list of threats found by the
+// antivirus scan with the given report id
+func GetIsiAntivirusReportThreats(
+	ctx context.Context,
+	client api.Client,
+	reportID string) (threats []*IsiAntivirusThreat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/reports/threats?report_id=id
+	if reportID == "" {
+		return nil, errors.New("no report id set")
+	}
+	var resp getIsiAntivirusThreatsResp
+	err = client.Get(
+		ctx, antivirusThreatsPath, "",
+		api.OrderedValues{{byteArrReportID, []byte(reportID)}},
+		nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Reports, nil
+}
+
+// GetIsiAntivirusQuarantine queries the quarantine state of a file by its
+// absolute path
+func GetIsiAntivirusQuarantine(
+	ctx context.Context,
+	client api.Client,
+	file string) (quarantine *IsiAntivirusQuarantine, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/antivirus/quarantine/path/to/file
+	if file == "" {
+		return nil, errors.New("no file set")
+	}
+	err = client.Get(
+		ctx, antivirusQuarantinePath, strings.TrimPrefix(file, "/"),
+		nil, nil, &quarantine)
+	if err != nil {
+		return nil, err
+	}
+	if quarantine == nil {
+		return nil, errors.New("antivirus quarantine missing from response")
+	}
+	return quarantine, nil
+}
+
+// UpdateIsiAntivirusQuarantine quarantines or releases a file by its
+// absolute path
+func UpdateIsiAntivirusQuarantine(
+	ctx context.Context,
+	client api.Client,
+	file string,
+	quarantined bool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/antivirus/quarantine/path/to/file
+	//            Content-Type: application/json
+	//            {quarantined: false}
+	if file == "" {
+		return errors.New("no file set")
+	}
+	return client.Put(
+		ctx, antivirusQuarantinePath, strings.TrimPrefix(file, "/"),
+		nil, nil, &isiAntivirusQuarantineReq{Quarantined: quarantined}, nil)
+}
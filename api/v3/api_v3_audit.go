@@ -0,0 +1,165 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// Audit events of protocol access that may be audited in an access zone.
+// AuditEventAll selects every event.
+const (
+	AuditEventAll         = "all"
+	AuditEventClose       = "close"
+	AuditEventCreate      = "create"
+	AuditEventDelete      = "delete"
+	AuditEventGetSecurity = "get_security"
+	AuditEventLogoff      = "logoff"
+	AuditEventLogon       = "logon"
+	AuditEventRead        = "read"
+	AuditEventRename      = "rename"
+	AuditEventSetSecurity = "set_security"
+	AuditEventTreeConnect = "tree_connect"
+	AuditEventWrite       = "write"
+)
+
+// IsiAuditGlobalSettings are the cluster-wide audit settings.
+type IsiAuditGlobalSettings struct {
+	AuditedZones            *[]string `json:"audited_zones,omitempty"`
+	CEEServerURIs           *[]string `json:"cee_server_uris,omitempty"`
+	ConfigAuditingEnabled   *bool     `json:"config_auditing_enabled,omitempty"`
+	ConfigSyslogEnabled     *bool     `json:"config_syslog_enabled,omitempty"`
+	Hostname                *string   `json:"hostname,omitempty"`
+	ProtocolAuditingEnabled *bool     `json:"protocol_auditing_enabled,omitempty"`
+	ProtocolSyslogEnabled   *bool     `json:"protocol_syslog_enabled,omitempty"`
+	SyslogServers           *[]string `json:"syslog_servers,omitempty"`
+}
+
+type getIsiAuditGlobalSettingsResp struct {
+	Settings *IsiAuditGlobalSettings `json:"settings"`
+}
+
+// IsiAuditZoneSettings are the audit settings of an access zone.
+type IsiAuditZoneSettings struct {
+	AuditFailure            *[]string `json:"audit_failure,omitempty"`
+	AuditSuccess            *[]string `json:"audit_success,omitempty"`
+	SyslogAuditEvents       *[]string `json:"syslog_audit_events,omitempty"`
+	SyslogForwardingEnabled *bool     `json:"syslog_forwarding_enabled,omitempty"`
+}
+
+type getIsiAuditZoneSettingsResp struct {
+	Settings *IsiAuditZoneSettings `json:"settings"`
+}
+
+// IsiAuditTopic is an audit topic.
+type IsiAuditTopic struct {
+	ID                string `json:"id,omitempty"`
+	Name              string `json:"name,omitempty"`
+	MaxCachedMessages int64  `json:"max_cached_messages,omitempty"`
+}
+
+type getIsiAuditTopicsResp struct {
+	Topics []*IsiAuditTopic `json:"topics"`
+}
+
+// GetIsiAuditGlobalSettings queries the cluster-wide audit settings
+func GetIsiAuditGlobalSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiAuditGlobalSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/audit/settings/global
+	var resp getIsiAuditGlobalSettingsResp
+	err = client.Get(ctx, auditGlobalSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("audit settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiAuditGlobalSettings modifies the cluster-wide audit settings. Only
+// the fields that are set are changed.
+func UpdateIsiAuditGlobalSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiAuditGlobalSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/audit/settings/global
+	//            Content-Type: application/json
+	//            {cee_server_uris: ["http://cee.example.com:12228/cee"]}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, auditGlobalSettingsPath, "", nil, nil, settings, nil)
+}
+
+// GetIsiAuditZoneSettings queries the audit settings of an access zone
+func GetIsiAuditZoneSettings(
+	ctx context.Context,
+	client api.Client,
+	zone string) (settings *IsiAuditZoneSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/audit/settings?zone=zone
+	var resp getIsiAuditZoneSettingsResp
+	err = client.Get(
+		ctx, auditSettingsPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("audit settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiAuditZoneSettings modifies the audit settings of an access zone.
+// Only the fields that are set are changed.
+func UpdateIsiAuditZoneSettings(
+	ctx context.Context,
+	client api.Client,
+	zone string, settings *IsiAuditZoneSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/audit/settings?zone=zone
+	//            Content-Type: application/json
+	//            {audit_success: ["create", "delete"]}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, auditSettingsPath, "", api.ZoneQS(client, zone), nil, settings, nil)
+}
+
+// GetIsiAuditTopics queries a list of all audit topics
+func GetIsiAuditTopics(
+	ctx context.Context,
+	client api.Client) (topics []*IsiAuditTopic, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/audit/topics
+	var resp getIsiAuditTopicsResp
+	err = client.Get(ctx, auditTopicsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Topics, nil
+}
+
+// UpdateIsiAuditTopic modifies an audit topic
+func UpdateIsiAuditTopic(
+	ctx context.Context,
+	client api.Client,
+	id string, maxCachedMessages int64) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/audit/topics/id
+	//            Content-Type: application/json
+	//            {max_cached_messages: 2048}
+	if id == "" {
+		return errors.New("no topic id set")
+	}
+	return client.Put(
+		ctx, auditTopicsPath, id, nil, nil,
+		&IsiAuditTopic{MaxCachedMessages: maxCachedMessages}, nil)
+}
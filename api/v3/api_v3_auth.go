@@ -0,0 +1,481 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// IsiUser is a user known to an authentication provider.
+type IsiUser struct {
+	Name               string         `json:"name"`
+	DN                 string         `json:"dn"`
+	Email              string         `json:"email"`
+	Enabled            bool           `json:"enabled"`
+	Expired            bool           `json:"expired"`
+	Expiry             int64          `json:"expiry"`
+	Gecos              string         `json:"gecos"`
+	HomeDirectory      string         `json:"home_directory"`
+	Locked             bool           `json:"locked"`
+	OnDiskUserIdentity *apiv2.Persona `json:"on_disk_user_identity"`
+	PasswordExpired    bool           `json:"password_expired"`
+	PasswordExpires    bool           `json:"password_expires"`
+	PasswordExpiry     int64          `json:"password_expiry"`
+	PasswordLastSet    int64          `json:"password_last_set"`
+	PrimaryGroupSID    *apiv2.Persona `json:"primary_group_sid"`
+	Provider           string         `json:"provider"`
+	SamAccountName     string         `json:"sam_account_name"`
+	Shell              string         `json:"shell"`
+	SID                *apiv2.Persona `json:"sid"`
+	Type               string         `json:"type"`
+	UID                *apiv2.Persona `json:"uid"`
+	UPN                string         `json:"upn"`
+}
+
+type getIsiUsersResp struct {
+	Users []*IsiUser `json:"users"`
+}
+
+// IsiUserReq is used to create or modify a local user. The name may only be
+// set when creating a user.
+type IsiUserReq struct {
+	Name            *string        `json:"name,omitempty"`
+	UID             *int           `json:"uid,omitempty"`
+	Password        *string        `json:"password,omitempty"`
+	HomeDirectory   *string        `json:"home_directory,omitempty"`
+	Shell           *string        `json:"shell,omitempty"`
+	Email           *string        `json:"email,omitempty"`
+	Enabled         *bool          `json:"enabled,omitempty"`
+	Expiry          *int64         `json:"expiry,omitempty"`
+	FullName        *string        `json:"gecos,omitempty"`
+	PasswordExpires *bool          `json:"password_expires,omitempty"`
+	PrimaryGroup    *apiv2.Persona `json:"primary_group,omitempty"`
+	Unlock          *bool          `json:"unlock,omitempty"`
+}
+
+// IsiGroup is a group known to an authentication provider.
+type IsiGroup struct {
+	Name         string         `json:"name"`
+	DN           string         `json:"dn"`
+	GID          *apiv2.Persona `json:"gid"`
+	GeneratedGID bool           `json:"generated_gid"`
+	Provider     string         `json:"provider"`
+	SID          *apiv2.Persona `json:"sid"`
+	Type         string         `json:"type"`
+}
+
+type getIsiGroupsResp struct {
+	Groups []*IsiGroup `json:"groups"`
+}
+
+// IsiGroupReq is used to create or modify a local group. The name and
+// members may only be set when creating a group.
+type IsiGroupReq struct {
+	Name    *string          `json:"name,omitempty"`
+	GID     *int             `json:"gid,omitempty"`
+	Members *[]apiv2.Persona `json:"members,omitempty"`
+}
+
+type getIsiGroupMembersResp struct {
+	Members []*apiv2.Persona `json:"members"`
+}
+
+// IsiAuthID is the access token of the authenticated user.
+type IsiAuthID struct {
+	UID                *apiv2.Persona   `json:"uid"`
+	UserSID            *apiv2.Persona   `json:"user_sid"`
+	GID                *apiv2.Persona   `json:"gid"`
+	GroupSID           *apiv2.Persona   `json:"group_sid"`
+	AdditionalID       []*apiv2.Persona `json:"additional_id"`
+	OnDiskUserIdentity *apiv2.Persona   `json:"on_disk_user_identity"`
+	Privilege          []*IsiPrivilege  `json:"privilege"`
+	ZID                int              `json:"zid"`
+	ZoneName           string           `json:"zone_name"`
+}
+
+type getIsiAuthIDResp struct {
+	NToken *IsiAuthID `json:"ntoken"`
+}
+
+type isiChangePasswordReq struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// IsiLocalProvider is the local authentication provider of an access zone,
+// which holds the password and lockout policy of local users.
+type IsiLocalProvider struct {
+	ID                    string    `json:"id,omitmarshal"`
+	Name                  string    `json:"name,omitmarshal"`
+	ZoneName              string    `json:"zone_name,omitmarshal"`
+	LockoutDuration       *int      `json:"lockout_duration,omitempty"`
+	LockoutThreshold      *int      `json:"lockout_threshold,omitempty"`
+	LockoutWindow         *int      `json:"lockout_window,omitempty"`
+	MaxPasswordAge        *int      `json:"max_password_age,omitempty"`
+	MinPasswordAge        *int      `json:"min_password_age,omitempty"`
+	MinPasswordLength     *int      `json:"min_password_length,omitempty"`
+	PasswordComplexity    *[]string `json:"password_complexity,omitempty"`
+	PasswordHistoryLength *int      `json:"password_history_length,omitempty"`
+	PasswordPromptTime    *int      `json:"password_prompt_time,omitempty"`
+}
+
+type getIsiLocalProvidersResp struct {
+	Local []*IsiLocalProvider `json:"local"`
+}
+
+type postIsiAuthResp struct {
+	ID string `json:"id"`
+}
+
+// GetIsiUsers queries a list of all users in an access zone
+func GetIsiUsers(
+	ctx context.Context,
+	client api.Client,
+	zone string) (users []*IsiUser, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/users?zone=zone
+	var resp getIsiUsersResp
+	err = client.Get(
+		ctx, authUsersPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Users, nil
+}
+
+// GetIsiUser queries an individual user in an access zone by name or by a
+// persona id such as "UID:2000" or "SID:S-1-5-21-..."
+func GetIsiUser(
+	ctx context.Context,
+	client api.Client,
+	zone, user string) (u *IsiUser, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/users/user?zone=zone
+	if user == "" {
+		return nil, errors.New("no user set")
+	}
+
+	var resp getIsiUsersResp
+	err = client.Get(
+		ctx, authUsersPath, user, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Users) == 0 {
+		return nil, errors.New("user missing from response")
+	}
+	return resp.Users[0], nil
+}
+
+// CreateIsiUser creates a user with the local provider of an access zone and
+// returns its id
+func CreateIsiUser(
+	ctx context.Context,
+	client api.Client,
+	zone string, user *IsiUserReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/users?zone=zone
+	//            Content-Type: application/json
+	//            {name: "user1", uid: 2000, password: "secret",
+	//             home_directory: "/ifs/home/user1", shell: "/bin/zsh"}
+	if user == nil || user.Name == nil || *user.Name == "" {
+		return "", errors.New("no user name set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(
+		ctx, authUsersPath, "", api.ZoneQS(client, zone), nil, user, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiUser modifies a user in an access zone
+func UpdateIsiUser(
+	ctx context.Context,
+	client api.Client,
+	zone, user string, req *IsiUserReq) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/users/user?zone=zone
+	//            Content-Type: application/json
+	//            {enabled: false}
+	if user == "" {
+		return errors.New("no user set")
+	}
+	if req == nil {
+		return errors.New("no user settings set")
+	}
+	return client.Put(
+		ctx, authUsersPath, user, api.ZoneQS(client, zone), nil, req, nil)
+}
+
+// DeleteIsiUser removes a user from an access zone
+func DeleteIsiUser(
+	ctx context.Context,
+	client api.Client,
+	zone, user string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/users/user?zone=zone
+	if user == "" {
+		return errors.New("no user set")
+	}
+	return client.Delete(
+		ctx, authUsersPath, user, api.ZoneQS(client, zone), nil, nil)
+}
+
+// GetIsiGroups queries a list of all groups in an access zone
+func GetIsiGroups(
+	ctx context.Context,
+	client api.Client,
+	zone string) (groups []*IsiGroup, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/groups?zone=zone
+	var resp getIsiGroupsResp
+	err = client.Get(
+		ctx, authGroupsPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Groups, nil
+}
+
+// GetIsiGroup queries an individual group in an access zone by name or by a
+// persona id such as "GID:2000"
+func GetIsiGroup(
+	ctx context.Context,
+	client api.Client,
+	zone, group string) (g *IsiGroup, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/groups/group?zone=zone
+	if group == "" {
+		return nil, errors.New("no group set")
+	}
+
+	var resp getIsiGroupsResp
+	err = client.Get(
+		ctx, authGroupsPath, group, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Groups) == 0 {
+		return nil, errors.New("group missing from response")
+	}
+	return resp.Groups[0], nil
+}
+
+// CreateIsiGroup creates a group with the local provider of an access zone
+// and returns its id
+func CreateIsiGroup(
+	ctx context.Context,
+	client api.Client,
+	zone string, group *IsiGroupReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/groups?zone=zone
+	//            Content-Type: application/json
+	//            {name: "group1", gid: 2000}
+	if group == nil || group.Name == nil || *group.Name == "" {
+		return "", errors.New("no group name set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(
+		ctx, authGroupsPath, "", api.ZoneQS(client, zone), nil, group, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiGroup modifies a group in an access zone
+func UpdateIsiGroup(
+	ctx context.Context,
+	client api.Client,
+	zone, group string, req *IsiGroupReq) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/groups/group?zone=zone
+	//            Content-Type: application/json
+	//            {gid: 2001}
+	if group == "" {
+		return errors.New("no group set")
+	}
+	if req == nil {
+		return errors.New("no group settings set")
+	}
+	return client.Put(
+		ctx, authGroupsPath, group, api.ZoneQS(client, zone), nil, req, nil)
+}
+
+// DeleteIsiGroup removes a group from an access zone
+func DeleteIsiGroup(
+	ctx context.Context,
+	client api.Client,
+	zone, group string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/groups/group?zone=zone
+	if group == "" {
+		return errors.New("no group set")
+	}
+	return client.Delete(
+		ctx, authGroupsPath, group, api.ZoneQS(client, zone), nil, nil)
+}
+
+// GetIsiGroupMembers queries the members of a group in an access zone
+func GetIsiGroupMembers(
+	ctx context.Context,
+	client api.Client,
+	zone, group string) (members []*apiv2.Persona, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/groups/group/members?zone=zone
+	if group == "" {
+		return nil, errors.New("no group set")
+	}
+
+	var resp getIsiGroupMembersResp
+	err = client.Get(
+		ctx,
+		path.Join(authGroupsPath, group, membersPathSegment),
+		"",
+		api.ZoneQS(client, zone),
+		nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Members, nil
+}
+
+// AddIsiGroupMember adds a member to a group in an access zone
+func AddIsiGroupMember(
+	ctx context.Context,
+	client api.Client,
+	zone, group string, member *apiv2.Persona) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/groups/group/members?zone=zone
+	//            Content-Type: application/json
+	//            {id: "UID:2000"}
+	if group == "" {
+		return errors.New("no group set")
+	}
+	if member == nil || member.ID == nil {
+		return errors.New("no member id set")
+	}
+	return client.Post(
+		ctx,
+		path.Join(authGroupsPath, group, membersPathSegment),
+		"",
+		api.ZoneQS(client, zone),
+		nil,
+		member,
+		nil)
+}
+
+// RemoveIsiGroupMember removes a member from a group in an access zone. The
+// member is identified by a persona id such as "UID:2000".
+func RemoveIsiGroupMember(
+	ctx context.Context,
+	client api.Client,
+	zone, group, member string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/groups/group/members/member?zone=zone
+	if group == "" {
+		return errors.New("no group set")
+	}
+	if member == "" {
+		return errors.New("no member set")
+	}
+	return client.Delete(
+		ctx,
+		path.Join(authGroupsPath, group, membersPathSegment),
+		member,
+		api.ZoneQS(client, zone),
+		nil,
+		nil)
+}
+
+// GetIsiAuthID queries the access token of the authenticated user
+func GetIsiAuthID(
+	ctx context.Context,
+	client api.Client) (id *IsiAuthID, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/id
+	var resp getIsiAuthIDResp
+	err = client.Get(ctx, authIDPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.NToken == nil {
+		return nil, errors.New("ntoken missing from response")
+	}
+	return resp.NToken, nil
+}
+
+// ChangeIsiUserPassword changes the password of a user in an access zone
+func ChangeIsiUserPassword(
+	ctx context.Context,
+	client api.Client,
+	zone, user, oldPassword, newPassword string) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/users/user/change-password?zone=zone
+	//            Content-Type: application/json
+	//            {old_password: "old", new_password: "new"}
+	if user == "" {
+		return errors.New("no user set")
+	}
+	if newPassword == "" {
+		return errors.New("no new password set")
+	}
+	return client.Put(
+		ctx,
+		path.Join(authUsersPath, user, changePasswordPathSegment),
+		"",
+		api.ZoneQS(client, zone),
+		nil,
+		&isiChangePasswordReq{OldPassword: oldPassword, NewPassword: newPassword},
+		nil)
+}
+
+// GetIsiLocalProvider queries the local provider of an access zone. The
+// provider has the same name as the zone.
+func GetIsiLocalProvider(
+	ctx context.Context,
+	client api.Client,
+	name string) (provider *IsiLocalProvider, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/providers/local/name
+	if name == "" {
+		return nil, errors.New("no provider name set")
+	}
+
+	var resp getIsiLocalProvidersResp
+	err = client.Get(ctx, authLocalProvidersPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Local) == 0 {
+		return nil, errors.New("local provider missing from response")
+	}
+	return resp.Local[0], nil
+}
+
+// UpdateIsiLocalProvider modifies the local provider of an access zone
+func UpdateIsiLocalProvider(
+	ctx context.Context,
+	client api.Client,
+	name string, provider *IsiLocalProvider) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/providers/local/name
+	//            Content-Type: application/json
+	//            {min_password_length: 12, max_password_age: 7776000}
+	if name == "" {
+		return errors.New("no provider name set")
+	}
+	if provider == nil {
+		return errors.New("no provider settings set")
+	}
+	return client.Put(
+		ctx, authLocalProvidersPath, name, nil, nil, provider, nil)
+}
@@ -0,0 +1,63 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+func TestUserDecodeJSON(t *testing.T) {
+	j := `{"users":[{"name":"user1","enabled":true,"provider":"lsa-local-provider:System",` +
+		`"home_directory":"/ifs/home/user1","shell":"/bin/zsh",` +
+		`"uid":{"id":"UID:2000","name":"user1","type":"user"},` +
+		`"sid":{"id":"SID:S-1-5-21-1-2-3-1000","name":"user1","type":"user"}}]}`
+
+	var resp getIsiUsersResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.Users, 1) {
+		t.FailNow()
+	}
+	u := resp.Users[0]
+	assert.Equal(t, "user1", u.Name)
+	assert.True(t, u.Enabled)
+	if !assert.NotNil(t, u.UID) || !assert.NotNil(t, u.UID.ID) {
+		t.FailNow()
+	}
+	assert.Equal(t, "2000", u.UID.ID.ID)
+	assert.Equal(t, apiv2.PersonaIDTypeUID, u.UID.ID.Type)
+	assert.Equal(t, apiv2.PersonaIDTypeSID, u.SID.ID.Type)
+}
+
+func TestUserReqEncodeJSON(t *testing.T) {
+	name, uid := "user1", 2000
+	buf, err := json.Marshal(&IsiUserReq{Name: &name, UID: &uid})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"name":"user1","uid":2000}`, string(buf))
+}
+
+func TestMappingTokenDecodeJSON(t *testing.T) {
+	j := `{"mapping":[{"zid":1,"zone":"System",` +
+		`"user":{"name":"root","uid":{"id":"UID:0"},"sid":{"id":"SID:S-1-22-1-0"}},` +
+		`"groups":[{"name":"wheel","gid":{"id":"GID:0"}}],` +
+		`"privileges":[{"id":"ISI_PRIV_LOGIN_PAPI","name":"Platform API","read_only":true}]}]}`
+
+	var resp getIsiMappingTokensResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.Mapping, 1) {
+		t.FailNow()
+	}
+	m := resp.Mapping[0]
+	assert.Equal(t, "System", m.Zone)
+	assert.Equal(t, "0", m.User.UID.ID.ID)
+	assert.Equal(t, apiv2.PersonaIDTypeGID, m.Groups[0].GID.ID.Type)
+	assert.True(t, m.Privileges[0].ReadOnly)
+}
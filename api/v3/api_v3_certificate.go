@@ -0,0 +1,188 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiCertificateFingerprint is a fingerprint of a certificate.
+type IsiCertificateFingerprint struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// IsiCertificate is a server or certificate authority certificate.
+type IsiCertificate struct {
+	ID           string                       `json:"id"`
+	Name         string                       `json:"name"`
+	Description  string                       `json:"description"`
+	Status       string                       `json:"status"`
+	Subject      string                       `json:"subject"`
+	Issuer       string                       `json:"issuer"`
+	NotBefore    int64                        `json:"not_before"`
+	NotAfter     int64                        `json:"not_after"`
+	Fingerprints []*IsiCertificateFingerprint `json:"fingerprints"`
+}
+
+type getIsiCertificatesResp struct {
+	Certificates []*IsiCertificate `json:"certificates"`
+}
+
+// IsiCertificateImportReq is used to import a certificate that has been
+// copied to the cluster.
+type IsiCertificateImportReq struct {
+	Name                   string `json:"name,omitempty"`
+	Description            string `json:"description,omitempty"`
+	CertificatePath        string `json:"certificate_path"`
+	CertificateKeyPath     string `json:"certificate_key_path,omitempty"`
+	CertificateKeyPassword string `json:"certificate_key_password,omitempty"`
+}
+
+type isiCertificateUpdateReq struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type postIsiCertificateResp struct {
+	ID string `json:"id"`
+}
+
+func getIsiCertificates(
+	ctx context.Context,
+	client api.Client,
+	certPath, id string) ([]*IsiCertificate, error) {
+
+	var resp getIsiCertificatesResp
+	if err := client.Get(ctx, certPath, id, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Certificates, nil
+}
+
+func importIsiCertificate(
+	ctx context.Context,
+	client api.Client,
+	certPath string,
+	req *IsiCertificateImportReq) (string, error) {
+
+	if req == nil || req.CertificatePath == "" {
+		return "", errors.New("no certificate path set")
+	}
+
+	var resp postIsiCertificateResp
+	if err := client.Post(ctx, certPath, "", nil, nil, req, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// GetIsiServerCertificates queries a list of all server certificates
+func GetIsiServerCertificates(
+	ctx context.Context,
+	client api.Client) (certs []*IsiCertificate, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/certificate/server
+	return getIsiCertificates(ctx, client, certificateServerPath, "")
+}
+
+// GetIsiServerCertificate queries an individual server certificate
+func GetIsiServerCertificate(
+	ctx context.Context,
+	client api.Client,
+	id string) (cert *IsiCertificate, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/certificate/server/id
+	certs, err := getIsiCertificates(ctx, client, certificateServerPath, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("certificate missing from response")
+	}
+	return certs[0], nil
+}
+
+// ImportIsiServerCertificate imports a server certificate and its key from
+// files on the cluster and returns the certificate id
+func ImportIsiServerCertificate(
+	ctx context.Context,
+	client api.Client,
+	req *IsiCertificateImportReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/certificate/server
+	//            Content-Type: application/json
+	//            {name: "cert_name",
+	//             certificate_path: "/ifs/path/to/cert.pem",
+	//             certificate_key_path: "/ifs/path/to/key.pem"}
+	if req != nil && req.CertificateKeyPath == "" {
+		return "", errors.New("no certificate key path set")
+	}
+	return importIsiCertificate(ctx, client, certificateServerPath, req)
+}
+
+// UpdateIsiServerCertificate modifies the name and description of a server
+// certificate
+func UpdateIsiServerCertificate(
+	ctx context.Context,
+	client api.Client,
+	id, name, description string) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/certificate/server/id
+	if id == "" {
+		return errors.New("no certificate id set")
+	}
+	return client.Put(
+		ctx, certificateServerPath, id, nil, nil,
+		&isiCertificateUpdateReq{Name: name, Description: description}, nil)
+}
+
+// DeleteIsiServerCertificate removes a server certificate
+func DeleteIsiServerCertificate(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/certificate/server/id
+	if id == "" {
+		return errors.New("no certificate id set")
+	}
+	return client.Delete(ctx, certificateServerPath, id, nil, nil, nil)
+}
+
+// GetIsiCertificateAuthorities queries a list of all trusted certificate
+// authorities
+func GetIsiCertificateAuthorities(
+	ctx context.Context,
+	client api.Client) (certs []*IsiCertificate, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/certificate/authority
+	return getIsiCertificates(ctx, client, certificateAuthorityPath, "")
+}
+
+// ImportIsiCertificateAuthority adds a trusted certificate authority from a
+// file on the cluster and returns its id
+func ImportIsiCertificateAuthority(
+	ctx context.Context,
+	client api.Client,
+	req *IsiCertificateImportReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/certificate/authority
+	//            Content-Type: application/json
+	//            {name: "ca_name", certificate_path: "/ifs/path/to/ca.pem"}
+	return importIsiCertificate(ctx, client, certificateAuthorityPath, req)
+}
+
+// DeleteIsiCertificateAuthority removes a trusted certificate authority
+func DeleteIsiCertificateAuthority(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/certificate/authority/id
+	if id == "" {
+		return errors.New("no certificate id set")
+	}
+	return client.Delete(ctx, certificateAuthorityPath, id, nil, nil, nil)
+}
@@ -0,0 +1,311 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiCloudAccount is a CloudPools cloud storage account.
+type IsiCloudAccount struct {
+	ID                string  `json:"id,omitmarshal"`
+	Name              *string `json:"name,omitempty"`
+	Type              *string `json:"type,omitempty"`
+	URI               *string `json:"uri,omitempty"`
+	AccountUsername   *string `json:"account_username,omitempty"`
+	AccountID         *string `json:"account_id,omitempty"`
+	Key               *string `json:"key,omitempty"`
+	Enabled           *bool   `json:"enabled,omitempty"`
+	Proxy             *string `json:"proxy,omitempty"`
+	SkipSSLValidation *bool   `json:"skip_ssl_validation,omitempty"`
+	StorageRegion     *string `json:"storage_region,omitempty"`
+	TelemetryBucket   *string `json:"telemetry_bucket,omitempty"`
+	State             string  `json:"state,omitmarshal"`
+}
+
+type getIsiCloudAccountsResp struct {
+	Accounts []*IsiCloudAccount `json:"accounts"`
+}
+
+// IsiCloudPool is a CloudPools target made up of one or more accounts.
+type IsiCloudPool struct {
+	ID          string    `json:"id,omitmarshal"`
+	Name        *string   `json:"name,omitempty"`
+	Type        *string   `json:"type,omitempty"`
+	Accounts    *[]string `json:"accounts,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Vendor      *string   `json:"vendor,omitempty"`
+	State       string    `json:"state,omitmarshal"`
+}
+
+type getIsiCloudPoolsResp struct {
+	Pools []*IsiCloudPool `json:"pools"`
+}
+
+type postIsiCloudResp struct {
+	ID string `json:"id"`
+}
+
+// IsiCloudJobReq is a request to archive files to or recall them from the
+// cloud.
+type IsiCloudJobReq struct {
+	Type        string   `json:"type"`
+	Files       []string `json:"files,omitempty"`
+	Directories []string `json:"directories,omitempty"`
+	Policy      string   `json:"policy,omitempty"`
+}
+
+// IsiCloudJob is a CloudPools archive or recall job.
+type IsiCloudJob struct {
+	ID             int64              `json:"id"`
+	Type           string             `json:"type"`
+	Description    string             `json:"description"`
+	State          string             `json:"state"`
+	EffectiveState string             `json:"effective_state"`
+	CreateTime     int64              `json:"create_time"`
+	CompletionTime int64              `json:"completion_time"`
+	JobEngineJob   *IsiCloudJobEngine `json:"job_engine_job"`
+}
+
+// IsiCloudJobEngine is the job engine job that runs a CloudPools job.
+type IsiCloudJobEngine struct {
+	ID    int64  `json:"id"`
+	State string `json:"state"`
+}
+
+type getIsiCloudJobsResp struct {
+	Jobs []*IsiCloudJob `json:"jobs"`
+}
+
+type postIsiCloudJobResp struct {
+	ID int64 `json:"id"`
+}
+
+type isiCloudJobStateReq struct {
+	State string `json:"state"`
+}
+
+// GetIsiCloudAccounts queries a list of all cloud accounts
+func GetIsiCloudAccounts(
+	ctx context.Context,
+	client api.Client) (accounts []*IsiCloudAccount, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cloud/accounts
+	var resp getIsiCloudAccountsResp
+	err = client.Get(ctx, cloudAccountsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Accounts, nil
+}
+
+// GetIsiCloudAccount queries an individual cloud account by id or name
+func GetIsiCloudAccount(
+	ctx context.Context,
+	client api.Client,
+	id string) (account *IsiCloudAccount, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cloud/accounts/id
+	var resp getIsiCloudAccountsResp
+	err = client.Get(ctx, cloudAccountsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Accounts) == 0 {
+		return nil, errors.New("cloud account missing from response")
+	}
+	return resp.Accounts[0], nil
+}
+
+// CreateIsiCloudAccount creates a cloud account and returns its id
+func CreateIsiCloudAccount(
+	ctx context.Context,
+	client api.Client,
+	account *IsiCloudAccount) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cloud/accounts
+	//            Content-Type: application/json
+	//            {name: "account_name", type: "ecs", uri: "https://...",
+	//             account_username: "user", key: "secret"}
+	if account == nil || account.Name == nil || *account.Name == "" {
+		return "", errors.New("no account name set")
+	}
+
+	var resp postIsiCloudResp
+	err = client.Post(ctx, cloudAccountsPath, "", nil, nil, account, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiCloudAccount modifies a cloud account
+func UpdateIsiCloudAccount(
+	ctx context.Context,
+	client api.Client,
+	account *IsiCloudAccount) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/cloud/accounts/id
+	if account == nil || account.ID == "" {
+		return errors.New("no account id set")
+	}
+	return client.Put(
+		ctx, cloudAccountsPath, account.ID, nil, nil, account, nil)
+}
+
+// DeleteIsiCloudAccount removes a cloud account
+func DeleteIsiCloudAccount(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/cloud/accounts/id
+	if id == "" {
+		return errors.New("no account id set")
+	}
+	return client.Delete(ctx, cloudAccountsPath, id, nil, nil, nil)
+}
+
+// GetIsiCloudPools queries a list of all cloud pools
+func GetIsiCloudPools(
+	ctx context.Context,
+	client api.Client) (pools []*IsiCloudPool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cloud/pools
+	var resp getIsiCloudPoolsResp
+	err = client.Get(ctx, cloudPoolsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Pools, nil
+}
+
+// GetIsiCloudPool queries an individual cloud pool by id or name
+func GetIsiCloudPool(
+	ctx context.Context,
+	client api.Client,
+	id string) (pool *IsiCloudPool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cloud/pools/id
+	var resp getIsiCloudPoolsResp
+	err = client.Get(ctx, cloudPoolsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Pools) == 0 {
+		return nil, errors.New("cloud pool missing from response")
+	}
+	return resp.Pools[0], nil
+}
+
+// CreateIsiCloudPool creates a cloud pool and returns its id
+func CreateIsiCloudPool(
+	ctx context.Context,
+	client api.Client,
+	pool *IsiCloudPool) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cloud/pools
+	//            Content-Type: application/json
+	//            {name: "pool_name", type: "ecs", accounts: ["account_name"]}
+	if pool == nil || pool.Name == nil || *pool.Name == "" {
+		return "", errors.New("no pool name set")
+	}
+
+	var resp postIsiCloudResp
+	err = client.Post(ctx, cloudPoolsPath, "", nil, nil, pool, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiCloudPool modifies a cloud pool
+func UpdateIsiCloudPool(
+	ctx context.Context,
+	client api.Client,
+	pool *IsiCloudPool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/cloud/pools/id
+	if pool == nil || pool.ID == "" {
+		return errors.New("no pool id set")
+	}
+	return client.Put(ctx, cloudPoolsPath, pool.ID, nil, nil, pool, nil)
+}
+
+// DeleteIsiCloudPool removes a cloud pool
+func DeleteIsiCloudPool(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/cloud/pools/id
+	if id == "" {
+		return errors.New("no pool id set")
+	}
+	return client.Delete(ctx, cloudPoolsPath, id, nil, nil, nil)
+}
+
+// StartIsiCloudJob starts a CloudPools archive or recall job and returns
+// its id
+func StartIsiCloudJob(
+	ctx context.Context,
+	client api.Client,
+	req *IsiCloudJobReq) (id int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cloud/jobs
+	//            Content-Type: application/json
+	//            {type: "recall", files: ["/path/to/file"],
+	//             directories: ["/path/to/dir"]}
+	if req == nil || req.Type == "" {
+		return 0, errors.New("no job type set")
+	}
+	if len(req.Files) == 0 && len(req.Directories) == 0 {
+		return 0, errors.New("no files or directories set")
+	}
+
+	var resp postIsiCloudJobResp
+	err = client.Post(ctx, cloudJobsPath, "", nil, nil, req, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// GetIsiCloudJob queries an individual CloudPools job
+func GetIsiCloudJob(
+	ctx context.Context,
+	client api.Client,
+	id int64) (job *IsiCloudJob, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cloud/jobs/id
+	var resp getIsiCloudJobsResp
+	err = client.Get(
+		ctx, cloudJobsPath, strconv.FormatInt(id, 10), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Jobs) == 0 {
+		return nil, errors.New("cloud job missing from response")
+	}
+	return resp.Jobs[0], nil
+}
+
+// UpdateIsiCloudJobState pauses, resumes or cancels a CloudPools job
+func UpdateIsiCloudJobState(
+	ctx context.Context,
+	client api.Client,
+	id int64,
+	state string) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/cloud/jobs/id
+	//            Content-Type: application/json
+	//            {state: "cancel"}
+	if state == "" {
+		return errors.New("no job state set")
+	}
+	return client.Put(
+		ctx, cloudJobsPath, strconv.FormatInt(id, 10), nil, nil,
+		&isiCloudJobStateReq{State: state}, nil)
+}
@@ -0,0 +1,137 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiDiagnosticsGatherStartReq holds the optional settings used when starting
+// a diagnostics gather.
+type IsiDiagnosticsGatherStartReq struct {
+	GatherMode  *string `json:"gather_mode,omitempty"`
+	Incremental *bool   `json:"incremental,omitempty"`
+	ESRSUpload  *bool   `json:"esrs,omitempty"`
+	FTPUpload   *bool   `json:"ftp_upload,omitempty"`
+	HTTPUpload  *bool   `json:"http_upload,omitempty"`
+	Clean       *bool   `json:"clean,omitempty"`
+	Nodes       []int   `json:"nodes,omitempty"`
+}
+
+// IsiDiagnosticsGatherStatus is the status of the current or last gather.
+type IsiDiagnosticsGatherStatus struct {
+	Item   string `json:"item"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+type getIsiDiagnosticsGatherStatusResp struct {
+	Gather *IsiDiagnosticsGatherStatus `json:"gather"`
+}
+
+// IsiDiagnosticsBundle is a log bundle produced by a diagnostics gather.
+type IsiDiagnosticsBundle struct {
+	Name         string `json:"name"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"last_modified"`
+}
+
+type getIsiDiagnosticsBundlesResp struct {
+	Children []*IsiDiagnosticsBundle `json:"children"`
+}
+
+var diagnosticsBundlesDetailQS = api.OrderedValues{
+	{[]byte("detail"), []byte("size"), []byte("last_modified")},
+}
+
+// StartIsiDiagnosticsGather starts a diagnostics gather on the cluster
+func StartIsiDiagnosticsGather(
+	ctx context.Context,
+	client api.Client,
+	req *IsiDiagnosticsGatherStartReq) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cluster/diagnostics/gather/start
+	//            Content-Type: application/json
+	//            {gather_mode: "full", esrs: false}
+	if req == nil {
+		req = &IsiDiagnosticsGatherStartReq{}
+	}
+
+	return client.Post(
+		ctx, path.Join(diagnosticsGatherPath, "start"), "", nil, nil, req, nil)
+}
+
+// StopIsiDiagnosticsGather stops the running diagnostics gather
+func StopIsiDiagnosticsGather(
+	ctx context.Context,
+	client api.Client) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cluster/diagnostics/gather/stop
+	return client.Post(
+		ctx, path.Join(diagnosticsGatherPath, "stop"), "", nil, nil,
+		struct{}{}, nil)
+}
+
+// GetIsiDiagnosticsGatherStatus queries the status of the diagnostics gather
+func GetIsiDiagnosticsGatherStatus(
+	ctx context.Context,
+	client api.Client) (status *IsiDiagnosticsGatherStatus, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/diagnostics/gather/status
+	var resp getIsiDiagnosticsGatherStatusResp
+	err = client.Get(
+		ctx, path.Join(diagnosticsGatherPath, "status"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Gather == nil {
+		return nil, errors.New("diagnostics gather status missing from response")
+	}
+	return resp.Gather, nil
+}
+
+// GetIsiDiagnosticsBundles lists the log bundles written by previous gathers
+func GetIsiDiagnosticsBundles(
+	ctx context.Context,
+	client api.Client) (bundles []*IsiDiagnosticsBundle, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/ifs/data/Isilon_Support/pkg?detail=size,last_modified
+	var resp getIsiDiagnosticsBundlesResp
+	err = client.Get(
+		ctx,
+		path.Join(namespacePath, diagnosticsBundlesDirPath),
+		"",
+		diagnosticsBundlesDetailQS,
+		nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Children, nil
+}
+
+// DownloadIsiDiagnosticsBundle streams the contents of a log bundle to w
+func DownloadIsiDiagnosticsBundle(
+	ctx context.Context,
+	client api.Client,
+	name string, w io.Writer) (err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/ifs/data/Isilon_Support/pkg/name
+	if name == "" {
+		return errors.New("no bundle name set")
+	}
+	if w == nil {
+		return errors.New("no writer set")
+	}
+
+	return client.Get(
+		ctx,
+		path.Join(namespacePath, diagnosticsBundlesDirPath),
+		name,
+		nil,
+		nil,
+		w)
+}
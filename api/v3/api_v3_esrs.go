@@ -0,0 +1,57 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiESRSSettings are the ESRS (EMC Secure Remote Services) settings.
+type IsiESRSSettings struct {
+	Enabled                *bool     `json:"enabled,omitempty"`
+	PrimaryESRSGateway     *string   `json:"primary_esrs_gateway,omitempty"`
+	SecondaryESRSGateway   *string   `json:"secondary_esrs_gateway,omitempty"`
+	GatewayAccessPools     *[]string `json:"gateway_access_pools,omitempty"`
+	EmailCustomerOnFailure *bool     `json:"email_customer_on_failure,omitempty"`
+	Username               *string   `json:"username,omitempty"`
+	Password               *string   `json:"password,omitempty"`
+	SavePassword           *bool     `json:"save_password,omitempty"`
+}
+
+type getIsiESRSSettingsResp struct {
+	Settings *IsiESRSSettings `json:"settings"`
+}
+
+// GetIsiESRSSettings queries the ESRS settings
+func GetIsiESRSSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiESRSSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/esrs/settings
+	var resp getIsiESRSSettingsResp
+	err = client.Get(ctx, esrsSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("esrs settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiESRSSettings modifies the ESRS settings. Only the fields that are
+// set are changed.
+func UpdateIsiESRSSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiESRSSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/esrs/settings
+	//            Content-Type: application/json
+	//            {enabled: true, primary_esrs_gateway: "esrs.example.com"}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, esrsSettingsPath, "", nil, nil, settings, nil)
+}
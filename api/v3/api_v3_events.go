@@ -0,0 +1,181 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiEventChannel is a channel that sends alerts, ex. by email or SNMP
+// trap.
+type IsiEventChannel struct {
+	ID            int64                      `json:"id,omitmarshal"`
+	Name          *string                    `json:"name,omitempty"`
+	Type          *string                    `json:"type,omitempty"`
+	Enabled       *bool                      `json:"enabled,omitempty"`
+	AllowedNodes  *[]int64                   `json:"allowed_nodes,omitempty"`
+	ExcludedNodes *[]int64                   `json:"excluded_nodes,omitempty"`
+	Rules         *[]string                  `json:"rules,omitempty"`
+	Parameters    *IsiEventChannelParameters `json:"parameters,omitempty"`
+	System        bool                       `json:"system,omitmarshal"`
+}
+
+// IsiEventChannelParameters are the type specific settings of an event
+// channel.
+type IsiEventChannelParameters struct {
+	Address     *[]string `json:"address,omitempty"`
+	SendAs      *string   `json:"send_as,omitempty"`
+	Subject     *string   `json:"subject,omitempty"`
+	SMTPHost    *string   `json:"smtp_host,omitempty"`
+	SMTPPort    *int      `json:"smtp_port,omitempty"`
+	SMTPUseAuth *bool     `json:"smtp_use_auth,omitempty"`
+	SMTPUser    *string   `json:"smtp_username,omitempty"`
+	SMTPPass    *string   `json:"smtp_password,omitempty"`
+	BatchMode   *string   `json:"batch,omitempty"`
+	Host        *string   `json:"host,omitempty"`
+	Community   *string   `json:"community,omitempty"`
+}
+
+type getIsiEventChannelsResp struct {
+	Channels []*IsiEventChannel `json:"channels"`
+}
+
+// IsiEventMaintenance is a maintenance window, during which events are
+// recorded but not alerted. Start is in seconds since the epoch and
+// Duration is in seconds; a zero Start means no window is scheduled.
+type IsiEventMaintenance struct {
+	Start    int64 `json:"start"`
+	Duration int64 `json:"duration"`
+}
+
+// IsiEventSettings are the cluster's event settings.
+type IsiEventSettings struct {
+	Maintenance       *IsiEventMaintenance `json:"maintenance,omitempty"`
+	RetentionDays     *int                 `json:"retention_days,omitempty"`
+	StorageLimit      *int                 `json:"storage_limit,omitempty"`
+	HeartbeatInterval *string              `json:"heartbeat_interval,omitempty"`
+}
+
+type getIsiEventSettingsResp struct {
+	Settings *IsiEventSettings `json:"settings"`
+}
+
+// isiEventChannelTestReq asks a channel to send a test alert.
+type isiEventChannelTestReq struct {
+	SendTestAlert bool `json:"send_test_alert"`
+}
+
+// isiTestEventReq creates a test event with a message.
+type isiTestEventReq struct {
+	Specifier isiTestEventSpecifier `json:"specifier"`
+}
+
+type isiTestEventSpecifier struct {
+	Message string `json:"message"`
+}
+
+// GetIsiEventChannels queries all event channels
+func GetIsiEventChannels(
+	ctx context.Context,
+	client api.Client) (channels []*IsiEventChannel, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/event/channels
+	var resp getIsiEventChannelsResp
+	err = client.Get(ctx, eventChannelsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Channels, nil
+}
+
+// GetIsiEventChannel queries an individual event channel by name or id
+func GetIsiEventChannel(
+	ctx context.Context,
+	client api.Client,
+	name string) (channel *IsiEventChannel, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/event/channels/name
+	if name == "" {
+		return nil, errors.New("no channel set")
+	}
+
+	var resp getIsiEventChannelsResp
+	err = client.Get(ctx, eventChannelsPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Channels) == 0 {
+		return nil, errors.New("channel missing from response")
+	}
+	return resp.Channels[0], nil
+}
+
+// SendIsiEventChannelTestAlert makes an event channel send a test alert
+func SendIsiEventChannelTestAlert(
+	ctx context.Context,
+	client api.Client,
+	name string) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/event/channels/name
+	//            Content-Type: application/json
+	//            {send_test_alert: true}
+	if name == "" {
+		return errors.New("no channel set")
+	}
+	return client.Put(
+		ctx, eventChannelsPath, name, nil, nil,
+		&isiEventChannelTestReq{SendTestAlert: true}, nil)
+}
+
+// CreateIsiTestEvent creates a test event with the given message, which is
+// alerted like any other event
+func CreateIsiTestEvent(
+	ctx context.Context,
+	client api.Client,
+	message string) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/event/events
+	//            Content-Type: application/json
+	//            {specifier: {message: "test"}}
+	if message == "" {
+		return errors.New("no message set")
+	}
+	return client.Post(
+		ctx, eventEventsPath, "", nil, nil,
+		&isiTestEventReq{Specifier: isiTestEventSpecifier{Message: message}},
+		nil)
+}
+
+// GetIsiEventSettings queries the event settings
+func GetIsiEventSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiEventSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/event/settings
+	var resp getIsiEventSettingsResp
+	err = client.Get(ctx, eventSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiEventSettings modifies the event settings. Only the fields that
+// are set are changed.
+func UpdateIsiEventSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiEventSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/event/settings
+	//            Content-Type: application/json
+	//            {maintenance: {start: 1700000000, duration: 3600}}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, eventSettingsPath, "", nil, nil, settings, nil)
+}
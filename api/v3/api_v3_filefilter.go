@@ -0,0 +1,116 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// File filter types.
+const (
+	FileFilterTypeAllow = "allow"
+	FileFilterTypeDeny  = "deny"
+)
+
+// IsiFileFilterSettings are the file filtering settings of an access zone
+// or an SMB share. Files whose extensions, ex. ".exe", are in the list are
+// denied, or with the allow type are the only files permitted.
+type IsiFileFilterSettings struct {
+	Enabled    *bool     `json:"file_filtering_enabled,omitempty"`
+	Type       *string   `json:"file_filter_type,omitempty"`
+	Extensions *[]string `json:"file_filter_extensions,omitempty"`
+}
+
+type getIsiFileFilterSettingsResp struct {
+	Settings *IsiFileFilterSettings `json:"settings"`
+}
+
+type getIsiSMBShareFileFilterResp struct {
+	Shares []*IsiFileFilterSettings `json:"shares"`
+}
+
+// GetIsiFileFilterSettings queries the file filtering settings of an access
+// zone
+func GetIsiFileFilterSettings(
+	ctx context.Context,
+	client api.Client,
+	zone string) (settings *IsiFileFilterSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/file-filter/settings?zone=zone
+	var resp getIsiFileFilterSettingsResp
+	err = client.Get(
+		ctx, fileFilterSettingsPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("file filter settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiFileFilterSettings modifies the file filtering settings of an
+// access zone. Only the fields that are set are changed.
+func UpdateIsiFileFilterSettings(
+	ctx context.Context,
+	client api.Client,
+	zone string, settings *IsiFileFilterSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/file-filter/settings?zone=zone
+	//            Content-Type: application/json
+	//            {file_filtering_enabled: true, file_filter_type: "deny",
+	//             file_filter_extensions: [".exe", ".bat"]}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, fileFilterSettingsPath, "", api.ZoneQS(client, zone), nil,
+		settings, nil)
+}
+
+// GetIsiSMBShareFileFilter queries the file filtering settings of an SMB
+// share in an access zone
+func GetIsiSMBShareFileFilter(
+	ctx context.Context,
+	client api.Client,
+	zone, share string) (settings *IsiFileFilterSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/protocols/smb/shares/share?zone=zone
+	if share == "" {
+		return nil, errors.New("no share set")
+	}
+
+	var resp getIsiSMBShareFileFilterResp
+	err = client.Get(
+		ctx, smbSharesPath, share, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Shares) == 0 {
+		return nil, errors.New("share missing from response")
+	}
+	return resp.Shares[0], nil
+}
+
+// UpdateIsiSMBShareFileFilter modifies the file filtering settings of an
+// SMB share in an access zone. Only the fields that are set are changed.
+func UpdateIsiSMBShareFileFilter(
+	ctx context.Context,
+	client api.Client,
+	zone, share string, settings *IsiFileFilterSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/protocols/smb/shares/share?zone=zone
+	//            Content-Type: application/json
+	//            {file_filtering_enabled: true, file_filter_type: "deny",
+	//             file_filter_extensions: [".exe"]}
+	if share == "" {
+		return errors.New("no share set")
+	}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, smbSharesPath, share, api.ZoneQS(client, zone), nil,
+		settings, nil)
+}
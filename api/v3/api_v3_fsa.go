@@ -0,0 +1,111 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// FSAnalyze result states.
+const (
+	FSAResultStatusAvailable = "available"
+)
+
+// IsiFSAResult is the result of an FSAnalyze job. Times are in seconds since
+// the epoch.
+type IsiFSAResult struct {
+	ID        int64  `json:"id"`
+	JobID     int64  `json:"job_id"`
+	BeginTime int64  `json:"begin_time"`
+	EndTime   int64  `json:"end_time"`
+	Pinned    bool   `json:"pinned"`
+	Size      int64  `json:"size"`
+	Status    string `json:"status"`
+}
+
+type getIsiFSAResultsResp struct {
+	Results []*IsiFSAResult `json:"results"`
+}
+
+// IsiFSADirectory is the usage of a directory in an FSAnalyze result. Sizes
+// are in bytes and counts include all descendants.
+type IsiFSADirectory struct {
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Lin         int64  `json:"lin"`
+	Parent      int64  `json:"parent"`
+	LogSizeSum  int64  `json:"log_size_sum"`
+	PhysSizeSum int64  `json:"phys_size_sum"`
+	FileCount   int64  `json:"file_cnt"`
+	DirCount    int64  `json:"dir_cnt"`
+	OtherCount  int64  `json:"other_cnt"`
+	ADSCount    int64  `json:"ads_cnt"`
+}
+
+type getIsiFSADirectoriesResp struct {
+	Directories []*IsiFSADirectory `json:"directories"`
+}
+
+var byteArrPath = []byte("path")
+
+// GetIsiFSAResults queries a list of all FSAnalyze results
+func GetIsiFSAResults(
+	ctx context.Context,
+	client api.Client) (results []*IsiFSAResult, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/fsa/results
+	var resp getIsiFSAResultsResp
+	err = client.Get(ctx, fsaResultsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// GetIsiFSAResult queries an individual FSAnalyze result
+func GetIsiFSAResult(
+	ctx context.Context,
+	client api.Client,
+	id int64) (result *IsiFSAResult, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/fsa/results/123
+	var resp getIsiFSAResultsResp
+	err = client.Get(
+		ctx, fsaResultsPath, strconv.FormatInt(id, 10), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) == 0 {
+		return nil, errors.New("fsa result missing from response")
+	}
+	return resp.Results[0], nil
+}
+
+// GetIsiFSADirectories queries the usage of the child directories of a
+// directory, by its absolute path, in an FSAnalyze result
+func GetIsiFSADirectories(
+	ctx context.Context,
+	client api.Client,
+	id int64, dirPath string) (dirs []*IsiFSADirectory, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/fsa/results/123/directories?path=/ifs/data
+	if dirPath == "" {
+		return nil, errors.New("no directory path set")
+	}
+
+	var resp getIsiFSADirectoriesResp
+	err = client.Get(
+		ctx,
+		path.Join(fsaResultsPath, strconv.FormatInt(id, 10)),
+		directoriesPathSegment,
+		api.OrderedValues{{byteArrPath, []byte(dirPath)}},
+		nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Directories, nil
+}
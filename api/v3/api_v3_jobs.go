@@ -0,0 +1,239 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiJob is a job engine job.
+type IsiJob struct {
+	ID           int64    `json:"id"`
+	Type         string   `json:"type"`
+	State        string   `json:"state"`
+	Impact       string   `json:"impact"`
+	Policy       string   `json:"policy"`
+	Priority     int      `json:"priority"`
+	Paths        []string `json:"paths"`
+	Progress     string   `json:"progress"`
+	CurrentPhase int      `json:"current_phase"`
+	TotalPhases  int      `json:"total_phases"`
+	CreateTime   int64    `json:"create_time"`
+	StartTime    int64    `json:"start_time"`
+	EndTime      int64    `json:"end_time"`
+}
+
+type getIsiJobsResp struct {
+	Jobs []*IsiJob `json:"jobs"`
+}
+
+// IsiJobAVScanParams are the parameters of an AVScan job.
+type IsiJobAVScanParams struct {
+	Policy string `json:"policy"`
+}
+
+// IsiJobChangelistCreateParams are the parameters of a ChangelistCreate job.
+type IsiJobChangelistCreateParams struct {
+	OlderSnapID int64 `json:"older_snapid"`
+	NewerSnapID int64 `json:"newer_snapid"`
+}
+
+// IsiJobReq is used to start a job engine job.
+type IsiJobReq struct {
+	Type         string              `json:"type"`
+	AllowDup     bool                `json:"allow_dup,omitempty"`
+	Paths        []string            `json:"paths,omitempty"`
+	Policy       string              `json:"policy,omitempty"`
+	Priority     int                 `json:"priority,omitempty"`
+	AVScanParams *IsiJobAVScanParams `json:"avscan_params,omitempty"`
+
+	ChangelistCreateParams *IsiJobChangelistCreateParams `json:"changelistcreate_params,omitempty"`
+}
+
+type postIsiJobResp struct {
+	ID int64 `json:"id"`
+}
+
+// IsiJobUpdateReq is used to modify a job engine job. Only the fields that
+// are set are changed.
+type IsiJobUpdateReq struct {
+	State    *string `json:"state,omitempty"`
+	Policy   *string `json:"policy,omitempty"`
+	Priority *int    `json:"priority,omitempty"`
+}
+
+// IsiJobImpactInterval is a weekly interval of a job impact policy, ex. from
+// "Monday 08:00" to "Friday 18:00", during which jobs run at an impact
+// level.
+type IsiJobImpactInterval struct {
+	Begin  string `json:"begin"`
+	End    string `json:"end"`
+	Impact string `json:"impact"`
+}
+
+// IsiJobImpactPolicy is a job impact policy, which sets the impact level of
+// the jobs that use it over the week.
+type IsiJobImpactPolicy struct {
+	ID          string                   `json:"id,omitmarshal"`
+	Name        *string                  `json:"name,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	Intervals   *[]*IsiJobImpactInterval `json:"intervals,omitempty"`
+	System      bool                     `json:"system,omitmarshal"`
+}
+
+type getIsiJobImpactPoliciesResp struct {
+	Policies []*IsiJobImpactPolicy `json:"policies"`
+}
+
+type postIsiJobImpactPolicyResp struct {
+	ID string `json:"id"`
+}
+
+// StartIsiJob starts a job engine job and returns its id
+func StartIsiJob(
+	ctx context.Context,
+	client api.Client,
+	req *IsiJobReq) (id int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/job/jobs
+	//            Content-Type: application/json
+	//            {type: "TreeDelete", paths: ["/path/to/dir"]}
+	if req == nil || req.Type == "" {
+		return 0, errors.New("no job type set")
+	}
+
+	var resp postIsiJobResp
+	if err = client.Post(ctx, jobsPath, "", nil, nil, req, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// GetIsiJob queries an individual job engine job
+func GetIsiJob(
+	ctx context.Context,
+	client api.Client,
+	id int64) (job *IsiJob, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/job/jobs/123
+	var resp getIsiJobsResp
+	err = client.Get(
+		ctx, jobsPath, strconv.FormatInt(id, 10), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Jobs) == 0 {
+		return nil, errors.New("job missing from response")
+	}
+	return resp.Jobs[0], nil
+}
+
+// UpdateIsiJob modifies a job engine job, ex. to pause it or change its
+// impact policy
+func UpdateIsiJob(
+	ctx context.Context,
+	client api.Client,
+	id int64, req *IsiJobUpdateReq) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/job/jobs/123
+	//            Content-Type: application/json
+	//            {policy: "LOW"}
+	if req == nil {
+		return errors.New("no job settings set")
+	}
+	return client.Put(
+		ctx, jobsPath, strconv.FormatInt(id, 10), nil, nil, req, nil)
+}
+
+// GetIsiJobImpactPolicies queries a list of all job impact policies
+func GetIsiJobImpactPolicies(
+	ctx context.Context,
+	client api.Client) (policies []*IsiJobImpactPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/job/policies
+	var resp getIsiJobImpactPoliciesResp
+	err = client.Get(ctx, jobPoliciesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Policies, nil
+}
+
+// GetIsiJobImpactPolicy queries an individual job impact policy by id
+func GetIsiJobImpactPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (policy *IsiJobImpactPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/job/policies/id
+	if id == "" {
+		return nil, errors.New("no policy id set")
+	}
+
+	var resp getIsiJobImpactPoliciesResp
+	err = client.Get(ctx, jobPoliciesPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Policies) == 0 {
+		return nil, errors.New("policy missing from response")
+	}
+	return resp.Policies[0], nil
+}
+
+// CreateIsiJobImpactPolicy creates a job impact policy and returns its id
+func CreateIsiJobImpactPolicy(
+	ctx context.Context,
+	client api.Client,
+	policy *IsiJobImpactPolicy) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/job/policies
+	//            Content-Type: application/json
+	//            {name: "business_hours",
+	//             intervals: [{begin: "Monday 08:00", end: "Friday 18:00",
+	//                          impact: "Low"}]}
+	if policy == nil || policy.Name == nil || *policy.Name == "" {
+		return "", errors.New("no policy name set")
+	}
+
+	var resp postIsiJobImpactPolicyResp
+	err = client.Post(ctx, jobPoliciesPath, "", nil, nil, policy, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiJobImpactPolicy modifies a job impact policy. Only the fields
+// that are set are changed.
+func UpdateIsiJobImpactPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string, policy *IsiJobImpactPolicy) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/job/policies/id
+	//            Content-Type: application/json
+	//            {description: "low impact during business hours"}
+	if id == "" {
+		return errors.New("no policy id set")
+	}
+	if policy == nil {
+		return errors.New("no policy set")
+	}
+	return client.Put(ctx, jobPoliciesPath, id, nil, nil, policy, nil)
+}
+
+// DeleteIsiJobImpactPolicy deletes a job impact policy
+func DeleteIsiJobImpactPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/job/policies/id
+	if id == "" {
+		return errors.New("no policy id set")
+	}
+	return client.Delete(ctx, jobPoliciesPath, id, nil, nil, nil)
+}
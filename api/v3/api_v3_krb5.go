@@ -0,0 +1,277 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiKrb5Realm is a Kerberos realm definition.
+type IsiKrb5Realm struct {
+	ID             string    `json:"id,omitmarshal"`
+	Realm          *string   `json:"realm,omitempty"`
+	KDC            *[]string `json:"kdc,omitempty"`
+	AdminServer    *string   `json:"admin_server,omitempty"`
+	DefaultDomain  *string   `json:"default_domain,omitempty"`
+	IsDefaultRealm *bool     `json:"is_default_realm,omitempty"`
+}
+
+type getIsiKrb5RealmsResp struct {
+	Realm []*IsiKrb5Realm `json:"realm"`
+}
+
+// IsiKrb5Domain maps a DNS domain to a Kerberos realm.
+type IsiKrb5Domain struct {
+	ID     string  `json:"id,omitmarshal"`
+	Domain *string `json:"domain,omitempty"`
+	Realm  *string `json:"realm,omitempty"`
+}
+
+type getIsiKrb5DomainsResp struct {
+	Domain []*IsiKrb5Domain `json:"domain"`
+}
+
+// IsiKrb5KeytabEntry is a key held in a Kerberos provider's keytab.
+type IsiKrb5KeytabEntry struct {
+	SPN             string   `json:"spn"`
+	KVNO            int      `json:"kvno"`
+	EncryptionTypes []string `json:"encryption_types"`
+}
+
+// IsiKrb5Provider is a Kerberos authentication provider. The pointer fields
+// are settings that may be modified after the provider is created.
+type IsiKrb5Provider struct {
+	ID            string                `json:"id,omitmarshal"`
+	Name          string                `json:"name,omitmarshal"`
+	Realm         string                `json:"realm,omitmarshal"`
+	Groupnet      string                `json:"groupnet,omitmarshal"`
+	Status        string                `json:"status,omitmarshal"`
+	System        bool                  `json:"system,omitmarshal"`
+	KeytabEntries []*IsiKrb5KeytabEntry `json:"keytab_entries,omitmarshal"`
+	ManualKeying  *bool                 `json:"manual_keying,omitempty"`
+	SPNs          *[]string             `json:"spns,omitempty"`
+}
+
+type getIsiKrb5ProvidersResp struct {
+	Krb5 []*IsiKrb5Provider `json:"krb5"`
+}
+
+// IsiKrb5JoinReq is used to create a Kerberos provider by joining a realm
+// with administrator credentials, or from a keytab file on the cluster.
+type IsiKrb5JoinReq struct {
+	Realm        string    `json:"realm"`
+	User         *string   `json:"user,omitempty"`
+	Password     *string   `json:"password,omitempty"`
+	KeytabFile   *string   `json:"keytab_file,omitempty"`
+	ManualKeying *bool     `json:"manual_keying,omitempty"`
+	Groupnet     *string   `json:"groupnet,omitempty"`
+	SPNs         *[]string `json:"spns,omitempty"`
+}
+
+// GetIsiKrb5Realms queries a list of all Kerberos realm definitions
+func GetIsiKrb5Realms(
+	ctx context.Context,
+	client api.Client) (realms []*IsiKrb5Realm, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/settings/krb5/realms
+	var resp getIsiKrb5RealmsResp
+	err = client.Get(ctx, krb5RealmsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Realm, nil
+}
+
+// CreateIsiKrb5Realm creates a Kerberos realm definition and returns its id
+func CreateIsiKrb5Realm(
+	ctx context.Context,
+	client api.Client,
+	realm *IsiKrb5Realm) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/settings/krb5/realms
+	//            Content-Type: application/json
+	//            {realm: "EXAMPLE.COM", kdc: ["kdc.example.com"]}
+	if realm == nil || realm.Realm == nil || *realm.Realm == "" {
+		return "", errors.New("no realm name set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(ctx, krb5RealmsPath, "", nil, nil, realm, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiKrb5Realm modifies a Kerberos realm definition
+func UpdateIsiKrb5Realm(
+	ctx context.Context,
+	client api.Client,
+	id string, realm *IsiKrb5Realm) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/settings/krb5/realms/id
+	if id == "" {
+		return errors.New("no realm id set")
+	}
+	if realm == nil {
+		return errors.New("no realm settings set")
+	}
+	return client.Put(ctx, krb5RealmsPath, id, nil, nil, realm, nil)
+}
+
+// DeleteIsiKrb5Realm removes a Kerberos realm definition
+func DeleteIsiKrb5Realm(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/settings/krb5/realms/id
+	if id == "" {
+		return errors.New("no realm id set")
+	}
+	return client.Delete(ctx, krb5RealmsPath, id, nil, nil, nil)
+}
+
+// GetIsiKrb5Domains queries a list of all Kerberos domain mappings
+func GetIsiKrb5Domains(
+	ctx context.Context,
+	client api.Client) (domains []*IsiKrb5Domain, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/settings/krb5/domains
+	var resp getIsiKrb5DomainsResp
+	err = client.Get(ctx, krb5DomainsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Domain, nil
+}
+
+// CreateIsiKrb5Domain maps a DNS domain to a Kerberos realm and returns the
+// id of the mapping
+func CreateIsiKrb5Domain(
+	ctx context.Context,
+	client api.Client,
+	domain *IsiKrb5Domain) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/settings/krb5/domains
+	//            Content-Type: application/json
+	//            {domain: ".example.com", realm: "EXAMPLE.COM"}
+	if domain == nil || domain.Domain == nil || *domain.Domain == "" {
+		return "", errors.New("no domain name set")
+	}
+	if domain.Realm == nil || *domain.Realm == "" {
+		return "", errors.New("no realm name set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(ctx, krb5DomainsPath, "", nil, nil, domain, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// DeleteIsiKrb5Domain removes a Kerberos domain mapping
+func DeleteIsiKrb5Domain(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/settings/krb5/domains/id
+	if id == "" {
+		return errors.New("no domain id set")
+	}
+	return client.Delete(ctx, krb5DomainsPath, id, nil, nil, nil)
+}
+
+// GetIsiKrb5Providers queries a list of all Kerberos providers
+func GetIsiKrb5Providers(
+	ctx context.Context,
+	client api.Client) (providers []*IsiKrb5Provider, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/providers/krb5
+	var resp getIsiKrb5ProvidersResp
+	err = client.Get(ctx, authKrb5ProvidersPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Krb5, nil
+}
+
+// GetIsiKrb5Provider queries an individual Kerberos provider by realm
+func GetIsiKrb5Provider(
+	ctx context.Context,
+	client api.Client,
+	id string) (provider *IsiKrb5Provider, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/providers/krb5/id
+	if id == "" {
+		return nil, errors.New("no provider id set")
+	}
+
+	var resp getIsiKrb5ProvidersResp
+	err = client.Get(ctx, authKrb5ProvidersPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Krb5) == 0 {
+		return nil, errors.New("krb5 provider missing from response")
+	}
+	return resp.Krb5[0], nil
+}
+
+// JoinIsiKrb5Realm creates a Kerberos provider for a realm and returns its id
+func JoinIsiKrb5Realm(
+	ctx context.Context,
+	client api.Client,
+	req *IsiKrb5JoinReq) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/providers/krb5
+	//            Content-Type: application/json
+	//            {realm: "EXAMPLE.COM", user: "admin", password: "secret"}
+	if req == nil || req.Realm == "" {
+		return "", errors.New("no realm name set")
+	}
+	if req.KeytabFile == nil && req.User == nil {
+		return "", errors.New("no realm user or keytab file set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(ctx, authKrb5ProvidersPath, "", nil, nil, req, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiKrb5Provider modifies a Kerberos provider
+func UpdateIsiKrb5Provider(
+	ctx context.Context,
+	client api.Client,
+	id string, provider *IsiKrb5Provider) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/providers/krb5/id
+	//            Content-Type: application/json
+	//            {spns: ["nfs/host.example.com"]}
+	if id == "" {
+		return errors.New("no provider id set")
+	}
+	if provider == nil {
+		return errors.New("no provider settings set")
+	}
+	return client.Put(ctx, authKrb5ProvidersPath, id, nil, nil, provider, nil)
+}
+
+// DeleteIsiKrb5Provider removes a Kerberos provider
+func DeleteIsiKrb5Provider(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/providers/krb5/id
+	if id == "" {
+		return errors.New("no provider id set")
+	}
+	return client.Delete(ctx, authKrb5ProvidersPath, id, nil, nil, nil)
+}
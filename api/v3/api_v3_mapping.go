@@ -0,0 +1,219 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// IsiMappingRuleUser identifies a user in a mapping rule. Either field may
+// be a wildcard.
+type IsiMappingRuleUser struct {
+	Domain string `json:"domain,omitempty"`
+	User   string `json:"user"`
+}
+
+// IsiMappingRuleOptions are the options of a mapping rule.
+type IsiMappingRuleOptions struct {
+	Break       bool                `json:"break,omitempty"`
+	DefaultUser *IsiMappingRuleUser `json:"default_user,omitempty"`
+	Group       bool                `json:"group,omitempty"`
+	Groups      bool                `json:"groups,omitempty"`
+	User        bool                `json:"user,omitempty"`
+}
+
+// IsiMappingRule is a user mapping rule. The operator is one of "append",
+// "insert", "join", "replace", or "trim".
+type IsiMappingRule struct {
+	Operator string                 `json:"operator"`
+	Options  *IsiMappingRuleOptions `json:"options,omitempty"`
+	User1    *IsiMappingRuleUser    `json:"user1,omitempty"`
+	User2    *IsiMappingRuleUser    `json:"user2,omitempty"`
+}
+
+// IsiMappingRulesParameters are the parameters applied to all mapping rules.
+type IsiMappingRulesParameters struct {
+	DefaultUnmappedUser *IsiMappingRuleUser `json:"default_unmapped_user,omitempty"`
+}
+
+// IsiMappingRules is the ordered list of user mapping rules of an access
+// zone.
+type IsiMappingRules struct {
+	Rules      []*IsiMappingRule          `json:"rules"`
+	Parameters *IsiMappingRulesParameters `json:"parameters,omitempty"`
+}
+
+type isiMappingRulesResp struct {
+	Rules *IsiMappingRules `json:"rules"`
+}
+
+// IsiMappingIdentityTarget is an identity that a source identity maps to.
+type IsiMappingIdentityTarget struct {
+	OnDisk bool           `json:"on_disk"`
+	Target *apiv2.Persona `json:"target"`
+	Type   string         `json:"type"`
+}
+
+// IsiMappingIdentity is a source identity and the identities it maps to.
+type IsiMappingIdentity struct {
+	Source  *apiv2.Persona              `json:"source"`
+	Targets []*IsiMappingIdentityTarget `json:"targets"`
+}
+
+type getIsiMappingIdentitiesResp struct {
+	Identities []*IsiMappingIdentity `json:"identities"`
+}
+
+// IsiMappingTokenIdentity is a user or group identity in an access token.
+type IsiMappingTokenIdentity struct {
+	Name                string         `json:"name"`
+	Provider            string         `json:"provider"`
+	UID                 *apiv2.Persona `json:"uid"`
+	GID                 *apiv2.Persona `json:"gid"`
+	SID                 *apiv2.Persona `json:"sid"`
+	PrimaryGroupSID     *apiv2.Persona `json:"primary_group_sid"`
+	OnDiskUserIdentity  *apiv2.Persona `json:"on_disk_user_identity"`
+	OnDiskGroupIdentity *apiv2.Persona `json:"on_disk_group_identity"`
+}
+
+// IsiMappingToken is the access token a user is given in an access zone,
+// after all identity mapping rules have been applied.
+type IsiMappingToken struct {
+	User       *IsiMappingTokenIdentity   `json:"user"`
+	Groups     []*IsiMappingTokenIdentity `json:"groups"`
+	Privileges []*IsiPrivilege            `json:"privileges"`
+	ZID        int                        `json:"zid"`
+	Zone       string                     `json:"zone"`
+}
+
+type getIsiMappingTokensResp struct {
+	Mapping []*IsiMappingToken `json:"mapping"`
+}
+
+var (
+	userByteArr = []byte("user")
+	uidByteArr  = []byte("uid")
+)
+
+// GetIsiMappingRules queries the user mapping rules of an access zone
+func GetIsiMappingRules(
+	ctx context.Context,
+	client api.Client,
+	zone string) (rules *IsiMappingRules, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/mapping/users/rules?zone=zone
+	var resp isiMappingRulesResp
+	err = client.Get(
+		ctx, mappingUserRulesPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		return nil, errors.New("mapping rules missing from response")
+	}
+	return resp.Rules, nil
+}
+
+// SetIsiMappingRules replaces the user mapping rules of an access zone
+func SetIsiMappingRules(
+	ctx context.Context,
+	client api.Client,
+	zone string, rules *IsiMappingRules) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/mapping/users/rules?zone=zone
+	//            Content-Type: application/json
+	//            {rules: {rules: [{operator: "replace",
+	//                              user1: {domain: "DOMAIN", user: "*"},
+	//                              user2: {user: "nobody"}}]}}
+	if rules == nil {
+		return errors.New("no mapping rules set")
+	}
+	if rules.Rules == nil {
+		rules.Rules = []*IsiMappingRule{}
+	}
+	return client.Put(
+		ctx, mappingUserRulesPath, "", api.ZoneQS(client, zone), nil,
+		&isiMappingRulesResp{Rules: rules}, nil)
+}
+
+// GetIsiMappingIdentities queries the identities that a source identity,
+// such as "UID:2000", maps to in an access zone
+func GetIsiMappingIdentities(
+	ctx context.Context,
+	client api.Client,
+	zone, source string) (identities []*IsiMappingIdentity, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/mapping/identities/source?zone=zone
+	if source == "" {
+		return nil, errors.New("no source identity set")
+	}
+
+	var resp getIsiMappingIdentitiesResp
+	err = client.Get(
+		ctx, mappingIdentitiesPath, source, api.ZoneQS(client, zone), nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Identities, nil
+}
+
+// FlushIsiMappingIdentities flushes the ID mapper cache of an access zone
+func FlushIsiMappingIdentities(
+	ctx context.Context,
+	client api.Client,
+	zone string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/mapping/identities?zone=zone
+	return client.Delete(
+		ctx, mappingIdentitiesPath, "", api.ZoneQS(client, zone), nil, nil)
+}
+
+// DumpIsiMappingIdentities streams the contents of the ID mapper database of
+// an access zone to w
+func DumpIsiMappingIdentities(
+	ctx context.Context,
+	client api.Client,
+	zone string, w io.Writer) (err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/mapping/dump?zone=zone
+	if w == nil {
+		return errors.New("no writer set")
+	}
+	return client.Get(
+		ctx, mappingDumpPath, "", api.ZoneQS(client, zone), nil, w)
+}
+
+// GetIsiMappingToken looks up the access token of a user in an access zone.
+// The user is identified by name, or by UID if name is empty.
+func GetIsiMappingToken(
+	ctx context.Context,
+	client api.Client,
+	zone, user string, uid int) (token *IsiMappingToken, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/mapping/users/lookup?user=user&zone=zone
+	var params api.OrderedValues
+	switch {
+	case user != "":
+		params = api.OrderedValues{{userByteArr, []byte(user)}}
+	case uid >= 0:
+		params = api.OrderedValues{{uidByteArr, []byte(strconv.Itoa(uid))}}
+	default:
+		return nil, errors.New("no user or uid set")
+	}
+	params = append(params, api.ZoneQS(client, zone)...)
+
+	var resp getIsiMappingTokensResp
+	err = client.Get(ctx, mappingUserLookupPath, "", params, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Mapping) == 0 {
+		return nil, errors.New("mapping missing from response")
+	}
+	return resp.Mapping[0], nil
+}
@@ -0,0 +1,581 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiGroupnet is a network groupnet, the top level of the network
+// configuration which holds DNS settings and a set of subnets.
+type IsiGroupnet struct {
+	ID                      string    `json:"id,omitmarshal"`
+	Name                    *string   `json:"name,omitempty"`
+	Description             *string   `json:"description,omitempty"`
+	AllowWildcardSubdomains *bool     `json:"allow_wildcard_subdomains,omitempty"`
+	DNSCacheEnabled         *bool     `json:"dns_cache_enabled,omitempty"`
+	DNSOptions              *[]string `json:"dns_options,omitempty"`
+	DNSSearch               *[]string `json:"dns_search,omitempty"`
+	DNSServers              *[]string `json:"dns_servers,omitempty"`
+	ServerSideDNSSearch     *bool     `json:"server_side_dns_search,omitempty"`
+	Subnets                 []string  `json:"subnets,omitmarshal"`
+}
+
+type getIsiGroupnetsResp struct {
+	Groupnets []*IsiGroupnet `json:"groupnets"`
+}
+
+// IsiSubnet is a network subnet within a groupnet.
+type IsiSubnet struct {
+	ID              string    `json:"id,omitmarshal"`
+	Name            *string   `json:"name,omitempty"`
+	Description     *string   `json:"description,omitempty"`
+	AddrFamily      *string   `json:"addr_family,omitempty"`
+	BaseAddr        string    `json:"base_addr,omitmarshal"`
+	Prefixlen       *int      `json:"prefixlen,omitempty"`
+	Gateway         *string   `json:"gateway,omitempty"`
+	GatewayPriority *int      `json:"gateway_priority,omitempty"`
+	MTU             *int      `json:"mtu,omitempty"`
+	DSRAddrs        *[]string `json:"dsr_addrs,omitempty"`
+	SCServiceAddr   *string   `json:"sc_service_addr,omitempty"`
+	SCServiceName   *string   `json:"sc_service_name,omitempty"`
+	VLANEnabled     *bool     `json:"vlan_enabled,omitempty"`
+	VLANID          *int      `json:"vlan_id,omitempty"`
+	Groupnet        string    `json:"groupnet,omitmarshal"`
+	Pools           []string  `json:"pools,omitmarshal"`
+}
+
+type getIsiSubnetsResp struct {
+	Subnets []*IsiSubnet `json:"subnets"`
+}
+
+// IsiIPRange is an inclusive range of IP addresses.
+type IsiIPRange struct {
+	Low  string `json:"low"`
+	High string `json:"high"`
+}
+
+// IsiPoolInterface is a node interface that is a member of a pool.
+type IsiPoolInterface struct {
+	Iface string `json:"iface"`
+	LNN   int    `json:"lnn"`
+}
+
+// IsiPool is a pool of IP addresses within a subnet, along with its access
+// zone binding and SmartConnect configuration.
+type IsiPool struct {
+	ID                   string              `json:"id,omitmarshal"`
+	Name                 *string             `json:"name,omitempty"`
+	Description          *string             `json:"description,omitempty"`
+	AccessZone           *string             `json:"access_zone,omitempty"`
+	AggregationMode      *string             `json:"aggregation_mode,omitempty"`
+	AllocMethod          *string             `json:"alloc_method,omitempty"`
+	Ifaces               *[]IsiPoolInterface `json:"ifaces,omitempty"`
+	Ranges               *[]IsiIPRange       `json:"ranges,omitempty"`
+	RebalancePolicy      *string             `json:"rebalance_policy,omitempty"`
+	SCAutoUnsuspendDelay *int                `json:"sc_auto_unsuspend_delay,omitempty"`
+	SCConnectPolicy      *string             `json:"sc_connect_policy,omitempty"`
+	SCDNSZone            *string             `json:"sc_dns_zone,omitempty"`
+	SCDNSZoneAliases     *[]string           `json:"sc_dns_zone_aliases,omitempty"`
+	SCFailoverPolicy     *string             `json:"sc_failover_policy,omitempty"`
+	SCSubnet             *string             `json:"sc_subnet,omitempty"`
+	SCTTL                *int                `json:"sc_ttl,omitempty"`
+	StaticRoutes         *[]string           `json:"static_routes,omitempty"`
+	Groupnet             string              `json:"groupnet,omitmarshal"`
+	Subnet               string              `json:"subnet,omitmarshal"`
+}
+
+type getIsiPoolsResp struct {
+	Pools []*IsiPool `json:"pools"`
+}
+
+// IsiNetworkExternalSettings are the cluster-wide settings of the external
+// network, including the SmartConnect rebalance delay.
+type IsiNetworkExternalSettings struct {
+	SBR              *bool  `json:"sbr,omitempty"`
+	SCRebalanceDelay *int   `json:"sc_rebalance_delay,omitempty"`
+	TCPPorts         *[]int `json:"tcp_ports,omitempty"`
+}
+
+type getIsiNetworkExternalSettingsResp struct {
+	Settings *IsiNetworkExternalSettings `json:"settings"`
+}
+
+// IsiInternalNetworkSettings are the settings of the cluster's back-end
+// networks, int-a, the optional int-b, and the failover range used when
+// both are configured. Changes take effect when the nodes are rebooted.
+type IsiInternalNetworkSettings struct {
+	IntAIPAddresses     *[]IsiIPRange `json:"int_a_ip_addresses,omitempty"`
+	IntAPrefixLength    *int          `json:"int_a_prefix_length,omitempty"`
+	IntAMTU             *int          `json:"int_a_mtu,omitempty"`
+	IntAFabric          string        `json:"int_a_fabric,omitmarshal"`
+	IntAStatus          string        `json:"int_a_status,omitmarshal"`
+	IntBIPAddresses     *[]IsiIPRange `json:"int_b_ip_addresses,omitempty"`
+	IntBPrefixLength    *int          `json:"int_b_prefix_length,omitempty"`
+	IntBMTU             *int          `json:"int_b_mtu,omitempty"`
+	IntBFabric          string        `json:"int_b_fabric,omitmarshal"`
+	IntBStatus          string        `json:"int_b_status,omitmarshal"`
+	FailoverIPAddresses *[]IsiIPRange `json:"failover_ip_addresses,omitempty"`
+	FailoverStatus      string        `json:"failover_status,omitmarshal"`
+}
+
+type getIsiInternalNetworkSettingsResp struct {
+	Settings *IsiInternalNetworkSettings `json:"settings"`
+}
+
+// IsiNetworkInterfaceOwner is a pool that has IP addresses allocated on a
+// network interface.
+type IsiNetworkInterfaceOwner struct {
+	Groupnet string   `json:"groupnet"`
+	Subnet   string   `json:"subnet"`
+	Pool     string   `json:"pool"`
+	Type     string   `json:"type"`
+	IPAddrs  []string `json:"ip_addrs"`
+}
+
+// IsiNetworkInterface is a network interface on a node. Speed is in
+// megabits per second, and it and MTU are nil if the cluster does not report
+// them.
+type IsiNetworkInterface struct {
+	ID      string                      `json:"id"`
+	LNN     int                         `json:"lnn"`
+	Name    string                      `json:"name"`
+	NICName string                      `json:"nic_name"`
+	Status  string                      `json:"status"`
+	Type    string                      `json:"type"`
+	Speed   *int64                      `json:"speed"`
+	MTU     *int                        `json:"mtu"`
+	Flags   []string                    `json:"flags"`
+	IPAddrs []string                    `json:"ip_addrs"`
+	Owners  []*IsiNetworkInterfaceOwner `json:"owners"`
+}
+
+type getIsiNetworkInterfacesResp struct {
+	Interfaces []*IsiNetworkInterface `json:"interfaces"`
+}
+
+type postIsiNetworkResp struct {
+	ID string `json:"id"`
+}
+
+func subnetsPath(groupnet string) string {
+	return path.Join(groupnetsPath, groupnet, subnetsPathSegment)
+}
+
+func poolsPath(groupnet, subnet string) string {
+	return path.Join(subnetsPath(groupnet), subnet, poolsPathSegment)
+}
+
+// GetIsiGroupnets queries a list of all groupnets
+func GetIsiGroupnets(
+	ctx context.Context,
+	client api.Client) (groupnets []*IsiGroupnet, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets
+	var resp getIsiGroupnetsResp
+	err = client.Get(ctx, groupnetsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Groupnets, nil
+}
+
+// GetIsiGroupnet queries an individual groupnet by name
+func GetIsiGroupnet(
+	ctx context.Context,
+	client api.Client,
+	name string) (groupnet *IsiGroupnet, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets/name
+	var resp getIsiGroupnetsResp
+	err = client.Get(ctx, groupnetsPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Groupnets) == 0 {
+		return nil, errors.New("groupnet missing from response")
+	}
+	return resp.Groupnets[0], nil
+}
+
+// CreateIsiGroupnet creates a groupnet and returns its id
+func CreateIsiGroupnet(
+	ctx context.Context,
+	client api.Client,
+	groupnet *IsiGroupnet) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/network/groupnets
+	//            Content-Type: application/json
+	//            {name: "groupnet1", dns_servers: ["10.0.0.53"]}
+	if groupnet == nil || groupnet.Name == nil || *groupnet.Name == "" {
+		return "", errors.New("no groupnet name set")
+	}
+
+	var resp postIsiNetworkResp
+	err = client.Post(ctx, groupnetsPath, "", nil, nil, groupnet, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiGroupnet modifies a groupnet
+func UpdateIsiGroupnet(
+	ctx context.Context,
+	client api.Client,
+	name string, groupnet *IsiGroupnet) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/network/groupnets/name
+	if name == "" {
+		return errors.New("no groupnet name set")
+	}
+	if groupnet == nil {
+		return errors.New("no groupnet set")
+	}
+	return client.Put(ctx, groupnetsPath, name, nil, nil, groupnet, nil)
+}
+
+// DeleteIsiGroupnet removes a groupnet
+func DeleteIsiGroupnet(
+	ctx context.Context,
+	client api.Client,
+	name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/network/groupnets/name
+	if name == "" {
+		return errors.New("no groupnet name set")
+	}
+	return client.Delete(ctx, groupnetsPath, name, nil, nil, nil)
+}
+
+// GetIsiSubnets queries a list of the subnets in a groupnet
+func GetIsiSubnets(
+	ctx context.Context,
+	client api.Client,
+	groupnet string) (subnets []*IsiSubnet, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets
+	if groupnet == "" {
+		return nil, errors.New("no groupnet name set")
+	}
+
+	var resp getIsiSubnetsResp
+	err = client.Get(ctx, subnetsPath(groupnet), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Subnets, nil
+}
+
+// GetIsiSubnet queries an individual subnet in a groupnet by name
+func GetIsiSubnet(
+	ctx context.Context,
+	client api.Client,
+	groupnet, name string) (subnet *IsiSubnet, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/name
+	if groupnet == "" {
+		return nil, errors.New("no groupnet name set")
+	}
+
+	var resp getIsiSubnetsResp
+	err = client.Get(ctx, subnetsPath(groupnet), name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Subnets) == 0 {
+		return nil, errors.New("subnet missing from response")
+	}
+	return resp.Subnets[0], nil
+}
+
+// CreateIsiSubnet creates a subnet in a groupnet and returns its id
+func CreateIsiSubnet(
+	ctx context.Context,
+	client api.Client,
+	groupnet string, subnet *IsiSubnet) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets
+	//            Content-Type: application/json
+	//            {name: "subnet1", addr_family: "ipv4", prefixlen: 24}
+	if groupnet == "" {
+		return "", errors.New("no groupnet name set")
+	}
+	if subnet == nil || subnet.Name == nil || *subnet.Name == "" {
+		return "", errors.New("no subnet name set")
+	}
+
+	var resp postIsiNetworkResp
+	err = client.Post(ctx, subnetsPath(groupnet), "", nil, nil, subnet, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiSubnet modifies a subnet
+func UpdateIsiSubnet(
+	ctx context.Context,
+	client api.Client,
+	groupnet, name string, subnet *IsiSubnet) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/name
+	if groupnet == "" {
+		return errors.New("no groupnet name set")
+	}
+	if name == "" {
+		return errors.New("no subnet name set")
+	}
+	if subnet == nil {
+		return errors.New("no subnet set")
+	}
+	return client.Put(ctx, subnetsPath(groupnet), name, nil, nil, subnet, nil)
+}
+
+// DeleteIsiSubnet removes a subnet
+func DeleteIsiSubnet(
+	ctx context.Context,
+	client api.Client,
+	groupnet, name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/name
+	if groupnet == "" {
+		return errors.New("no groupnet name set")
+	}
+	if name == "" {
+		return errors.New("no subnet name set")
+	}
+	return client.Delete(ctx, subnetsPath(groupnet), name, nil, nil, nil)
+}
+
+// GetIsiPools queries a list of the pools in a subnet
+func GetIsiPools(
+	ctx context.Context,
+	client api.Client,
+	groupnet, subnet string) (pools []*IsiPool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/subnet/pools
+	if groupnet == "" || subnet == "" {
+		return nil, errors.New("no groupnet or subnet name set")
+	}
+
+	var resp getIsiPoolsResp
+	err = client.Get(ctx, poolsPath(groupnet, subnet), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Pools, nil
+}
+
+// GetIsiAllPools queries a list of the pools in all groupnets and subnets
+func GetIsiAllPools(
+	ctx context.Context,
+	client api.Client) (pools []*IsiPool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/pools
+	var resp getIsiPoolsResp
+	err = client.Get(ctx, networkPoolsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Pools, nil
+}
+
+// GetIsiPool queries an individual pool in a subnet by name
+func GetIsiPool(
+	ctx context.Context,
+	client api.Client,
+	groupnet, subnet, name string) (pool *IsiPool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/subnet/pools/name
+	if groupnet == "" || subnet == "" {
+		return nil, errors.New("no groupnet or subnet name set")
+	}
+
+	var resp getIsiPoolsResp
+	err = client.Get(ctx, poolsPath(groupnet, subnet), name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Pools) == 0 {
+		return nil, errors.New("pool missing from response")
+	}
+	return resp.Pools[0], nil
+}
+
+// CreateIsiPool creates a pool in a subnet and returns its id
+func CreateIsiPool(
+	ctx context.Context,
+	client api.Client,
+	groupnet, subnet string, pool *IsiPool) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/subnet/pools
+	//            Content-Type: application/json
+	//            {name: "pool1", access_zone: "System", alloc_method: "static",
+	//             ranges: [{low: "10.0.0.10", high: "10.0.0.20"}]}
+	if groupnet == "" || subnet == "" {
+		return "", errors.New("no groupnet or subnet name set")
+	}
+	if pool == nil || pool.Name == nil || *pool.Name == "" {
+		return "", errors.New("no pool name set")
+	}
+
+	var resp postIsiNetworkResp
+	err = client.Post(
+		ctx, poolsPath(groupnet, subnet), "", nil, nil, pool, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiPool modifies a pool
+func UpdateIsiPool(
+	ctx context.Context,
+	client api.Client,
+	groupnet, subnet, name string, pool *IsiPool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/subnet/pools/name
+	if groupnet == "" || subnet == "" {
+		return errors.New("no groupnet or subnet name set")
+	}
+	if name == "" {
+		return errors.New("no pool name set")
+	}
+	if pool == nil {
+		return errors.New("no pool set")
+	}
+	return client.Put(
+		ctx, poolsPath(groupnet, subnet), name, nil, nil, pool, nil)
+}
+
+// DeleteIsiPool removes a pool
+func DeleteIsiPool(
+	ctx context.Context,
+	client api.Client,
+	groupnet, subnet, name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/network/groupnets/groupnet/subnets/subnet/pools/name
+	if groupnet == "" || subnet == "" {
+		return errors.New("no groupnet or subnet name set")
+	}
+	if name == "" {
+		return errors.New("no pool name set")
+	}
+	return client.Delete(ctx, poolsPath(groupnet, subnet), name, nil, nil, nil)
+}
+
+// GetIsiNetworkExternalSettings queries the external network settings
+func GetIsiNetworkExternalSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiNetworkExternalSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/external
+	var resp getIsiNetworkExternalSettingsResp
+	err = client.Get(ctx, networkExternalPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("network external settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiNetworkExternalSettings modifies the external network settings
+func UpdateIsiNetworkExternalSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiNetworkExternalSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/network/external
+	//            Content-Type: application/json
+	//            {sc_rebalance_delay: 0}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, networkExternalPath, "", nil, nil, settings, nil)
+}
+
+// FlushIsiDNSCache flushes the cluster's DNS cache, so that changes to DNS
+// records, ex. a new SmartConnect delegation, are seen immediately
+func FlushIsiDNSCache(
+	ctx context.Context,
+	client api.Client) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/network/dnscache/flush
+	return client.Post(
+		ctx, dnsCacheFlushPath, "", nil, nil, map[string]string{}, nil)
+}
+
+// GetIsiInternalNetworkSettings queries the internal network settings
+func GetIsiInternalNetworkSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiInternalNetworkSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/internal-networks
+	var resp getIsiInternalNetworkSettingsResp
+	err = client.Get(ctx, internalNetworksPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("internal network settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiInternalNetworkSettings modifies the internal network settings
+func UpdateIsiInternalNetworkSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiInternalNetworkSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/cluster/internal-networks
+	//            Content-Type: application/json
+	//            {int_b_ip_addresses: [{low: "192.168.1.1", high: "192.168.1.64"}],
+	//             int_b_prefix_length: 24}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, internalNetworksPath, "", nil, nil, settings, nil)
+}
+
+// GetIsiNetworkInterfaces queries a list of the network interfaces on all
+// nodes, including the IP addresses allocated to each from their pools
+func GetIsiNetworkInterfaces(
+	ctx context.Context,
+	client api.Client) (ifaces []*IsiNetworkInterface, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/interfaces
+	var resp getIsiNetworkInterfacesResp
+	err = client.Get(ctx, networkInterfacesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Interfaces, nil
+}
+
+var byteArrLNNs = []byte("lnns")
+
+// GetIsiNodeNetworkInterfaces queries a list of the network interfaces on a
+// node by its logical node number
+func GetIsiNodeNetworkInterfaces(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (ifaces []*IsiNetworkInterface, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/network/interfaces?lnns=1
+	var resp getIsiNetworkInterfacesResp
+	err = client.Get(
+		ctx, networkInterfacesPath, "",
+		api.OrderedValues{{byteArrLNNs, []byte(strconv.Itoa(lnn))}},
+		nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Interfaces, nil
+}
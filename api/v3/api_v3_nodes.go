@@ -0,0 +1,317 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiNode is a node of the cluster.
+type IsiNode struct {
+	ID     int             `json:"id"`
+	LNN    int             `json:"lnn"`
+	Drives []*IsiNodeDrive `json:"drives"`
+}
+
+// IsiNodeDrive is a drive of a node.
+type IsiNodeDrive struct {
+	Baynum        int    `json:"baynum"`
+	Lnum          int    `json:"lnum"`
+	Devname       string `json:"devname"`
+	InterfaceType string `json:"interface_type"`
+	MediaType     string `json:"media_type"`
+	Model         string `json:"model"`
+	Serial        string `json:"serial"`
+	Purpose       string `json:"purpose"`
+	Present       bool   `json:"present"`
+	UIState       string `json:"ui_state"`
+	WWN           string `json:"wwn"`
+}
+
+type getIsiNodesResp struct {
+	Nodes []*IsiNode `json:"nodes"`
+}
+
+// IsiNodePowerReq is used to shut down or reboot a node. AllowDown permits
+// the request even if it would take the cluster below quorum.
+type IsiNodePowerReq struct {
+	AllowDown bool `json:"allow_down"`
+}
+
+// IsiNodeReadOnly is the read-only state of a node.
+type IsiNodeReadOnly struct {
+	Allowed *bool   `json:"allowed,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+	Mode    *bool   `json:"mode,omitempty"`
+	Status  *string `json:"status,omitempty"`
+	Valid   *bool   `json:"valid,omitempty"`
+	Value   *int    `json:"value,omitempty"`
+}
+
+type isiNodeReadOnlyReq struct {
+	Enabled bool `json:"enabled"`
+}
+
+type getIsiNodeReadOnlyResp struct {
+	ReadOnly *IsiNodeReadOnly `json:"readonly"`
+}
+
+// IsiNodeSensor is a reading of a hardware sensor of a node. The value is
+// the text reported by the sensor, ex. "7920" or "34.5".
+type IsiNodeSensor struct {
+	Desc  string `json:"desc"`
+	Name  string `json:"name"`
+	Units string `json:"units"`
+	Value string `json:"value"`
+}
+
+// IsiNodeSensorGroup is a group of hardware sensors of a node of the same
+// kind, ex. "Fan" or "Temp".
+type IsiNodeSensorGroup struct {
+	Count  int              `json:"count"`
+	Name   string           `json:"name"`
+	Values []*IsiNodeSensor `json:"values"`
+}
+
+type getIsiNodeSensorsResp struct {
+	Nodes []*struct {
+		Sensors []*IsiNodeSensorGroup `json:"sensors"`
+	} `json:"nodes"`
+}
+
+// IsiNodeBatteryStatus is the status of the NVRAM batteries of a node, and
+// of their last self tests, by battery number.
+type IsiNodeBatteryStatus struct {
+	Present       bool   `json:"present"`
+	Supported     bool   `json:"supported"`
+	Status1       string `json:"status1"`
+	Status2       string `json:"status2"`
+	Result1       string `json:"result1"`
+	Result2       string `json:"result2"`
+	LastTestTime1 string `json:"last_test_time1"`
+	LastTestTime2 string `json:"last_test_time2"`
+	NextTestTime1 string `json:"next_test_time1"`
+	NextTestTime2 string `json:"next_test_time2"`
+}
+
+// IsiNodeNVRAMBattery is an NVRAM battery of a node. Color is the color of
+// its status light, ex. "green".
+type IsiNodeNVRAMBattery struct {
+	ID      int    `json:"id"`
+	Color   string `json:"color"`
+	Status  string `json:"status"`
+	Voltage string `json:"voltage"`
+}
+
+// IsiNodeNVRAM is the NVRAM of a node.
+type IsiNodeNVRAM struct {
+	Present            bool                   `json:"present"`
+	Supported          bool                   `json:"supported"`
+	BatteryCount       int                    `json:"battery_count"`
+	Batteries          []*IsiNodeNVRAMBattery `json:"batteries"`
+	ChargeStatus       string                 `json:"charge_status"`
+	ChargeStatusNumber int                    `json:"charge_status_number"`
+}
+
+// IsiNodePowerSupply is a power supply of a node.
+type IsiNodePowerSupply struct {
+	ID       int    `json:"id"`
+	Chassis  int    `json:"chassis"`
+	Name     string `json:"name"`
+	Firmware string `json:"firmware"`
+	Good     string `json:"good"`
+	Status   string `json:"status"`
+	Type     string `json:"type"`
+}
+
+// IsiNodePowerSupplies are the power supplies of a node. Failures is the
+// number that have failed.
+type IsiNodePowerSupplies struct {
+	Count    int                   `json:"count"`
+	Failures int                   `json:"failures"`
+	Status   string                `json:"status"`
+	Supplies []*IsiNodePowerSupply `json:"supplies"`
+}
+
+// IsiNodeStatus is the hardware status of a node.
+type IsiNodeStatus struct {
+	ID            int                   `json:"id"`
+	LNN           int                   `json:"lnn"`
+	BatteryStatus *IsiNodeBatteryStatus `json:"batterystatus"`
+	NVRAM         *IsiNodeNVRAM         `json:"nvram"`
+	PowerSupplies *IsiNodePowerSupplies `json:"powersupplies"`
+}
+
+type getIsiNodeStatusResp struct {
+	Nodes []*IsiNodeStatus `json:"nodes"`
+}
+
+// IsiNodeHardware is the hardware identity of a node.
+type IsiNodeHardware struct {
+	ID           int    `json:"id"`
+	LNN          int    `json:"lnn"`
+	Product      string `json:"product"`
+	SerialNumber string `json:"serial_number"`
+}
+
+type getIsiNodeHardwareResp struct {
+	Nodes []*IsiNodeHardware `json:"nodes"`
+}
+
+func nodePath(lnn int, op string) string {
+	return path.Join(clusterNodesPath, strconv.Itoa(lnn), op)
+}
+
+// GetIsiNodes queries all nodes of the cluster and their drives
+func GetIsiNodes(
+	ctx context.Context,
+	client api.Client) (nodes []*IsiNode, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes
+	var resp getIsiNodesResp
+	err = client.Get(ctx, clusterNodesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Nodes, nil
+}
+
+// GetIsiNodeDrives queries the drives of a node by its logical node number
+func GetIsiNodeDrives(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (drives []*IsiNodeDrive, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes/1/drives
+	var resp getIsiNodesResp
+	err = client.Get(ctx, nodePath(lnn, "drives"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Nodes) == 0 {
+		return nil, errors.New("node missing from response")
+	}
+	return resp.Nodes[0].Drives, nil
+}
+
+// ShutdownIsiNode shuts down a node by its logical node number
+func ShutdownIsiNode(
+	ctx context.Context,
+	client api.Client,
+	lnn int, req *IsiNodePowerReq) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cluster/nodes/1/shutdown
+	//            Content-Type: application/json
+	//            {allow_down: false}
+	if req == nil {
+		return errors.New("no request set")
+	}
+	return client.Post(ctx, nodePath(lnn, "shutdown"), "", nil, nil, req, nil)
+}
+
+// RebootIsiNode reboots a node by its logical node number
+func RebootIsiNode(
+	ctx context.Context,
+	client api.Client,
+	lnn int, req *IsiNodePowerReq) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/cluster/nodes/1/reboot
+	//            Content-Type: application/json
+	//            {allow_down: false}
+	if req == nil {
+		return errors.New("no request set")
+	}
+	return client.Post(ctx, nodePath(lnn, "reboot"), "", nil, nil, req, nil)
+}
+
+// GetIsiNodeReadOnly queries the read-only state of a node
+func GetIsiNodeReadOnly(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (readOnly *IsiNodeReadOnly, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes/1/readonly
+	var resp getIsiNodeReadOnlyResp
+	err = client.Get(ctx, nodePath(lnn, "readonly"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ReadOnly == nil {
+		return nil, errors.New("readonly state missing from response")
+	}
+	return resp.ReadOnly, nil
+}
+
+// SetIsiNodeReadOnly enables or disables read-only mode on a node
+func SetIsiNodeReadOnly(
+	ctx context.Context,
+	client api.Client,
+	lnn int, enabled bool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/cluster/nodes/1/readonly
+	//            Content-Type: application/json
+	//            {enabled: true}
+	return client.Put(
+		ctx, nodePath(lnn, "readonly"), "", nil, nil,
+		&isiNodeReadOnlyReq{Enabled: enabled}, nil)
+}
+
+// GetIsiNodeSensors queries the hardware sensors of a node by its logical
+// node number
+func GetIsiNodeSensors(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (sensors []*IsiNodeSensorGroup, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes/1/sensors
+	var resp getIsiNodeSensorsResp
+	err = client.Get(ctx, nodePath(lnn, "sensors"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Nodes) == 0 {
+		return nil, errors.New("node missing from response")
+	}
+	return resp.Nodes[0].Sensors, nil
+}
+
+// GetIsiNodeStatus queries the hardware status of a node by its logical node
+// number
+func GetIsiNodeStatus(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (status *IsiNodeStatus, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes/1/status
+	var resp getIsiNodeStatusResp
+	err = client.Get(ctx, nodePath(lnn, "status"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Nodes) == 0 {
+		return nil, errors.New("node missing from response")
+	}
+	return resp.Nodes[0], nil
+}
+
+// GetIsiNodeHardware queries the hardware identity of a node by its logical
+// node number
+func GetIsiNodeHardware(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (hardware *IsiNodeHardware, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/cluster/nodes/1/hardware
+	var resp getIsiNodeHardwareResp
+	err = client.Get(ctx, nodePath(lnn, "hardware"), "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Nodes) == 0 {
+		return nil, errors.New("node missing from response")
+	}
+	return resp.Nodes[0], nil
+}
@@ -0,0 +1,229 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// IsiPrivilege is an RBAC privilege, such as "ISI_PRIV_NFS". When assigned
+// to a role, ReadOnly limits the privilege to read access.
+type IsiPrivilege struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Category    string `json:"category,omitmarshal"`
+	Description string `json:"description,omitmarshal"`
+	ReadOnly    bool   `json:"read_only"`
+}
+
+type getIsiPrivilegesResp struct {
+	Privileges []*IsiPrivilege `json:"privileges"`
+}
+
+// IsiRole is an RBAC role, which grants a set of privileges to its members.
+type IsiRole struct {
+	ID          string            `json:"id,omitmarshal"`
+	Name        *string           `json:"name,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	Members     *[]*apiv2.Persona `json:"members,omitempty"`
+	Privileges  *[]*IsiPrivilege  `json:"privileges,omitempty"`
+}
+
+type getIsiRolesResp struct {
+	Roles []*IsiRole `json:"roles"`
+}
+
+func roleMembersPath(role string) string {
+	return path.Join(authRolesPath, role, membersPathSegment)
+}
+
+func rolePrivilegesPath(role string) string {
+	return path.Join(authRolesPath, role, privilegesPathSegment)
+}
+
+// GetIsiPrivileges queries a list of all privileges that can be assigned to
+// roles
+func GetIsiPrivileges(
+	ctx context.Context,
+	client api.Client) (privileges []*IsiPrivilege, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/privileges
+	var resp getIsiPrivilegesResp
+	err = client.Get(ctx, authPrivilegesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Privileges, nil
+}
+
+// GetIsiRoles queries a list of all roles in an access zone
+func GetIsiRoles(
+	ctx context.Context,
+	client api.Client,
+	zone string) (roles []*IsiRole, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/roles?zone=zone
+	var resp getIsiRolesResp
+	err = client.Get(
+		ctx, authRolesPath, "", api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Roles, nil
+}
+
+// GetIsiRole queries an individual role in an access zone by name
+func GetIsiRole(
+	ctx context.Context,
+	client api.Client,
+	zone, role string) (r *IsiRole, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/auth/roles/role?zone=zone
+	if role == "" {
+		return nil, errors.New("no role set")
+	}
+
+	var resp getIsiRolesResp
+	err = client.Get(
+		ctx, authRolesPath, role, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Roles) == 0 {
+		return nil, errors.New("role missing from response")
+	}
+	return resp.Roles[0], nil
+}
+
+// CreateIsiRole creates a role in an access zone and returns its id
+func CreateIsiRole(
+	ctx context.Context,
+	client api.Client,
+	zone string, role *IsiRole) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/roles?zone=zone
+	//            Content-Type: application/json
+	//            {name: "role1", description: "...",
+	//             privileges: [{id: "ISI_PRIV_LOGIN_PAPI", read_only: true}]}
+	if role == nil || role.Name == nil || *role.Name == "" {
+		return "", errors.New("no role name set")
+	}
+
+	var resp postIsiAuthResp
+	err = client.Post(
+		ctx, authRolesPath, "", api.ZoneQS(client, zone), nil, role, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiRole modifies a role in an access zone
+func UpdateIsiRole(
+	ctx context.Context,
+	client api.Client,
+	zone, role string, r *IsiRole) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/auth/roles/role?zone=zone
+	if role == "" {
+		return errors.New("no role set")
+	}
+	if r == nil {
+		return errors.New("no role settings set")
+	}
+	return client.Put(
+		ctx, authRolesPath, role, api.ZoneQS(client, zone), nil, r, nil)
+}
+
+// DeleteIsiRole removes a role from an access zone
+func DeleteIsiRole(
+	ctx context.Context,
+	client api.Client,
+	zone, role string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/roles/role?zone=zone
+	if role == "" {
+		return errors.New("no role set")
+	}
+	return client.Delete(
+		ctx, authRolesPath, role, api.ZoneQS(client, zone), nil, nil)
+}
+
+// AddIsiRoleMember adds a member to a role in an access zone
+func AddIsiRoleMember(
+	ctx context.Context,
+	client api.Client,
+	zone, role string, member *apiv2.Persona) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/roles/role/members?zone=zone
+	//            Content-Type: application/json
+	//            {id: "UID:2000"}
+	if role == "" {
+		return errors.New("no role set")
+	}
+	if member == nil || member.ID == nil {
+		return errors.New("no member id set")
+	}
+	return client.Post(
+		ctx, roleMembersPath(role), "", api.ZoneQS(client, zone), nil,
+		member, nil)
+}
+
+// RemoveIsiRoleMember removes a member from a role in an access zone. The
+// member is identified by a persona id such as "UID:2000".
+func RemoveIsiRoleMember(
+	ctx context.Context,
+	client api.Client,
+	zone, role, member string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/roles/role/members/member?zone=zone
+	if role == "" {
+		return errors.New("no role set")
+	}
+	if member == "" {
+		return errors.New("no member set")
+	}
+	return client.Delete(
+		ctx, roleMembersPath(role), member, api.ZoneQS(client, zone), nil, nil)
+}
+
+// AddIsiRolePrivilege assigns a privilege to a role in an access zone
+func AddIsiRolePrivilege(
+	ctx context.Context,
+	client api.Client,
+	zone, role string, privilege *IsiPrivilege) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/auth/roles/role/privileges?zone=zone
+	//            Content-Type: application/json
+	//            {id: "ISI_PRIV_NFS", read_only: false}
+	if role == "" {
+		return errors.New("no role set")
+	}
+	if privilege == nil || privilege.ID == "" {
+		return errors.New("no privilege id set")
+	}
+	return client.Post(
+		ctx, rolePrivilegesPath(role), "", api.ZoneQS(client, zone), nil,
+		privilege, nil)
+}
+
+// RemoveIsiRolePrivilege removes a privilege from a role in an access zone
+func RemoveIsiRolePrivilege(
+	ctx context.Context,
+	client api.Client,
+	zone, role, privilege string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/auth/roles/role/privileges/privilege?zone=zone
+	if role == "" {
+		return errors.New("no role set")
+	}
+	if privilege == "" {
+		return errors.New("no privilege set")
+	}
+	return client.Delete(
+		ctx, rolePrivilegesPath(role), privilege, api.ZoneQS(client, zone),
+		nil, nil)
+}
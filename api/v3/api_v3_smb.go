@@ -0,0 +1,165 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// SMB share permission values.
+const (
+	SMBPermissionFull   = "full"
+	SMBPermissionChange = "change"
+	SMBPermissionRead   = "read"
+
+	SMBPermissionTypeAllow = "allow"
+	SMBPermissionTypeDeny  = "deny"
+)
+
+// IsiSMBSharePermission grants or denies a trustee access to an SMB share.
+type IsiSMBSharePermission struct {
+	Permission     string         `json:"permission"`
+	PermissionType string         `json:"permission_type"`
+	Trustee        *apiv2.Persona `json:"trustee"`
+}
+
+// IsiSMBShare is an SMB share. The ID is the share's name.
+type IsiSMBShare struct {
+	ID                     string                    `json:"id,omitmarshal"`
+	Zid                    int                       `json:"zid,omitmarshal"`
+	Name                   *string                   `json:"name,omitempty"`
+	Path                   *string                   `json:"path,omitempty"`
+	Description            *string                   `json:"description,omitempty"`
+	Permissions            *[]*IsiSMBSharePermission `json:"permissions,omitempty"`
+	RunAsRoot              *[]*apiv2.Persona         `json:"run_as_root,omitempty"`
+	Browsable              *bool                     `json:"browsable,omitempty"`
+	AccessBasedEnumeration *bool                     `json:"access_based_enumeration,omitempty"`
+	CreatePermissions      *string                   `json:"create_permissions,omitempty"`
+	DirectoryCreateMask    *int                      `json:"directory_create_mask,omitempty"`
+	DirectoryCreateMode    *int                      `json:"directory_create_mode,omitempty"`
+	FileCreateMask         *int                      `json:"file_create_mask,omitempty"`
+	FileCreateMode         *int                      `json:"file_create_mode,omitempty"`
+	ContinuouslyAvailable  *bool                     `json:"continuously_available,omitempty"`
+
+	// CreatePath and InheritablePathACL only apply when a share is created.
+	// CreatePath creates the share's path if it does not exist, and
+	// InheritablePathACL sets an inheritable ACL on the path.
+	CreatePath         *bool `json:"create_path,omitempty"`
+	InheritablePathACL *bool `json:"inheritable_path_acl,omitempty"`
+}
+
+type getIsiSMBSharesResp struct {
+	Shares []*IsiSMBShare `json:"shares"`
+	Resume string         `json:"resume,omitempty"`
+}
+
+type postIsiSMBShareResp struct {
+	ID string `json:"id"`
+}
+
+var byteArrResume = []byte("resume")
+
+// GetIsiSMBSharesPage queries a page of the SMB shares in an access zone. An
+// empty resume token queries the first page, and the returned resume token
+// is empty after the last page.
+func GetIsiSMBSharesPage(
+	ctx context.Context,
+	client api.Client,
+	zone, resume string) ([]*IsiSMBShare, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/protocols/smb/shares?zone=zone
+	// a resume token encodes the original query, so no other arguments may be
+	// sent with it
+	qs := api.ZoneQS(client, zone)
+	if resume != "" {
+		qs = api.OrderedValues{{byteArrResume, []byte(resume)}}
+	}
+
+	var resp getIsiSMBSharesResp
+	if err := client.Get(ctx, smbSharesPath, "", qs, nil, &resp); err != nil {
+		return nil, "", err
+	}
+	return resp.Shares, resp.Resume, nil
+}
+
+// GetIsiSMBShare queries an SMB share in an access zone by name
+func GetIsiSMBShare(
+	ctx context.Context,
+	client api.Client,
+	zone, name string) (share *IsiSMBShare, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/protocols/smb/shares/name?zone=zone
+	if name == "" {
+		return nil, errors.New("no share name set")
+	}
+
+	var resp getIsiSMBSharesResp
+	err = client.Get(
+		ctx, smbSharesPath, name, api.ZoneQS(client, zone), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Shares) == 0 {
+		return nil, errors.New("share missing from response")
+	}
+	return resp.Shares[0], nil
+}
+
+// CreateIsiSMBShare creates an SMB share in an access zone and returns its
+// ID
+func CreateIsiSMBShare(
+	ctx context.Context,
+	client api.Client,
+	zone string, share *IsiSMBShare) (id string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/protocols/smb/shares?zone=zone
+	//            Content-Type: application/json
+	//            {name: "share", path: "/ifs/data/share"}
+	if share == nil || share.Name == nil || share.Path == nil {
+		return "", errors.New("no share name or path set")
+	}
+
+	var resp postIsiSMBShareResp
+	err = client.Post(
+		ctx, smbSharesPath, "", api.ZoneQS(client, zone), nil, share, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiSMBShare modifies an SMB share in an access zone. Only the fields
+// that are set are changed.
+func UpdateIsiSMBShare(
+	ctx context.Context,
+	client api.Client,
+	zone, name string, share *IsiSMBShare) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/protocols/smb/shares/name?zone=zone
+	//            Content-Type: application/json
+	//            {browsable: false}
+	if name == "" {
+		return errors.New("no share name set")
+	}
+	if share == nil {
+		return errors.New("no share settings set")
+	}
+	return client.Put(
+		ctx, smbSharesPath, name, api.ZoneQS(client, zone), nil, share, nil)
+}
+
+// DeleteIsiSMBShare removes an SMB share from an access zone
+func DeleteIsiSMBShare(
+	ctx context.Context,
+	client api.Client,
+	zone, name string) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/protocols/smb/shares/name?zone=zone
+	if name == "" {
+		return errors.New("no share name set")
+	}
+	return client.Delete(
+		ctx, smbSharesPath, name, api.ZoneQS(client, zone), nil, nil)
+}
@@ -0,0 +1,61 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiSNMPSettings are the SNMP service settings.
+type IsiSNMPSettings struct {
+	Service             *bool   `json:"service,omitempty"`
+	ReadOnlyCommunity   *string `json:"read_only_community,omitempty"`
+	SNMPv1v2cAccess     *bool   `json:"snmp_v1_v2c_access,omitempty"`
+	SNMPv3Access        *bool   `json:"snmp_v3_access,omitempty"`
+	SNMPv3AuthProtocol  *string `json:"snmp_v3_auth_protocol,omitempty"`
+	SNMPv3PrivProtocol  *string `json:"snmp_v3_priv_protocol,omitempty"`
+	SNMPv3ReadOnlyUser  *string `json:"snmp_v3_read_only_user,omitempty"`
+	SNMPv3Password      *string `json:"snmp_v3_password,omitempty"`
+	SNMPv3PrivPassword  *string `json:"snmp_v3_priv_password,omitempty"`
+	SNMPv3SecurityLevel *string `json:"snmp_v3_security_level,omitempty"`
+	SystemContact       *string `json:"system_contact,omitempty"`
+	SystemLocation      *string `json:"system_location,omitempty"`
+}
+
+type getIsiSNMPSettingsResp struct {
+	Settings *IsiSNMPSettings `json:"settings"`
+}
+
+// GetIsiSNMPSettings queries the SNMP service settings
+func GetIsiSNMPSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiSNMPSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/protocols/snmp/settings
+	var resp getIsiSNMPSettingsResp
+	err = client.Get(ctx, snmpSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("snmp settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiSNMPSettings modifies the SNMP service settings. Only the fields
+// that are set are changed.
+func UpdateIsiSNMPSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiSNMPSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/protocols/snmp/settings
+	//            Content-Type: application/json
+	//            {service: true, system_location: "DC1 row 4"}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, snmpSettingsPath, "", nil, nil, settings, nil)
+}
@@ -0,0 +1,109 @@
+package v3
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiProtocolSummaryStat is the summary of the operations of a protocol on
+// a node, by operation or class depending on the query. Rates are per
+// second, In and Out are in bytes per second, and times are in
+// microseconds.
+type IsiProtocolSummaryStat struct {
+	Protocol       string  `json:"protocol"`
+	Node           int     `json:"node"`
+	Class          string  `json:"class"`
+	Operation      string  `json:"operation"`
+	OperationCount int64   `json:"operation_count"`
+	OperationRate  float64 `json:"operation_rate"`
+	In             float64 `json:"in"`
+	Out            float64 `json:"out"`
+	TimeAvg        float64 `json:"time_avg"`
+	TimeMin        float64 `json:"time_min"`
+	TimeMax        float64 `json:"time_max"`
+	TimeStddev     float64 `json:"time_stddev"`
+	Time           int64   `json:"time"`
+}
+
+type getIsiProtocolSummaryResp struct {
+	Protocol []*IsiProtocolSummaryStat `json:"protocol"`
+}
+
+// IsiClientSummaryStat is the summary of the operations of a client of a
+// protocol on a node. Rates are per second, In and Out are in bytes per
+// second, and times are in microseconds.
+type IsiClientSummaryStat struct {
+	Client        string  `json:"client"`
+	Protocol      string  `json:"protocol"`
+	Node          int     `json:"node"`
+	RemoteAddr    string  `json:"remote_addr"`
+	LocalAddr     string  `json:"local_addr"`
+	NumOperations int64   `json:"num_operations"`
+	OperationRate float64 `json:"operation_rate"`
+	In            float64 `json:"in"`
+	Out           float64 `json:"out"`
+	TimeAvg       float64 `json:"time_avg"`
+	Time          int64   `json:"time"`
+}
+
+type getIsiClientSummaryResp struct {
+	Client []*IsiClientSummaryStat `json:"client"`
+}
+
+var (
+	byteArrProtocols = []byte("protocols")
+	byteArrNodes     = []byte("nodes")
+)
+
+// statsSummaryQS returns the query string selecting the protocols and nodes
+// of a statistics summary. Empty protocols and nodes select all of them.
+func statsSummaryQS(protocols []string, nodes string) api.OrderedValues {
+	var qs api.OrderedValues
+	if len(protocols) > 0 {
+		qs = append(qs, [][]byte{
+			byteArrProtocols, []byte(strings.Join(protocols, ","))})
+	}
+	if nodes != "" {
+		qs = append(qs, [][]byte{byteArrNodes, []byte(nodes)})
+	}
+	return qs
+}
+
+// GetIsiProtocolSummary queries the summary of the operations of protocols,
+// ex. "nfs3" or "smb2", on nodes, ex. "all" or "1,2". Empty protocols and
+// nodes select all of them.
+func GetIsiProtocolSummary(
+	ctx context.Context,
+	client api.Client,
+	protocols []string, nodes string) (stats []*IsiProtocolSummaryStat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/statistics/summary/protocol?protocols=nfs3,smb2&nodes=all
+	var resp getIsiProtocolSummaryResp
+	err = client.Get(
+		ctx, statsSummaryProtocolPath, "",
+		statsSummaryQS(protocols, nodes), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Protocol, nil
+}
+
+// GetIsiClientSummary queries the summary of the operations of the clients
+// of protocols on nodes. Empty protocols and nodes select all of them.
+func GetIsiClientSummary(
+	ctx context.Context,
+	client api.Client,
+	protocols []string, nodes string) (stats []*IsiClientSummaryStat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/statistics/summary/client?protocols=nfs3,smb2&nodes=all
+	var resp getIsiClientSummaryResp
+	err = client.Get(
+		ctx, statsSummaryClientPath, "",
+		statsSummaryQS(protocols, nodes), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Client, nil
+}
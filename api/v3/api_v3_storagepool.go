@@ -0,0 +1,252 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiStoragePoolUsage is the capacity of a storage pool. OneFS reports these
+// values as strings, so they are decoded from quoted numbers.
+type IsiStoragePoolUsage struct {
+	AvailBytes    int64 `json:"avail_bytes,string"`
+	AvailHDDBytes int64 `json:"avail_hdd_bytes,string"`
+	AvailSSDBytes int64 `json:"avail_ssd_bytes,string"`
+	FreeBytes     int64 `json:"free_bytes,string"`
+	FreeHDDBytes  int64 `json:"free_hdd_bytes,string"`
+	FreeSSDBytes  int64 `json:"free_ssd_bytes,string"`
+	TotalBytes    int64 `json:"total_bytes,string"`
+	TotalHDDBytes int64 `json:"total_hdd_bytes,string"`
+	TotalSSDBytes int64 `json:"total_ssd_bytes,string"`
+	UsedBytes     int64 `json:"used_bytes,string"`
+	UsedHDDBytes  int64 `json:"used_hdd_bytes,string"`
+	UsedSSDBytes  int64 `json:"used_ssd_bytes,string"`
+	Balanced      bool  `json:"balanced"`
+}
+
+// IsiStoragePool is a node pool or tier as reported by the combined storage
+// pool listing.
+type IsiStoragePool struct {
+	ID               int64               `json:"id"`
+	Name             string              `json:"name"`
+	Type             string              `json:"type"`
+	Children         []string            `json:"children"`
+	HealthFlags      []string            `json:"health_flags"`
+	LNNs             []int               `json:"lnns"`
+	ProtectionPolicy string              `json:"protection_policy"`
+	Tier             *string             `json:"tier"`
+	L3               bool                `json:"l3"`
+	Usage            IsiStoragePoolUsage `json:"usage"`
+}
+
+type getIsiStoragePoolsResp struct {
+	StoragePools []*IsiStoragePool `json:"storagepools"`
+}
+
+// IsiNodePool is a pool of nodes with compatible hardware.
+type IsiNodePool struct {
+	ID               int64               `json:"id,omitmarshal"`
+	Name             *string             `json:"name,omitempty"`
+	L3               *bool               `json:"l3,omitempty"`
+	LNNs             *[]int              `json:"lnns,omitempty"`
+	ProtectionPolicy *string             `json:"protection_policy,omitempty"`
+	Tier             *string             `json:"tier,omitempty"`
+	NodeTypeIDs      []int               `json:"node_type_ids,omitmarshal"`
+	HealthFlags      []string            `json:"health_flags,omitmarshal"`
+	Manual           bool                `json:"manual,omitmarshal"`
+	Usage            IsiStoragePoolUsage `json:"usage,omitmarshal"`
+}
+
+type getIsiNodePoolsResp struct {
+	NodePools []*IsiNodePool `json:"nodepools"`
+}
+
+// IsiTier is a group of node pools.
+type IsiTier struct {
+	ID       int64               `json:"id,omitmarshal"`
+	Name     *string             `json:"name,omitempty"`
+	Children *[]string           `json:"children,omitempty"`
+	Usage    IsiStoragePoolUsage `json:"usage,omitmarshal"`
+}
+
+type getIsiTiersResp struct {
+	Tiers []*IsiTier `json:"tiers"`
+}
+
+type postIsiStoragePoolResp struct {
+	ID int64 `json:"id"`
+}
+
+// IsiStoragePoolSettings are the global SmartPools settings.
+type IsiStoragePoolSettings struct {
+	AutomaticallyManageIOOptimization  *string `json:"automatically_manage_io_optimization,omitempty"`
+	AutomaticallyManageProtection      *string `json:"automatically_manage_protection,omitempty"`
+	GlobalNamespaceAccelerationEnabled *bool   `json:"global_namespace_acceleration_enabled,omitempty"`
+	ProtectDirectoriesOneLevelHigher   *bool   `json:"protect_directories_one_level_higher,omitempty"`
+	SpilloverEnabled                   *bool   `json:"spillover_enabled,omitempty"`
+	SSDL3CacheDefaultEnabled           *bool   `json:"ssd_l3_cache_default_enabled,omitempty"`
+	VirtualHotSpareDenyWrites          *bool   `json:"virtual_hot_spare_deny_writes,omitempty"`
+	VirtualHotSpareHideSpare           *bool   `json:"virtual_hot_spare_hide_spare,omitempty"`
+	VirtualHotSpareLimitDrives         *int    `json:"virtual_hot_spare_limit_drives,omitempty"`
+	VirtualHotSpareLimitPercent        *int    `json:"virtual_hot_spare_limit_percent,omitempty"`
+}
+
+type getIsiStoragePoolSettingsResp struct {
+	Settings *IsiStoragePoolSettings `json:"settings"`
+}
+
+// GetIsiStoragePools queries a list of all node pools and tiers
+func GetIsiStoragePools(
+	ctx context.Context,
+	client api.Client) (pools []*IsiStoragePool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/storagepool/storagepools
+	var resp getIsiStoragePoolsResp
+	err = client.Get(ctx, storagePoolsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.StoragePools, nil
+}
+
+// GetIsiNodePools queries a list of all node pools
+func GetIsiNodePools(
+	ctx context.Context,
+	client api.Client) (pools []*IsiNodePool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/storagepool/nodepools
+	var resp getIsiNodePoolsResp
+	err = client.Get(ctx, nodePoolsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.NodePools, nil
+}
+
+// GetIsiNodePool queries an individual node pool by id or name
+func GetIsiNodePool(
+	ctx context.Context,
+	client api.Client,
+	id string) (pool *IsiNodePool, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/storagepool/nodepools/id
+	var resp getIsiNodePoolsResp
+	err = client.Get(ctx, nodePoolsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.NodePools) == 0 {
+		return nil, errors.New("node pool missing from response")
+	}
+	return resp.NodePools[0], nil
+}
+
+// UpdateIsiNodePool modifies a node pool
+func UpdateIsiNodePool(
+	ctx context.Context,
+	client api.Client,
+	pool *IsiNodePool) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/storagepool/nodepools/id
+	//            Content-Type: application/json
+	//            {protection_policy: "+2d:1n", tier: "tier_name"}
+	if pool == nil || pool.ID == 0 {
+		return errors.New("no node pool id set")
+	}
+	return client.Put(
+		ctx, nodePoolsPath, strconv.FormatInt(pool.ID, 10), nil, nil,
+		pool, nil)
+}
+
+// GetIsiTiers queries a list of all tiers
+func GetIsiTiers(
+	ctx context.Context,
+	client api.Client) (tiers []*IsiTier, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/storagepool/tiers
+	var resp getIsiTiersResp
+	err = client.Get(ctx, tiersPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Tiers, nil
+}
+
+// CreateIsiTier creates a tier and returns its id
+func CreateIsiTier(
+	ctx context.Context,
+	client api.Client,
+	tier *IsiTier) (id int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/storagepool/tiers
+	//            Content-Type: application/json
+	//            {name: "tier_name", children: ["nodepool_name"]}
+	if tier == nil || tier.Name == nil || *tier.Name == "" {
+		return 0, errors.New("no tier name set")
+	}
+
+	var resp postIsiStoragePoolResp
+	if err = client.Post(ctx, tiersPath, "", nil, nil, tier, &resp); err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// UpdateIsiTier modifies a tier
+func UpdateIsiTier(
+	ctx context.Context,
+	client api.Client,
+	tier *IsiTier) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/storagepool/tiers/id
+	if tier == nil || tier.ID == 0 {
+		return errors.New("no tier id set")
+	}
+	return client.Put(
+		ctx, tiersPath, strconv.FormatInt(tier.ID, 10), nil, nil, tier, nil)
+}
+
+// DeleteIsiTier removes a tier
+func DeleteIsiTier(
+	ctx context.Context,
+	client api.Client,
+	id int64) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/3/storagepool/tiers/id
+	return client.Delete(
+		ctx, tiersPath, strconv.FormatInt(id, 10), nil, nil, nil)
+}
+
+// GetIsiStoragePoolSettings queries the global SmartPools settings
+func GetIsiStoragePoolSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiStoragePoolSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/storagepool/settings
+	var resp getIsiStoragePoolSettingsResp
+	err = client.Get(ctx, storagePoolSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("storage pool settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiStoragePoolSettings modifies the global SmartPools settings. Only
+// the fields that are set are changed.
+func UpdateIsiStoragePoolSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiStoragePoolSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/storagepool/settings
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(
+		ctx, storagePoolSettingsPath, "", nil, nil, settings, nil)
+}
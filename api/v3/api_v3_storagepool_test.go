@@ -0,0 +1,40 @@
+package v3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api/json"
+)
+
+func TestStoragePoolUsageDecodeJSON(t *testing.T) {
+	j := `{"storagepools":[{"id":1,"name":"x410_pool","type":"nodepool",` +
+		`"lnns":[1,2,3],"protection_policy":"+2d:1n","tier":null,` +
+		`"usage":{"avail_bytes":"98740969472","total_bytes":"9223372036854775807",` +
+		`"used_bytes":"0","balanced":true}}]}`
+
+	var resp getIsiStoragePoolsResp
+	if err := json.Unmarshal([]byte(j), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Len(t, resp.StoragePools, 1) {
+		t.FailNow()
+	}
+	p := resp.StoragePools[0]
+	assert.Equal(t, "x410_pool", p.Name)
+	assert.Nil(t, p.Tier)
+	assert.Equal(t, []int{1, 2, 3}, p.LNNs)
+	assert.Equal(t, int64(98740969472), p.Usage.AvailBytes)
+	assert.Equal(t, int64(9223372036854775807), p.Usage.TotalBytes)
+	assert.True(t, p.Usage.Balanced)
+}
+
+func TestNodePoolEncodeJSON(t *testing.T) {
+	policy := "+2d:1n"
+	buf, err := json.Marshal(&IsiNodePool{ID: 3, ProtectionPolicy: &policy})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"protection_policy":"+2d:1n"}`, string(buf))
+}
@@ -0,0 +1,273 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiSyncSettings are the cluster's SyncIQ settings. ReportMaxAge is in
+// seconds and ReportMaxCount is the most reports kept for each policy.
+type IsiSyncSettings struct {
+	Service        *string `json:"service,omitempty"`
+	ReportMaxAge   *int64  `json:"report_max_age,omitempty"`
+	ReportMaxCount *int    `json:"report_max_count,omitempty"`
+}
+
+type getIsiSyncSettingsResp struct {
+	Settings *IsiSyncSettings `json:"settings"`
+}
+
+// IsiSyncReport is the report of a run of a SyncIQ policy. Times are in
+// seconds since the epoch.
+type IsiSyncReport struct {
+	ID               string   `json:"id"`
+	JobID            int64    `json:"job_id"`
+	PolicyID         string   `json:"policy_id"`
+	PolicyName       string   `json:"policy_name"`
+	Action           string   `json:"action"`
+	State            string   `json:"state"`
+	StartTime        int64    `json:"start_time"`
+	EndTime          int64    `json:"end_time"`
+	Duration         int64    `json:"duration"`
+	TotalFiles       int64    `json:"total_files"`
+	BytesTransferred int64    `json:"bytes_transferred"`
+	Errors           []string `json:"errors"`
+	Warnings         []string `json:"warnings"`
+}
+
+type getIsiSyncReportsResp struct {
+	Reports []*IsiSyncReport `json:"reports"`
+	Resume  string           `json:"resume,omitempty"`
+}
+
+// SyncIQ policy actions.
+const (
+	SyncPolicyActionCopy = "copy"
+	SyncPolicyActionSync = "sync"
+)
+
+// IsiSyncPolicy is a SyncIQ replication policy. Times are in seconds since
+// the epoch and are nil if not set.
+type IsiSyncPolicy struct {
+	ID                       string `json:"id"`
+	Name                     string `json:"name"`
+	Enabled                  bool   `json:"enabled"`
+	Action                   string `json:"action"`
+	SourceRootPath           string `json:"source_root_path"`
+	TargetHost               string `json:"target_host"`
+	TargetPath               string `json:"target_path"`
+	Schedule                 string `json:"schedule"`
+	LastJobState             string `json:"last_job_state"`
+	LastStarted              *int64 `json:"last_started"`
+	LastSuccess              *int64 `json:"last_success"`
+	NextRun                  *int64 `json:"next_run"`
+	TargetCompareInitialSync bool   `json:"target_compare_initial_sync"`
+}
+
+type getIsiSyncPoliciesResp struct {
+	Policies []*IsiSyncPolicy `json:"policies"`
+	Resume   string           `json:"resume,omitempty"`
+}
+
+// IsiSyncJobReq is used to start a job of a SyncIQ policy, by the policy's
+// id or name.
+type IsiSyncJobReq struct {
+	ID     string  `json:"id"`
+	Action *string `json:"action,omitempty"`
+}
+
+// IsiSyncJob is a running job of a SyncIQ policy. ID is the policy's id and
+// JobID is the number of the run. Times are in seconds since the epoch.
+type IsiSyncJob struct {
+	ID               string `json:"id"`
+	JobID            int64  `json:"job_id"`
+	PolicyName       string `json:"policy_name"`
+	Action           string `json:"action"`
+	State            string `json:"state"`
+	StartTime        int64  `json:"start_time"`
+	TotalFiles       int64  `json:"total_files"`
+	FilesTransferred int64  `json:"files_transferred"`
+	BytesTransferred int64  `json:"bytes_transferred"`
+}
+
+type getIsiSyncJobsResp struct {
+	Jobs []*IsiSyncJob `json:"jobs"`
+}
+
+var byteArrPolicyName = []byte("policy_name")
+
+// GetIsiSyncSettings queries the cluster's SyncIQ settings
+func GetIsiSyncSettings(
+	ctx context.Context,
+	client api.Client) (settings *IsiSyncSettings, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/sync/settings
+	var resp getIsiSyncSettingsResp
+	err = client.Get(ctx, syncSettingsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Settings == nil {
+		return nil, errors.New("sync settings missing from response")
+	}
+	return resp.Settings, nil
+}
+
+// UpdateIsiSyncSettings modifies the cluster's SyncIQ settings
+func UpdateIsiSyncSettings(
+	ctx context.Context,
+	client api.Client,
+	settings *IsiSyncSettings) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/sync/settings
+	//            Content-Type: application/json
+	//            {report_max_age: 2592000, report_max_count: 100}
+	if settings == nil {
+		return errors.New("no settings set")
+	}
+	return client.Put(ctx, syncSettingsPath, "", nil, nil, settings, nil)
+}
+
+// GetIsiSyncReportsPage queries a page of the SyncIQ reports, of a policy by
+// name or of all policies if policyName is empty. An empty resume token
+// queries the first page, and the returned resume token is empty after the
+// last page.
+func GetIsiSyncReportsPage(
+	ctx context.Context,
+	client api.Client,
+	policyName, resume string) ([]*IsiSyncReport, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/sync/reports?policy_name=policy
+	var qs api.OrderedValues
+	switch {
+	case resume != "":
+		// the resume token carries the query's filters
+		qs = api.OrderedValues{{byteArrResume, []byte(resume)}}
+	case policyName != "":
+		qs = api.OrderedValues{{byteArrPolicyName, []byte(policyName)}}
+	}
+
+	var resp getIsiSyncReportsResp
+	err := client.Get(ctx, syncReportsPath, "", qs, nil, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Reports, resp.Resume, nil
+}
+
+// RotateIsiSyncReports deletes the SyncIQ reports that are beyond the
+// report retention settings
+func RotateIsiSyncReports(
+	ctx context.Context,
+	client api.Client) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/sync/reports-rotate
+	return client.Post(
+		ctx, syncReportsRotatePath, "", nil, nil, struct{}{}, nil)
+}
+
+// GetIsiSyncPolicies queries a list of all SyncIQ policies
+func GetIsiSyncPolicies(
+	ctx context.Context,
+	client api.Client) (policies []*IsiSyncPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/sync/policies
+	var resume string
+	for {
+		var qs api.OrderedValues
+		if resume != "" {
+			qs = api.OrderedValues{{byteArrResume, []byte(resume)}}
+		}
+		var resp getIsiSyncPoliciesResp
+		err = client.Get(ctx, syncPoliciesPath, "", qs, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, resp.Policies...)
+		if resp.Resume == "" {
+			return policies, nil
+		}
+		resume = resp.Resume
+	}
+}
+
+// GetIsiSyncPolicy queries a SyncIQ policy by id or name
+func GetIsiSyncPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (policy *IsiSyncPolicy, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/sync/policies/policy
+	if id == "" {
+		return nil, errors.New("no sync policy id set")
+	}
+
+	var resp getIsiSyncPoliciesResp
+	err = client.Get(
+		ctx, syncPoliciesPath, url.PathEscape(id), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Policies) == 0 {
+		return nil, errors.New("sync policy missing from response")
+	}
+	return resp.Policies[0], nil
+}
+
+// ResetIsiSyncPolicy clears the replication state of a SyncIQ policy by id
+// or name, so that its next run is a full or initial diff sync
+func ResetIsiSyncPolicy(
+	ctx context.Context,
+	client api.Client,
+	id string) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/sync/policies/policy/reset
+	if id == "" {
+		return errors.New("no sync policy id set")
+	}
+	return client.Post(
+		ctx, path.Join(syncPoliciesPath, url.PathEscape(id)),
+		resetPathSegment, nil, nil, struct{}{}, nil)
+}
+
+// StartIsiSyncJob starts a job of a SyncIQ policy
+func StartIsiSyncJob(
+	ctx context.Context,
+	client api.Client,
+	req *IsiSyncJobReq) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/3/sync/jobs
+	//            Content-Type: application/json
+	//            {id: "policy"}
+	if req == nil || req.ID == "" {
+		return errors.New("no sync policy id set")
+	}
+	return client.Post(ctx, syncJobsPath, "", nil, nil, req, nil)
+}
+
+// GetIsiSyncJob queries the running job of a SyncIQ policy by the policy's
+// id or name
+func GetIsiSyncJob(
+	ctx context.Context,
+	client api.Client,
+	id string) (job *IsiSyncJob, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/sync/jobs/policy
+	if id == "" {
+		return nil, errors.New("no sync policy id set")
+	}
+
+	var resp getIsiSyncJobsResp
+	err = client.Get(ctx, syncJobsPath, url.PathEscape(id), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Jobs) == 0 {
+		return nil, errors.New("sync job missing from response")
+	}
+	return resp.Jobs[0], nil
+}
@@ -0,0 +1,187 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// SmartLock domain types.
+const (
+	WormDomainTypeEnterprise = "enterprise"
+	WormDomainTypeCompliance = "compliance"
+)
+
+// IsiWormDomain is a SmartLock domain, a directory whose files may be
+// committed to a WORM (write once, read many) state. Retention periods are
+// in seconds and are nil if not set.
+type IsiWormDomain struct {
+	ID               int64  `json:"id"`
+	Path             string `json:"path"`
+	Type             string `json:"type"`
+	AutocommitOffset *int64 `json:"autocommit_offset"`
+	MinRetention     *int64 `json:"min_retention"`
+	MaxRetention     *int64 `json:"max_retention"`
+	OverrideDate     *int64 `json:"override_date"`
+	PrivilegedDelete string `json:"privileged_delete"`
+	Incomplete       bool   `json:"incomplete"`
+}
+
+type getIsiWormDomainsResp struct {
+	Domains []*IsiWormDomain `json:"domains"`
+	Resume  string           `json:"resume,omitempty"`
+}
+
+// IsiWormAttributes are the WORM attributes of a file in a SmartLock domain.
+// Dates are in seconds since the epoch and are nil if not set.
+type IsiWormAttributes struct {
+	DomainID               int64  `json:"domain_id"`
+	DomainPath             string `json:"domain_path"`
+	Committed              bool   `json:"worm_committed"`
+	CTime                  *int64 `json:"worm_ctime"`
+	RetentionDate          *int64 `json:"worm_retention_date_val"`
+	OverrideRetentionDate  *int64 `json:"worm_override_retention_date_val"`
+	AutocommitDelay        *int64 `json:"autocommit_delay"`
+	EffectiveRetentionDate string `json:"worm_retention_date"`
+	EffectiveOverrideDate  string `json:"worm_override_retention_date"`
+}
+
+// wormFilesPageLimit is the number of files queried in a page of the files
+// in a SmartLock domain.
+const wormFilesPageLimit = 1000
+
+var (
+	byteArrWorm     = []byte("worm")
+	byteArrQuery    = []byte("query")
+	byteArrLimit    = []byte("limit")
+	byteArrMaxDepth = []byte("max-depth")
+	byteArrType     = []byte("type")
+	byteArrObject   = []byte("object")
+	wormFilesDetail = [][]byte{
+		[]byte("detail"),
+		[]byte("name"),
+		[]byte("container_path"),
+		[]byte("size"),
+	}
+)
+
+// GetIsiWormDomains queries a list of all SmartLock domains
+func GetIsiWormDomains(
+	ctx context.Context,
+	client api.Client) (domains []*IsiWormDomain, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/worm/domains
+	var resume string
+	for {
+		var qs api.OrderedValues
+		if resume != "" {
+			qs = api.OrderedValues{{byteArrResume, []byte(resume)}}
+		}
+		var resp getIsiWormDomainsResp
+		err = client.Get(ctx, wormDomainsPath, "", qs, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, resp.Domains...)
+		if resp.Resume == "" {
+			return domains, nil
+		}
+		resume = resp.Resume
+	}
+}
+
+// GetIsiWormDomain queries a SmartLock domain by id or path
+func GetIsiWormDomain(
+	ctx context.Context,
+	client api.Client,
+	id string) (domain *IsiWormDomain, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/worm/domains/id
+	if id == "" {
+		return nil, errors.New("no worm domain id set")
+	}
+
+	var resp getIsiWormDomainsResp
+	// a domain's path is escaped so that it is a single path segment
+	err = client.Get(
+		ctx, wormDomainsPath, url.PathEscape(id), nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Domains) == 0 {
+		return nil, errors.New("worm domain missing from response")
+	}
+	return resp.Domains[0], nil
+}
+
+// GetIsiWormAttributes queries the WORM attributes of a file by its absolute
+// path
+func GetIsiWormAttributes(
+	ctx context.Context,
+	client api.Client,
+	filePath string) (attrs *IsiWormAttributes, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/ifs/path/file?worm
+	if filePath == "" {
+		return nil, errors.New("no file path set")
+	}
+
+	err = client.Get(
+		ctx,
+		namespacePath,
+		strings.TrimPrefix(filePath, "/"),
+		api.OrderedValues{{byteArrWorm}},
+		nil,
+		&attrs)
+	if err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// GetIsiWormFilesPage queries a page of the files under a directory, at any
+// depth, by the directory's absolute path. An empty resume token queries the
+// first page, and the returned resume token is empty after the last page.
+func GetIsiWormFilesPage(
+	ctx context.Context,
+	client api.Client,
+	dirPath, resume string) ([]*apiv2.ContainerChild, string, error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/namespace/ifs/path?query&limit=1000&max-depth=-1&type=object&detail=name,container_path,size
+	if dirPath == "" {
+		return nil, "", errors.New("no directory path set")
+	}
+
+	qs := api.OrderedValues{
+		{byteArrQuery},
+		{byteArrLimit, []byte(strconv.Itoa(wormFilesPageLimit))},
+		{byteArrMaxDepth, []byte("-1")},
+		{byteArrType, byteArrObject},
+		wormFilesDetail,
+	}
+	if resume != "" {
+		qs = append(qs, [][]byte{byteArrResume, []byte(resume)})
+	}
+
+	var resp struct {
+		Children []*apiv2.ContainerChild `json:"children"`
+		Resume   string                  `json:"resume,omitempty"`
+	}
+	err := client.Get(
+		ctx,
+		path.Join(namespacePath, dirPath),
+		"",
+		qs,
+		nil,
+		&resp)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Children, resp.Resume, nil
+}
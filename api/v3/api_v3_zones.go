@@ -0,0 +1,78 @@
+package v3
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiZone is an access zone.
+type IsiZone struct {
+	ID                      int       `json:"id,omitmarshal"`
+	ZoneID                  int       `json:"zone_id,omitmarshal"`
+	Name                    *string   `json:"name,omitempty"`
+	Path                    *string   `json:"path,omitempty"`
+	Groupnet                *string   `json:"groupnet,omitempty"`
+	AuthProviders           *[]string `json:"auth_providers,omitempty"`
+	AlternateSystemProvider *string   `json:"alternate_system_provider,omitempty"`
+	System                  bool      `json:"system,omitmarshal"`
+}
+
+type getIsiZonesResp struct {
+	Zones []*IsiZone `json:"zones"`
+}
+
+// GetIsiZones queries a list of all access zones
+func GetIsiZones(
+	ctx context.Context,
+	client api.Client) (zones []*IsiZone, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/zones
+	var resp getIsiZonesResp
+	err = client.Get(ctx, zonesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Zones, nil
+}
+
+// GetIsiZone queries an individual access zone by name
+func GetIsiZone(
+	ctx context.Context,
+	client api.Client,
+	name string) (zone *IsiZone, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/3/zones/name
+	if name == "" {
+		return nil, errors.New("no zone name set")
+	}
+
+	var resp getIsiZonesResp
+	err = client.Get(ctx, zonesPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Zones) == 0 {
+		return nil, errors.New("zone missing from response")
+	}
+	return resp.Zones[0], nil
+}
+
+// UpdateIsiZone modifies an access zone
+func UpdateIsiZone(
+	ctx context.Context,
+	client api.Client,
+	name string, zone *IsiZone) (err error) {
+
+	// PAPI call: PUT https://1.2.3.4:8080/platform/3/zones/name
+	//            Content-Type: application/json
+	//            {auth_providers: ["lsa-local-provider:System"]}
+	if name == "" {
+		return errors.New("no zone name set")
+	}
+	if zone == nil {
+		return errors.New("no zone settings set")
+	}
+	return client.Put(ctx, zonesPath, name, nil, nil, zone, nil)
+}
@@ -0,0 +1,10 @@
+package v5
+
+const (
+	licensesPath        = "platform/5/license/licenses"
+	licenseGeneratePath = "platform/5/license/generate"
+	hardeningApplyPath  = "platform/5/hardening/apply"
+	hardeningRevertPath = "platform/5/hardening/revert"
+	hardeningStatePath  = "platform/5/hardening/state"
+	hardeningStatusPath = "platform/5/hardening/status"
+)
@@ -0,0 +1,111 @@
+package v5
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiHardeningState is the state of the hardening profile on the cluster,
+// ex. "applied", "applying", "not applied" or "reverting".
+type IsiHardeningState struct {
+	Profile string `json:"profile"`
+	State   string `json:"state"`
+}
+
+// IsiHardeningStatus is the per-node hardening compliance of the cluster,
+// as a human readable report.
+type IsiHardeningStatus struct {
+	Message string `json:"message"`
+}
+
+type isiHardeningProfileReq struct {
+	Profile string `json:"profile"`
+}
+
+type isiHardeningMessageResp struct {
+	Message string `json:"message"`
+}
+
+type getIsiHardeningStateResp struct {
+	State *IsiHardeningState `json:"state"`
+}
+
+type getIsiHardeningStatusResp struct {
+	Status *IsiHardeningStatus `json:"status"`
+}
+
+// ApplyIsiHardening applies a hardening profile to the cluster and returns
+// the cluster's report of the changes
+func ApplyIsiHardening(
+	ctx context.Context,
+	client api.Client,
+	profile string) (message string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/5/hardening/apply
+	//            Content-Type: application/json
+	//            {profile: "STIG"}
+	if profile == "" {
+		return "", errors.New("no profile set")
+	}
+
+	var resp isiHardeningMessageResp
+	err = client.Post(
+		ctx, hardeningApplyPath, "", nil, nil,
+		&isiHardeningProfileReq{Profile: profile}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.Message, nil
+}
+
+// RevertIsiHardening reverts the hardening profile applied to the cluster
+// and returns the cluster's report of the changes
+func RevertIsiHardening(
+	ctx context.Context,
+	client api.Client) (message string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/5/hardening/revert
+	var resp isiHardeningMessageResp
+	err = client.Post(
+		ctx, hardeningRevertPath, "", nil, nil, map[string]string{}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.Message, nil
+}
+
+// GetIsiHardeningState queries the state of the hardening profile
+func GetIsiHardeningState(
+	ctx context.Context,
+	client api.Client) (state *IsiHardeningState, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/5/hardening/state
+	var resp getIsiHardeningStateResp
+	err = client.Get(ctx, hardeningStatePath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.State == nil {
+		return nil, errors.New("hardening state missing from response")
+	}
+	return resp.State, nil
+}
+
+// GetIsiHardeningStatus queries the hardening compliance of the nodes
+func GetIsiHardeningStatus(
+	ctx context.Context,
+	client api.Client) (status *IsiHardeningStatus, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/5/hardening/status
+	var resp getIsiHardeningStatusResp
+	err = client.Get(ctx, hardeningStatusPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == nil {
+		return nil, errors.New("hardening status missing from response")
+	}
+	return resp.Status, nil
+}
@@ -0,0 +1,132 @@
+package v5
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiLicense is a OneFS feature license.
+type IsiLicense struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Expiration string `json:"expiration"`
+	Days       int    `json:"days"`
+	Tier       string `json:"tier"`
+}
+
+type getIsiLicensesResp struct {
+	Licenses []*IsiLicense `json:"licenses"`
+}
+
+type isiLicenseGenerateReq struct {
+	Action            string   `json:"action"`
+	LicensesToInclude []string `json:"licenses_to_include,omitempty"`
+	LicensesToExclude []string `json:"licenses_to_exclude,omitempty"`
+	OnlyTheseLicenses []string `json:"only_these_licenses,omitempty"`
+}
+
+type postIsiLicenseGenerateResp struct {
+	ActivationFile string `json:"activation_file"`
+}
+
+type isiLicenseAddReq struct {
+	SourceFile  string   `json:"source_file,omitempty"`
+	Evaluations []string `json:"evaluations,omitempty"`
+}
+
+// GetIsiLicenses queries a list of all licenses and their status
+func GetIsiLicenses(
+	ctx context.Context,
+	client api.Client) (licenses []*IsiLicense, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/5/license/licenses
+	var resp getIsiLicensesResp
+	err = client.Get(ctx, licensesPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Licenses, nil
+}
+
+// GetIsiLicense queries an individual license by name
+func GetIsiLicense(
+	ctx context.Context,
+	client api.Client,
+	name string) (license *IsiLicense, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/5/license/licenses/name
+	var resp getIsiLicensesResp
+	err = client.Get(ctx, licensesPath, name, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Licenses) == 0 {
+		return nil, errors.New("license missing from response")
+	}
+	return resp.Licenses[0], nil
+}
+
+// GenerateIsiLicenseActivationFile generates the activation file to submit
+// to the licensing portal. If include is empty, the activation file covers
+// the licenses that are already activated or under evaluation.
+func GenerateIsiLicenseActivationFile(
+	ctx context.Context,
+	client api.Client,
+	include, exclude []string) (activationFile string, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/5/license/generate
+	//            Content-Type: application/json
+	//            {action: "generate", licenses_to_include: ["SmartQuotas"]}
+	var resp postIsiLicenseGenerateResp
+	err = client.Post(
+		ctx, licenseGeneratePath, "", nil, nil,
+		&isiLicenseGenerateReq{
+			Action:            "generate",
+			LicensesToInclude: include,
+			LicensesToExclude: exclude,
+		},
+		&resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.ActivationFile, nil
+}
+
+// AddIsiLicenseFile applies a signed license file returned by the licensing
+// portal
+func AddIsiLicenseFile(
+	ctx context.Context,
+	client api.Client,
+	licenseFile string) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/5/license/licenses
+	//            Content-Type: application/json
+	//            {source_file: "<signed license file contents>"}
+	if licenseFile == "" {
+		return errors.New("no license file set")
+	}
+	return client.Post(
+		ctx, licensesPath, "", nil, nil,
+		&isiLicenseAddReq{SourceFile: licenseFile}, nil)
+}
+
+// StartIsiLicenseEvaluations starts evaluation licenses for the named
+// features
+func StartIsiLicenseEvaluations(
+	ctx context.Context,
+	client api.Client,
+	names []string) (err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/5/license/licenses
+	//            Content-Type: application/json
+	//            {evaluations: ["SmartQuotas", "SnapshotIQ"]}
+	if len(names) == 0 {
+		return errors.New("no licenses set")
+	}
+	return client.Post(
+		ctx, licensesPath, "", nil, nil,
+		&isiLicenseAddReq{Evaluations: names}, nil)
+}
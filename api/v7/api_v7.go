@@ -0,0 +1,9 @@
+package v7
+
+const (
+	performanceDatasetsPath = "platform/7/performance/datasets"
+	workloadsPathSegment    = "workloads"
+	statisticsWorkloadPath  = "platform/7/statistics/summary/workload"
+	auditProgressPath       = "platform/7/audit/progress"
+	auditProgressGlobalPath = "platform/7/audit/progress/global"
+)
@@ -0,0 +1,66 @@
+package v7
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiAuditProgress is how far audit events have been logged and forwarded.
+// Times are in seconds since the epoch of the last event logged, and of the
+// last event forwarded to the CEE servers and to syslog, and are zero if
+// none has been.
+type IsiAuditProgress struct {
+	ProtocolAuditLogTime    int64 `json:"protocol_audit_log_time"`
+	ProtocolAuditCEETime    int64 `json:"protocol_audit_cee_time"`
+	ProtocolAuditSyslogTime int64 `json:"protocol_audit_syslog_time"`
+}
+
+type getIsiAuditProgressResp struct {
+	Progress *IsiAuditProgress `json:"progress"`
+}
+
+var byteArrLNN = []byte("lnn")
+
+// GetIsiAuditProgress queries how far audit events have been logged and
+// forwarded on a node by its logical node number
+func GetIsiAuditProgress(
+	ctx context.Context,
+	client api.Client,
+	lnn int) (progress *IsiAuditProgress, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/audit/progress?lnn=1
+	var resp getIsiAuditProgressResp
+	err = client.Get(
+		ctx, auditProgressPath, "",
+		api.OrderedValues{{byteArrLNN, []byte(strconv.Itoa(lnn))}},
+		nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Progress == nil {
+		return nil, errors.New("audit progress missing from response")
+	}
+	return resp.Progress, nil
+}
+
+// GetIsiAuditProgressGlobal queries how far audit events have been logged
+// and forwarded across the cluster, which is as far as the furthest behind
+// node
+func GetIsiAuditProgressGlobal(
+	ctx context.Context,
+	client api.Client) (progress *IsiAuditProgress, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/audit/progress/global
+	var resp getIsiAuditProgressResp
+	err = client.Get(ctx, auditProgressGlobalPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Progress == nil {
+		return nil, errors.New("audit progress missing from response")
+	}
+	return resp.Progress, nil
+}
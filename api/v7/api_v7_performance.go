@@ -0,0 +1,257 @@
+package v7
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// IsiPerformanceDatasetFilter restricts a dataset to workloads whose metric
+// matches one of the given values.
+type IsiPerformanceDatasetFilter struct {
+	Metric string   `json:"metric"`
+	Values []string `json:"values"`
+}
+
+// IsiPerformanceDataset groups workload statistics by a set of metrics, such
+// as username, protocol, export_id, or path.
+type IsiPerformanceDataset struct {
+	ID           int64                          `json:"id,omitmarshal"`
+	Name         *string                        `json:"name,omitempty"`
+	Metrics      []string                       `json:"metrics,omitempty"`
+	Filters      *[]IsiPerformanceDatasetFilter `json:"filters,omitempty"`
+	Statkey      string                         `json:"statkey,omitmarshal"`
+	CreationTime int64                          `json:"creation_time,omitmarshal"`
+}
+
+type getIsiPerformanceDatasetsResp struct {
+	Datasets []*IsiPerformanceDataset `json:"datasets"`
+}
+
+// IsiPerformanceWorkload is a workload pinned to a dataset so its statistics
+// are always reported, regardless of whether it is among the top consumers.
+// The metric fields that are set must match the metrics of the dataset.
+type IsiPerformanceWorkload struct {
+	ID            int64   `json:"id,omitmarshal"`
+	Name          *string `json:"name,omitempty"`
+	ExportID      *int64  `json:"export_id,omitempty"`
+	GroupID       *int64  `json:"groupid,omitempty"`
+	GroupSID      *string `json:"groupsid,omitempty"`
+	JobType       *string `json:"job_type,omitempty"`
+	LocalAddress  *string `json:"local_address,omitempty"`
+	Path          *string `json:"path,omitempty"`
+	Protocol      *string `json:"protocol,omitempty"`
+	RemoteAddress *string `json:"remote_address,omitempty"`
+	ShareName     *string `json:"share_name,omitempty"`
+	UserID        *int64  `json:"userid,omitempty"`
+	UserSID       *string `json:"usersid,omitempty"`
+	ZoneID        *int64  `json:"zone_id,omitempty"`
+	CreationTime  int64   `json:"creation_time,omitmarshal"`
+	Error         string  `json:"error,omitmarshal"`
+}
+
+type getIsiPerformanceWorkloadsResp struct {
+	Workloads []*IsiPerformanceWorkload `json:"workloads"`
+}
+
+type postIsiPerformanceResp struct {
+	ID int64 `json:"id"`
+}
+
+// IsiWorkloadStat is a summary of the resources consumed by a workload over
+// the sampling interval.
+type IsiWorkloadStat struct {
+	WorkloadID    int64   `json:"workload_id"`
+	WorkloadType  string  `json:"workload_type"`
+	Node          int     `json:"node"`
+	Time          int64   `json:"time"`
+	CPU           float64 `json:"cpu"`
+	BytesIn       float64 `json:"bytes_in"`
+	BytesOut      float64 `json:"bytes_out"`
+	Ops           float64 `json:"ops"`
+	Reads         float64 `json:"reads"`
+	Writes        float64 `json:"writes"`
+	L2            float64 `json:"l2"`
+	L3            float64 `json:"l3"`
+	LatencyRead   float64 `json:"latency_read"`
+	LatencyWrite  float64 `json:"latency_write"`
+	LatencyOther  float64 `json:"latency_other"`
+	Path          string  `json:"path"`
+	Protocol      string  `json:"protocol"`
+	ShareName     string  `json:"share_name"`
+	ExportID      int64   `json:"export_id"`
+	UserName      string  `json:"username"`
+	GroupName     string  `json:"groupname"`
+	LocalAddress  string  `json:"local_address"`
+	RemoteAddress string  `json:"remote_address"`
+	ZoneName      string  `json:"zone_name"`
+	JobType       string  `json:"job_type"`
+	SystemName    string  `json:"system_name"`
+}
+
+type getIsiWorkloadStatsResp struct {
+	Workload []*IsiWorkloadStat `json:"workload"`
+}
+
+var datasetByteArr = []byte("dataset")
+
+func datasetID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// GetIsiPerformanceDatasets queries a list of all performance datasets
+func GetIsiPerformanceDatasets(
+	ctx context.Context,
+	client api.Client) (datasets []*IsiPerformanceDataset, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/performance/datasets
+	var resp getIsiPerformanceDatasetsResp
+	err = client.Get(ctx, performanceDatasetsPath, "", nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Datasets, nil
+}
+
+// GetIsiPerformanceDataset queries an individual performance dataset by id
+// or name
+func GetIsiPerformanceDataset(
+	ctx context.Context,
+	client api.Client,
+	id string) (dataset *IsiPerformanceDataset, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/performance/datasets/id
+	var resp getIsiPerformanceDatasetsResp
+	err = client.Get(ctx, performanceDatasetsPath, id, nil, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Datasets) == 0 {
+		return nil, errors.New("performance dataset missing from response")
+	}
+	return resp.Datasets[0], nil
+}
+
+// CreateIsiPerformanceDataset creates a performance dataset and returns its id
+func CreateIsiPerformanceDataset(
+	ctx context.Context,
+	client api.Client,
+	dataset *IsiPerformanceDataset) (id int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/7/performance/datasets
+	//            Content-Type: application/json
+	//            {name: "dataset_name", metrics: ["username", "protocol"]}
+	if dataset == nil || len(dataset.Metrics) == 0 {
+		return 0, errors.New("no dataset metrics set")
+	}
+
+	var resp postIsiPerformanceResp
+	err = client.Post(
+		ctx, performanceDatasetsPath, "", nil, nil, dataset, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// DeleteIsiPerformanceDataset removes a performance dataset
+func DeleteIsiPerformanceDataset(
+	ctx context.Context,
+	client api.Client,
+	id int64) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/7/performance/datasets/id
+	return client.Delete(
+		ctx, performanceDatasetsPath, datasetID(id), nil, nil, nil)
+}
+
+// GetIsiPerformanceWorkloads queries the workloads pinned to a dataset
+func GetIsiPerformanceWorkloads(
+	ctx context.Context,
+	client api.Client,
+	dataset int64) (workloads []*IsiPerformanceWorkload, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/performance/datasets/dataset/workloads
+	var resp getIsiPerformanceWorkloadsResp
+	err = client.Get(
+		ctx,
+		path.Join(performanceDatasetsPath, datasetID(dataset), workloadsPathSegment),
+		"",
+		nil,
+		nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Workloads, nil
+}
+
+// PinIsiPerformanceWorkload pins a workload to a dataset and returns its id
+func PinIsiPerformanceWorkload(
+	ctx context.Context,
+	client api.Client,
+	dataset int64,
+	workload *IsiPerformanceWorkload) (id int64, err error) {
+
+	// PAPI call: POST https://1.2.3.4:8080/platform/7/performance/datasets/dataset/workloads
+	//            Content-Type: application/json
+	//            {name: "workload_name", username: "user1", protocol: "nfs3"}
+	if workload == nil {
+		return 0, errors.New("no workload set")
+	}
+
+	var resp postIsiPerformanceResp
+	err = client.Post(
+		ctx,
+		path.Join(performanceDatasetsPath, datasetID(dataset), workloadsPathSegment),
+		"",
+		nil,
+		nil,
+		workload,
+		&resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// UnpinIsiPerformanceWorkload removes a pinned workload from a dataset
+func UnpinIsiPerformanceWorkload(
+	ctx context.Context,
+	client api.Client,
+	dataset, id int64) (err error) {
+
+	// PAPI call: DELETE https://1.2.3.4:8080/platform/7/performance/datasets/dataset/workloads/id
+	return client.Delete(
+		ctx,
+		path.Join(performanceDatasetsPath, datasetID(dataset), workloadsPathSegment),
+		datasetID(id),
+		nil,
+		nil,
+		nil)
+}
+
+// GetIsiWorkloadStats queries the current per-workload statistics for a
+// dataset
+func GetIsiWorkloadStats(
+	ctx context.Context,
+	client api.Client,
+	dataset int64) (stats []*IsiWorkloadStat, err error) {
+
+	// PAPI call: GET https://1.2.3.4:8080/platform/7/statistics/summary/workload?dataset=id
+	var resp getIsiWorkloadStatsResp
+	err = client.Get(
+		ctx,
+		statisticsWorkloadPath,
+		"",
+		api.OrderedValues{{datasetByteArr, []byte(datasetID(dataset))}},
+		nil,
+		&resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Workload, nil
+}
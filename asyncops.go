@@ -0,0 +1,245 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tenortim/goisilon/api"
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// The states of an asynchronous namespace operation.
+const (
+	AsyncOpStateRunning   = "running"
+	AsyncOpStateSucceeded = "succeeded"
+	AsyncOpStateFailed    = "failed"
+	AsyncOpStateCancelled = "cancelled"
+)
+
+// AsyncOpStatus is the status of an asynchronous namespace operation.
+type AsyncOpStatus struct {
+	State string
+
+	// JobID is the ID of the job engine job that runs the operation, or
+	// zero if the operation is run by the client.
+	JobID int64
+
+	// Detail describes the progress of the operation, ex. the job's phase.
+	Detail string
+
+	// Err is why the operation failed or was cancelled.
+	Err error
+}
+
+// Done returns whether the operation has finished.
+func (s *AsyncOpStatus) Done() bool {
+	return s.State != AsyncOpStateRunning
+}
+
+// AsyncOp is a handle to a namespace operation that runs in the background,
+// so that it is not limited by the time limit of a request. It is returned
+// by the Async variants of the operations, ex. DeleteVolumeAsync.
+type AsyncOp struct {
+
+	// Op describes the operation, ex. "delete volume x".
+	Op string
+
+	status func(ctx context.Context) (*AsyncOpStatus, error)
+	cancel func(ctx context.Context) error
+
+	// done is closed when an operation run by the client finishes, and is
+	// nil for operations run by the cluster.
+	done <-chan struct{}
+}
+
+// Status returns the status of the operation.
+func (o *AsyncOp) Status(ctx context.Context) (*AsyncOpStatus, error) {
+	return o.status(ctx)
+}
+
+// Wait waits until the operation finishes and returns its status. The
+// operation's error is returned if it failed, or an error wrapping
+// context.Canceled if it was cancelled. A *TimeoutError is returned if the
+// context is done first, which does not cancel the operation.
+func (o *AsyncOp) Wait(ctx context.Context) (*AsyncOpStatus, error) {
+	var (
+		status *AsyncOpStatus
+		err    error
+	)
+	if o.done != nil {
+		status, err = o.waitDone(ctx)
+	} else {
+		status, err = waitFor(ctx, o.Op,
+			func(ctx context.Context) (*AsyncOpStatus, bool, error) {
+				s, err := o.status(ctx)
+				if err != nil {
+					return nil, false, err
+				}
+				progressFrom(ctx).OnProgress(
+					o.Op, ProgressUpdate{Detail: s.Detail})
+				return s, s.Done(), nil
+			})
+	}
+	if err != nil {
+		return status, err
+	}
+	if status.Err != nil {
+		return status, fmt.Errorf("%s: %w", o.Op, status.Err)
+	}
+	return status, nil
+}
+
+// waitDone waits for an operation run by the client to finish.
+func (o *AsyncOp) waitDone(
+	ctx context.Context) (status *AsyncOpStatus, err error) {
+
+	progress := progressFrom(ctx)
+	progress.OnStart(o.Op)
+	defer func() { progress.OnComplete(o.Op, err) }()
+
+	select {
+	case <-o.done:
+		return o.status(ctx)
+	case <-ctx.Done():
+		return nil, &TimeoutError{Op: o.Op, Err: ctx.Err()}
+	}
+}
+
+// Cancel stops the operation. The work done before it stopped is not
+// undone.
+func (o *AsyncOp) Cancel(ctx context.Context) error {
+	return o.cancel(ctx)
+}
+
+// DeleteVolumeAsync starts deleting a volume and its contents with a
+// TreeDelete job, as TreeDeleteVolume does, and returns a handle to the job
+// without waiting for it.
+func (c *Client) DeleteVolumeAsync(
+	ctx context.Context, name string) (*AsyncOp, error) {
+
+	id, err := c.StartJob(ctx, &apiv3.IsiJobReq{
+		Type:  JobTypeTreeDelete,
+		Paths: []string{c.API.VolumePath(name)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.jobAsyncOp(fmt.Sprintf("delete volume %s", name), id), nil
+}
+
+// CopyVolumeAsync starts copying a volume, as CopyVolume does, and returns
+// a handle to the copy without waiting for it. The namespace API copies in
+// a single request, so the request is made in the background without a
+// time limit; cancelling the copy abandons the request, and the cluster may
+// have copied part of the volume.
+func (c *Client) CopyVolumeAsync(
+	ctx context.Context, src, dest string) (*AsyncOp, error) {
+
+	if _, err := c.GetVolume(ctx, src, src); err != nil {
+		return nil, err
+	}
+
+	// the copy outlives the caller's context, but keeps its values
+	copyCtx, cancel := context.WithCancel(
+		api.WithTimeout(context.WithoutCancel(ctx), 0))
+	var (
+		mu     sync.Mutex
+		done   = make(chan struct{})
+		status = &AsyncOpStatus{State: AsyncOpStateRunning}
+	)
+	go func() {
+		defer close(done)
+		defer cancel()
+		_, err := apiv1.CopyIsiVolume(copyCtx, c.API, src, dest)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case copyCtx.Err() == context.Canceled:
+			status.State, status.Err = AsyncOpStateCancelled, context.Canceled
+		case err != nil:
+			status.State, status.Err = AsyncOpStateFailed, err
+		default:
+			status.State = AsyncOpStateSucceeded
+		}
+	}()
+
+	return &AsyncOp{
+		Op: fmt.Sprintf("copy volume %s to %s", src, dest),
+		status: func(context.Context) (*AsyncOpStatus, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			s := *status
+			return &s, nil
+		},
+		cancel: func(context.Context) error {
+			cancel()
+			<-done
+			return nil
+		},
+		done: done,
+	}, nil
+}
+
+// jobAsyncOp returns a handle to a namespace operation run by a job engine
+// job.
+func (c *Client) jobAsyncOp(op string, id int64) *AsyncOp {
+	var (
+		mu   sync.Mutex
+		last Job
+	)
+	return &AsyncOp{
+		Op: op,
+		status: func(ctx context.Context) (*AsyncOpStatus, error) {
+			job, err := c.GetJob(ctx, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// finished jobs are removed from the job list
+				if last != nil && IsNotFound(err) {
+					return jobAsyncOpStatus(id, last, true), nil
+				}
+				return nil, err
+			}
+			last = job
+			return jobAsyncOpStatus(id, job, false), nil
+		},
+		cancel: func(ctx context.Context) error {
+			state := "cancel"
+			err := apiv3.UpdateIsiJob(
+				ctx, c.API, id, &apiv3.IsiJobUpdateReq{State: &state})
+			if IsNotFound(err) {
+				return nil
+			}
+			return err
+		},
+	}
+}
+
+// jobAsyncOpStatus returns the status of an operation from its job. If
+// gone is set the job has finished and been removed since it was last
+// seen.
+func jobAsyncOpStatus(id int64, job Job, gone bool) *AsyncOpStatus {
+	s := &AsyncOpStatus{
+		State:  AsyncOpStateRunning,
+		JobID:  id,
+		Detail: job.Progress,
+	}
+	switch job.State {
+	case JobStateSucceeded:
+		s.State = AsyncOpStateSucceeded
+	case JobStateFailed:
+		s.State = AsyncOpStateFailed
+		s.Err = fmt.Errorf("%s job %d %s", job.Type, id, job.State)
+	case JobStateCancelledUser, JobStateCancelledSystem:
+		s.State = AsyncOpStateCancelled
+		s.Err = fmt.Errorf("%s job %d %s: %w",
+			job.Type, id, job.State, context.Canceled)
+	default:
+		if gone {
+			s.State = AsyncOpStateSucceeded
+		}
+	}
+	return s
+}
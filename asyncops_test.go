@@ -0,0 +1,62 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestJobAsyncOpStatus(t *testing.T) {
+	job := &apiv3.IsiJob{
+		Type: JobTypeTreeDelete, State: JobStateRunning, Progress: "Phase 1",
+	}
+	s := jobAsyncOpStatus(7, job, false)
+	assert.Equal(t, AsyncOpStateRunning, s.State)
+	assert.Equal(t, int64(7), s.JobID)
+	assert.Equal(t, "Phase 1", s.Detail)
+	assert.False(t, s.Done())
+	assert.NoError(t, s.Err)
+
+	// a running job that has since been removed finished
+	s = jobAsyncOpStatus(7, job, true)
+	assert.Equal(t, AsyncOpStateSucceeded, s.State)
+	assert.True(t, s.Done())
+
+	job.State = JobStateFailed
+	s = jobAsyncOpStatus(7, job, false)
+	assert.Equal(t, AsyncOpStateFailed, s.State)
+	assert.Error(t, s.Err)
+
+	job.State = JobStateCancelledUser
+	s = jobAsyncOpStatus(7, job, false)
+	assert.Equal(t, AsyncOpStateCancelled, s.State)
+	assert.True(t, errors.Is(s.Err, context.Canceled), "%v", s.Err)
+}
+
+func TestVolumeAsyncOps(t *testing.T) {
+	src, dest := "test_async_ops_src", "test_async_ops_dest"
+
+	_, err := client.CreateVolume(defaultCtx, src)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, src)
+
+	op, err := client.CopyVolumeAsync(defaultCtx, src, dest)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, dest)
+	status, err := op.Wait(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, AsyncOpStateSucceeded, status.State)
+
+	op, err = client.DeleteVolumeAsync(defaultCtx, dest)
+	assertNoError(t, err)
+	status, err = op.Wait(defaultCtx)
+	assertNoError(t, err)
+	assert.NotZero(t, status.JobID)
+
+	_, err = client.GetVolume(defaultCtx, dest, dest)
+	assert.True(t, IsNotFound(err), "%v", err)
+}
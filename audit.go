@@ -0,0 +1,227 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// AuditSettings are the cluster-wide audit settings.
+type AuditSettings *api.IsiAuditGlobalSettings
+
+// AuditZoneSettings are the audit settings of an access zone.
+type AuditZoneSettings *api.IsiAuditZoneSettings
+
+// AuditTopicList is a list of audit topics.
+type AuditTopicList []*api.IsiAuditTopic
+
+// AuditTopic is an audit topic.
+type AuditTopic *api.IsiAuditTopic
+
+// GetAuditSettings returns the cluster-wide audit settings.
+func (c *Client) GetAuditSettings(ctx context.Context) (AuditSettings, error) {
+	return api.GetIsiAuditGlobalSettings(ctx, c.API)
+}
+
+// UpdateAuditSettings modifies the cluster-wide audit settings. Fields that
+// are nil are left unchanged.
+func (c *Client) UpdateAuditSettings(
+	ctx context.Context, settings AuditSettings) error {
+
+	return api.UpdateIsiAuditGlobalSettings(ctx, c.API, settings)
+}
+
+// SetAuditCEEServers sets the CEE servers that audit events are forwarded to.
+func (c *Client) SetAuditCEEServers(
+	ctx context.Context, uris ...string) error {
+
+	return c.UpdateAuditSettings(
+		ctx, &api.IsiAuditGlobalSettings{CEEServerURIs: &uris})
+}
+
+// SetAuditSyslogServers sets the syslog servers that audit events are
+// forwarded to.
+func (c *Client) SetAuditSyslogServers(
+	ctx context.Context, servers ...string) error {
+
+	return c.UpdateAuditSettings(
+		ctx, &api.IsiAuditGlobalSettings{SyslogServers: &servers})
+}
+
+// GetAuditZoneSettings returns the audit settings of an access zone. If zone
+// is empty the client's zone is used.
+func (c *Client) GetAuditZoneSettings(
+	ctx context.Context, zone string) (AuditZoneSettings, error) {
+
+	return api.GetIsiAuditZoneSettings(ctx, c.API, zone)
+}
+
+// UpdateAuditZoneSettings modifies the audit settings of an access zone. If
+// zone is empty the client's zone is used. Fields that are nil are left unchanged.
+func (c *Client) UpdateAuditZoneSettings(
+	ctx context.Context, zone string, settings AuditZoneSettings) error {
+
+	return api.UpdateIsiAuditZoneSettings(ctx, c.API, zone, settings)
+}
+
+// GetAuditTopics returns the audit topics.
+func (c *Client) GetAuditTopics(ctx context.Context) (AuditTopicList, error) {
+	return api.GetIsiAuditTopics(ctx, c.API)
+}
+
+// SetAuditTopicMaxCachedMessages sets the number of messages that may be
+// cached for an audit topic.
+func (c *Client) SetAuditTopicMaxCachedMessages(
+	ctx context.Context, id string, max int64) error {
+
+	return api.UpdateIsiAuditTopic(ctx, c.API, id, max)
+}
+
+// AuditZoneConfig is the protocol audit configuration of an access zone.
+// Which protocols are audited, and where events are forwarded, are set
+// cluster-wide by the AuditSettings; this API version has no per-zone
+// exclusions of users or paths.
+type AuditZoneConfig struct {
+
+	// Enabled is whether protocol access in the zone is audited.
+	Enabled bool
+
+	// Success and Failure are the events audited when they succeed and
+	// when they fail, ex. api.AuditEventCreate.
+	Success []string
+	Failure []string
+
+	// SyslogEvents are the audited events that are forwarded to syslog if
+	// SyslogForwarding is set.
+	SyslogEvents     []string
+	SyslogForwarding bool
+}
+
+var auditEvents = map[string]bool{
+	api.AuditEventAll:         true,
+	api.AuditEventClose:       true,
+	api.AuditEventCreate:      true,
+	api.AuditEventDelete:      true,
+	api.AuditEventGetSecurity: true,
+	api.AuditEventLogoff:      true,
+	api.AuditEventLogon:       true,
+	api.AuditEventRead:        true,
+	api.AuditEventRename:      true,
+	api.AuditEventSetSecurity: true,
+	api.AuditEventTreeConnect: true,
+	api.AuditEventWrite:       true,
+}
+
+// GetAuditZoneConfig returns the protocol audit configuration of an access
+// zone. If zone is empty the client's zone is used.
+func (c *Client) GetAuditZoneConfig(
+	ctx context.Context, zone string) (*AuditZoneConfig, error) {
+
+	if zone == "" {
+		zone = c.API.Zone()
+	}
+	global, err := api.GetIsiAuditGlobalSettings(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := api.GetIsiAuditZoneSettings(ctx, c.API, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &AuditZoneConfig{
+		Success:      []string{},
+		Failure:      []string{},
+		SyslogEvents: []string{},
+	}
+	if global.AuditedZones != nil {
+		for _, z := range *global.AuditedZones {
+			if z == zone {
+				cfg.Enabled = true
+			}
+		}
+	}
+	if settings.AuditSuccess != nil {
+		cfg.Success = *settings.AuditSuccess
+	}
+	if settings.AuditFailure != nil {
+		cfg.Failure = *settings.AuditFailure
+	}
+	if settings.SyslogAuditEvents != nil {
+		cfg.SyslogEvents = *settings.SyslogAuditEvents
+	}
+	if settings.SyslogForwardingEnabled != nil {
+		cfg.SyslogForwarding = *settings.SyslogForwardingEnabled
+	}
+	return cfg, nil
+}
+
+// SetAuditZoneConfig sets the protocol audit configuration of an access
+// zone, adding the zone to or removing it from the cluster's audited zones.
+// If zone is empty the client's zone is used. The events are validated
+// before anything is changed.
+func (c *Client) SetAuditZoneConfig(
+	ctx context.Context, zone string, cfg *AuditZoneConfig) error {
+
+	if cfg == nil {
+		return errors.New("no audit config set")
+	}
+	for _, events := range [][]string{
+		cfg.Success, cfg.Failure, cfg.SyslogEvents} {
+
+		for _, e := range events {
+			if !auditEvents[e] {
+				return fmt.Errorf("invalid audit event: %s", e)
+			}
+		}
+	}
+	if zone == "" {
+		zone = c.API.Zone()
+	}
+
+	success, failure, syslog := cfg.Success, cfg.Failure, cfg.SyslogEvents
+	for _, events := range []*[]string{&success, &failure, &syslog} {
+		if *events == nil {
+			*events = []string{}
+		}
+	}
+	forwarding := cfg.SyslogForwarding
+	err := api.UpdateIsiAuditZoneSettings(ctx, c.API, zone,
+		&api.IsiAuditZoneSettings{
+			AuditSuccess:            &success,
+			AuditFailure:            &failure,
+			SyslogAuditEvents:       &syslog,
+			SyslogForwardingEnabled: &forwarding,
+		})
+	if err != nil {
+		return err
+	}
+
+	global, err := api.GetIsiAuditGlobalSettings(ctx, c.API)
+	if err != nil {
+		return err
+	}
+	var (
+		audited = []string{}
+		was     bool
+	)
+	if global.AuditedZones != nil {
+		for _, z := range *global.AuditedZones {
+			if z == zone {
+				was = true
+				continue
+			}
+			audited = append(audited, z)
+		}
+	}
+	if was == cfg.Enabled {
+		return nil
+	}
+	if cfg.Enabled {
+		audited = append(audited, zone)
+	}
+	return api.UpdateIsiAuditGlobalSettings(ctx, c.API,
+		&api.IsiAuditGlobalSettings{AuditedZones: &audited})
+}
@@ -0,0 +1,62 @@
+package goisilon
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetAuditSettings(t *testing.T) {
+	settings, err := client.GetAuditSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+}
+
+func TestAuditZoneSettingsUpdate(t *testing.T) {
+	settings, err := client.GetAuditZoneSettings(defaultCtx, "System")
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+
+	// re-apply the current settings, which should be a no-op
+	err = client.UpdateAuditZoneSettings(defaultCtx, "System", settings)
+	assertNoError(t, err)
+
+	updated, err := client.GetAuditZoneSettings(defaultCtx, "System")
+	assertNoError(t, err)
+	assert.Equal(t, settings, updated)
+}
+
+func TestGetAuditTopics(t *testing.T) {
+	topics, err := client.GetAuditTopics(defaultCtx)
+	assertNoError(t, err)
+	for _, topic := range topics {
+		assertNotNil(t, topic)
+		t.Logf("topic=%s", topic.Name)
+	}
+}
+
+func TestAuditZoneConfig(t *testing.T) {
+	orig, err := client.GetAuditZoneConfig(defaultCtx, "System")
+	assertNoError(t, err)
+	defer client.SetAuditZoneConfig(defaultCtx, "System", orig)
+
+	cfg := &AuditZoneConfig{
+		Enabled: true,
+		Success: []string{api.AuditEventCreate, api.AuditEventDelete},
+		Failure: []string{api.AuditEventAll},
+	}
+	assertNoError(t, client.SetAuditZoneConfig(defaultCtx, "System", cfg))
+
+	got, err := client.GetAuditZoneConfig(defaultCtx, "System")
+	assertNoError(t, err)
+	assert.True(t, got.Enabled)
+	sort.Strings(got.Success)
+	assert.Equal(t, cfg.Success, got.Success)
+	assert.Equal(t, cfg.Failure, got.Failure)
+
+	assertError(t, client.SetAuditZoneConfig(defaultCtx, "System",
+		&AuditZoneConfig{Success: []string{"chmod"}}))
+}
@@ -0,0 +1,162 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tenortim/goisilon/api"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+	apiv7 "github.com/tenortim/goisilon/api/v7"
+)
+
+// ErrAuditForwardingBehind is returned when the forwarding of audit events
+// has fallen further behind their logging than allowed.
+var ErrAuditForwardingBehind = errors.New("audit forwarding is behind")
+
+// AuditForwardingStatus is how far the audit events of a node have been
+// logged and forwarded. Times are zero if no event has been.
+type AuditForwardingStatus struct {
+	LNN int
+
+	// LastLogged is the time of the last event logged, and LastCEE and
+	// LastSyslog are the times of the last events forwarded to the CEE
+	// servers and to syslog.
+	LastLogged time.Time
+	LastCEE    time.Time
+	LastSyslog time.Time
+
+	// CEEBacklog and SyslogBacklog are how far forwarding is behind
+	// logging. They are zero if forwarding has caught up.
+	CEEBacklog    time.Duration
+	SyslogBacklog time.Duration
+}
+
+// GetAuditCEEServers returns the URIs of the CEE servers that audit events
+// are forwarded to.
+func (c *Client) GetAuditCEEServers(ctx context.Context) ([]string, error) {
+	settings, err := apiv3.GetIsiAuditGlobalSettings(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	if settings.CEEServerURIs == nil {
+		return []string{}, nil
+	}
+	return *settings.CEEServerURIs, nil
+}
+
+// GetAuditForwardingStatus returns how far the audit events of each node
+// have been logged and forwarded, ordered by logical node number. The nodes
+// are queried concurrently.
+func (c *Client) GetAuditForwardingStatus(
+	ctx context.Context) ([]*AuditForwardingStatus, error) {
+
+	nodes, err := apiv3.GetIsiNodes(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]*AuditForwardingStatus, len(nodes))
+	calls := make([]func(ctx context.Context) error, len(nodes))
+	for i, n := range nodes {
+		i, lnn := i, n.LNN
+		calls[i] = func(ctx context.Context) error {
+			p, err := apiv7.GetIsiAuditProgress(ctx, c.API, lnn)
+			if err != nil {
+				return err
+			}
+			status[i] = auditForwardingStatus(lnn, p, time.Now())
+			return nil
+		}
+	}
+	if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+		return nil, err
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].LNN < status[j].LNN
+	})
+	return status, nil
+}
+
+// CheckAuditForwarding returns the audit forwarding status of each node,
+// and an error wrapping ErrAuditForwardingBehind if forwarding to the CEE
+// servers, or to syslog if protocol syslog forwarding is enabled, is more
+// than maxBacklog behind logging on any node. Forwarding that is not
+// configured is not checked.
+func (c *Client) CheckAuditForwarding(
+	ctx context.Context,
+	maxBacklog time.Duration) ([]*AuditForwardingStatus, error) {
+
+	settings, err := apiv3.GetIsiAuditGlobalSettings(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	status, err := c.GetAuditForwardingStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkCEE := settings.CEEServerURIs != nil &&
+		len(*settings.CEEServerURIs) > 0
+	checkSyslog := settings.ProtocolSyslogEnabled != nil &&
+		*settings.ProtocolSyslogEnabled
+	return status, auditForwardingErr(
+		status, maxBacklog, checkCEE, checkSyslog)
+}
+
+// auditForwardingStatus returns the forwarding status of a node as of now. A
+// forwarder that has never forwarded an event is behind by the age of the
+// last event logged.
+func auditForwardingStatus(
+	lnn int, p *apiv7.IsiAuditProgress, now time.Time) *AuditForwardingStatus {
+
+	unix := func(t int64) time.Time {
+		if t == 0 {
+			return time.Time{}
+		}
+		return time.Unix(t, 0)
+	}
+	s := &AuditForwardingStatus{
+		LNN:        lnn,
+		LastLogged: unix(p.ProtocolAuditLogTime),
+		LastCEE:    unix(p.ProtocolAuditCEETime),
+		LastSyslog: unix(p.ProtocolAuditSyslogTime),
+	}
+	backlog := func(forwarded time.Time) time.Duration {
+		switch {
+		case s.LastLogged.IsZero():
+			return 0
+		case forwarded.IsZero():
+			return now.Sub(s.LastLogged)
+		case forwarded.Before(s.LastLogged):
+			return s.LastLogged.Sub(forwarded)
+		}
+		return 0
+	}
+	s.CEEBacklog = backlog(s.LastCEE)
+	s.SyslogBacklog = backlog(s.LastSyslog)
+	return s
+}
+
+func auditForwardingErr(
+	status []*AuditForwardingStatus,
+	maxBacklog time.Duration, checkCEE, checkSyslog bool) error {
+
+	var behind []string
+	for _, s := range status {
+		if checkCEE && s.CEEBacklog > maxBacklog {
+			behind = append(behind, fmt.Sprintf(
+				"node %d cee %s", s.LNN, s.CEEBacklog))
+		}
+		if checkSyslog && s.SyslogBacklog > maxBacklog {
+			behind = append(behind, fmt.Sprintf(
+				"node %d syslog %s", s.LNN, s.SyslogBacklog))
+		}
+	}
+	if len(behind) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrAuditForwardingBehind,
+		strings.Join(behind, ", "))
+}
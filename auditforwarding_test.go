@@ -0,0 +1,66 @@
+package goisilon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv7 "github.com/tenortim/goisilon/api/v7"
+)
+
+func TestAuditForwardingStatus(t *testing.T) {
+	now := time.Unix(10000, 0)
+
+	s := auditForwardingStatus(1, &apiv7.IsiAuditProgress{
+		ProtocolAuditLogTime:    9000,
+		ProtocolAuditCEETime:    8400,
+		ProtocolAuditSyslogTime: 9000,
+	}, now)
+	assert.Equal(t, 1, s.LNN)
+	assert.Equal(t, time.Unix(9000, 0), s.LastLogged)
+	assert.Equal(t, 10*time.Minute, s.CEEBacklog)
+	assert.Equal(t, time.Duration(0), s.SyslogBacklog)
+
+	// never forwarded
+	s = auditForwardingStatus(2, &apiv7.IsiAuditProgress{
+		ProtocolAuditLogTime: 9000,
+	}, now)
+	assert.True(t, s.LastCEE.IsZero())
+	assert.Equal(t, 1000*time.Second, s.CEEBacklog)
+
+	// never logged
+	s = auditForwardingStatus(3, &apiv7.IsiAuditProgress{}, now)
+	assert.True(t, s.LastLogged.IsZero())
+	assert.Equal(t, time.Duration(0), s.CEEBacklog)
+	assert.Equal(t, time.Duration(0), s.SyslogBacklog)
+}
+
+func TestAuditForwardingErr(t *testing.T) {
+	status := []*AuditForwardingStatus{
+		{LNN: 1, CEEBacklog: time.Minute},
+		{LNN: 2, CEEBacklog: time.Hour, SyslogBacklog: time.Hour},
+	}
+	assert.NoError(t, auditForwardingErr(status, 2*time.Hour, true, true))
+	assert.NoError(t, auditForwardingErr(status, time.Minute, false, false))
+
+	err := auditForwardingErr(status, time.Minute, true, false)
+	assert.True(t, errors.Is(err, ErrAuditForwardingBehind), "%v", err)
+	assert.Contains(t, err.Error(), "node 2 cee")
+	assert.NotContains(t, err.Error(), "node 1")
+	assert.NotContains(t, err.Error(), "syslog")
+}
+
+func TestGetAuditForwardingStatus(t *testing.T) {
+	servers, err := client.GetAuditCEEServers(defaultCtx)
+	assertNoError(t, err)
+	t.Logf("cee servers=%v", servers)
+
+	status, err := client.GetAuditForwardingStatus(defaultCtx)
+	assertNoError(t, err)
+	for _, s := range status {
+		t.Logf("node %d: cee backlog=%s syslog backlog=%s",
+			s.LNN, s.CEEBacklog, s.SyslogBacklog)
+	}
+}
@@ -0,0 +1,190 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v2"
+)
+
+// BulkOp is an operation run by Bulk.
+type BulkOp struct {
+
+	// Name identifies the operation in the report.
+	Name string
+
+	// Do performs the operation.
+	Do func(ctx context.Context) error
+}
+
+// BulkOptions configure how Bulk runs operations.
+type BulkOptions struct {
+
+	// Workers is the number of operations run concurrently. If zero,
+	// ConcurrentHTTPConnections is used.
+	Workers int
+
+	// Retries is the number of times a failed operation is retried.
+	Retries int
+
+	// RetryDelay is the time waited before the first retry. It doubles with
+	// each subsequent retry.
+	RetryDelay time.Duration
+
+	// Retryable reports whether an error should be retried. If nil,
+	// IsRetryable is used.
+	Retryable func(error) bool
+}
+
+// BulkResult is the result of an operation run by Bulk.
+type BulkResult struct {
+	Name     string
+	Attempts int
+	Err      error
+}
+
+// BulkReport is the aggregate result of the operations run by Bulk. Results
+// are in the same order as the operations.
+type BulkReport struct {
+	Results   []*BulkResult
+	Succeeded int
+	Failed    int
+}
+
+// Err returns a *BulkError describing the failed operations, or nil if all
+// operations succeeded.
+func (r *BulkReport) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	e := &BulkError{Total: len(r.Results)}
+	for _, res := range r.Results {
+		if res.Err != nil {
+			e.Failures = append(e.Failures, res)
+		}
+	}
+	return e
+}
+
+// BulkError is the error returned by BulkReport.Err.
+type BulkError struct {
+	Total    int
+	Failures []*BulkResult
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		msgs[i] = fmt.Sprintf("%s: %v", f.Name, f.Err)
+	}
+	return fmt.Sprintf("%d of %d operations failed: %s",
+		len(e.Failures), e.Total, strings.Join(msgs, "; "))
+}
+
+// Bulk runs a set of operations with a bounded number of workers, retrying
+// failed operations as configured, and returns a report of the results.
+// Operations that have not started when the context is done fail with the
+// context's error.
+func Bulk(ctx context.Context, ops []BulkOp, opts *BulkOptions) *BulkReport {
+	if opts == nil {
+		opts = &BulkOptions{}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = ConcurrentHTTPConnections
+	}
+
+	report := &BulkReport{Results: make([]*BulkResult, len(ops))}
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				report.Results[i] = runBulkOp(ctx, ops[i], opts)
+			}
+		}()
+	}
+	for i := range ops {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	for _, res := range report.Results {
+		if res.Err != nil {
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+	}
+	return report
+}
+
+func runBulkOp(
+	ctx context.Context, op BulkOp, opts *BulkOptions) *BulkResult {
+
+	res := &BulkResult{Name: op.Name}
+	delay := opts.RetryDelay
+	for {
+		if res.Err = ctx.Err(); res.Err != nil {
+			return res
+		}
+		res.Attempts++
+		if res.Err = op.Do(ctx); res.Err == nil {
+			return res
+		}
+		if res.Attempts > opts.Retries {
+			return res
+		}
+		retryable := opts.Retryable
+		if retryable == nil {
+			retryable = IsRetryable
+		}
+		if !retryable(res.Err) {
+			return res
+		}
+		select {
+		case <-ctx.Done():
+			return res
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// CreateVolumeOp returns an operation that creates a volume.
+func (c *Client) CreateVolumeOp(name string) BulkOp {
+	return BulkOp{
+		Name: "create volume " + name,
+		Do: func(ctx context.Context) error {
+			_, err := c.CreateVolume(ctx, name)
+			return err
+		},
+	}
+}
+
+// RemoveSnapshotOp returns an operation that removes a snapshot by id, or
+// failing that, the snapshot matching name.
+func (c *Client) RemoveSnapshotOp(id int64, name string) BulkOp {
+	return BulkOp{
+		Name: fmt.Sprintf("remove snapshot %d (%s)", id, name),
+		Do: func(ctx context.Context) error {
+			return c.RemoveSnapshot(ctx, id, name)
+		},
+	}
+}
+
+// UpdateExportOp returns an operation that updates an export.
+func (c *Client) UpdateExportOp(export Export) BulkOp {
+	return BulkOp{
+		Name: fmt.Sprintf("update export %d", export.ID),
+		Do: func(ctx context.Context) error {
+			return api.ExportUpdate(ctx, c.API, export)
+		},
+	}
+}
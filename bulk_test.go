@@ -0,0 +1,74 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulk(t *testing.T) {
+	var running, maxRunning int32
+	errFail := errors.New("fail")
+	attempts := make([]int32, 10)
+
+	ops := make([]BulkOp, len(attempts))
+	for i := range ops {
+		i := i
+		ops[i] = BulkOp{
+			Name: fmt.Sprintf("op%d", i),
+			Do: func(ctx context.Context) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&maxRunning)
+					if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				a := atomic.AddInt32(&attempts[i], 1)
+				switch {
+				case i == 3:
+					return errFail
+				case i%2 == 0 && a == 1:
+					return errFail
+				}
+				return nil
+			},
+		}
+	}
+
+	report := Bulk(defaultCtx, ops, &BulkOptions{
+		Workers:   3,
+		Retries:   2,
+		Retryable: func(error) bool { return true },
+	})
+	assert.True(t, maxRunning <= 3)
+	assert.Equal(t, 9, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 3, report.Results[3].Attempts)
+	assert.Equal(t, 2, report.Results[4].Attempts)
+	assert.Equal(t, 1, report.Results[5].Attempts)
+	assert.EqualError(t, report.Err(), "1 of 10 operations failed: op3: fail")
+
+	report = Bulk(defaultCtx, ops[3:4], &BulkOptions{Retries: 2})
+	assert.Equal(t, 1, report.Results[0].Attempts)
+}
+
+func TestBulkCreateVolumes(t *testing.T) {
+	names := []string{"test_bulk_volume_0", "test_bulk_volume_1"}
+	ops := make([]BulkOp, len(names))
+	for i, name := range names {
+		ops[i] = client.CreateVolumeOp(name)
+		defer client.DeleteVolume(defaultCtx, name)
+	}
+
+	report := Bulk(defaultCtx, ops, nil)
+	assertNoError(t, report.Err())
+	assert.Equal(t, len(names), report.Succeeded)
+}
@@ -0,0 +1,133 @@
+package goisilon
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+
+	apiv10 "github.com/tenortim/goisilon/api/v10"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// CertificateList is a list of certificates.
+type CertificateList []*api.IsiCertificate
+
+// Certificate is a server or certificate authority certificate.
+type Certificate *api.IsiCertificate
+
+// GetServerCertificates returns the cluster's server certificates.
+func (c *Client) GetServerCertificates(
+	ctx context.Context) (CertificateList, error) {
+
+	return api.GetIsiServerCertificates(ctx, c.API)
+}
+
+// GetServerCertificate returns the server certificate with the given ID.
+func (c *Client) GetServerCertificate(
+	ctx context.Context, id string) (Certificate, error) {
+
+	return api.GetIsiServerCertificate(ctx, c.API, id)
+}
+
+// ImportServerCertificate imports a PEM encoded server certificate and
+// private key and returns the ID of the new certificate. The PEM data is
+// staged in the volumes path for the import and removed afterwards.
+func (c *Client) ImportServerCertificate(
+	ctx context.Context,
+	name string, certPEM, keyPEM []byte, keyPassword string) (string, error) {
+
+	certFile, err := c.stageCertificateFile(ctx, name+".crt.pem", certPEM)
+	if err != nil {
+		return "", err
+	}
+	defer apiv2.ContainerChildDelete(ctx, c.API, certFile, false)
+
+	keyFile, err := c.stageCertificateFile(ctx, name+".key.pem", keyPEM)
+	if err != nil {
+		return "", err
+	}
+	defer apiv2.ContainerChildDelete(ctx, c.API, keyFile, false)
+
+	return api.ImportIsiServerCertificate(
+		ctx, c.API,
+		&api.IsiCertificateImportReq{
+			Name:                   name,
+			CertificatePath:        c.API.VolumePath(certFile),
+			CertificateKeyPath:     c.API.VolumePath(keyFile),
+			CertificateKeyPassword: keyPassword,
+		})
+}
+
+// ReplaceServerCertificate imports a new server certificate, makes it the
+// certificate used by the cluster's HTTPS services, and removes the
+// certificate it replaces. The new certificate's ID is returned. Selecting
+// the HTTPS certificate requires OneFS 9.0 or later.
+func (c *Client) ReplaceServerCertificate(
+	ctx context.Context,
+	oldID, name string, certPEM, keyPEM []byte, keyPassword string) (string, error) {
+
+	id, err := c.ImportServerCertificate(ctx, name, certPEM, keyPEM, keyPassword)
+	if err != nil {
+		return "", err
+	}
+	if err := apiv10.SetIsiDefaultHTTPSCertificate(ctx, c.API, id); err != nil {
+		return id, err
+	}
+	if oldID == "" || oldID == id {
+		return id, nil
+	}
+	return id, api.DeleteIsiServerCertificate(ctx, c.API, oldID)
+}
+
+// DeleteServerCertificate removes a server certificate.
+func (c *Client) DeleteServerCertificate(ctx context.Context, id string) error {
+	return api.DeleteIsiServerCertificate(ctx, c.API, id)
+}
+
+// GetCertificateAuthorities returns the trusted certificate authorities.
+func (c *Client) GetCertificateAuthorities(
+	ctx context.Context) (CertificateList, error) {
+
+	return api.GetIsiCertificateAuthorities(ctx, c.API)
+}
+
+// ImportCertificateAuthority adds a PEM encoded certificate to the trusted
+// certificate authorities and returns its ID.
+func (c *Client) ImportCertificateAuthority(
+	ctx context.Context, name string, certPEM []byte) (string, error) {
+
+	certFile, err := c.stageCertificateFile(ctx, name+".ca.pem", certPEM)
+	if err != nil {
+		return "", err
+	}
+	defer apiv2.ContainerChildDelete(ctx, c.API, certFile, false)
+
+	return api.ImportIsiCertificateAuthority(
+		ctx, c.API,
+		&api.IsiCertificateImportReq{
+			Name:            name,
+			CertificatePath: c.API.VolumePath(certFile),
+		})
+}
+
+// DeleteCertificateAuthority removes a trusted certificate authority.
+func (c *Client) DeleteCertificateAuthority(
+	ctx context.Context, id string) error {
+
+	return api.DeleteIsiCertificateAuthority(ctx, c.API, id)
+}
+
+// stageCertificateFile writes PEM data to a file that is only readable by
+// the owner in the volumes path and returns the file's name.
+func (c *Client) stageCertificateFile(
+	ctx context.Context, name string, data []byte) (string, error) {
+
+	fileName := ".goisilon_" + name
+	if err := apiv2.ContainerCreateFile(
+		ctx, c.API, "", fileName, len(data), apiv2.FileMode(0600),
+		ioutil.NopCloser(bytes.NewReader(data)), true); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
@@ -0,0 +1,19 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetServerCertificates(t *testing.T) {
+	certs, err := client.GetServerCertificates(defaultCtx)
+	assertNoError(t, err)
+	for _, cert := range certs {
+		t.Logf("certificate=%s subject=%s status=%s",
+			cert.Name, cert.Subject, cert.Status)
+	}
+}
+
+func TestGetCertificateAuthorities(t *testing.T) {
+	_, err := client.GetCertificateAuthorities(defaultCtx)
+	assertNoError(t, err)
+}
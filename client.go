@@ -2,11 +2,12 @@ package goisilon
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
-	"strconv"
-	"time"
 
 	"github.com/tenortim/goisilon/api"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
 )
 
 // Client is an Isilon client.
@@ -18,15 +19,11 @@ type Client struct {
 
 // NewClient returns a new Isilon client struct initialized from the environment.
 func NewClient(ctx context.Context) (*Client, error) {
-	insecure, _ := strconv.ParseBool(os.Getenv("GOISILON_INSECURE"))
-	return NewClientWithArgs(
-		ctx,
-		os.Getenv("GOISILON_ENDPOINT"),
-		insecure,
-		os.Getenv("GOISILON_USERNAME"),
-		os.Getenv("GOISILON_GROUP"),
-		os.Getenv("GOISILON_PASSWORD"),
-		os.Getenv("GOISILON_VOLUMEPATH"))
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromConfig(ctx, config)
 }
 
 // NewClientWithArgs returns a new Isilon client struct initialized from the supplied arguments.
@@ -36,18 +33,137 @@ func NewClientWithArgs(
 	insecure bool,
 	user, group, pass, volumesPath string) (*Client, error) {
 
-	timeout, _ := time.ParseDuration(os.Getenv("GOISILON_TIMEOUT"))
+	config, err := configFromArgs(
+		endpoint, insecure, user, group, pass, volumesPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromConfig(ctx, config)
+}
+
+// configFromArgs returns the Config of NewClientWithArgs. Only the settings
+// that have no argument, the zone and timeout, are read from the
+// environment.
+func configFromArgs(
+	endpoint string,
+	insecure bool,
+	user, group, pass, volumesPath string) (*Config, error) {
+
+	config := &Config{
+		Endpoint:    endpoint,
+		Insecure:    insecure,
+		Username:    user,
+		Group:       group,
+		Password:    pass,
+		VolumesPath: volumesPath,
+		Zone:        os.Getenv("GOISILON_ZONE"),
+	}
+	if v := os.Getenv("GOISILON_TIMEOUT"); v != "" {
+		if err := config.set("timeout", v); err != nil {
+			return nil, fmt.Errorf("invalid GOISILON_TIMEOUT: %v", err)
+		}
+	}
+	return config, nil
+}
+
+// NewClientFromConfig returns a new Isilon client struct initialized from the
+// supplied configuration and options. The configuration is validated before
+// the client connects. If a zone is set, the volumes path is scoped to the
+// zone's base path as by ForZoneWithPath.
+func NewClientFromConfig(
+	ctx context.Context, config *Config, opts ...Option) (*Client, error) {
+
+	if config == nil {
+		return nil, errors.New("no config set")
+	}
+	c := *config
+	for _, o := range opts {
+		if err := o(&c); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
 
 	client, err := api.New(
-		ctx, endpoint, user, pass, group,
+		ctx, c.Endpoint, c.Username, c.Password, c.Group,
 		&api.ClientOptions{
-			Insecure:    insecure,
-			VolumesPath: volumesPath,
-			Timeout:     timeout,
+			Insecure:       c.Insecure,
+			VolumesPath:    c.VolumesPath,
+			Timeout:        c.Timeout,
+			Zone:           c.Zone,
+			TLSConfig:      c.TLSConfig,
+			Redactor:       c.Redactor,
+			Capturer:       c.Capturer,
+			CircuitBreaker: c.CircuitBreaker,
+			Authenticator:  c.Authenticator,
 		})
 	if err != nil {
 		return nil, err
 	}
+	if c.Zone != "" {
+		if client, err = forZonePath(ctx, client, c.Zone); err != nil {
+			return nil, err
+		}
+	}
 
 	return &Client{client}, err
 }
+
+// ForZone returns a client that shares this client's connection but scopes
+// zone-aware calls, such as those for NFS exports and audit settings, to the
+// provided access zone. The volumes path is the configured one; use
+// ForZoneWithPath to also scope it to the zone's base path.
+func (c *Client) ForZone(zone string) *Client {
+	return &Client{c.API.ForZone(zone)}
+}
+
+// ForZoneWithPath returns a client like ForZone whose volumes path is also
+// scoped to the access zone's base path, which is looked up on the cluster.
+// Ex. the volumes path "/ifs/volumes" in a zone at "/ifs/zone1" becomes
+// "/ifs/zone1/volumes".
+func (c *Client) ForZoneWithPath(
+	ctx context.Context, zone string) (*Client, error) {
+
+	client, err := forZonePath(ctx, c.API, zone)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{client}, nil
+}
+
+// forZonePath returns a client for the zone with the volumes path scoped to
+// the zone's base path.
+func forZonePath(
+	ctx context.Context, client api.Client, zone string) (api.Client, error) {
+
+	z, err := apiv3.GetIsiZone(ctx, client, zone)
+	if err != nil {
+		return nil, fmt.Errorf("get zone %s: %w", zone, err)
+	}
+	if z.Path == nil {
+		return nil, fmt.Errorf("zone %s has no path", zone)
+	}
+	return client.ForZonePath(zone, *z.Path), nil
+}
+
+// DoRaw sends a request to any OneFS API path, ex. "platform/12/..." for an
+// endpoint that has no typed function, with the client's credentials and
+// logging. The body, if set, is encoded as JSON. The response is returned
+// undecoded, whatever its status; the error is only set if no response was
+// received.
+func (c *Client) DoRaw(
+	ctx context.Context,
+	method, path string,
+	params api.OrderedValues, body interface{}) (*api.RawResponse, error) {
+
+	return c.API.DoRaw(ctx, method, path, "", params, nil, body)
+}
+
+// Stats returns the statistics of the requests the client has made, per
+// endpoint, including the number of errors, the last error, and the 95th
+// percentile latency. Clients returned by ForZone share the statistics.
+func (c *Client) Stats() []api.EndpointStats {
+	return c.API.Stats()
+}
@@ -1,6 +1,9 @@
 package goisilon
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,3 +14,78 @@ func TestNewClient(t *testing.T) {
 	assert.NotZero(t, client.API.APIVersion())
 	t.Logf("api version=%d", client.API.APIVersion())
 }
+
+func TestClientForZone(t *testing.T) {
+	zc := client.ForZone("System")
+	assert.Equal(t, "System", zc.API.Zone())
+	assert.Equal(t, client.API.VolumesPath(), zc.API.VolumesPath())
+
+	_, err := zc.GetExports(defaultCtx)
+	assertNoError(t, err)
+}
+
+func TestClientZonePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch strings.TrimSuffix(r.URL.Path, "/") {
+			case "/platform/latest":
+				w.Write([]byte(`{"latest":"9"}`))
+			case "/platform/3/zones/zone1":
+				w.Write([]byte(`{"zones":[{"name":"zone1","path":"/ifs/zone1"}]}`))
+			case "/platform/3/zones/System":
+				w.Write([]byte(`{"zones":[{"name":"System","path":"/ifs"}]}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errors":[{"code":"AEC_NOT_FOUND","message":"not found"}]}`))
+			}
+		}))
+	defer srv.Close()
+
+	zc, err := NewClientFromConfig(defaultCtx, &Config{
+		Endpoint:    srv.URL,
+		Username:    "user",
+		Password:    "password",
+		VolumesPath: "/ifs/volumes",
+		Zone:        "zone1",
+	})
+	assertNoError(t, err)
+	assert.Equal(t, "zone1", zc.API.Zone())
+	assert.Equal(t, "/ifs/zone1/volumes", zc.API.VolumesPath())
+
+	sc, err := zc.ForZoneWithPath(defaultCtx, "System")
+	assertNoError(t, err)
+	assert.Equal(t, "/ifs/volumes", sc.API.VolumesPath())
+	assert.Equal(t, "/ifs/volumes", zc.ForZone("System").API.VolumesPath())
+
+	_, err = zc.ForZoneWithPath(defaultCtx, "nozone")
+	assert.Error(t, err)
+}
+
+func TestClientDoRaw(t *testing.T) {
+	res, err := client.DoRaw(
+		defaultCtx, http.MethodGet, "platform/latest", nil, nil)
+	assertNoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(res.Body), "latest")
+
+	res, err = client.DoRaw(
+		defaultCtx, http.MethodGet, "platform/1/no-such-endpoint", nil, nil)
+	assertNoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestClientStats(t *testing.T) {
+	_, err := client.GetExports(defaultCtx)
+	assertNoError(t, err)
+
+	var found bool
+	for _, s := range client.Stats() {
+		if s.Endpoint == "GET platform/2/protocols/nfs/exports" {
+			found = true
+			assert.NotZero(t, s.Requests)
+			assert.NotZero(t, s.P95Latency)
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,187 @@
+package goisilon
+
+import (
+	"context"
+	"path"
+
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// CloudAccountList is a list of CloudPools accounts.
+type CloudAccountList []*api.IsiCloudAccount
+
+// CloudAccount is a CloudPools cloud storage account.
+type CloudAccount *api.IsiCloudAccount
+
+// CloudPoolList is a list of CloudPools targets.
+type CloudPoolList []*api.IsiCloudPool
+
+// CloudPool is a CloudPools target made up of one or more accounts.
+type CloudPool *api.IsiCloudPool
+
+// CloudJob is a CloudPools archive or recall job.
+type CloudJob *api.IsiCloudJob
+
+// CloudPools job types.
+const (
+	CloudJobTypeArchive = "archive"
+	CloudJobTypeRecall  = "recall"
+)
+
+// CloudPools job states.
+const (
+	CloudJobStateRunning   = "running"
+	CloudJobStatePaused    = "paused"
+	CloudJobStateCancelled = "cancelled"
+	CloudJobStateCompleted = "completed"
+)
+
+// GetCloudAccounts returns the CloudPools accounts.
+func (c *Client) GetCloudAccounts(
+	ctx context.Context) (CloudAccountList, error) {
+
+	return api.GetIsiCloudAccounts(ctx, c.API)
+}
+
+// GetCloudAccount returns the CloudPools account with the given ID or name.
+func (c *Client) GetCloudAccount(
+	ctx context.Context, id string) (CloudAccount, error) {
+
+	return api.GetIsiCloudAccount(ctx, c.API, id)
+}
+
+// CreateCloudAccount creates a CloudPools account and returns its ID.
+func (c *Client) CreateCloudAccount(
+	ctx context.Context, account CloudAccount) (string, error) {
+
+	return api.CreateIsiCloudAccount(ctx, c.API, account)
+}
+
+// UpdateCloudAccount modifies a CloudPools account.
+func (c *Client) UpdateCloudAccount(
+	ctx context.Context, account CloudAccount) error {
+
+	return api.UpdateIsiCloudAccount(ctx, c.API, account)
+}
+
+// DeleteCloudAccount removes a CloudPools account.
+func (c *Client) DeleteCloudAccount(ctx context.Context, id string) error {
+	return api.DeleteIsiCloudAccount(ctx, c.API, id)
+}
+
+// GetCloudPools returns the CloudPools targets.
+func (c *Client) GetCloudPools(ctx context.Context) (CloudPoolList, error) {
+	return api.GetIsiCloudPools(ctx, c.API)
+}
+
+// GetCloudPool returns the CloudPools target with the given ID or name.
+func (c *Client) GetCloudPool(
+	ctx context.Context, id string) (CloudPool, error) {
+
+	return api.GetIsiCloudPool(ctx, c.API, id)
+}
+
+// CreateCloudPool creates a CloudPools target and returns its ID.
+func (c *Client) CreateCloudPool(
+	ctx context.Context, pool CloudPool) (string, error) {
+
+	return api.CreateIsiCloudPool(ctx, c.API, pool)
+}
+
+// UpdateCloudPool modifies a CloudPools target.
+func (c *Client) UpdateCloudPool(ctx context.Context, pool CloudPool) error {
+	return api.UpdateIsiCloudPool(ctx, c.API, pool)
+}
+
+// DeleteCloudPool removes a CloudPools target.
+func (c *Client) DeleteCloudPool(ctx context.Context, id string) error {
+	return api.DeleteIsiCloudPool(ctx, c.API, id)
+}
+
+// IsFileArchived returns a flag indicating whether or not a file in a volume
+// has been archived to the cloud and replaced with a SmartLink stub. The
+// namespace API only reports this on OneFS releases that expose the "stub"
+// attribute; on older releases the flag is always false.
+func (c *Client) IsFileArchived(
+	ctx context.Context, volumeName, filePath string) (bool, error) {
+
+	resp, err := apiv1.GetIsiVolume(
+		ctx, c.API, path.Join(volumeName, filePath))
+	if err != nil {
+		return false, err
+	}
+	for _, attr := range resp.AttributeMap {
+		if attr.Name == "stub" {
+			stub, _ := attr.Value.(bool)
+			return stub, nil
+		}
+	}
+	return false, nil
+}
+
+// ArchiveToCloud starts a CloudPools job that archives files and the files
+// under directories, by their absolute paths, to the cloud and returns its
+// ID. If policy is set the files are archived as the file pool policy with
+// that name specifies, otherwise by the policy that matches each file.
+func (c *Client) ArchiveToCloud(
+	ctx context.Context,
+	policy string, files, directories []string) (int64, error) {
+
+	return api.StartIsiCloudJob(ctx, c.API, &api.IsiCloudJobReq{
+		Type:        CloudJobTypeArchive,
+		Files:       files,
+		Directories: directories,
+		Policy:      policy,
+	})
+}
+
+// RecallFromCloud starts a CloudPools job that recalls archived files and
+// the archived files under directories, by their absolute paths, from the
+// cloud and returns its ID, ex. so they are local before a batch run reads
+// them.
+func (c *Client) RecallFromCloud(
+	ctx context.Context, files, directories []string) (int64, error) {
+
+	return api.StartIsiCloudJob(ctx, c.API, &api.IsiCloudJobReq{
+		Type:        CloudJobTypeRecall,
+		Files:       files,
+		Directories: directories,
+	})
+}
+
+// GetCloudJob returns a CloudPools job by ID.
+func (c *Client) GetCloudJob(ctx context.Context, id int64) (CloudJob, error) {
+	return api.GetIsiCloudJob(ctx, c.API, id)
+}
+
+// PauseCloudJob pauses a running CloudPools job.
+func (c *Client) PauseCloudJob(ctx context.Context, id int64) error {
+	return api.UpdateIsiCloudJobState(ctx, c.API, id, "pause")
+}
+
+// ResumeCloudJob resumes a paused CloudPools job.
+func (c *Client) ResumeCloudJob(ctx context.Context, id int64) error {
+	return api.UpdateIsiCloudJobState(ctx, c.API, id, "resume")
+}
+
+// CancelCloudJob cancels a CloudPools job.
+func (c *Client) CancelCloudJob(ctx context.Context, id int64) error {
+	return api.UpdateIsiCloudJobState(ctx, c.API, id, "cancel")
+}
+
+// IsCloudJobFinished returns whether a CloudPools job is in a terminal state.
+func IsCloudJobFinished(job CloudJob) bool {
+	if job == nil {
+		return false
+	}
+	state := job.EffectiveState
+	if state == "" {
+		state = job.State
+	}
+	switch state {
+	case CloudJobStateCompleted, CloudJobStateCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package goisilon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetCloudAccounts(t *testing.T) {
+	accounts, err := client.GetCloudAccounts(defaultCtx)
+	assertNoError(t, err)
+	for _, a := range accounts {
+		assertNotNil(t, a.Name)
+		t.Logf("account=%s state=%s", *a.Name, a.State)
+	}
+}
+
+func TestGetCloudPools(t *testing.T) {
+	pools, err := client.GetCloudPools(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range pools {
+		assertNotNil(t, p.Name)
+		t.Logf("pool=%s state=%s", *p.Name, p.State)
+	}
+}
+
+func TestIsFileArchived(t *testing.T) {
+	volumeName := "test_is_file_archived"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	archived, err := client.IsFileArchived(defaultCtx, volumeName, "")
+	assertNoError(t, err)
+	if archived {
+		t.Fatal("a new volume should not be archived")
+	}
+}
+
+func TestIsCloudJobFinished(t *testing.T) {
+	for state, finished := range map[string]bool{
+		CloudJobStateRunning:   false,
+		CloudJobStatePaused:    false,
+		CloudJobStateCancelled: true,
+		CloudJobStateCompleted: true,
+	} {
+		job := &api.IsiCloudJob{EffectiveState: state}
+		if IsCloudJobFinished(job) != finished {
+			t.Errorf("state %s: finished=%v", state, !finished)
+		}
+	}
+	if IsCloudJobFinished(nil) {
+		t.Error("nil job finished")
+	}
+}
+
+func TestRecallFromCloud(t *testing.T) {
+	licensed, err := client.IsLicensed(defaultCtx, "CloudPools")
+	if err != nil || !licensed {
+		t.Skip("CloudPools is not licensed")
+	}
+
+	volumeName := "test_recall_from_cloud"
+
+	_, err = client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	id, err := client.RecallFromCloud(defaultCtx,
+		nil, []string{client.API.VolumePath(volumeName)})
+	assertNoError(t, err)
+
+	ctx, cancel := context.WithTimeout(defaultCtx, time.Minute)
+	defer cancel()
+	job, err := client.WaitForCloudJobCompletion(ctx, id)
+	assertNoError(t, err)
+	t.Logf("job=%d state=%s", job.ID, job.EffectiveState)
+}
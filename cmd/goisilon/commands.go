@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/tenortim/goisilon"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// rootCommand returns the tree of the tool's commands.
+func rootCommand() *command {
+	return &command{
+		name: "goisilon",
+		subs: []*command{
+			volumeCommand(),
+			quotaCommand(),
+			snapshotCommand(),
+			exportCommand(),
+			shareCommand(),
+		},
+	}
+}
+
+func volumeCommand() *command {
+	var force bool
+	return &command{
+		name:  "volume",
+		short: "manage volumes",
+		subs: []*command{
+			{
+				name:  "list",
+				short: "list the volumes",
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					volumes, err := c.GetVolumes(ctx)
+					if err != nil {
+						return err
+					}
+					return e.print(volumes)
+				},
+			},
+			{
+				name:  "get",
+				args:  "NAME",
+				short: "get a volume",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					volume, err := c.GetVolume(ctx, args[0], args[0])
+					if err != nil {
+						return err
+					}
+					return e.print(volume)
+				},
+			},
+			{
+				name:  "create",
+				args:  "NAME",
+				short: "create a volume",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					volume, err := c.CreateVolume(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					return e.print(volume)
+				},
+			},
+			{
+				name:  "delete",
+				args:  "NAME",
+				short: "delete a volume",
+				nargs: 1,
+				flags: func(fs *flag.FlagSet) {
+					fs.BoolVar(&force, "force", false,
+						"delete the volume even if it is not empty")
+				},
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					if force {
+						return c.ForceDeleteVolume(ctx, args[0])
+					}
+					return c.DeleteVolume(ctx, args[0])
+				},
+			},
+		},
+	}
+}
+
+func quotaCommand() *command {
+	var (
+		path   string
+		format string
+	)
+	return &command{
+		name:  "quota",
+		short: "manage volume quotas",
+		subs: []*command{
+			{
+				name:  "get",
+				args:  "VOLUME",
+				short: "get a volume's quota",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					quota, err := c.GetQuota(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					return e.print(quota)
+				},
+			},
+			{
+				name:  "set",
+				args:  "VOLUME SIZE",
+				short: "set a volume's hard quota, ex. 10GiB or 500GB",
+				nargs: 2,
+				run: func(ctx context.Context, e *env, args []string) error {
+					size, err := goisilon.ParseSize(args[1])
+					if err != nil || size <= 0 {
+						return fmt.Errorf("invalid size: %s", args[1])
+					}
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					return c.SetQuotaSize(ctx, args[0], size)
+				},
+			},
+			{
+				name:  "clear",
+				args:  "VOLUME",
+				short: "remove a volume's quota",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					return c.ClearQuota(ctx, args[0])
+				},
+			},
+			{
+				name:  "report",
+				short: "report the usage of directory quotas",
+				flags: func(fs *flag.FlagSet) {
+					fs.StringVar(&path, "path", "",
+						"report only the quotas on this path and its descendants")
+					fs.StringVar(&format, "format", "json",
+						"the report format, csv or json")
+				},
+				run: func(ctx context.Context, e *env, args []string) error {
+					if format != "csv" && format != "json" {
+						return fmt.Errorf("invalid format: %s", format)
+					}
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					report, err := c.GetUsageReport(ctx, path)
+					if err != nil {
+						return err
+					}
+					if format == "csv" {
+						return report.WriteCSV(e.out)
+					}
+					return report.WriteJSON(e.out)
+				},
+			},
+		},
+	}
+}
+
+func snapshotCommand() *command {
+	var path, origin string
+	return &command{
+		name:  "snapshot",
+		short: "manage snapshots",
+		subs: []*command{
+			{
+				name:  "list",
+				short: "list the snapshots",
+				flags: func(fs *flag.FlagSet) {
+					fs.StringVar(&path, "path", "",
+						"list only the snapshots of this volume")
+					fs.StringVar(&origin, "origin", "",
+						"list only the snapshots created by this origin: "+
+							"user, schedule or synciq")
+				},
+				run: func(ctx context.Context, e *env, args []string) error {
+					switch goisilon.SnapshotOrigin(origin) {
+					case "", goisilon.SnapshotOriginUser,
+						goisilon.SnapshotOriginSchedule,
+						goisilon.SnapshotOriginSyncIQ:
+					default:
+						return fmt.Errorf("invalid origin: %s", origin)
+					}
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					var snapshots goisilon.SnapshotList
+					if path != "" {
+						snapshots, err = c.GetSnapshotsByPath(ctx, path)
+					} else {
+						snapshots, err = c.GetSnapshots(ctx)
+					}
+					if err != nil {
+						return err
+					}
+					if origin != "" {
+						snapshots = snapshots.ByOrigin(
+							goisilon.SnapshotOrigin(origin))
+					}
+					return e.print(snapshots)
+				},
+			},
+			{
+				name:  "create",
+				args:  "VOLUME NAME",
+				short: "snapshot a volume",
+				nargs: 2,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					snapshot, err := c.CreateSnapshot(ctx, args[0], args[1])
+					if err != nil {
+						return err
+					}
+					return e.print(snapshot)
+				},
+			},
+			{
+				name:  "delete",
+				args:  "ID|NAME",
+				short: "delete a snapshot",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					id, name := idOrName(args[0])
+					return c.RemoveSnapshot(ctx, id, name)
+				},
+			},
+		},
+	}
+}
+
+func exportCommand() *command {
+	return &command{
+		name:  "export",
+		short: "manage NFS exports",
+		subs: []*command{
+			{
+				name:  "list",
+				short: "list the NFS exports",
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					exports, err := c.GetExports(ctx)
+					if err != nil {
+						return err
+					}
+					return e.print(exports)
+				},
+			},
+			{
+				name:  "create",
+				args:  "VOLUME",
+				short: "export a volume, printing the export's ID",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					id, err := c.Export(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					return e.print(id)
+				},
+			},
+			{
+				name:  "delete",
+				args:  "ID",
+				short: "delete an NFS export",
+				nargs: 1,
+				run: func(ctx context.Context, e *env, args []string) error {
+					id, err := strconv.Atoi(args[0])
+					if err != nil {
+						return fmt.Errorf("invalid export id: %s", args[0])
+					}
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					return c.UnexportByID(ctx, id)
+				},
+			},
+		},
+	}
+}
+
+func shareCommand() *command {
+	var (
+		zone, description string
+		opts              goisilon.SMBShareCreateOptions
+	)
+	zoneFlag := func(fs *flag.FlagSet) {
+		fs.StringVar(&zone, "zone", "",
+			"the access zone, if not the client's zone")
+	}
+	return &command{
+		name:  "share",
+		short: "manage SMB shares",
+		subs: []*command{
+			{
+				name:  "list",
+				short: "list the SMB shares",
+				flags: zoneFlag,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					shares, err := c.ListSMBShares(zone).Collect(ctx)
+					if err != nil {
+						return err
+					}
+					return e.print(shares)
+				},
+			},
+			{
+				name:  "get",
+				args:  "NAME",
+				short: "get an SMB share",
+				nargs: 1,
+				flags: zoneFlag,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					share, err := c.GetSMBShare(ctx, zone, args[0])
+					if err != nil {
+						return err
+					}
+					return e.print(share)
+				},
+			},
+			{
+				name:  "create",
+				args:  "NAME PATH",
+				short: "create an SMB share, printing its ID",
+				nargs: 2,
+				flags: func(fs *flag.FlagSet) {
+					zoneFlag(fs)
+					fs.StringVar(&description, "description", "",
+						"the share's description")
+					fs.BoolVar(&opts.CreatePath, "create-path", false,
+						"create the share's path if it does not exist")
+					fs.BoolVar(&opts.InheritablePathACL, "inheritable-acl",
+						false, "set an inheritable ACL on the share's path")
+					fs.StringVar(&opts.ACLTemplate, "acl-template", "",
+						"apply the ACL of this directory to the share's path")
+				},
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					share := &apiv3.IsiSMBShare{Name: &args[0], Path: &args[1]}
+					if description != "" {
+						share.Description = &description
+					}
+					id, err := c.CreateSMBShareWithOptions(
+						ctx, zone, share, &opts)
+					if err != nil {
+						return err
+					}
+					return e.print(id)
+				},
+			},
+			{
+				name:  "delete",
+				args:  "NAME",
+				short: "delete an SMB share",
+				nargs: 1,
+				flags: zoneFlag,
+				run: func(ctx context.Context, e *env, args []string) error {
+					c, err := e.Client(ctx)
+					if err != nil {
+						return err
+					}
+					return c.DeleteSMBShare(ctx, zone, args[0])
+				},
+			},
+		},
+	}
+}
+
+// idOrName returns the ID in s if it is numeric, or else s as a name.
+func idOrName(s string) (int64, string) {
+	if id, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return id, ""
+	}
+	return 0, s
+}
@@ -0,0 +1,216 @@
+// Command goisilon is a command-line tool for managing an Isilon cluster
+// with the goisilon library. It is both a practical administration utility
+// and a smoke test of the library against a cluster.
+//
+// Usage:
+//
+//	goisilon [flags] <command> <subcommand> [flags] [args]
+//
+// The connection is configured by the GOISILON_* environment variables, a
+// JSON or YAML file named by -config, and the -isilon-* flags, in increasing
+// order of precedence. Results are written to standard output as JSON.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tenortim/goisilon"
+)
+
+// command is a command or subcommand of the tool. A command has either
+// subcommands or a run function.
+type command struct {
+	name  string
+	args  string
+	short string
+
+	// flags registers the command's flags, if it has any.
+	flags func(fs *flag.FlagSet)
+
+	// nargs is the number of positional arguments run requires, or -1 for
+	// any number.
+	nargs int
+
+	run  func(ctx context.Context, env *env, args []string) error
+	subs []*command
+}
+
+// env is the environment a command runs in.
+type env struct {
+	config *goisilon.Config
+	out    io.Writer
+	client *goisilon.Client
+}
+
+// Client returns the client, creating it on first use so that usage errors
+// are reported without connecting to the cluster.
+func (e *env) Client(ctx context.Context) (*goisilon.Client, error) {
+	if e.client == nil {
+		c, err := goisilon.New(ctx, goisilon.WithConfig(e.config))
+		if err != nil {
+			return nil, err
+		}
+		e.client = c
+	}
+	return e.client, nil
+}
+
+// print writes a value to the output as indented JSON.
+func (e *env) print(v interface{}) error {
+	enc := json.NewEncoder(e.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// errUsage is returned, after the usage has been written, when a command is
+// used incorrectly.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if err := run(
+		context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "goisilon:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments and runs the command they name.
+func run(ctx context.Context, args []string, out, errOut io.Writer) error {
+	root := rootCommand()
+	fs := flag.NewFlagSet(root.name, flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.Usage = func() { usage(errOut, root, fs, nil) }
+	config, err := parseConfig(fs, args)
+	if err == flag.ErrHelp {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	e := &env{config: config, out: out}
+	return dispatch(ctx, e, root, nil, fs.Args(), errOut)
+}
+
+// parseConfig parses the global flags and returns the configuration read
+// from the environment, overridden by the file named by -config, overridden
+// by the -isilon-* flags that are set. errUsage is returned if the flags
+// are invalid.
+func parseConfig(fs *flag.FlagSet, args []string) (*goisilon.Config, error) {
+	var (
+		configFile  string
+		flagsConfig = &goisilon.Config{}
+	)
+	fs.StringVar(&configFile, "config", "", "a JSON or YAML configuration file")
+	flagsConfig.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil, err
+		}
+		return nil, errUsage
+	}
+
+	config, err := goisilon.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if configFile != "" {
+		if err := config.LoadFile(configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	// the flags that were set are applied by setting them again on flags
+	// bound to the merged configuration
+	merged := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+	config.RegisterFlags(merged)
+	fs.Visit(func(f *flag.Flag) {
+		if err == nil && merged.Lookup(f.Name) != nil {
+			err = merged.Set(f.Name, f.Value.String())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// dispatch runs the subcommand of cmd named by args.
+func dispatch(
+	ctx context.Context,
+	e *env, cmd *command, path []string, args []string,
+	errOut io.Writer) error {
+
+	path = append(path, cmd.name)
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Usage = func() { usage(errOut, cmd, fs, path) }
+
+	if cmd.run == nil {
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" ||
+			args[0] == "--help" {
+			fs.Usage()
+			if len(args) == 0 {
+				return errUsage
+			}
+			return nil
+		}
+		for _, sub := range cmd.subs {
+			if sub.name == args[0] {
+				return dispatch(ctx, e, sub, path, args[1:], errOut)
+			}
+		}
+		fmt.Fprintf(errOut, "unknown command %q\n", args[0])
+		fs.Usage()
+		return errUsage
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return errUsage
+	}
+	if cmd.nargs >= 0 && fs.NArg() != cmd.nargs {
+		fs.Usage()
+		return errUsage
+	}
+	return cmd.run(ctx, e, fs.Args())
+}
+
+// usage writes the usage of a command.
+func usage(w io.Writer, cmd *command, fs *flag.FlagSet, path []string) {
+	name := strings.Join(path, " ")
+	if name == "" {
+		name = cmd.name + " [flags]"
+	}
+	if cmd.run != nil {
+		fmt.Fprintf(w, "usage: %s [flags] %s\n\n%s\n", name, cmd.args, cmd.short)
+	} else {
+		fmt.Fprintf(w, "usage: %s <command>\n\ncommands:\n", name)
+		subs := append([]*command(nil), cmd.subs...)
+		sort.Slice(subs, func(i, j int) bool { return subs[i].name < subs[j].name })
+		for _, sub := range subs {
+			fmt.Fprintf(w, "  %-10s %s\n", sub.name, sub.short)
+		}
+	}
+	var hasFlags bool
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintf(w, "\nflags:\n")
+		fs.PrintDefaults()
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  error
+		want string
+	}{
+		{nil, errUsage, "commands:\n  export"},
+		{[]string{"help"}, nil, "  volume"},
+		{[]string{"bogus"}, errUsage, `unknown command "bogus"`},
+		{[]string{"volume"}, errUsage, "usage: goisilon volume <command>"},
+		{[]string{"volume", "get"}, errUsage, "usage: goisilon volume get"},
+		{[]string{"volume", "delete", "-h"}, nil, "-force"},
+		{[]string{"share", "list", "extra"}, errUsage, "-zone"},
+	}
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		err := run(context.Background(), tt.args, &out, &errOut)
+		assert.Equal(t, tt.err, err, "%v", tt.args)
+		assert.Contains(t, errOut.String(), tt.want, "%v", tt.args)
+		assert.Empty(t, out.String(), "%v", tt.args)
+	}
+}
+
+func TestRunInvalidArgs(t *testing.T) {
+	tests := [][]string{
+		{"quota", "set", "vol", "10Q"},
+		{"quota", "set", "vol", "0GiB"},
+		{"quota", "report", "-format", "xml"},
+		{"export", "delete", "abc"},
+	}
+	for _, args := range tests {
+		var out, errOut bytes.Buffer
+		err := run(context.Background(), args, &out, &errOut)
+		assert.Error(t, err, "%v", args)
+		assert.NotEqual(t, errUsage, err, "%v", args)
+	}
+}
+
+func TestIDOrName(t *testing.T) {
+	id, name := idOrName("42")
+	assert.Equal(t, int64(42), id)
+	assert.Equal(t, "", name)
+
+	id, name = idOrName("daily")
+	assert.Equal(t, int64(0), id)
+	assert.Equal(t, "daily", name)
+}
+
+func TestParseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goisilon")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(configFile, []byte(
+		"endpoint: https://file:8080\nusername: file\ntimeout: 30s\n"), 0600))
+
+	t.Setenv("GOISILON_ENDPOINT", "https://env:8080")
+	t.Setenv("GOISILON_USERNAME", "env")
+	t.Setenv("GOISILON_PASSWORD", "env")
+	t.Setenv("GOISILON_TIMEOUT", "10s")
+
+	tests := []struct {
+		args                         []string
+		endpoint, username, password string
+		timeout                      time.Duration
+	}{
+		// the file overrides the environment
+		{[]string{"-config", configFile, "volume", "list"},
+			"https://file:8080", "file", "env", 30 * time.Second},
+		// flags override the file wherever -config is
+		{[]string{"-isilon-endpoint", "https://flag:8080",
+			"-config", configFile, "volume", "list"},
+			"https://flag:8080", "file", "env", 30 * time.Second},
+		{[]string{"-config=" + configFile, "-isilon-timeout", "1m",
+			"volume", "list"},
+			"https://file:8080", "file", "env", time.Minute},
+		{[]string{"volume", "list"},
+			"https://env:8080", "env", "env", 10 * time.Second},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("goisilon", flag.ContinueOnError)
+		config, err := parseConfig(fs, tt.args)
+		assert.NoError(t, err, "%v", tt.args)
+		assert.Equal(t, tt.endpoint, config.Endpoint, "%v", tt.args)
+		assert.Equal(t, tt.username, config.Username, "%v", tt.args)
+		assert.Equal(t, tt.password, config.Password, "%v", tt.args)
+		assert.Equal(t, tt.timeout, config.Timeout, "%v", tt.args)
+		assert.Equal(t, []string{"volume", "list"}, fs.Args(), "%v", tt.args)
+	}
+}
+
+func TestParseConfigFileFalseValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goisilon")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(configFile, []byte(
+		"insecure: false\ntimeout: 0s\n"), 0600))
+
+	t.Setenv("GOISILON_ENDPOINT", "https://env:8080")
+	t.Setenv("GOISILON_INSECURE", "true")
+	t.Setenv("GOISILON_TIMEOUT", "10s")
+
+	config, err := parseConfig(flag.NewFlagSet("goisilon", flag.ContinueOnError),
+		[]string{"-config", configFile, "volume", "list"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://env:8080", config.Endpoint)
+	assert.False(t, config.Insecure)
+	assert.Equal(t, time.Duration(0), config.Timeout)
+}
@@ -0,0 +1,302 @@
+package goisilon
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// maxTimeout is the longest request timeout a Config may specify.
+const maxTimeout = time.Hour
+
+// Config is the configuration used to create a client. It may be populated
+// from the environment, a JSON or YAML file, or command-line flags.
+type Config struct {
+
+	// Endpoint is the API endpoint, ex. https://172.17.177.230:8080.
+	Endpoint string
+
+	// Username is the user name used to access the API.
+	Username string
+
+	// Group is the user's group.
+	Group string
+
+	// Password is the user's password.
+	Password string
+
+	// Insecure is whether or not to skip SSL validation.
+	Insecure bool
+
+	// VolumesPath is the base path of volume directories.
+	VolumesPath string
+
+	// Zone is the access zone used to scope zone-aware calls. The volumes
+	// path is scoped to the zone's base path.
+	Zone string
+
+	// Timeout is the time limit for requests. Zero means no limit. It can be
+	// overridden for a call with api.WithTimeout.
+	Timeout time.Duration
+
+	// TLSConfig is the TLS configuration used to connect to the API. It is
+	// not read from the environment, files, or flags.
+	TLSConfig *tls.Config
+
+	// Redactor is applied to HTTP requests and responses dumped to the debug
+	// log, after credentials have been redacted. It is not read from the
+	// environment, files, or flags.
+	Redactor api.Redactor
+
+	// Capturer, if set, records the client's HTTP exchanges with the
+	// credentials redacted. It is not read from the environment, files, or
+	// flags.
+	Capturer api.Capturer
+
+	// CircuitBreaker, if set, enables a circuit breaker that fails requests
+	// fast while the cluster is failing. It is not read from the
+	// environment, files, or flags.
+	CircuitBreaker *api.CircuitBreakerOptions
+
+	// Authenticator, if set, adds credentials to the client's requests. It
+	// defaults to api.BasicAuth. It is not read from the environment, files,
+	// or flags.
+	Authenticator api.Authenticator
+}
+
+// configEnvVars maps the environment variables read by ConfigFromEnv to the
+// keys used in configuration files.
+var configEnvVars = []struct{ env, key string }{
+	{"GOISILON_ENDPOINT", "endpoint"},
+	{"GOISILON_USERNAME", "username"},
+	{"GOISILON_GROUP", "group"},
+	{"GOISILON_PASSWORD", "password"},
+	{"GOISILON_INSECURE", "insecure"},
+	{"GOISILON_VOLUMEPATH", "volumes_path"},
+	{"GOISILON_ZONE", "zone"},
+	{"GOISILON_TIMEOUT", "timeout"},
+}
+
+// ConfigFromEnv returns a Config populated from the GOISILON_* environment
+// variables. Variables that are unset are left at their zero value.
+func ConfigFromEnv() (*Config, error) {
+	c := &Config{}
+	for _, v := range configEnvVars {
+		val, ok := os.LookupEnv(v.env)
+		if !ok || val == "" {
+			continue
+		}
+		if err := c.set(v.key, val); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", v.env, err)
+		}
+	}
+	return c, nil
+}
+
+// LoadConfig returns a Config read from a JSON or YAML file. The format is
+// chosen by the file's extension, and YAML files must be a flat mapping of
+// keys to scalar values. The keys are endpoint, username, group, password,
+// insecure, volumes_path, zone, and timeout. The timeout is a duration, ex.
+// "30s", or in JSON files may also be a number of seconds.
+func LoadConfig(name string) (*Config, error) {
+	c := &Config{}
+	if err := c.LoadFile(name); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadFile sets the fields of the Config that a JSON or YAML file sets, as
+// read by LoadConfig, and leaves the other fields as they are, so that the
+// file overrides values loaded from the environment, including false and
+// zero values.
+func (c *Config) LoadFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return c.readJSON(f)
+	case ".yaml", ".yml":
+		return c.readYAML(f)
+	}
+	return fmt.Errorf("unsupported config file format: %s", name)
+}
+
+func (c *Config) readJSON(r io.Reader) error {
+	var m map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return err
+	}
+	for k, v := range m {
+		var s string
+		switch v := v.(type) {
+		case string:
+			s = v
+		case bool:
+			s = strconv.FormatBool(v)
+		case float64:
+			// a bare number of seconds is accepted for the timeout
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+			if k == "timeout" {
+				s += "s"
+			}
+		default:
+			return fmt.Errorf("invalid %s: not a scalar value", k)
+		}
+		if err := c.set(k, s); err != nil {
+			return fmt.Errorf("invalid %s: %v", k, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) readYAML(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := stripYAMLComment(s.Text())
+		if t := strings.TrimSpace(line); t == "" || t == "---" ||
+			strings.HasPrefix(t, "#") {
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			return fmt.Errorf("line %d: nested values are not supported", n)
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("line %d: expected key: value", n)
+		}
+		k, v := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+			v = v[1 : len(v)-1]
+		}
+		if err := c.set(k, v); err != nil {
+			return fmt.Errorf("line %d: invalid %s: %v", n, k, err)
+		}
+	}
+	return s.Err()
+}
+
+// stripYAMLComment removes a comment from a line of YAML. A comment starts
+// with a "#" at the start of the line or after whitespace, outside quoted
+// values, such as passwords, which may contain " #".
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		var prev byte = ' '
+		if i > 0 {
+			prev = line[i-1]
+		}
+		switch ch := line[i]; {
+		case quote == '"' && ch == '\\':
+			i++
+		case quote == '\'' && ch == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			i++
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case (ch == '"' || ch == '\'') && strings.IndexByte(" \t:", prev) >= 0:
+			quote = ch
+		case ch == '#' && (prev == ' ' || prev == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// set assigns a value to the field identified by a configuration file key.
+func (c *Config) set(key, value string) error {
+	var err error
+	switch key {
+	case "endpoint":
+		c.Endpoint = value
+	case "username":
+		c.Username = value
+	case "group":
+		c.Group = value
+	case "password":
+		c.Password = value
+	case "insecure":
+		c.Insecure, err = strconv.ParseBool(value)
+	case "volumes_path":
+		c.VolumesPath = value
+	case "zone":
+		c.Zone = value
+	case "timeout":
+		c.Timeout, err = time.ParseDuration(value)
+	default:
+		err = errors.New("unknown key")
+	}
+	return err
+}
+
+// RegisterFlags binds the fields of the Config to flags in the provided flag
+// set. The current field values are used as the flags' defaults, so flags may
+// override values loaded from the environment or a file.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Endpoint, "isilon-endpoint", c.Endpoint,
+		"the API endpoint, ex. https://172.17.177.230:8080")
+	fs.StringVar(&c.Username, "isilon-username", c.Username, "the username")
+	fs.StringVar(&c.Group, "isilon-group", c.Group, "the user's group")
+	fs.StringVar(&c.Password, "isilon-password", c.Password, "the password")
+	fs.BoolVar(&c.Insecure, "isilon-insecure", c.Insecure,
+		"whether to skip SSL validation")
+	fs.StringVar(&c.VolumesPath, "isilon-volumepath", c.VolumesPath,
+		"which base path to use when looking for volume directories")
+	fs.StringVar(&c.Zone, "isilon-zone", c.Zone,
+		"the access zone used to scope zone-aware calls")
+	fs.DurationVar(&c.Timeout, "isilon-timeout", c.Timeout,
+		"the time limit for requests")
+}
+
+// Validate returns an error if the Config is missing required fields or has
+// invalid values.
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("no endpoint set")
+	}
+	if err := validateEndpoint(c.Endpoint); err != nil {
+		return err
+	}
+	if c.Username == "" || c.Password == "" {
+		return errors.New("no credentials set")
+	}
+	return validateTimeout(c.Timeout)
+}
+
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid endpoint scheme: %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid endpoint: no host in %q", endpoint)
+	}
+	return nil
+}
+
+func validateTimeout(timeout time.Duration) error {
+	if timeout < 0 || timeout > maxTimeout {
+		return fmt.Errorf("invalid timeout: %v", timeout)
+	}
+	return nil
+}
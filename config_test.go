@@ -0,0 +1,118 @@
+package goisilon
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	config, err := ConfigFromEnv()
+	assertNoError(t, err)
+	assertNoError(t, config.Validate())
+	assert.Equal(t, os.Getenv("GOISILON_ENDPOINT"), config.Endpoint)
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goisilon")
+	assertNoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"config.json": `{"endpoint": "https://172.17.177.230:8080",
+			"username": "admin", "password": "secret",
+			"insecure": true, "timeout": "30s"}`,
+		"config.yaml": "---\n# cluster\nendpoint: https://172.17.177.230:8080\n" +
+			"username: admin\npassword: 'secret'\ninsecure: true # lab\ntimeout: 30s\n",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		assertNoError(t, ioutil.WriteFile(p, []byte(data), 0600))
+		config, err := LoadConfig(p)
+		assertNoError(t, err)
+		assert.Equal(t, &Config{
+			Endpoint: "https://172.17.177.230:8080",
+			Username: "admin",
+			Password: "secret",
+			Insecure: true,
+			Timeout:  30 * time.Second,
+		}, config)
+		assertNoError(t, config.Validate())
+	}
+
+	// quoted values may contain " #", and a bare timeout is in seconds
+	files = map[string]string{
+		"quoted.json": `{"password": "pa #ss", "timeout": 30}`,
+		"quoted.yaml": "password: \"pa #ss\" # comment\ntimeout: 30s\n",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		assertNoError(t, ioutil.WriteFile(p, []byte(data), 0600))
+		config, err := LoadConfig(p)
+		assertNoError(t, err)
+		assert.Equal(t, "pa #ss", config.Password, name)
+		assert.Equal(t, 30*time.Second, config.Timeout, name)
+	}
+
+	p := filepath.Join(dir, "bad.yaml")
+	assertNoError(t, ioutil.WriteFile(p, []byte("timeout: soon\n"), 0600))
+	_, err = LoadConfig(p)
+	assertError(t, err)
+}
+
+func TestConfigRegisterFlags(t *testing.T) {
+	config := &Config{Username: "admin", Password: "secret"}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	config.RegisterFlags(fs)
+	assertNoError(t, fs.Parse([]string{
+		"-isilon-endpoint", "https://172.17.177.230:8080",
+		"-isilon-timeout", "2h"}))
+	assert.Equal(t, "https://172.17.177.230:8080", config.Endpoint)
+	assert.Equal(t, "admin", config.Username)
+	assert.EqualError(t, config.Validate(), "invalid timeout: 2h0m0s")
+}
+
+func TestStripYAMLComment(t *testing.T) {
+	tests := map[string]string{
+		"# comment":             "",
+		"key: value # comment":  "key: value ",
+		"key: value#hash":       "key: value#hash",
+		`key: "a #b" # comment`: `key: "a #b" `,
+		`key: 'it''s #1' # c`:   `key: 'it''s #1' `,
+		`key: "a \" #b"`:        `key: "a \" #b"`,
+		"key: it's # comment":   "key: it's ",
+		`key:'a #b'`:            `key:'a #b'`,
+	}
+	for in, out := range tests {
+		assert.Equal(t, out, stripYAMLComment(in), in)
+	}
+}
+
+func TestConfigFromArgs(t *testing.T) {
+	t.Setenv("GOISILON_INSECURE", "maybe")
+	t.Setenv("GOISILON_ENDPOINT", "not a url")
+	t.Setenv("GOISILON_ZONE", "zone1")
+	t.Setenv("GOISILON_TIMEOUT", "30s")
+	config, err := configFromArgs(
+		"https://172.17.177.230:8080", true, "admin", "", "secret", "/ifs/v")
+	assertNoError(t, err)
+	assert.Equal(t, &Config{
+		Endpoint:    "https://172.17.177.230:8080",
+		Insecure:    true,
+		Username:    "admin",
+		Password:    "secret",
+		VolumesPath: "/ifs/v",
+		Zone:        "zone1",
+		Timeout:     30 * time.Second,
+	}, config)
+
+	t.Setenv("GOISILON_TIMEOUT", "soon")
+	_, err = configFromArgs(
+		"https://172.17.177.230:8080", true, "admin", "", "secret", "/ifs/v")
+	assertError(t, err)
+}
@@ -0,0 +1,273 @@
+package goisilon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// configBackupVersion is the version of the ConfigBackup document format.
+const configBackupVersion = 1
+
+// ConfigBackup is a backup of the definitions of the NFS exports, SMB shares
+// and directory quotas under a path, which can be restored to the same or
+// another cluster. It is serialized as JSON.
+type ConfigBackup struct {
+	Version int `json:"version"`
+
+	// Zone is the access zone the exports and shares were read from.
+	Zone string `json:"zone,omitempty"`
+
+	// Path is the path the definitions are under, or empty for all of them.
+	Path string `json:"path,omitempty"`
+
+	Exports   []*apiv2.Export      `json:"exports"`
+	SMBShares []*apiv3.IsiSMBShare `json:"smb_shares"`
+	Quotas    []*QuotaDefinition   `json:"quotas"`
+}
+
+// QuotaDefinition is the definition of a directory quota in a ConfigBackup.
+// Thresholds that are not set are nil.
+type QuotaDefinition struct {
+	Path                      string `json:"path"`
+	Container                 bool   `json:"container"`
+	Enforced                  bool   `json:"enforced"`
+	IncludeSnapshots          bool   `json:"include_snapshots"`
+	ThresholdsIncludeOverhead bool   `json:"thresholds_include_overhead"`
+	Advisory                  *int64 `json:"advisory,omitempty"`
+	Soft                      *int64 `json:"soft,omitempty"`
+	SoftGrace                 *int64 `json:"soft_grace,omitempty"`
+	Hard                      *int64 `json:"hard,omitempty"`
+}
+
+func quotaDefinition(q *Quota) *QuotaDefinition {
+	threshold := func(v int64) *int64 {
+		if v == 0 {
+			return nil
+		}
+		return &v
+	}
+	d := &QuotaDefinition{
+		Path:                      q.Path,
+		Container:                 q.Container,
+		Enforced:                  q.Enforced,
+		IncludeSnapshots:          q.IncludeSnapshots,
+		ThresholdsIncludeOverhead: q.ThresholdsIncludeOverhead,
+		Advisory:                  threshold(q.Thresholds.Advisory),
+		Soft:                      threshold(q.Thresholds.Soft),
+		Hard:                      threshold(q.Thresholds.Hard),
+	}
+	if d.Soft != nil {
+		d.SoftGrace = threshold(q.Thresholds.SoftGrace)
+	}
+	return d
+}
+
+func (d *QuotaDefinition) request() *apiv1.IsiQuotaReq {
+	// nil thresholds must be sent as null rather than a nil *int64
+	threshold := func(v *int64) interface{} {
+		if v == nil {
+			return nil
+		}
+		return *v
+	}
+	return &apiv1.IsiQuotaReq{
+		Enforced:                  d.Enforced,
+		IncludeSnapshots:          d.IncludeSnapshots,
+		Path:                      d.Path,
+		Container:                 d.Container,
+		ThresholdsIncludeOverhead: d.ThresholdsIncludeOverhead,
+		Type:                      "directory",
+		Thresholds: apiv1.IsiThresholdsReq{
+			Advisory:  threshold(d.Advisory),
+			Soft:      threshold(d.Soft),
+			SoftGrace: threshold(d.SoftGrace),
+			Hard:      threshold(d.Hard),
+		},
+	}
+}
+
+// isUnderPath returns whether p is root or a descendant of it. Every path is
+// under an empty root.
+func isUnderPath(p, root string) bool {
+	root = strings.TrimSuffix(root, "/")
+	return root == "" || p == root || strings.HasPrefix(p, root+"/")
+}
+
+// BackupConfig returns a backup of the NFS exports and SMB shares in an
+// access zone, and of the directory quotas, whose paths are under a path. If
+// zone is empty the client's zone is used, and if path is empty all of them
+// are backed up. An export is backed up if any of its paths is under path.
+func (c *Client) BackupConfig(
+	ctx context.Context, zone, path string) (*ConfigBackup, error) {
+
+	backup := &ConfigBackup{
+		Version:   configBackupVersion,
+		Zone:      zone,
+		Path:      path,
+		Exports:   []*apiv2.Export{},
+		SMBShares: []*apiv3.IsiSMBShare{},
+		Quotas:    []*QuotaDefinition{},
+	}
+
+	err := c.ListExports(zone).ForEach(ctx, func(e *apiv2.Export) bool {
+		if e.Paths == nil {
+			return true
+		}
+		for _, p := range *e.Paths {
+			if isUnderPath(p, path) {
+				backup.Exports = append(backup.Exports, e)
+				break
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.ListSMBShares(zone).ForEach(ctx, func(s *apiv3.IsiSMBShare) bool {
+		if s.Path != nil && isUnderPath(*s.Path, path) {
+			backup.SMBShares = append(backup.SMBShares, s)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quotaRoot := path
+	if quotaRoot == "" {
+		quotaRoot = "/ifs"
+	}
+	err = c.ListDirectoryQuotas(quotaRoot).ForEach(ctx, func(q *Quota) bool {
+		backup.Quotas = append(backup.Quotas, quotaDefinition(q))
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// WriteTo writes the backup to w as JSON.
+func (b *ConfigBackup) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(data, '\n'))
+	return int64(n), err
+}
+
+// ReadConfigBackup reads a backup written by ConfigBackup.WriteTo.
+func ReadConfigBackup(r io.Reader) (*ConfigBackup, error) {
+	var b ConfigBackup
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, err
+	}
+	if b.Version != configBackupVersion {
+		return nil, fmt.Errorf(
+			"unsupported config backup version: %d", b.Version)
+	}
+	return &b, nil
+}
+
+// RestoreConfigOptions configure RestoreConfig.
+type RestoreConfigOptions struct {
+
+	// Zone is the access zone the exports and shares are created in. If
+	// empty the client's zone is used, not the zone in the backup.
+	Zone string
+
+	// SkipExisting treats definitions that conflict with existing ones, ex.
+	// a share with the same name, as restored rather than failed.
+	SkipExisting bool
+
+	// Workers is the number of definitions restored concurrently. If zero,
+	// ConcurrentHTTPConnections is used.
+	Workers int
+}
+
+// RestoreConfig creates the NFS exports, SMB shares and directory quotas in
+// a backup. The directories they refer to must already exist. The returned
+// report has the result of each definition, quotas first, and the error is
+// that of the report.
+func (c *Client) RestoreConfig(
+	ctx context.Context,
+	backup *ConfigBackup, opts *RestoreConfigOptions) (*BulkReport, error) {
+
+	if backup == nil {
+		return nil, errors.New("no config backup set")
+	}
+	if opts == nil {
+		opts = &RestoreConfigOptions{}
+	}
+
+	var ops []BulkOp
+	for _, q := range backup.Quotas {
+		q := q
+		ops = append(ops, BulkOp{
+			Name: "create quota " + q.Path,
+			Do: func(ctx context.Context) error {
+				return apiv1.CreateIsiQuotaFromReq(ctx, c.API, q.request())
+			},
+		})
+	}
+	for _, e := range backup.Exports {
+		export := *e
+		export.ID = 0
+		name := "create export"
+		if export.Paths != nil {
+			name += " " + strings.Join(*export.Paths, ",")
+		}
+		ops = append(ops, BulkOp{
+			Name: name,
+			Do: func(ctx context.Context) error {
+				if opts.Zone == "" {
+					_, err := apiv2.ExportCreate(ctx, c.API, &export)
+					return err
+				}
+				_, err := apiv2.ExportCreateWithZone(
+					ctx, c.API, &export, opts.Zone)
+				return err
+			},
+		})
+	}
+	for _, s := range backup.SMBShares {
+		s := s
+		name := "create smb share"
+		if s.Name != nil {
+			name += " " + *s.Name
+		}
+		ops = append(ops, BulkOp{
+			Name: name,
+			Do: func(ctx context.Context) error {
+				_, err := apiv3.CreateIsiSMBShare(ctx, c.API, opts.Zone, s)
+				return err
+			},
+		})
+	}
+
+	if opts.SkipExisting {
+		for i := range ops {
+			do := ops[i].Do
+			ops[i].Do = func(ctx context.Context) error {
+				if err := do(ctx); err != nil && !IsConflict(err) {
+					return err
+				}
+				return nil
+			}
+		}
+	}
+
+	report := Bulk(ctx, ops, &BulkOptions{Workers: opts.Workers})
+	return report, report.Err()
+}
@@ -0,0 +1,93 @@
+package goisilon
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestConfigBackupReadWrite(t *testing.T) {
+	hard, soft, grace := int64(2000), int64(1000), int64(3600)
+	paths := []string{"/ifs/data/a"}
+	name, path := "a", "/ifs/data/a"
+	backup := &ConfigBackup{
+		Version:   configBackupVersion,
+		Path:      "/ifs/data",
+		Exports:   []*apiv2.Export{{ID: 5, Paths: &paths}},
+		SMBShares: []*apiv3.IsiSMBShare{{Name: &name, Path: &path}},
+		Quotas: []*QuotaDefinition{{
+			Path: path, Enforced: true,
+			Hard: &hard, Soft: &soft, SoftGrace: &grace,
+		}},
+	}
+
+	var buf bytes.Buffer
+	_, err := backup.WriteTo(&buf)
+	assertNoError(t, err)
+	read, err := ReadConfigBackup(&buf)
+	assertNoError(t, err)
+	assert.Equal(t, backup, read)
+
+	req := read.Quotas[0].request()
+	assert.Equal(t, "directory", req.Type)
+	assert.Equal(t, hard, req.Thresholds.Hard)
+	assert.Equal(t, grace, req.Thresholds.SoftGrace)
+	assert.Nil(t, req.Thresholds.Advisory)
+
+	_, err = ReadConfigBackup(strings.NewReader(`{"version":2}`))
+	assert.EqualError(t, err, "unsupported config backup version: 2")
+}
+
+func TestIsUnderPath(t *testing.T) {
+	assert.True(t, isUnderPath("/ifs/data", ""))
+	assert.True(t, isUnderPath("/ifs/data", "/ifs/data"))
+	assert.True(t, isUnderPath("/ifs/data/a", "/ifs/data/"))
+	assert.False(t, isUnderPath("/ifs/database", "/ifs/data"))
+}
+
+func TestBackupRestoreConfig(t *testing.T) {
+	volumeName := "test_backup_config"
+	quotaSize := int64(12345)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	path := client.API.VolumePath(volumeName)
+
+	assertNoError(t, client.CreateQuota(
+		defaultCtx, volumeName, true, quotaSize))
+	defer client.ClearQuota(defaultCtx, volumeName)
+	id, err := client.Export(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.UnexportByID(defaultCtx, id)
+
+	backup, err := client.BackupConfig(defaultCtx, "", path)
+	assertNoError(t, err)
+	assert.Len(t, backup.Exports, 1)
+	assert.Len(t, backup.SMBShares, 0)
+	if assert.Len(t, backup.Quotas, 1) {
+		assert.Equal(t, quotaSize, *backup.Quotas[0].Hard)
+		assert.True(t, backup.Quotas[0].Container)
+	}
+
+	// restoring over the existing definitions only succeeds if conflicts
+	// are skipped
+	assertNoError(t, client.UnexportByID(defaultCtx, id))
+	report, err := client.RestoreConfig(
+		defaultCtx, backup, &RestoreConfigOptions{SkipExisting: true})
+	assertNoError(t, err)
+	assert.Equal(t, 2, report.Succeeded)
+
+	exports, err := client.GetExports(defaultCtx)
+	assertNoError(t, err)
+	for _, e := range exports {
+		if e.Paths != nil && len(*e.Paths) == 1 && (*e.Paths)[0] == path {
+			defer client.UnexportByID(defaultCtx, e.ID)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v1"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// DedupeSettings are the SmartDedupe settings.
+type DedupeSettings *api.IsiDedupeSettings
+
+// DedupeReportList is a list of SmartDedupe reports.
+type DedupeReportList []*api.IsiDedupeReport
+
+// DedupeReport is the report of a Dedupe or DedupeAssessment job.
+type DedupeReport *api.IsiDedupeReport
+
+// DedupeSummary is the cluster-wide SmartDedupe savings.
+type DedupeSummary *api.IsiDedupeSummary
+
+// GetDedupeSettings returns the SmartDedupe settings.
+func (c *Client) GetDedupeSettings(
+	ctx context.Context) (DedupeSettings, error) {
+
+	return api.GetIsiDedupeSettings(ctx, c.API)
+}
+
+// UpdateDedupeSettings modifies the SmartDedupe settings. Fields that are nil
+// are left unchanged.
+func (c *Client) UpdateDedupeSettings(
+	ctx context.Context, settings DedupeSettings) error {
+
+	return api.UpdateIsiDedupeSettings(ctx, c.API, settings)
+}
+
+// GetDedupeReports returns the SmartDedupe reports.
+func (c *Client) GetDedupeReports(
+	ctx context.Context) (DedupeReportList, error) {
+
+	return api.GetIsiDedupeReports(ctx, c.API)
+}
+
+// GetDedupeReport returns the SmartDedupe report with the given ID.
+func (c *Client) GetDedupeReport(
+	ctx context.Context, id string) (DedupeReport, error) {
+
+	return api.GetIsiDedupeReport(ctx, c.API, id)
+}
+
+// GetDedupeSummary returns the cluster-wide SmartDedupe savings.
+func (c *Client) GetDedupeSummary(
+	ctx context.Context) (DedupeSummary, error) {
+
+	return api.GetIsiDedupeSummary(ctx, c.API)
+}
+
+// StartDedupeAssessment sets the assessment paths to the given volumes and
+// starts a DedupeAssessment job, returning the job ID. The resulting report
+// can be retrieved with GetDedupeReports once the job completes.
+func (c *Client) StartDedupeAssessment(
+	ctx context.Context, volumeNames ...string) (int64, error) {
+
+	paths := make([]string, len(volumeNames))
+	for i, name := range volumeNames {
+		paths[i] = c.API.VolumePath(name)
+	}
+
+	if err := api.UpdateIsiDedupeSettings(
+		ctx, c.API, &api.IsiDedupeSettings{AssessPaths: &paths}); err != nil {
+		return 0, err
+	}
+
+	return c.StartJob(ctx, &apiv3.IsiJobReq{Type: "DedupeAssessment"})
+}
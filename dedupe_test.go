@@ -0,0 +1,23 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetDedupeSettings(t *testing.T) {
+	settings, err := client.GetDedupeSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+}
+
+func TestGetDedupeSummary(t *testing.T) {
+	summary, err := client.GetDedupeSummary(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, summary)
+	t.Logf("saved blocks=%d", summary.SavedLogicalBlocks)
+}
+
+func TestGetDedupeReports(t *testing.T) {
+	_, err := client.GetDedupeReports(defaultCtx)
+	assertNoError(t, err)
+}
@@ -0,0 +1,56 @@
+package goisilon
+
+import (
+	"context"
+	"io"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// DiagnosticsGatherStatus is the status of a diagnostics gather.
+type DiagnosticsGatherStatus *api.IsiDiagnosticsGatherStatus
+
+// DiagnosticsBundleList is a list of diagnostics log bundles.
+type DiagnosticsBundleList []*api.IsiDiagnosticsBundle
+
+// DiagnosticsBundle is a log bundle produced by a diagnostics gather.
+type DiagnosticsBundle *api.IsiDiagnosticsBundle
+
+// DiagnosticsGatherOptions are the optional settings used to start a
+// diagnostics gather.
+type DiagnosticsGatherOptions *api.IsiDiagnosticsGatherStartReq
+
+// StartDiagnosticsGather starts collecting a log bundle on the cluster. The
+// options may be nil to use the cluster's defaults.
+func (c *Client) StartDiagnosticsGather(
+	ctx context.Context, opts DiagnosticsGatherOptions) error {
+
+	return api.StartIsiDiagnosticsGather(ctx, c.API, opts)
+}
+
+// StopDiagnosticsGather stops a running diagnostics gather.
+func (c *Client) StopDiagnosticsGather(ctx context.Context) error {
+	return api.StopIsiDiagnosticsGather(ctx, c.API)
+}
+
+// GetDiagnosticsGatherStatus returns the status of the current or most
+// recent diagnostics gather.
+func (c *Client) GetDiagnosticsGatherStatus(
+	ctx context.Context) (DiagnosticsGatherStatus, error) {
+
+	return api.GetIsiDiagnosticsGatherStatus(ctx, c.API)
+}
+
+// GetDiagnosticsBundles returns the log bundles available on the cluster.
+func (c *Client) GetDiagnosticsBundles(
+	ctx context.Context) (DiagnosticsBundleList, error) {
+
+	return api.GetIsiDiagnosticsBundles(ctx, c.API)
+}
+
+// DownloadDiagnosticsBundle writes the contents of the named log bundle to w.
+func (c *Client) DownloadDiagnosticsBundle(
+	ctx context.Context, name string, w io.Writer) error {
+
+	return api.DownloadIsiDiagnosticsBundle(ctx, c.API, name, w)
+}
@@ -0,0 +1,20 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetDiagnosticsGatherStatus(t *testing.T) {
+	status, err := client.GetDiagnosticsGatherStatus(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, status)
+	t.Logf("gather status=%s", status.Status)
+}
+
+func TestGetDiagnosticsBundles(t *testing.T) {
+	bundles, err := client.GetDiagnosticsBundles(defaultCtx)
+	assertNoError(t, err)
+	for _, b := range bundles {
+		t.Logf("bundle=%s size=%d", b.Name, b.Size)
+	}
+}
@@ -0,0 +1,60 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// EmailSettings are the cluster email settings used to deliver alerts.
+type EmailSettings *api.IsiEmailSettings
+
+// GetEmailSettings returns the cluster email settings.
+func (c *Client) GetEmailSettings(ctx context.Context) (EmailSettings, error) {
+	return api.GetIsiEmailSettings(ctx, c.API)
+}
+
+// UpdateEmailSettings modifies the cluster email settings. Fields that are
+// nil are left unchanged.
+func (c *Client) UpdateEmailSettings(
+	ctx context.Context, settings EmailSettings) error {
+
+	return api.UpdateIsiEmailSettings(ctx, c.API, settings)
+}
+
+// SetEmailRelay sets the SMTP relay, port, and sender address used for
+// cluster email.
+func (c *Client) SetEmailRelay(
+	ctx context.Context, relay string, port int, sender string) error {
+
+	return api.UpdateIsiEmailSettings(
+		ctx, c.API,
+		&api.IsiEmailSettings{
+			MailRelay:  &relay,
+			SmtpPort:   &port,
+			MailSender: &sender,
+		})
+}
+
+// SetEmailAuthentication enables SMTP authentication with the given
+// credentials. The security mode is either "none" or "starttls".
+func (c *Client) SetEmailAuthentication(
+	ctx context.Context, username, password, security string) error {
+
+	enabled := true
+	return api.UpdateIsiEmailSettings(
+		ctx, c.API,
+		&api.IsiEmailSettings{
+			UseSmtpAuth:      &enabled,
+			SmtpAuthUsername: &username,
+			SmtpAuthPasswd:   &password,
+			SmtpAuthSecurity: &security,
+		})
+}
+
+// DisableEmailAuthentication disables SMTP authentication.
+func (c *Client) DisableEmailAuthentication(ctx context.Context) error {
+	enabled := false
+	return api.UpdateIsiEmailSettings(
+		ctx, c.API, &api.IsiEmailSettings{UseSmtpAuth: &enabled})
+}
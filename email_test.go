@@ -0,0 +1,16 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetEmailSettings(t *testing.T) {
+	settings, err := client.GetEmailSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+
+	// re-apply the current settings, which should be a no-op
+	settings.SmtpAuthPasswd = nil
+	err = client.UpdateEmailSettings(defaultCtx, settings)
+	assertNoError(t, err)
+}
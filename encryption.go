@@ -0,0 +1,87 @@
+package goisilon
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// DriveEncryption is the encryption status of a drive.
+type DriveEncryption struct {
+	LNN            int
+	Bay            int
+	Model          string
+	Serial         string
+	SelfEncrypting bool
+}
+
+// EncryptionStatus is the data-at-rest encryption status of the cluster's
+// drives.
+type EncryptionStatus struct {
+	Drives []*DriveEncryption
+
+	// SelfEncrypting is the number of self-encrypting drives.
+	SelfEncrypting int
+}
+
+// Encrypted returns whether every drive is self-encrypting, which is
+// required for the cluster's data at rest to be encrypted.
+func (s *EncryptionStatus) Encrypted() bool {
+	return s != nil && len(s.Drives) > 0 && s.SelfEncrypting == len(s.Drives)
+}
+
+// isSelfEncrypting returns whether a drive is a SED, which OneFS reports in
+// its interface or media type, ex. "SED-SAS".
+func isSelfEncrypting(d *api.IsiNodeDrive) bool {
+	return strings.Contains(strings.ToUpper(d.InterfaceType), "SED") ||
+		strings.Contains(strings.ToUpper(d.MediaType), "SED")
+}
+
+func encryptionStatus(nodes []*api.IsiNode) *EncryptionStatus {
+	status := &EncryptionStatus{}
+	for _, n := range nodes {
+		for _, d := range n.Drives {
+			if !d.Present {
+				continue
+			}
+			de := &DriveEncryption{
+				LNN:            n.LNN,
+				Bay:            d.Baynum,
+				Model:          d.Model,
+				Serial:         d.Serial,
+				SelfEncrypting: isSelfEncrypting(d),
+			}
+			if de.SelfEncrypting {
+				status.SelfEncrypting++
+			}
+			status.Drives = append(status.Drives, de)
+		}
+	}
+	return status
+}
+
+// GetEncryptionStatus returns the self-encrypting drive status of the
+// present drives of every node.
+func (c *Client) GetEncryptionStatus(
+	ctx context.Context) (*EncryptionStatus, error) {
+
+	nodes, err := api.GetIsiNodes(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	return encryptionStatus(nodes), nil
+}
+
+// GetNodeEncryptionStatus returns the self-encrypting drive status of the
+// present drives of a node.
+func (c *Client) GetNodeEncryptionStatus(
+	ctx context.Context, lnn int) (*EncryptionStatus, error) {
+
+	drives, err := api.GetIsiNodeDrives(ctx, c.API, lnn)
+	if err != nil {
+		return nil, err
+	}
+	return encryptionStatus(
+		[]*api.IsiNode{{LNN: lnn, Drives: drives}}), nil
+}
@@ -0,0 +1,40 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestEncryptionStatusSummary(t *testing.T) {
+	status := encryptionStatus([]*api.IsiNode{
+		{LNN: 1, Drives: []*api.IsiNodeDrive{
+			{Baynum: 1, InterfaceType: "SED-SAS", Present: true},
+			{Baynum: 2, InterfaceType: "SAS", Present: false},
+		}},
+		{LNN: 2, Drives: []*api.IsiNodeDrive{
+			{Baynum: 1, MediaType: "sed-ssd", Present: true},
+		}},
+	})
+	assert.Len(t, status.Drives, 2)
+	assert.Equal(t, 2, status.SelfEncrypting)
+	assert.True(t, status.Encrypted())
+
+	status.Drives[0].SelfEncrypting = false
+	status.SelfEncrypting--
+	assert.False(t, status.Encrypted())
+	assert.False(t, (&EncryptionStatus{}).Encrypted())
+}
+
+func TestGetEncryptionStatus(t *testing.T) {
+	status, err := client.GetEncryptionStatus(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, status)
+	assert.NotEmpty(t, status.Drives)
+
+	node, err := client.GetNodeEncryptionStatus(defaultCtx, 1)
+	assertNoError(t, err)
+	assert.NotEmpty(t, node.Drives)
+}
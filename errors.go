@@ -0,0 +1,115 @@
+package goisilon
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// ErrMismatch is returned, wrapped with the details of the difference, by
+// the exists-ok create functions when the object that already exists does
+// not match the one that was requested.
+var ErrMismatch = errors.New("existing object does not match request")
+
+// IsNotFound returns whether err reports that the requested resource does
+// not exist.
+func IsNotFound(err error) bool {
+	return hasStatusOrCode(err, http.StatusNotFound, api.ErrNotFound)
+}
+
+// IsConflict returns whether err reports that the request conflicts with
+// the current state of the resource, for example because the resource
+// already exists.
+func IsConflict(err error) bool {
+	return hasStatusOrCode(err, http.StatusConflict, api.ErrExists, api.ErrConflict)
+}
+
+// IsForbidden returns whether err reports that the authenticated user is
+// not allowed to make the request, ex. because it lacks a privilege.
+func IsForbidden(err error) bool {
+	return hasStatusOrCode(err, http.StatusForbidden, api.ErrForbidden)
+}
+
+// ErrorCode returns the OneFS API error code of err, ex. api.ErrNotFound, or
+// an empty code if err is not an error response from the cluster or has no
+// code. Use errors.Is to test for a specific code, as a response may have
+// several.
+func ErrorCode(err error) api.ErrorCode {
+	var jerr *api.JSONError
+	if !errors.As(err, &jerr) {
+		return ""
+	}
+	return jerr.Code()
+}
+
+// IsUnsupported returns whether err reports that the cluster's OneFS release
+// does not support the endpoint that was called, ex. because the endpoint
+// was added by a later release. Releases report an unknown endpoint as not
+// found, so only a not found error for the path itself, rather than for a
+// resource under it, is unsupported.
+func IsUnsupported(err error) bool {
+	if hasStatusOrCode(
+		err, http.StatusNotImplemented, api.ErrNotImplemented) {
+		return true
+	}
+	var jerr *api.JSONError
+	if !errors.As(err, &jerr) || jerr.StatusCode != http.StatusNotFound {
+		return false
+	}
+	for _, e := range jerr.Err {
+		if strings.HasPrefix(strings.ToLower(e.Message), "path not found") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryable returns whether err is transient, such that the request that
+// caused it may succeed if it is retried. Network errors and responses
+// reporting that the cluster is busy or unavailable are retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var jerr *api.JSONError
+	if errors.As(err, &jerr) {
+		switch jerr.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var nerr net.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+func hasStatusOrCode(
+	err error, status int, codes ...api.ErrorCode) bool {
+
+	var jerr *api.JSONError
+	if !errors.As(err, &jerr) {
+		return false
+	}
+	if jerr.StatusCode == status {
+		return true
+	}
+	for _, c := range codes {
+		if errors.Is(jerr, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+func TestErrorClassification(t *testing.T) {
+	jsonErr := func(status int, code string) error {
+		return &api.JSONError{
+			StatusCode: status,
+			Err:        []api.Error{{Code: code, Message: "error"}},
+		}
+	}
+	timeoutErr := &url.Error{
+		Op:  "Get",
+		URL: "https://1.2.3.4:8080/platform/latest",
+		Err: &net.OpError{Op: "dial", Err: timeoutError{}},
+	}
+
+	tests := []struct {
+		err                                      error
+		notFound, conflict, forbidden, retryable bool
+	}{
+		{nil, false, false, false, false},
+		{jsonErr(404, "AEC_NOT_FOUND"), true, false, false, false},
+		{jsonErr(500, "AEC_NOT_FOUND"), true, false, false, false},
+		{fmt.Errorf("get quota: %w", jsonErr(404, "")), true, false, false, false},
+		{jsonErr(409, ""), false, true, false, false},
+		{jsonErr(500, "AEC_EXISTS"), false, true, false, false},
+		{jsonErr(403, ""), false, false, true, false},
+		{jsonErr(500, "AEC_FORBIDDEN"), false, false, true, false},
+		{jsonErr(503, ""), false, false, false, true},
+		{jsonErr(429, ""), false, false, false, true},
+		{jsonErr(500, ""), false, false, false, false},
+		{timeoutErr, false, false, false, true},
+		{&net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, false, false, false, true},
+		{io.ErrUnexpectedEOF, false, false, false, true},
+		{context.Canceled, false, false, false, false},
+	}
+	for i, tt := range tests {
+		assert.Equal(t, tt.notFound, IsNotFound(tt.err), "IsNotFound %d", i)
+		assert.Equal(t, tt.conflict, IsConflict(tt.err), "IsConflict %d", i)
+		assert.Equal(t, tt.forbidden, IsForbidden(tt.err), "IsForbidden %d", i)
+		assert.Equal(t, tt.retryable, IsRetryable(tt.err), "IsRetryable %d", i)
+	}
+
+	assert.Equal(t, api.ErrException, ErrorCode(
+		fmt.Errorf("create share: %w", jsonErr(500, "AEC_EXCEPTION"))))
+	assert.Equal(t, api.ErrorCode(""), ErrorCode(jsonErr(502, "")))
+	assert.Equal(t, api.ErrorCode(""), ErrorCode(io.EOF))
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsNotFound(t *testing.T) {
+	_, err := client.GetUserByName(defaultCtx, "", "test_is_not_found_no_such_user")
+	assertError(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsRetryable(err))
+}
@@ -0,0 +1,162 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// EventChannel is a channel that sends alerts, ex. by email or SNMP trap.
+type EventChannel *api.IsiEventChannel
+
+// GetEventChannels returns all event channels.
+func (c *Client) GetEventChannels(ctx context.Context) ([]EventChannel, error) {
+	channels, err := api.GetIsiEventChannels(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]EventChannel, len(channels))
+	for i, ch := range channels {
+		list[i] = ch
+	}
+	return list, nil
+}
+
+// GetEventChannel returns the event channel with the given name.
+func (c *Client) GetEventChannel(
+	ctx context.Context, name string) (EventChannel, error) {
+
+	return api.GetIsiEventChannel(ctx, c.API, name)
+}
+
+// SendTestAlert makes the event channel with the given name send a test
+// alert, to verify its delivery settings.
+func (c *Client) SendTestAlert(ctx context.Context, name string) error {
+	return api.SendIsiEventChannelTestAlert(ctx, c.API, name)
+}
+
+// SendTestAlerts makes every enabled event channel send a test alert. The
+// errors of the channels that fail are joined.
+func (c *Client) SendTestAlerts(ctx context.Context) error {
+	channels, err := api.GetIsiEventChannels(ctx, c.API)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, ch := range channels {
+		if ch.Name == nil || ch.Enabled == nil || !*ch.Enabled {
+			continue
+		}
+		err := api.SendIsiEventChannelTestAlert(ctx, c.API, *ch.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("channel %s: %w", *ch.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CreateTestEvent creates a test event with the given message. Unlike
+// SendTestAlert, the event passes through the alert rules, so it verifies
+// the whole path from event to channel.
+func (c *Client) CreateTestEvent(ctx context.Context, message string) error {
+	return api.CreateIsiTestEvent(ctx, c.API, message)
+}
+
+// MaintenanceWindow is a period during which events are recorded but not
+// alerted, ex. around planned disruptive operations.
+type MaintenanceWindow struct {
+	Start    time.Time
+	Duration time.Duration
+}
+
+// End returns the time the window ends.
+func (w *MaintenanceWindow) End() time.Time {
+	return w.Start.Add(w.Duration)
+}
+
+// IsActive returns whether the window covers the time t.
+func (w *MaintenanceWindow) IsActive(t time.Time) bool {
+	return w != nil && !t.Before(w.Start) && t.Before(w.End())
+}
+
+// GetMaintenanceWindow returns the scheduled maintenance window, or nil if
+// none is scheduled. The window may already have ended.
+func (c *Client) GetMaintenanceWindow(
+	ctx context.Context) (*MaintenanceWindow, error) {
+
+	settings, err := api.GetIsiEventSettings(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	m := settings.Maintenance
+	if m == nil || m.Start == 0 {
+		return nil, nil
+	}
+	return &MaintenanceWindow{
+		Start:    time.Unix(m.Start, 0),
+		Duration: time.Duration(m.Duration) * time.Second,
+	}, nil
+}
+
+// ScheduleMaintenanceWindow schedules a maintenance window, replacing any
+// that is scheduled. The duration is rounded up to whole seconds.
+func (c *Client) ScheduleMaintenanceWindow(
+	ctx context.Context, start time.Time, duration time.Duration) error {
+
+	if duration <= 0 {
+		return fmt.Errorf("invalid maintenance duration: %v", duration)
+	}
+	seconds := int64((duration + time.Second - 1) / time.Second)
+	return api.UpdateIsiEventSettings(ctx, c.API, &api.IsiEventSettings{
+		Maintenance: &api.IsiEventMaintenance{
+			Start:    start.Unix(),
+			Duration: seconds,
+		},
+	})
+}
+
+// ClearMaintenanceWindow removes the scheduled maintenance window, ending it
+// if it is active.
+func (c *Client) ClearMaintenanceWindow(ctx context.Context) error {
+	return api.UpdateIsiEventSettings(ctx, c.API, &api.IsiEventSettings{
+		Maintenance: &api.IsiEventMaintenance{},
+	})
+}
+
+// DuringMaintenanceWindow runs f in a maintenance window that starts now and
+// lasts at most maxDuration, so that the alerts f causes are suppressed.
+// When f returns, the window that was scheduled before is restored, or the
+// window is cleared if there was none. The error of f is returned, joined
+// with that of restoring the window.
+func (c *Client) DuringMaintenanceWindow(
+	ctx context.Context,
+	maxDuration time.Duration, f func(ctx context.Context) error) error {
+
+	prev, err := c.GetMaintenanceWindow(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.ScheduleMaintenanceWindow(
+		ctx, time.Now(), maxDuration); err != nil {
+		return err
+	}
+
+	err = f(ctx)
+
+	// restore the window even if ctx is done
+	restoreCtx := context.WithoutCancel(ctx)
+	var restoreErr error
+	if prev != nil && prev.End().After(time.Now()) {
+		restoreErr = c.ScheduleMaintenanceWindow(
+			restoreCtx, prev.Start, prev.Duration)
+	} else {
+		restoreErr = c.ClearMaintenanceWindow(restoreCtx)
+	}
+	if restoreErr != nil {
+		restoreErr = fmt.Errorf("restore maintenance window: %w", restoreErr)
+	}
+	return errors.Join(err, restoreErr)
+}
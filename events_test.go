@@ -0,0 +1,70 @@
+package goisilon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventChannels(t *testing.T) {
+	channels, err := client.GetEventChannels(defaultCtx)
+	assertNoError(t, err)
+
+	for _, ch := range channels {
+		assertNotNil(t, ch.Name)
+		channel, err := client.GetEventChannel(defaultCtx, *ch.Name)
+		assertNoError(t, err)
+		assertNotNil(t, channel)
+	}
+}
+
+func TestSendTestAlert(t *testing.T) {
+	assertError(t, client.SendTestAlert(defaultCtx, ""))
+	assertNoError(t, client.SendTestAlerts(defaultCtx))
+}
+
+func TestCreateTestEvent(t *testing.T) {
+	assertError(t, client.CreateTestEvent(defaultCtx, ""))
+	assertNoError(t, client.CreateTestEvent(defaultCtx, "goisilon test event"))
+}
+
+func TestMaintenanceWindowIsActive(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	w := &MaintenanceWindow{Start: start, Duration: time.Hour}
+	assert.Equal(t, start.Add(time.Hour), w.End())
+	assert.False(t, w.IsActive(start.Add(-time.Second)))
+	assert.True(t, w.IsActive(start))
+	assert.True(t, w.IsActive(start.Add(59*time.Minute)))
+	assert.False(t, w.IsActive(start.Add(time.Hour)))
+
+	var none *MaintenanceWindow
+	assert.False(t, none.IsActive(start))
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	prev, err := client.GetMaintenanceWindow(defaultCtx)
+	assertNoError(t, err)
+	if prev != nil && prev.End().After(time.Now()) {
+		t.Skip("a maintenance window is scheduled")
+	}
+
+	assertError(t, client.ScheduleMaintenanceWindow(defaultCtx, time.Now(), 0))
+
+	err = client.DuringMaintenanceWindow(defaultCtx, time.Hour,
+		func(ctx context.Context) error {
+			w, err := client.GetMaintenanceWindow(ctx)
+			if err != nil {
+				return err
+			}
+			assert.True(t, w.IsActive(time.Now()))
+			assert.Equal(t, time.Hour, w.Duration)
+			return nil
+		})
+	assertNoError(t, err)
+
+	w, err := client.GetMaintenanceWindow(defaultCtx)
+	assertNoError(t, err)
+	assert.Nil(t, w)
+}
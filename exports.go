@@ -17,7 +17,17 @@ type UserMapping *api.UserMapping
 
 // GetExports returns a list of all exports on the cluster
 func (c *Client) GetExports(ctx context.Context) (ExportList, error) {
-	return api.ExportsList(ctx, c.API)
+	return c.ListExports("").Collect(ctx)
+}
+
+// ListExports returns a list of the exports in an access zone that is fetched
+// a page at a time. If zone is empty the client's zone is used.
+func (c *Client) ListExports(zone string) *List[*api.Export] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*api.Export, string, error) {
+
+		return api.ExportsListPage(ctx, c.API, zone, resume)
+	})
 }
 
 // GetExportByID returns an export with the provided ID.
@@ -30,19 +40,7 @@ func (c *Client) GetExportByID(ctx context.Context, id int) (Export, error) {
 func (c *Client) GetExportByName(
 	ctx context.Context, name string) (Export, error) {
 
-	exports, err := api.ExportsList(ctx, c.API)
-	if err != nil {
-		return nil, err
-	}
-	path := c.API.VolumePath(name)
-	for _, ex := range exports {
-		for _, p := range *ex.Paths {
-			if p == path {
-				return ex, nil
-			}
-		}
-	}
-	return nil, nil
+	return c.GetExportByNameWithZone(ctx, name, "")
 }
 
 // GetExportByNameWithZone returns the first export with a path for the provided
@@ -50,19 +48,22 @@ func (c *Client) GetExportByName(
 func (c *Client) GetExportByNameWithZone(
 	ctx context.Context, name, zone string) (Export, error) {
 
-	exports, err := api.ExportsListWithZone(ctx, c.API, zone)
-	if err != nil {
-		return nil, err
-	}
 	path := c.API.VolumePath(name)
-	for _, ex := range exports {
+	ex, ok, err := c.ListExports(zone).Filter(func(ex *api.Export) bool {
+		if ex.Paths == nil {
+			return false
+		}
 		for _, p := range *ex.Paths {
 			if p == path {
-				return ex, nil
+				return true
 			}
 		}
+		return false
+	}).First(ctx)
+	if !ok {
+		return nil, err
 	}
-	return nil, nil
+	return ex, nil
 }
 
 // Export the volume with a given name on the cluster
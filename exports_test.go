@@ -24,7 +24,7 @@ func TestExportsList(t *testing.T) {
 	initialExportCount := len(exports)
 
 	var (
-		vol      Volume
+		vol      *Volume
 		exportID int
 	)
 
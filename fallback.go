@@ -0,0 +1,25 @@
+package goisilon
+
+import (
+	"context"
+)
+
+// withFallback calls newer if the cluster's API version is at least
+// minVersion, and otherwise, or if newer reports that its endpoint is
+// unsupported, calls older, so that one binary works with a fleet of
+// clusters running different OneFS releases. older must return its result
+// in the shape that newer does.
+func withFallback[T any](
+	ctx context.Context,
+	c *Client,
+	minVersion uint8,
+	newer, older func(ctx context.Context) (T, error)) (T, error) {
+
+	if c.API.APIVersion() >= minVersion {
+		v, err := newer(ctx)
+		if !IsUnsupported(err) {
+			return v, err
+		}
+	}
+	return older(ctx)
+}
@@ -0,0 +1,119 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+	apiv16 "github.com/tenortim/goisilon/api/v16"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// versionClient is an API client that reports an API version.
+type versionClient struct {
+	api.Client
+	version uint8
+}
+
+func (c *versionClient) APIVersion() uint8 { return c.version }
+
+func TestIsUnsupported(t *testing.T) {
+	jsonErr := func(status int, code, msg string) error {
+		return &api.JSONError{
+			StatusCode: status,
+			Err:        []api.Error{{Code: code, Message: msg}},
+		}
+	}
+	assert.True(t, IsUnsupported(jsonErr(404, "AEC_NOT_FOUND",
+		"Path not found: /16/supportassist/settings")))
+	assert.True(t, IsUnsupported(jsonErr(501, "", "")))
+	assert.True(t, IsUnsupported(jsonErr(400, "AEC_NOT_IMPLEMENTED", "")))
+	assert.False(t, IsUnsupported(jsonErr(404, "AEC_NOT_FOUND",
+		"License not found: Bogus")))
+	assert.False(t, IsUnsupported(errors.New("path not found")))
+	assert.False(t, IsUnsupported(nil))
+}
+
+func TestWithFallback(t *testing.T) {
+	unsupported := &api.JSONError{
+		StatusCode: http.StatusNotFound,
+		Err:        []api.Error{{Message: "Path not found: /16/x"}},
+	}
+	var calls []string
+	newer := func(err error) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) {
+			calls = append(calls, "newer")
+			return "newer", err
+		}
+	}
+	older := func(context.Context) (string, error) {
+		calls = append(calls, "older")
+		return "older", nil
+	}
+
+	tests := []struct {
+		version uint8
+		err     error
+		want    string
+		calls   []string
+	}{
+		{16, nil, "newer", []string{"newer"}},
+		{15, nil, "older", []string{"older"}},
+		{16, unsupported, "older", []string{"newer", "older"}},
+		{16, errUnavailable, "newer", []string{"newer"}},
+	}
+	for _, tt := range tests {
+		calls = nil
+		c := &Client{API: &versionClient{version: tt.version}}
+		v, err := withFallback(defaultCtx, c, 16, newer(tt.err), older)
+		assert.Equal(t, tt.want, v)
+		assert.Equal(t, tt.calls, calls)
+		if tt.err == errUnavailable {
+			assert.Equal(t, errUnavailable, err)
+		}
+	}
+}
+
+var errUnavailable = errors.New("unavailable")
+
+func TestRemoteSupportStatusShapes(t *testing.T) {
+	enabled, disabled := true, false
+	gateways := []*apiv16.IsiSupportAssistGateway{
+		{Host: "gw2", Priority: 2},
+		{Host: "off", Priority: 0, Enabled: &disabled},
+		{Host: "gw1", Priority: 1},
+	}
+	s := supportAssistStatus(&apiv16.IsiSupportAssistSettings{
+		SupportAssistEnabled: &enabled,
+		Connection: &apiv16.IsiSupportAssistConnection{
+			GatewayEndpoints: &gateways,
+		},
+	})
+	assert.Equal(t, &RemoteSupportStatus{
+		Mechanism: RemoteSupportSupportAssist,
+		Enabled:   true,
+		Gateways:  []string{"gw1", "gw2"},
+	}, s)
+
+	primary, secondary := "esrs1", ""
+	s = esrsStatus(&apiv3.IsiESRSSettings{
+		Enabled:              &disabled,
+		PrimaryESRSGateway:   &primary,
+		SecondaryESRSGateway: &secondary,
+	})
+	assert.Equal(t, &RemoteSupportStatus{
+		Mechanism: RemoteSupportESRS,
+		Gateways:  []string{"esrs1"},
+	}, s)
+}
+
+func TestGetRemoteSupportStatus(t *testing.T) {
+	status, err := client.GetRemoteSupportStatus(defaultCtx)
+	assertNoError(t, err)
+	t.Logf("mechanism=%s enabled=%v gateways=%v",
+		status.Mechanism, status.Enabled, status.Gateways)
+}
@@ -0,0 +1,83 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// FileFilterSettings are the file filtering settings of an access zone or an
+// SMB share.
+type FileFilterSettings *api.IsiFileFilterSettings
+
+// GetFileFilterSettings returns the file filtering settings of an access
+// zone. If zone is empty the client's zone is used.
+func (c *Client) GetFileFilterSettings(
+	ctx context.Context, zone string) (FileFilterSettings, error) {
+
+	return api.GetIsiFileFilterSettings(ctx, c.API, zone)
+}
+
+// UpdateFileFilterSettings modifies the file filtering settings of an access
+// zone. Fields that are nil are left unchanged.
+func (c *Client) UpdateFileFilterSettings(
+	ctx context.Context, zone string, settings FileFilterSettings) error {
+
+	return api.UpdateIsiFileFilterSettings(ctx, c.API, zone, settings)
+}
+
+// DenyFileExtensions enables file filtering in an access zone so that files
+// with the given extensions, ex. ".exe", cannot be written.
+func (c *Client) DenyFileExtensions(
+	ctx context.Context, zone string, extensions ...string) error {
+
+	return api.UpdateIsiFileFilterSettings(
+		ctx, c.API, zone, fileFilter(api.FileFilterTypeDeny, extensions))
+}
+
+// AllowOnlyFileExtensions enables file filtering in an access zone so that
+// only files with the given extensions can be written.
+func (c *Client) AllowOnlyFileExtensions(
+	ctx context.Context, zone string, extensions ...string) error {
+
+	return api.UpdateIsiFileFilterSettings(
+		ctx, c.API, zone, fileFilter(api.FileFilterTypeAllow, extensions))
+}
+
+// DisableFileFiltering disables file filtering in an access zone.
+func (c *Client) DisableFileFiltering(ctx context.Context, zone string) error {
+	enabled := false
+	return api.UpdateIsiFileFilterSettings(
+		ctx, c.API, zone, &api.IsiFileFilterSettings{Enabled: &enabled})
+}
+
+// GetSMBShareFileFilter returns the file filtering settings of an SMB share
+// in an access zone. If zone is empty the client's zone is used.
+func (c *Client) GetSMBShareFileFilter(
+	ctx context.Context, zone, share string) (FileFilterSettings, error) {
+
+	return api.GetIsiSMBShareFileFilter(ctx, c.API, zone, share)
+}
+
+// UpdateSMBShareFileFilter modifies the file filtering settings of an SMB
+// share in an access zone. Fields that are nil are left unchanged.
+func (c *Client) UpdateSMBShareFileFilter(
+	ctx context.Context,
+	zone, share string, settings FileFilterSettings) error {
+
+	return api.UpdateIsiSMBShareFileFilter(ctx, c.API, zone, share, settings)
+}
+
+func fileFilter(
+	filterType string, extensions []string) *api.IsiFileFilterSettings {
+
+	enabled := true
+	if extensions == nil {
+		extensions = []string{}
+	}
+	return &api.IsiFileFilterSettings{
+		Enabled:    &enabled,
+		Type:       &filterType,
+		Extensions: &extensions,
+	}
+}
@@ -0,0 +1,31 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileFilterSettings(t *testing.T) {
+	settings, err := client.GetFileFilterSettings(defaultCtx, "")
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+	defer client.UpdateFileFilterSettings(defaultCtx, "", settings)
+
+	assertNoError(t, client.DenyFileExtensions(defaultCtx, "", ".exe", ".bat"))
+
+	settings, err = client.GetFileFilterSettings(defaultCtx, "")
+	assertNoError(t, err)
+	assertNotNil(t, settings.Enabled)
+	assert.True(t, *settings.Enabled)
+	assertNotNil(t, settings.Type)
+	assert.Equal(t, "deny", *settings.Type)
+	assertNotNil(t, settings.Extensions)
+	assert.Equal(t, []string{".exe", ".bat"}, *settings.Extensions)
+
+	assertNoError(t, client.DisableFileFiltering(defaultCtx, ""))
+	settings, err = client.GetFileFilterSettings(defaultCtx, "")
+	assertNoError(t, err)
+	assertNotNil(t, settings.Enabled)
+	assert.False(t, *settings.Enabled)
+}
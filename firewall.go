@@ -0,0 +1,151 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v16"
+)
+
+// FirewallPolicyList is a list of firewall policies.
+type FirewallPolicyList []*api.IsiFirewallPolicy
+
+// FirewallPolicy is a firewall policy.
+type FirewallPolicy *api.IsiFirewallPolicy
+
+// FirewallRuleList is a list of firewall rules.
+type FirewallRuleList []*api.IsiFirewallRule
+
+// FirewallRule is a rule of a firewall policy.
+type FirewallRule *api.IsiFirewallRule
+
+// FirewallSettings are the cluster firewall settings.
+type FirewallSettings *api.IsiFirewallSettings
+
+// GetFirewallPolicies returns the firewall policies. Firewall policies
+// require OneFS 9.5 or later.
+func (c *Client) GetFirewallPolicies(
+	ctx context.Context) (FirewallPolicyList, error) {
+
+	return api.GetIsiFirewallPolicies(ctx, c.API)
+}
+
+// GetFirewallPolicy returns the firewall policy with the given id or name.
+func (c *Client) GetFirewallPolicy(
+	ctx context.Context, id string) (FirewallPolicy, error) {
+
+	return api.GetIsiFirewallPolicy(ctx, c.API, id)
+}
+
+// CreateFirewallPolicy creates a firewall policy and returns its ID.
+func (c *Client) CreateFirewallPolicy(
+	ctx context.Context, policy FirewallPolicy) (string, error) {
+
+	return api.CreateIsiFirewallPolicy(ctx, c.API, policy)
+}
+
+// UpdateFirewallPolicy modifies a firewall policy. Fields that are nil are
+// left unchanged.
+func (c *Client) UpdateFirewallPolicy(
+	ctx context.Context, id string, policy FirewallPolicy) error {
+
+	return api.UpdateIsiFirewallPolicy(ctx, c.API, id, policy)
+}
+
+// DeleteFirewallPolicy deletes a firewall policy.
+func (c *Client) DeleteFirewallPolicy(ctx context.Context, id string) error {
+	return api.DeleteIsiFirewallPolicy(ctx, c.API, id)
+}
+
+// GetFirewallRules returns the rules of a firewall policy.
+func (c *Client) GetFirewallRules(
+	ctx context.Context, policy string) (FirewallRuleList, error) {
+
+	return api.GetIsiFirewallRules(ctx, c.API, policy)
+}
+
+// CreateFirewallRule creates a rule of a firewall policy and returns its ID.
+func (c *Client) CreateFirewallRule(
+	ctx context.Context, policy string, rule FirewallRule) (string, error) {
+
+	return api.CreateIsiFirewallRule(ctx, c.API, policy, rule)
+}
+
+// UpdateFirewallRule modifies a rule of a firewall policy. Fields that are
+// nil are left unchanged.
+func (c *Client) UpdateFirewallRule(
+	ctx context.Context, policy, id string, rule FirewallRule) error {
+
+	return api.UpdateIsiFirewallRule(ctx, c.API, policy, id, rule)
+}
+
+// DeleteFirewallRule deletes a rule of a firewall policy.
+func (c *Client) DeleteFirewallRule(
+	ctx context.Context, policy, id string) error {
+
+	return api.DeleteIsiFirewallRule(ctx, c.API, policy, id)
+}
+
+// AssignFirewallPolicyToPool applies a firewall policy to a network pool,
+// ex. "groupnet0.subnet0.pool0", in addition to the pools it already
+// applies to.
+func (c *Client) AssignFirewallPolicyToPool(
+	ctx context.Context, policy, pool string) error {
+
+	return c.updateFirewallPolicyPools(ctx, policy, func(pools []string) []string {
+		for _, p := range pools {
+			if p == pool {
+				return nil
+			}
+		}
+		return append(pools, pool)
+	})
+}
+
+// RemoveFirewallPolicyFromPool stops applying a firewall policy to a network
+// pool.
+func (c *Client) RemoveFirewallPolicyFromPool(
+	ctx context.Context, policy, pool string) error {
+
+	return c.updateFirewallPolicyPools(ctx, policy, func(pools []string) []string {
+		for i, p := range pools {
+			if p == pool {
+				return append(pools[:i:i], pools[i+1:]...)
+			}
+		}
+		return nil
+	})
+}
+
+// updateFirewallPolicyPools replaces the pools of a policy with the result
+// of update, which returns nil if the pools are unchanged.
+func (c *Client) updateFirewallPolicyPools(
+	ctx context.Context, policy string,
+	update func(pools []string) []string) error {
+
+	p, err := api.GetIsiFirewallPolicy(ctx, c.API, policy)
+	if err != nil {
+		return err
+	}
+	var pools []string
+	if p.Pools != nil {
+		pools = *p.Pools
+	}
+	if pools = update(pools); pools == nil {
+		return nil
+	}
+	return api.UpdateIsiFirewallPolicy(
+		ctx, c.API, policy, &api.IsiFirewallPolicy{Pools: &pools})
+}
+
+// GetFirewallSettings returns the cluster firewall settings.
+func (c *Client) GetFirewallSettings(
+	ctx context.Context) (FirewallSettings, error) {
+
+	return api.GetIsiFirewallSettings(ctx, c.API)
+}
+
+// EnableFirewall enables or disables the cluster firewall.
+func (c *Client) EnableFirewall(ctx context.Context, enabled bool) error {
+	return api.UpdateIsiFirewallSettings(
+		ctx, c.API, &api.IsiFirewallSettings{Enabled: &enabled})
+}
@@ -0,0 +1,55 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v16"
+)
+
+func TestFirewallPolicy(t *testing.T) {
+	var (
+		name     = "test_firewall_policy"
+		ruleName = "test_firewall_rule"
+		action   = "allow"
+		protocol = "TCP"
+		networks = []string{"10.0.0.0/8"}
+		ports    = []string{"22"}
+		pool     = "groupnet0.subnet0.pool0"
+	)
+
+	id, err := client.CreateFirewallPolicy(
+		defaultCtx, &api.IsiFirewallPolicy{Name: &name})
+	assertNoError(t, err)
+	defer client.DeleteFirewallPolicy(defaultCtx, id)
+
+	ruleID, err := client.CreateFirewallRule(defaultCtx, id,
+		&api.IsiFirewallRule{
+			Name:        &ruleName,
+			Action:      &action,
+			Protocol:    &protocol,
+			SrcNetworks: &networks,
+			DstPorts:    &ports,
+		})
+	assertNoError(t, err)
+	defer client.DeleteFirewallRule(defaultCtx, id, ruleID)
+
+	rules, err := client.GetFirewallRules(defaultCtx, id)
+	assertNoError(t, err)
+	assert.Len(t, rules, 1)
+
+	assertNoError(t, client.AssignFirewallPolicyToPool(defaultCtx, id, pool))
+	assertNoError(t, client.AssignFirewallPolicyToPool(defaultCtx, id, pool))
+	policy, err := client.GetFirewallPolicy(defaultCtx, id)
+	assertNoError(t, err)
+	assertNotNil(t, policy.Pools)
+	assert.Equal(t, []string{pool}, *policy.Pools)
+
+	assertNoError(t, client.RemoveFirewallPolicyFromPool(defaultCtx, id, pool))
+	policy, err = client.GetFirewallPolicy(defaultCtx, id)
+	assertNoError(t, err)
+	if policy.Pools != nil {
+		assert.Empty(t, *policy.Pools)
+	}
+}
@@ -0,0 +1,137 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// FSAResult is the result of an FSAnalyze job.
+type FSAResult *api.IsiFSAResult
+
+// FSADirectory is the usage of a directory in an FSAnalyze result.
+type FSADirectory *api.IsiFSADirectory
+
+// JobTypeFSAnalyze is the type of the job that gathers file system
+// analytics.
+const JobTypeFSAnalyze = "FSAnalyze"
+
+// GetFSAResults returns the FSAnalyze results.
+func (c *Client) GetFSAResults(ctx context.Context) ([]FSAResult, error) {
+	results, err := api.GetIsiFSAResults(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]FSAResult, len(results))
+	for i, r := range results {
+		res[i] = r
+	}
+	return res, nil
+}
+
+// GetFSAResult returns an FSAnalyze result by id.
+func (c *Client) GetFSAResult(
+	ctx context.Context, id int64) (FSAResult, error) {
+
+	return api.GetIsiFSAResult(ctx, c.API, id)
+}
+
+// GetLatestFSAResult returns the most recent FSAnalyze result that is
+// available, or nil if there is none.
+func (c *Client) GetLatestFSAResult(ctx context.Context) (FSAResult, error) {
+	results, err := api.GetIsiFSAResults(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	var latest *api.IsiFSAResult
+	for _, r := range results {
+		if r.Status != api.FSAResultStatusAvailable {
+			continue
+		}
+		if latest == nil || r.EndTime > latest.EndTime {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+// RunFSAnalyze runs an FSAnalyze job, waits for it to finish and returns its
+// result. The job's progress is reported to the context's Progress.
+func (c *Client) RunFSAnalyze(ctx context.Context) (FSAResult, error) {
+	job, err := c.RunJob(ctx, &api.IsiJobReq{Type: JobTypeFSAnalyze})
+	if err != nil {
+		return nil, err
+	}
+	results, err := api.GetIsiFSAResults(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if r.JobID == job.ID {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("no fsa result for job %d", job.ID)
+}
+
+// GetFSADirectories returns the usage of the child directories of a
+// directory, ex. "/ifs/data", in an FSAnalyze result.
+func (c *Client) GetFSADirectories(
+	ctx context.Context, id int64, dirPath string) ([]FSADirectory, error) {
+
+	dirs, err := api.GetIsiFSADirectories(ctx, c.API, id, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]FSADirectory, len(dirs))
+	for i, d := range dirs {
+		if d.Path == "" && d.Name != "" {
+			d.Path = path.Join(dirPath, d.Name)
+		}
+		res[i] = d
+	}
+	return res, nil
+}
+
+// WalkFSADirectories calls f for each directory under a directory, ex.
+// "/ifs/data", in an FSAnalyze result, parents before their children, down
+// to maxDepth levels below it; a maxDepth of zero means no limit. If f
+// returns false the directory's children are skipped.
+func (c *Client) WalkFSADirectories(
+	ctx context.Context, id int64, dirPath string, maxDepth int,
+	f func(dir FSADirectory, depth int) bool) error {
+
+	if f == nil {
+		return errors.New("no walk function set")
+	}
+
+	var walk func(dirPath string, depth int) error
+	walk = func(dirPath string, depth int) error {
+		dirs, err := c.GetFSADirectories(ctx, id, dirPath)
+		if err != nil {
+			return err
+		}
+		for _, d := range dirs {
+			if !f(d, depth) {
+				continue
+			}
+			if maxDepth > 0 && depth >= maxDepth {
+				continue
+			}
+			if d.DirCount == 0 {
+				continue
+			}
+			if err := walk(d.Path, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(dirPath, 1)
+}
@@ -0,0 +1,35 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFSAResults(t *testing.T) {
+	result, err := client.GetLatestFSAResult(defaultCtx)
+	assertNoError(t, err)
+	if result == nil {
+		t.Skip("no fsa result available")
+	}
+
+	r, err := client.GetFSAResult(defaultCtx, result.ID)
+	assertNoError(t, err)
+	assert.Equal(t, result.ID, r.ID)
+
+	dirs, err := client.GetFSADirectories(defaultCtx, result.ID, "/ifs")
+	assertNoError(t, err)
+	for _, d := range dirs {
+		assert.True(t, isUnderPath(d.Path, "/ifs"))
+	}
+
+	var count int
+	err = client.WalkFSADirectories(defaultCtx, result.ID, "/ifs", 2,
+		func(d FSADirectory, depth int) bool {
+			assert.True(t, depth >= 1 && depth <= 2)
+			count++
+			return true
+		})
+	assertNoError(t, err)
+	assert.True(t, count >= len(dirs))
+}
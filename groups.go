@@ -0,0 +1,98 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// GroupList is a list of groups.
+type GroupList []*api.IsiGroup
+
+// Group is a group known to an authentication provider.
+type Group *api.IsiGroup
+
+// GroupMemberList is a list of group members.
+type GroupMemberList []*apiv2.Persona
+
+// GetGroups returns the groups in an access zone. If zone is empty the
+// client's zone is used.
+func (c *Client) GetGroups(
+	ctx context.Context, zone string) (GroupList, error) {
+
+	return api.GetIsiGroups(ctx, c.API, zone)
+}
+
+// GetGroupByName returns the group in an access zone with the given name.
+func (c *Client) GetGroupByName(
+	ctx context.Context, zone, name string) (Group, error) {
+
+	return api.GetIsiGroup(ctx, c.API, zone, name)
+}
+
+// GetGroupByGID returns the group in an access zone with the given GID.
+func (c *Client) GetGroupByGID(
+	ctx context.Context, zone string, gid int) (Group, error) {
+
+	return api.GetIsiGroup(ctx, c.API, zone, "GID:"+strconv.Itoa(gid))
+}
+
+// CreateGroup creates a group with the local provider of an access zone and
+// returns its ID. If gid is zero the cluster assigns the next available GID.
+func (c *Client) CreateGroup(
+	ctx context.Context, zone, name string, gid int) (string, error) {
+
+	group := &api.IsiGroupReq{Name: &name}
+	if gid != 0 {
+		group.GID = &gid
+	}
+	return api.CreateIsiGroup(ctx, c.API, zone, group)
+}
+
+// DeleteGroup removes a group from an access zone.
+func (c *Client) DeleteGroup(ctx context.Context, zone, name string) error {
+	return api.DeleteIsiGroup(ctx, c.API, zone, name)
+}
+
+// GetGroupMembers returns the members of a group in an access zone.
+func (c *Client) GetGroupMembers(
+	ctx context.Context, zone, group string) (GroupMemberList, error) {
+
+	return api.GetIsiGroupMembers(ctx, c.API, zone, group)
+}
+
+// AddUserToGroup adds the user with the given name to a group in an access
+// zone.
+func (c *Client) AddUserToGroup(
+	ctx context.Context, zone, group, user string) error {
+
+	u, err := c.GetUserByName(ctx, zone, user)
+	if err != nil {
+		return err
+	}
+	if u.SID == nil || u.SID.ID == nil {
+		return errors.New("user SID missing from response")
+	}
+	return api.AddIsiGroupMember(
+		ctx, c.API, zone, group, &apiv2.Persona{ID: u.SID.ID})
+}
+
+// RemoveUserFromGroup removes the user with the given name from a group in an
+// access zone.
+func (c *Client) RemoveUserFromGroup(
+	ctx context.Context, zone, group, user string) error {
+
+	u, err := c.GetUserByName(ctx, zone, user)
+	if err != nil {
+		return err
+	}
+	if u.SID == nil || u.SID.ID == nil {
+		return errors.New("user SID missing from response")
+	}
+	return api.RemoveIsiGroupMember(
+		ctx, c.API, zone, group,
+		apiv2.PersonaIDTypeSID.String()+":"+u.SID.ID.ID)
+}
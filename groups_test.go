@@ -0,0 +1,36 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGroupMembership(t *testing.T) {
+	groupName := "test_goisilon_group"
+	userName := "test_goisilon_group_user"
+
+	_, err := client.CreateGroup(defaultCtx, "", groupName, 0)
+	assertNoError(t, err)
+	defer client.DeleteGroup(defaultCtx, "", groupName)
+
+	_, err = client.CreateUser(defaultCtx, "", userName, 0, "", "", "")
+	assertNoError(t, err)
+	defer client.DeleteUser(defaultCtx, "", userName)
+
+	err = client.AddUserToGroup(defaultCtx, "", groupName, userName)
+	assertNoError(t, err)
+
+	members, err := client.GetGroupMembers(defaultCtx, "", groupName)
+	assertNoError(t, err)
+	if len(members) != 1 {
+		t.Fatalf("expected 1 group member, got %d", len(members))
+	}
+
+	err = client.RemoveUserFromGroup(defaultCtx, "", groupName, userName)
+	assertNoError(t, err)
+
+	members, err = client.GetGroupMembers(defaultCtx, "", groupName)
+	assertNoError(t, err)
+	if len(members) != 0 {
+		t.Fatalf("expected no group members, got %d", len(members))
+	}
+}
@@ -0,0 +1,62 @@
+package goisilon
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/tenortim/goisilon/api/v5"
+)
+
+// HardeningProfileSTIG is the DISA STIG hardening profile.
+const HardeningProfileSTIG = "STIG"
+
+// HardeningState is the state of the hardening profile on the cluster.
+type HardeningState *api.IsiHardeningState
+
+// HardeningStatus is the per-node hardening compliance of the cluster.
+type HardeningStatus *api.IsiHardeningStatus
+
+// HardeningProfiles returns the hardening profiles that can be applied.
+// OneFS has no API to list them, so these are the profiles it ships with.
+func HardeningProfiles() []string {
+	return []string{HardeningProfileSTIG}
+}
+
+// ApplyHardening applies a hardening profile to the cluster and returns the
+// cluster's report of the changes. Applying the STIG profile restricts
+// access to the cluster, including disabling root logins.
+func (c *Client) ApplyHardening(
+	ctx context.Context, profile string) (string, error) {
+
+	return api.ApplyIsiHardening(ctx, c.API, profile)
+}
+
+// RevertHardening reverts the hardening profile applied to the cluster and
+// returns the cluster's report of the changes.
+func (c *Client) RevertHardening(ctx context.Context) (string, error) {
+	return api.RevertIsiHardening(ctx, c.API)
+}
+
+// GetHardeningState returns the state of the hardening profile.
+func (c *Client) GetHardeningState(
+	ctx context.Context) (HardeningState, error) {
+
+	return api.GetIsiHardeningState(ctx, c.API)
+}
+
+// GetHardeningStatus returns the hardening compliance of the nodes.
+func (c *Client) GetHardeningStatus(
+	ctx context.Context) (HardeningStatus, error) {
+
+	return api.GetIsiHardeningStatus(ctx, c.API)
+}
+
+// IsHardened returns whether a hardening profile is applied to the cluster.
+func (c *Client) IsHardened(ctx context.Context, profile string) (bool, error) {
+	state, err := c.GetHardeningState(ctx)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(state.Profile, profile) &&
+		strings.EqualFold(state.State, "applied"), nil
+}
@@ -0,0 +1,22 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardeningState(t *testing.T) {
+	assert.Contains(t, HardeningProfiles(), HardeningProfileSTIG)
+
+	state, err := client.GetHardeningState(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, state)
+
+	status, err := client.GetHardeningStatus(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, status)
+
+	_, err = client.IsHardened(defaultCtx, HardeningProfileSTIG)
+	assertNoError(t, err)
+}
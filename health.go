@@ -0,0 +1,150 @@
+package goisilon
+
+import (
+	"context"
+	"net"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// PingResult is the result of a successful Ping.
+type PingResult struct {
+
+	// Latency is the round-trip time of the request.
+	Latency time.Duration
+
+	// Node is the address of the node that responded, which identifies the
+	// node that SmartConnect balanced the request to.
+	Node string
+}
+
+// Ping makes a cheap authenticated request to verify that the cluster is
+// reachable and the client's credentials are accepted, and returns the
+// request's round-trip latency and the node that responded.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	var node string
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			node = info.Conn.RemoteAddr().String()
+		},
+	})
+
+	start := time.Now()
+	if _, err := api.GetIsiAuthID(ctx, c.API); err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		node = host
+	}
+	return &PingResult{Latency: latency, Node: node}, nil
+}
+
+// HealthCheckOptions configure a HealthChecker.
+type HealthCheckOptions struct {
+
+	// Interval is the time between pings. It defaults to 30 seconds.
+	Interval time.Duration
+
+	// Timeout is the time limit of a ping. It defaults to the interval.
+	Timeout time.Duration
+
+	// Failures is the number of consecutive failed pings after which the
+	// cluster is considered unhealthy. It defaults to 1.
+	Failures int
+}
+
+// HealthChecker pings the cluster in the background so that callers, ex.
+// controllers, can cheaply check whether it is reachable before doing work.
+// The cluster is considered unhealthy until the first ping succeeds.
+type HealthChecker struct {
+	watcher *Watcher[*PingResult]
+	healthy atomic.Bool
+	done    chan struct{}
+
+	mu       sync.Mutex
+	last     *PingResult
+	lastErr  error
+	failures int
+}
+
+// NewHealthChecker starts a HealthChecker that pings the cluster until ctx
+// is done or Stop is called.
+func (c *Client) NewHealthChecker(
+	ctx context.Context, opts *HealthCheckOptions) *HealthChecker {
+
+	return newHealthChecker(ctx, opts, c.Ping)
+}
+
+func newHealthChecker(
+	ctx context.Context,
+	opts *HealthCheckOptions,
+	ping func(ctx context.Context) (*PingResult, error)) *HealthChecker {
+
+	interval, failures := 30*time.Second, 1
+	if opts != nil && opts.Interval > 0 {
+		interval = opts.Interval
+	}
+	if opts != nil && opts.Failures > 0 {
+		failures = opts.Failures
+	}
+	timeout := interval
+	if opts != nil && opts.Timeout > 0 {
+		timeout = opts.Timeout
+	}
+
+	h := &HealthChecker{done: make(chan struct{})}
+	h.watcher = NewWatcher(ctx, &WatchOptions{Interval: interval},
+		func(ctx context.Context) (*PingResult, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return ping(ctx)
+		})
+	go func() {
+		defer close(h.done)
+		for s := range h.watcher.C() {
+			h.record(s.Value, s.Err, failures)
+		}
+	}()
+	return h
+}
+
+// record updates the health from the result of a ping.
+func (h *HealthChecker) record(res *PingResult, err error, failures int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lastErr = err
+	if err != nil {
+		if h.failures++; h.failures >= failures {
+			h.healthy.Store(false)
+		}
+		return
+	}
+	h.last, h.failures = res, 0
+	h.healthy.Store(true)
+}
+
+// Healthy returns whether the cluster was reachable when it was last
+// pinged.
+func (h *HealthChecker) Healthy() bool {
+	return h.healthy.Load()
+}
+
+// Last returns the result of the last successful ping, or nil if no ping
+// has succeeded, and the error of the last ping.
+func (h *HealthChecker) Last() (*PingResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.last, h.lastErr
+}
+
+// Stop stops pinging the cluster. The health is no longer updated.
+func (h *HealthChecker) Stop() {
+	h.watcher.Stop()
+	<-h.done
+}
@@ -0,0 +1,90 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+func TestPingServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch strings.TrimSuffix(r.URL.Path, "/") {
+			case "/platform/latest":
+				w.Write([]byte(`{"latest":"9"}`))
+			case "/platform/3/auth/id":
+				w.Write([]byte(`{"ntoken":{"zone_name":"System"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	apiClient, err := api.New(
+		defaultCtx, srv.URL, "user", "password", "", nil)
+	assertNoError(t, err)
+	res, err := (&Client{apiClient}).Ping(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, "127.0.0.1", res.Node)
+	assert.True(t, res.Latency > 0)
+}
+
+func TestHealthCheckerRecord(t *testing.T) {
+	var (
+		h    HealthChecker
+		down = errors.New("down")
+		up   = &PingResult{Node: "1.2.3.4"}
+	)
+	tests := []struct {
+		res     *PingResult
+		err     error
+		healthy bool
+	}{
+		{nil, down, false},
+		{up, nil, true},
+		{nil, down, true},
+		{nil, down, false},
+		{nil, down, false},
+		{up, nil, true},
+	}
+	for i, tt := range tests {
+		h.record(tt.res, tt.err, 2)
+		assert.Equal(t, tt.healthy, h.Healthy(), "%d", i)
+	}
+	last, err := h.Last()
+	assertNoError(t, err)
+	assert.Equal(t, up, last)
+}
+
+func TestHealthChecker(t *testing.T) {
+	h := newHealthChecker(defaultCtx, &HealthCheckOptions{Interval: time.Hour},
+		func(context.Context) (*PingResult, error) {
+			return &PingResult{Node: "1.2.3.4"}, nil
+		})
+	defer h.Stop()
+	for deadline := time.Now().Add(5 * time.Second); !h.Healthy(); {
+		if time.Now().After(deadline) {
+			t.Fatal("health checker did not become healthy")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	last, err := h.Last()
+	assertNoError(t, err)
+	assert.Equal(t, "1.2.3.4", last.Node)
+}
+
+func TestPing(t *testing.T) {
+	res, err := client.Ping(defaultCtx)
+	assertNoError(t, err)
+	assert.NotEmpty(t, res.Node)
+	assert.True(t, res.Latency > 0)
+}
@@ -0,0 +1,212 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// Job is a job engine job.
+type Job *api.IsiJob
+
+// Job engine job states.
+const (
+	JobStateRunning         = "running"
+	JobStatePausedUser      = "paused_user"
+	JobStatePausedSystem    = "paused_system"
+	JobStatePausedPolicy    = "paused_policy"
+	JobStatePausedPriority  = "paused_priority"
+	JobStateCancelledUser   = "cancelled_user"
+	JobStateCancelledSystem = "cancelled_system"
+	JobStateFailed          = "failed"
+	JobStateSucceeded       = "succeeded"
+	JobStateUnknown         = "unknown"
+)
+
+// Job engine job types.
+const (
+	JobTypeSmartPools     = "SmartPools"
+	JobTypeSmartPoolsTree = "SmartPoolsTree"
+	JobTypeSetProtectPlus = "SetProtectPlus"
+	JobTypeTreeDelete     = "TreeDelete"
+)
+
+// Job impact levels of the intervals of a job impact policy.
+const (
+	JobImpactLow    = "Low"
+	JobImpactMedium = "Medium"
+	JobImpactHigh   = "High"
+	JobImpactPaused = "Paused"
+)
+
+// The system job impact policies.
+const (
+	JobPolicyLow      = "LOW"
+	JobPolicyMedium   = "MEDIUM"
+	JobPolicyHigh     = "HIGH"
+	JobPolicyOffHours = "OFF_HOURS"
+)
+
+// JobImpactPolicy is a job impact policy.
+type JobImpactPolicy *api.IsiJobImpactPolicy
+
+// licenseSmartPools is the name of the SmartPools license.
+const licenseSmartPools = "SmartPools"
+
+// GetJob returns a job engine job by id.
+func (c *Client) GetJob(ctx context.Context, id int64) (Job, error) {
+	return api.GetIsiJob(ctx, c.API, id)
+}
+
+// IsJobFinished returns whether a job is in a terminal state.
+func IsJobFinished(job Job) bool {
+	if job == nil {
+		return false
+	}
+	switch job.State {
+	case JobStateSucceeded, JobStateFailed,
+		JobStateCancelledUser, JobStateCancelledSystem:
+		return true
+	}
+	return false
+}
+
+type jobPolicyKey struct{}
+
+// ContextWithJobPolicy returns a copy of ctx that runs the jobs started by
+// the helpers it is passed to, ex. TreeDeleteVolume, with an impact policy,
+// ex. JobPolicyLow, unless the job's request sets one.
+func ContextWithJobPolicy(ctx context.Context, policy string) context.Context {
+	return context.WithValue(ctx, jobPolicyKey{}, policy)
+}
+
+// jobPolicyFrom returns the job impact policy of a context, which is empty
+// if none is set.
+func jobPolicyFrom(ctx context.Context) string {
+	policy, _ := ctx.Value(jobPolicyKey{}).(string)
+	return policy
+}
+
+// StartJob starts a job engine job and returns its id. If the request does
+// not set an impact policy the context's policy is used; see
+// ContextWithJobPolicy.
+func (c *Client) StartJob(
+	ctx context.Context, req *api.IsiJobReq) (int64, error) {
+
+	if req != nil && req.Policy == "" {
+		if policy := jobPolicyFrom(ctx); policy != "" {
+			r := *req
+			r.Policy = policy
+			req = &r
+		}
+	}
+	return api.StartIsiJob(ctx, c.API, req)
+}
+
+// SetJobImpactPolicy changes the impact policy of a job that is running or
+// waiting to run.
+func (c *Client) SetJobImpactPolicy(
+	ctx context.Context, id int64, policy string) error {
+
+	return api.UpdateIsiJob(
+		ctx, c.API, id, &api.IsiJobUpdateReq{Policy: &policy})
+}
+
+// GetJobImpactPolicies returns the job impact policies, including the
+// system policies.
+func (c *Client) GetJobImpactPolicies(
+	ctx context.Context) ([]JobImpactPolicy, error) {
+
+	policies, err := api.GetIsiJobImpactPolicies(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]JobImpactPolicy, len(policies))
+	for i, p := range policies {
+		res[i] = p
+	}
+	return res, nil
+}
+
+// GetJobImpactPolicy returns a job impact policy by id, which is its name.
+func (c *Client) GetJobImpactPolicy(
+	ctx context.Context, id string) (JobImpactPolicy, error) {
+
+	return api.GetIsiJobImpactPolicy(ctx, c.API, id)
+}
+
+// CreateJobImpactPolicy creates a job impact policy that runs jobs at the
+// impact levels of its intervals, and returns its id.
+func (c *Client) CreateJobImpactPolicy(
+	ctx context.Context,
+	name, description string,
+	intervals ...*api.IsiJobImpactInterval) (string, error) {
+
+	policy := &api.IsiJobImpactPolicy{Name: &name, Intervals: &intervals}
+	if description != "" {
+		policy.Description = &description
+	}
+	return api.CreateIsiJobImpactPolicy(ctx, c.API, policy)
+}
+
+// UpdateJobImpactPolicy modifies a job impact policy. Fields that are nil
+// are left unchanged.
+func (c *Client) UpdateJobImpactPolicy(
+	ctx context.Context, id string, policy JobImpactPolicy) error {
+
+	return api.UpdateIsiJobImpactPolicy(ctx, c.API, id, policy)
+}
+
+// DeleteJobImpactPolicy deletes a job impact policy.
+func (c *Client) DeleteJobImpactPolicy(ctx context.Context, id string) error {
+	return api.DeleteIsiJobImpactPolicy(ctx, c.API, id)
+}
+
+// RunJob starts a job engine job and waits for it to finish. An error is
+// returned if the job does not succeed. The job's progress is reported to
+// the context's Progress.
+func (c *Client) RunJob(ctx context.Context, req *api.IsiJobReq) (Job, error) {
+	id, err := c.StartJob(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	job, err := c.WaitForJobCompletion(ctx, id)
+	if err != nil {
+		return job, err
+	}
+	if job.State != JobStateSucceeded && IsJobFinished(job) {
+		return job, fmt.Errorf("%s job %d %s", req.Type, id, job.State)
+	}
+	return job, nil
+}
+
+// ApplyFilePoolPolicies applies the file pool policies and requested
+// protection to the existing files on the cluster, which otherwise happens
+// at the next scheduled run, and waits for it to finish. It runs a
+// SmartPools job if SmartPools is licensed, and otherwise a SetProtectPlus
+// job, which applies only the default policy.
+func (c *Client) ApplyFilePoolPolicies(ctx context.Context) (Job, error) {
+	licensed, err := c.IsLicensed(ctx, licenseSmartPools)
+	if err != nil {
+		return nil, err
+	}
+	jobType := JobTypeSetProtectPlus
+	if licensed {
+		jobType = JobTypeSmartPools
+	}
+	return c.RunJob(ctx, &api.IsiJobReq{Type: jobType})
+}
+
+// ApplyVolumeProtection applies the file pool policies, such as the one set
+// by SetVolumeProtection, to the existing files in a volume with a
+// SmartPoolsTree job, rather than to the whole cluster, and waits for it to
+// finish.
+func (c *Client) ApplyVolumeProtection(
+	ctx context.Context, name string) (Job, error) {
+
+	return c.RunJob(ctx, &api.IsiJobReq{
+		Type:  JobTypeSmartPoolsTree,
+		Paths: []string{c.API.VolumePath(name)},
+	})
+}
@@ -0,0 +1,48 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestContextWithJobPolicy(t *testing.T) {
+	assert.Equal(t, "", jobPolicyFrom(defaultCtx))
+	ctx := ContextWithJobPolicy(defaultCtx, JobPolicyLow)
+	assert.Equal(t, JobPolicyLow, jobPolicyFrom(ctx))
+}
+
+func TestJobImpactPolicies(t *testing.T) {
+	policies, err := client.GetJobImpactPolicies(defaultCtx)
+	assertNoError(t, err)
+	var names []string
+	for _, p := range policies {
+		names = append(names, *p.Name)
+	}
+	assert.Contains(t, names, JobPolicyLow)
+
+	id, err := client.CreateJobImpactPolicy(
+		defaultCtx, "test_job_impact_policy", "business hours",
+		&apiv3.IsiJobImpactInterval{
+			Begin: "Monday 08:00", End: "Friday 18:00", Impact: JobImpactLow,
+		},
+		&apiv3.IsiJobImpactInterval{
+			Begin: "Friday 18:00", End: "Monday 08:00", Impact: JobImpactHigh,
+		})
+	assertNoError(t, err)
+	defer client.DeleteJobImpactPolicy(defaultCtx, id)
+
+	description := "business hours only"
+	assertNoError(t, client.UpdateJobImpactPolicy(defaultCtx, id,
+		&apiv3.IsiJobImpactPolicy{Description: &description}))
+
+	policy, err := client.GetJobImpactPolicy(defaultCtx, id)
+	assertNoError(t, err)
+	assert.Equal(t, description, *policy.Description)
+	assertLen(t, *policy.Intervals, 2)
+
+	assertNoError(t, client.DeleteJobImpactPolicy(defaultCtx, id))
+	_, err = client.GetJobImpactPolicy(defaultCtx, id)
+	assert.True(t, IsNotFound(err))
+}
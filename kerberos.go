@@ -0,0 +1,144 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// KerberosRealmList is a list of Kerberos realm definitions.
+type KerberosRealmList []*api.IsiKrb5Realm
+
+// KerberosDomainList is a list of Kerberos domain mappings.
+type KerberosDomainList []*api.IsiKrb5Domain
+
+// KerberosProviderList is a list of Kerberos providers.
+type KerberosProviderList []*api.IsiKrb5Provider
+
+// KerberosProvider is a Kerberos authentication provider.
+type KerberosProvider *api.IsiKrb5Provider
+
+// GetKerberosRealms returns the Kerberos realm definitions.
+func (c *Client) GetKerberosRealms(
+	ctx context.Context) (KerberosRealmList, error) {
+
+	return api.GetIsiKrb5Realms(ctx, c.API)
+}
+
+// CreateKerberosRealm defines a Kerberos realm served by the given KDCs and
+// returns its ID.
+func (c *Client) CreateKerberosRealm(
+	ctx context.Context,
+	realm, adminServer string, kdcs ...string) (string, error) {
+
+	r := &api.IsiKrb5Realm{Realm: &realm, KDC: &kdcs}
+	if adminServer != "" {
+		r.AdminServer = &adminServer
+	}
+	return api.CreateIsiKrb5Realm(ctx, c.API, r)
+}
+
+// DeleteKerberosRealm removes a Kerberos realm definition.
+func (c *Client) DeleteKerberosRealm(ctx context.Context, id string) error {
+	return api.DeleteIsiKrb5Realm(ctx, c.API, id)
+}
+
+// GetKerberosDomains returns the Kerberos domain mappings.
+func (c *Client) GetKerberosDomains(
+	ctx context.Context) (KerberosDomainList, error) {
+
+	return api.GetIsiKrb5Domains(ctx, c.API)
+}
+
+// CreateKerberosDomain maps a DNS domain to a Kerberos realm and returns the
+// ID of the mapping.
+func (c *Client) CreateKerberosDomain(
+	ctx context.Context, domain, realm string) (string, error) {
+
+	return api.CreateIsiKrb5Domain(
+		ctx, c.API, &api.IsiKrb5Domain{Domain: &domain, Realm: &realm})
+}
+
+// DeleteKerberosDomain removes a Kerberos domain mapping.
+func (c *Client) DeleteKerberosDomain(ctx context.Context, id string) error {
+	return api.DeleteIsiKrb5Domain(ctx, c.API, id)
+}
+
+// GetKerberosProviders returns the Kerberos providers.
+func (c *Client) GetKerberosProviders(
+	ctx context.Context) (KerberosProviderList, error) {
+
+	return api.GetIsiKrb5Providers(ctx, c.API)
+}
+
+// GetKerberosProvider returns the Kerberos provider for a realm.
+func (c *Client) GetKerberosProvider(
+	ctx context.Context, realm string) (KerberosProvider, error) {
+
+	return api.GetIsiKrb5Provider(ctx, c.API, realm)
+}
+
+// JoinKerberosRealm creates a Kerberos provider by joining a realm with the
+// credentials of a realm administrator, so no keytab file is required, and
+// returns the provider's ID.
+func (c *Client) JoinKerberosRealm(
+	ctx context.Context, realm, user, password string) (string, error) {
+
+	return api.JoinIsiKrb5Realm(
+		ctx, c.API,
+		&api.IsiKrb5JoinReq{Realm: realm, User: &user, Password: &password})
+}
+
+// LeaveKerberosRealm removes the Kerberos provider for a realm.
+func (c *Client) LeaveKerberosRealm(ctx context.Context, realm string) error {
+	return api.DeleteIsiKrb5Provider(ctx, c.API, realm)
+}
+
+// GetKerberosSPNs returns the service principal names registered for the
+// Kerberos provider of a realm.
+func (c *Client) GetKerberosSPNs(
+	ctx context.Context, realm string) ([]string, error) {
+
+	provider, err := c.GetKerberosProvider(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+	if provider.SPNs == nil {
+		return nil, nil
+	}
+	return *provider.SPNs, nil
+}
+
+// RepairKerberosSPNs registers any of the expected service principal names,
+// such as "nfs/host.example.com", that are missing from the Kerberos provider
+// of a realm, and returns the names that were added.
+func (c *Client) RepairKerberosSPNs(
+	ctx context.Context, realm string, expected ...string) ([]string, error) {
+
+	spns, err := c.GetKerberosSPNs(ctx, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := map[string]bool{}
+	for _, s := range spns {
+		registered[s] = true
+	}
+	var missing []string
+	for _, s := range expected {
+		if !registered[s] {
+			registered[s] = true
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	spns = append(spns, missing...)
+	if err := api.UpdateIsiKrb5Provider(
+		ctx, c.API, realm, &api.IsiKrb5Provider{SPNs: &spns}); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
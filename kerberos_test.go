@@ -0,0 +1,41 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestKerberosRealmAndDomain(t *testing.T) {
+	realm := "GOISILON.TEST"
+
+	realmID, err := client.CreateKerberosRealm(
+		defaultCtx, realm, "", "kdc.goisilon.test")
+	assertNoError(t, err)
+	defer client.DeleteKerberosRealm(defaultCtx, realmID)
+
+	domainID, err := client.CreateKerberosDomain(
+		defaultCtx, ".goisilon.test", realm)
+	assertNoError(t, err)
+	defer client.DeleteKerberosDomain(defaultCtx, domainID)
+
+	realms, err := client.GetKerberosRealms(defaultCtx)
+	assertNoError(t, err)
+	found := false
+	for _, r := range realms {
+		if r.Realm != nil && *r.Realm == realm {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("realm %s missing from realm list", realm)
+	}
+}
+
+func TestGetKerberosProviders(t *testing.T) {
+	providers, err := client.GetKerberosProviders(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range providers {
+		spns, err := client.GetKerberosSPNs(defaultCtx, p.Realm)
+		assertNoError(t, err)
+		t.Logf("realm=%s status=%s spns=%v", p.Realm, p.Status, spns)
+	}
+}
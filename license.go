@@ -0,0 +1,111 @@
+package goisilon
+
+import (
+	"context"
+	"strings"
+
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+	api "github.com/tenortim/goisilon/api/v5"
+)
+
+// LicenseList is a list of OneFS feature licenses.
+type LicenseList []*api.IsiLicense
+
+// License is a OneFS feature license.
+type License *api.IsiLicense
+
+// GetLicenses returns the cluster's licenses and their status. Releases
+// before OneFS 8.1 are queried with the version 1 license API.
+func (c *Client) GetLicenses(ctx context.Context) (LicenseList, error) {
+	licenses, err := withFallback(ctx, c, 5,
+		func(ctx context.Context) ([]*api.IsiLicense, error) {
+			return api.GetIsiLicenses(ctx, c.API)
+		},
+		func(ctx context.Context) ([]*api.IsiLicense, error) {
+			v1, err := apiv1.GetIsiLicenses(ctx, c.API)
+			if err != nil {
+				return nil, err
+			}
+			licenses := make([]*api.IsiLicense, len(v1))
+			for i, l := range v1 {
+				licenses[i] = licenseFromV1(l)
+			}
+			return licenses, nil
+		})
+	return licenses, err
+}
+
+// GetLicense returns the license for the named feature. Releases before
+// OneFS 8.1 are queried with the version 1 license API.
+func (c *Client) GetLicense(ctx context.Context, name string) (License, error) {
+	return withFallback(ctx, c, 5,
+		func(ctx context.Context) (*api.IsiLicense, error) {
+			return api.GetIsiLicense(ctx, c.API, name)
+		},
+		func(ctx context.Context) (*api.IsiLicense, error) {
+			l, err := apiv1.GetIsiLicense(ctx, c.API, name)
+			if err != nil {
+				return nil, err
+			}
+			return licenseFromV1(l), nil
+		})
+}
+
+// licenseFromV1 converts a license from the version 1 API, which reports
+// its duration in days, to the current shape.
+func licenseFromV1(l *apiv1.IsiLicense) *api.IsiLicense {
+	return &api.IsiLicense{
+		ID:         l.ID,
+		Name:       l.Name,
+		Status:     l.Status,
+		Expiration: l.Expiration,
+		Days:       l.Duration,
+	}
+}
+
+// IsLicensed returns a flag indicating whether or not the named feature has
+// an activated or evaluation license.
+func (c *Client) IsLicensed(ctx context.Context, name string) (bool, error) {
+	license, err := c.GetLicense(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(license.Status, "Activated") ||
+		strings.EqualFold(license.Status, "Evaluation"), nil
+}
+
+// GenerateLicenseActivationFile returns an activation file covering the named
+// features, in addition to any that are already activated or under
+// evaluation, to submit to the licensing portal.
+func (c *Client) GenerateLicenseActivationFile(
+	ctx context.Context, names ...string) (string, error) {
+
+	return api.GenerateIsiLicenseActivationFile(ctx, c.API, names, nil)
+}
+
+// AddLicenseFile applies the signed license file returned by the licensing
+// portal in response to an activation file.
+func (c *Client) AddLicenseFile(ctx context.Context, licenseFile string) error {
+	return api.AddIsiLicenseFile(ctx, c.API, licenseFile)
+}
+
+// StartLicenseEvaluation starts evaluation licenses for the named features.
+// Features that are already activated or under evaluation are skipped.
+func (c *Client) StartLicenseEvaluation(
+	ctx context.Context, names ...string) error {
+
+	var pending []string
+	for _, name := range names {
+		ok, err := c.IsLicensed(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			pending = append(pending, name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return api.StartIsiLicenseEvaluations(ctx, c.API, pending)
+}
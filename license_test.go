@@ -0,0 +1,22 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetLicenses(t *testing.T) {
+	licenses, err := client.GetLicenses(defaultCtx)
+	assertNoError(t, err)
+	for _, l := range licenses {
+		t.Logf("license=%s status=%s", l.Name, l.Status)
+	}
+}
+
+func TestIsLicensed(t *testing.T) {
+	// quotas are required by the rest of the test suite
+	ok, err := client.IsLicensed(defaultCtx, "SmartQuotas")
+	assertNoError(t, err)
+	if !ok {
+		t.Fatal("SmartQuotas should be licensed")
+	}
+}
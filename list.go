@@ -0,0 +1,139 @@
+package goisilon
+
+import (
+	"context"
+)
+
+// pageFunc fetches the page of a collection identified by a resume token. An
+// empty token fetches the first page, and an empty returned token indicates
+// the last page.
+type pageFunc[T any] func(
+	ctx context.Context, resume string) ([]T, string, error)
+
+// List is a collection of items that are fetched from the cluster a page at
+// a time. Lists are immutable; Filter and Limit return new lists.
+type List[T any] struct {
+	fetch   pageFunc[T]
+	filters []func(T) bool
+	limit   int
+}
+
+func newList[T any](fetch pageFunc[T]) *List[T] {
+	return &List[T]{fetch: fetch}
+}
+
+// Filter returns a list of the items for which f returns true.
+func (l *List[T]) Filter(f func(T) bool) *List[T] {
+	nl := *l
+	nl.filters = append(l.filters[:len(l.filters):len(l.filters)], f)
+	return &nl
+}
+
+// Limit returns a list of at most n items. A limit of zero means no limit.
+func (l *List[T]) Limit(n int) *List[T] {
+	nl := *l
+	nl.limit = n
+	return &nl
+}
+
+// Iterator returns an iterator over the list's items.
+func (l *List[T]) Iterator(ctx context.Context) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, list: l}
+}
+
+// Collect returns all of the list's items.
+func (l *List[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	it := l.Iterator(ctx)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// First returns the list's first item. The returned bool is false if the
+// list is empty.
+func (l *List[T]) First(ctx context.Context) (T, bool, error) {
+	it := l.Limit(1).Iterator(ctx)
+	if it.Next() {
+		return it.Item(), true, nil
+	}
+	var zero T
+	return zero, false, it.Err()
+}
+
+// ForEach calls f for each of the list's items until f returns false. No
+// more pages are fetched once f returns false.
+func (l *List[T]) ForEach(ctx context.Context, f func(T) bool) error {
+	it := l.Iterator(ctx)
+	for it.Next() {
+		if !f(it.Item()) {
+			break
+		}
+	}
+	return it.Err()
+}
+
+func (l *List[T]) match(item T) bool {
+	for _, f := range l.filters {
+		if !f(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterator iterates over the items of a List, fetching pages as required.
+type Iterator[T any] struct {
+	ctx     context.Context
+	list    *List[T]
+	page    []T
+	resume  string
+	started bool
+	count   int
+	item    T
+	err     error
+}
+
+// Next advances the iterator to the next item, which is then available
+// through Item. It returns false when there are no more items or an error
+// occurred.
+func (it *Iterator[T]) Next() bool {
+	for {
+		if it.err != nil {
+			return false
+		}
+		if it.list.limit > 0 && it.count >= it.list.limit {
+			return false
+		}
+		if len(it.page) == 0 {
+			if it.started && it.resume == "" {
+				return false
+			}
+			it.started = true
+			if it.err = it.ctx.Err(); it.err != nil {
+				return false
+			}
+			it.page, it.resume, it.err = it.list.fetch(it.ctx, it.resume)
+			continue
+		}
+		item := it.page[0]
+		it.page = it.page[1:]
+		if !it.list.match(item) {
+			continue
+		}
+		it.item = item
+		it.count++
+		return true
+	}
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
@@ -0,0 +1,109 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPages returns a page function that serves the provided pages and
+// records how many were fetched.
+func testPages(pages [][]int, fetched *int) pageFunc[int] {
+	return func(ctx context.Context, resume string) ([]int, string, error) {
+		i := 0
+		if resume != "" {
+			i, _ = strconv.Atoi(resume)
+		}
+		*fetched++
+		next := ""
+		if i+1 < len(pages) {
+			next = strconv.Itoa(i + 1)
+		}
+		return pages[i], next, nil
+	}
+}
+
+func TestListCollect(t *testing.T) {
+	var fetched int
+	l := newList(testPages([][]int{{1, 2}, {}, {3, 4}, {5}}, &fetched))
+
+	items, err := l.Collect(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, 4, fetched)
+
+	odd := l.Filter(func(i int) bool { return i%2 == 1 })
+	items, err = odd.Collect(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, []int{1, 3, 5}, items)
+
+	items, err = odd.Filter(func(i int) bool { return i > 1 }).Collect(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, []int{3, 5}, items)
+}
+
+func TestListEarlyTermination(t *testing.T) {
+	var fetched int
+	l := newList(testPages([][]int{{1, 2}, {3, 4}, {5}}, &fetched))
+
+	item, ok, err := l.Filter(func(i int) bool { return i > 2 }).First(defaultCtx)
+	assertNoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, item)
+	assert.Equal(t, 2, fetched)
+
+	fetched = 0
+	items, err := l.Limit(2).Collect(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+	assert.Equal(t, 1, fetched)
+
+	fetched = 0
+	var seen []int
+	assertNoError(t, l.ForEach(defaultCtx, func(i int) bool {
+		seen = append(seen, i)
+		return i < 3
+	}))
+	assert.Equal(t, []int{1, 2, 3}, seen)
+	assert.Equal(t, 2, fetched)
+
+	_, ok, err = l.Filter(func(i int) bool { return i > 5 }).First(defaultCtx)
+	assertNoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestListError(t *testing.T) {
+	errPage := errors.New("page error")
+	l := newList(func(
+		ctx context.Context, resume string) ([]int, string, error) {
+
+		if resume == "" {
+			return []int{1}, "next", nil
+		}
+		return nil, "", errPage
+	})
+	items, err := l.Collect(defaultCtx)
+	assert.Equal(t, errPage, err)
+	assert.Equal(t, []int{1}, items)
+
+	ctx, cancel := context.WithCancel(defaultCtx)
+	cancel()
+	_, err = l.Collect(ctx)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestListSnapshots(t *testing.T) {
+	snapshots, err := client.GetSnapshots(defaultCtx)
+	assertNoError(t, err)
+
+	var count int
+	assertNoError(t, client.ListSnapshots().ForEach(
+		defaultCtx, func(s *Snapshot) bool {
+			count++
+			return true
+		}))
+	assert.Equal(t, len(snapshots), count)
+}
@@ -0,0 +1,94 @@
+package goisilon
+
+import (
+	"context"
+	"os"
+
+	api "github.com/tenortim/goisilon/api/v2"
+)
+
+// VolumeManager manages volumes and their ACLs.
+type VolumeManager interface {
+	GetVolume(ctx context.Context, id, name string) (*Volume, error)
+	GetVolumes(ctx context.Context) ([]*Volume, error)
+	ListVolumes() *List[*Volume]
+	CreateVolume(ctx context.Context, name string) (*Volume, error)
+	CreateVolumeNoACL(ctx context.Context, name string) (*Volume, error)
+	CreateVolumeIfAbsent(ctx context.Context, name string) (*Volume, error)
+	CreateVolumeDir(
+		ctx context.Context,
+		volumeName, dirPath string,
+		fileMode os.FileMode,
+		overwrite, recursive bool) error
+	CopyVolume(ctx context.Context, src, dest string) (*Volume, error)
+	DeleteVolume(ctx context.Context, name string) error
+	ForceDeleteVolume(ctx context.Context, name string) error
+	TreeDeleteVolume(ctx context.Context, name string) error
+	QueryVolumeChildren(
+		ctx context.Context, name string) (VolumeChildrenMap, error)
+	GetVolumeACL(ctx context.Context, volumeName string) (*ACL, error)
+	SetVolumeACL(ctx context.Context, volumeName string, acl *ACL) error
+	SetVolumeOwner(ctx context.Context, volumeName, userName string) error
+	SetVolumeMode(ctx context.Context, volumeName string, mode int) error
+}
+
+// QuotaManager manages volume quotas.
+type QuotaManager interface {
+	GetQuota(ctx context.Context, name string) (*Quota, error)
+	ListQuotas() *List[*Quota]
+	CreateQuota(
+		ctx context.Context, name string, container bool, size int64) error
+	EnsureQuota(
+		ctx context.Context,
+		name string, container bool, size int64) (*Quota, error)
+	SetQuotaSize(ctx context.Context, name string, size int64) error
+	UpdateQuotaSize(ctx context.Context, name string, size int64) error
+	ClearQuota(ctx context.Context, name string) error
+	WaitForQuotaReady(ctx context.Context, name string) (*Quota, error)
+}
+
+// SnapshotManager manages snapshots.
+type SnapshotManager interface {
+	GetSnapshots(ctx context.Context) (SnapshotList, error)
+	GetSnapshotsByPath(ctx context.Context, path string) (SnapshotList, error)
+	ListSnapshots() *List[*Snapshot]
+	GetSnapshot(ctx context.Context, id int64, name string) (*Snapshot, error)
+	CreateSnapshot(ctx context.Context, path, name string) (*Snapshot, error)
+	RemoveSnapshot(ctx context.Context, id int64, name string) error
+	CopySnapshot(
+		ctx context.Context,
+		sourceID int64, sourceName, destinationName string) (*Volume, error)
+	WaitForSnapshotState(
+		ctx context.Context, id int64, state string) (*Snapshot, error)
+}
+
+// ExportManager manages NFS exports and their clients.
+type ExportManager interface {
+	GetExports(ctx context.Context) (ExportList, error)
+	ListExports(zone string) *List[*api.Export]
+	GetExportByID(ctx context.Context, id int) (Export, error)
+	GetExportByName(ctx context.Context, name string) (Export, error)
+	GetExportByNameWithZone(
+		ctx context.Context, name, zone string) (Export, error)
+	Export(ctx context.Context, name string) (int, error)
+	ExportWithZone(ctx context.Context, name, zone string) (int, error)
+	Unexport(ctx context.Context, name string) error
+	UnexportWithZone(ctx context.Context, name, zone string) error
+	UnexportByID(ctx context.Context, id int) error
+	IsExported(ctx context.Context, name string) (bool, int, error)
+	IsExportedWithZone(
+		ctx context.Context, name, zone string) (bool, int, error)
+	GetExportClients(ctx context.Context, name string) ([]string, error)
+	AddExportClients(ctx context.Context, name string, clients ...string) error
+	SetExportClients(ctx context.Context, name string, clients ...string) error
+	ClearExportClients(ctx context.Context, name string) error
+	WaitForExportVisible(
+		ctx context.Context, name, zone string) (Export, error)
+}
+
+var (
+	_ VolumeManager   = (*Client)(nil)
+	_ QuotaManager    = (*Client)(nil)
+	_ SnapshotManager = (*Client)(nil)
+	_ ExportManager   = (*Client)(nil)
+)
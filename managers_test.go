@@ -0,0 +1,48 @@
+package goisilon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQuotaManager is a QuotaManager that records quota sizes in memory.
+type fakeQuotaManager struct {
+	QuotaManager
+	sizes map[string]int64
+}
+
+func (m *fakeQuotaManager) SetQuotaSize(
+	ctx context.Context, name string, size int64) error {
+
+	m.sizes[name] = size
+	return nil
+}
+
+// ensureQuotaSize is an example consumer that depends only on QuotaManager.
+func ensureQuotaSize(
+	ctx context.Context, qm QuotaManager, name string, size int64) error {
+
+	return qm.SetQuotaSize(ctx, name, size)
+}
+
+func TestQuotaManagerFake(t *testing.T) {
+	m := &fakeQuotaManager{sizes: map[string]int64{}}
+	assertNoError(t, ensureQuotaSize(defaultCtx, m, "vol", 1024))
+	assert.Equal(t, int64(1024), m.sizes["vol"])
+}
+
+func TestClientManagers(t *testing.T) {
+	var (
+		vm VolumeManager   = client
+		sm SnapshotManager = client
+		em ExportManager   = client
+	)
+	_, err := vm.GetVolumes(defaultCtx)
+	assertNoError(t, err)
+	_, err = sm.GetSnapshots(defaultCtx)
+	assertNoError(t, err)
+	_, err = em.GetExports(defaultCtx)
+	assertNoError(t, err)
+}
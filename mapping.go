@@ -0,0 +1,86 @@
+package goisilon
+
+import (
+	"context"
+	"io"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// MappingRules is the ordered list of user mapping rules of an access zone.
+type MappingRules *api.IsiMappingRules
+
+// MappingRule is a user mapping rule.
+type MappingRule *api.IsiMappingRule
+
+// MappingIdentityList is a list of identity mappings.
+type MappingIdentityList []*api.IsiMappingIdentity
+
+// GetMappingRules returns the user mapping rules of an access zone. If zone
+// is empty the client's zone is used.
+func (c *Client) GetMappingRules(
+	ctx context.Context, zone string) (MappingRules, error) {
+
+	return api.GetIsiMappingRules(ctx, c.API, zone)
+}
+
+// SetMappingRules replaces the user mapping rules of an access zone.
+func (c *Client) SetMappingRules(
+	ctx context.Context, zone string, rules MappingRules) error {
+
+	return api.SetIsiMappingRules(ctx, c.API, zone, rules)
+}
+
+// AddMappingRule appends a rule to the user mapping rules of an access zone.
+func (c *Client) AddMappingRule(
+	ctx context.Context, zone string, rule MappingRule) error {
+
+	rules, err := c.GetMappingRules(ctx, zone)
+	if err != nil {
+		return err
+	}
+	rules.Rules = append(rules.Rules, rule)
+	return c.SetMappingRules(ctx, zone, rules)
+}
+
+// GetMappingIdentities returns the identities that a source identity, such
+// as "UID:2000" or "SID:S-1-5-21-...", maps to in an access zone.
+func (c *Client) GetMappingIdentities(
+	ctx context.Context, zone, source string) (MappingIdentityList, error) {
+
+	return api.GetIsiMappingIdentities(ctx, c.API, zone, source)
+}
+
+// FlushIDMapper flushes the ID mapper cache of an access zone.
+func (c *Client) FlushIDMapper(ctx context.Context, zone string) error {
+	return api.FlushIsiMappingIdentities(ctx, c.API, zone)
+}
+
+// DumpIDMapper writes the contents of the ID mapper database of an access
+// zone to w.
+func (c *Client) DumpIDMapper(
+	ctx context.Context, zone string, w io.Writer) error {
+
+	return api.DumpIsiMappingIdentities(ctx, c.API, zone, w)
+}
+
+// MappingToken is the access token a user is given in an access zone.
+type MappingToken *api.IsiMappingToken
+
+// GetUserAccessToken returns the access token that the user with the given
+// name is given in an access zone, including the resolved UID, GID, SIDs,
+// group memberships, and privileges. It is useful for diagnosing why a user
+// is denied access to a share or export.
+func (c *Client) GetUserAccessToken(
+	ctx context.Context, zone, name string) (MappingToken, error) {
+
+	return api.GetIsiMappingToken(ctx, c.API, zone, name, -1)
+}
+
+// GetUserAccessTokenByUID returns the access token that the user with the
+// given UID is given in an access zone.
+func (c *Client) GetUserAccessTokenByUID(
+	ctx context.Context, zone string, uid int) (MappingToken, error) {
+
+	return api.GetIsiMappingToken(ctx, c.API, zone, "", uid)
+}
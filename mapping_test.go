@@ -0,0 +1,44 @@
+package goisilon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMappingRules(t *testing.T) {
+	rules, err := client.GetMappingRules(defaultCtx, "")
+	assertNoError(t, err)
+
+	// re-applying the current rules should be a no-op
+	err = client.SetMappingRules(defaultCtx, "", rules)
+	assertNoError(t, err)
+}
+
+func TestDumpIDMapper(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := client.DumpIDMapper(defaultCtx, "", buf)
+	assertNoError(t, err)
+	t.Logf("dump size=%d", buf.Len())
+
+	identities, err := client.GetMappingIdentities(defaultCtx, "", "UID:0")
+	assertNoError(t, err)
+	for _, i := range identities {
+		t.Logf("identity targets=%d", len(i.Targets))
+	}
+}
+
+func TestGetUserAccessToken(t *testing.T) {
+	token, err := client.GetUserAccessToken(defaultCtx, "", "root")
+	assertNoError(t, err)
+	assertNotNil(t, token.User)
+	assertNotNil(t, token.User.UID)
+	t.Logf("user=%s zone=%s groups=%d privileges=%d",
+		token.User.Name, token.Zone, len(token.Groups), len(token.Privileges))
+
+	token, err = client.GetUserAccessTokenByUID(defaultCtx, "", 0)
+	assertNoError(t, err)
+	assertNotNil(t, token.User)
+	if token.User.Name != "root" {
+		t.Fatalf("UID 0 resolved to %s", token.User.Name)
+	}
+}
@@ -0,0 +1,306 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// GroupnetList is a list of groupnets.
+type GroupnetList []*api.IsiGroupnet
+
+// Groupnet is the top level of the network configuration.
+type Groupnet *api.IsiGroupnet
+
+// SubnetList is a list of subnets.
+type SubnetList []*api.IsiSubnet
+
+// Subnet is a network subnet within a groupnet.
+type Subnet *api.IsiSubnet
+
+// NetworkPoolList is a list of IP address pools.
+type NetworkPoolList []*api.IsiPool
+
+// NetworkPool is a pool of IP addresses within a subnet.
+type NetworkPool *api.IsiPool
+
+// GetGroupnets returns the groupnets.
+func (c *Client) GetGroupnets(ctx context.Context) (GroupnetList, error) {
+	return api.GetIsiGroupnets(ctx, c.API)
+}
+
+// GetGroupnet returns the groupnet with the given name.
+func (c *Client) GetGroupnet(
+	ctx context.Context, name string) (Groupnet, error) {
+
+	return api.GetIsiGroupnet(ctx, c.API, name)
+}
+
+// CreateGroupnet creates a groupnet and returns its ID.
+func (c *Client) CreateGroupnet(
+	ctx context.Context, groupnet Groupnet) (string, error) {
+
+	return api.CreateIsiGroupnet(ctx, c.API, groupnet)
+}
+
+// UpdateGroupnet modifies a groupnet. Fields that are nil are left unchanged.
+func (c *Client) UpdateGroupnet(
+	ctx context.Context, name string, groupnet Groupnet) error {
+
+	return api.UpdateIsiGroupnet(ctx, c.API, name, groupnet)
+}
+
+// DeleteGroupnet removes a groupnet.
+func (c *Client) DeleteGroupnet(ctx context.Context, name string) error {
+	return api.DeleteIsiGroupnet(ctx, c.API, name)
+}
+
+// GetSubnets returns the subnets in a groupnet.
+func (c *Client) GetSubnets(
+	ctx context.Context, groupnet string) (SubnetList, error) {
+
+	return api.GetIsiSubnets(ctx, c.API, groupnet)
+}
+
+// GetSubnet returns the subnet in a groupnet with the given name.
+func (c *Client) GetSubnet(
+	ctx context.Context, groupnet, name string) (Subnet, error) {
+
+	return api.GetIsiSubnet(ctx, c.API, groupnet, name)
+}
+
+// CreateSubnet creates a subnet in a groupnet and returns its ID.
+func (c *Client) CreateSubnet(
+	ctx context.Context, groupnet string, subnet Subnet) (string, error) {
+
+	return api.CreateIsiSubnet(ctx, c.API, groupnet, subnet)
+}
+
+// UpdateSubnet modifies a subnet. Fields that are nil are left unchanged.
+func (c *Client) UpdateSubnet(
+	ctx context.Context, groupnet, name string, subnet Subnet) error {
+
+	return api.UpdateIsiSubnet(ctx, c.API, groupnet, name, subnet)
+}
+
+// DeleteSubnet removes a subnet.
+func (c *Client) DeleteSubnet(
+	ctx context.Context, groupnet, name string) error {
+
+	return api.DeleteIsiSubnet(ctx, c.API, groupnet, name)
+}
+
+// GetAllNetworkPools returns the IP address pools in every groupnet and
+// subnet.
+func (c *Client) GetAllNetworkPools(
+	ctx context.Context) (NetworkPoolList, error) {
+
+	return api.GetIsiAllPools(ctx, c.API)
+}
+
+// GetNetworkPools returns the IP address pools in a subnet.
+func (c *Client) GetNetworkPools(
+	ctx context.Context, groupnet, subnet string) (NetworkPoolList, error) {
+
+	return api.GetIsiPools(ctx, c.API, groupnet, subnet)
+}
+
+// GetNetworkPool returns the IP address pool in a subnet with the given name.
+func (c *Client) GetNetworkPool(
+	ctx context.Context, groupnet, subnet, name string) (NetworkPool, error) {
+
+	return api.GetIsiPool(ctx, c.API, groupnet, subnet, name)
+}
+
+// CreateNetworkPool creates an IP address pool in a subnet and returns its
+// ID.
+func (c *Client) CreateNetworkPool(
+	ctx context.Context,
+	groupnet, subnet string, pool NetworkPool) (string, error) {
+
+	return api.CreateIsiPool(ctx, c.API, groupnet, subnet, pool)
+}
+
+// CreateNetworkPoolWithRange creates an IP address pool in a subnet that
+// serves a single range of addresses in the given access zone, and returns
+// its ID.
+func (c *Client) CreateNetworkPoolWithRange(
+	ctx context.Context,
+	groupnet, subnet, name, zone, low, high string) (string, error) {
+
+	return api.CreateIsiPool(
+		ctx, c.API, groupnet, subnet,
+		&api.IsiPool{
+			Name:       &name,
+			AccessZone: &zone,
+			Ranges:     &[]api.IsiIPRange{{Low: low, High: high}},
+		})
+}
+
+// UpdateNetworkPool modifies an IP address pool. Fields that are nil are left
+// unchanged.
+func (c *Client) UpdateNetworkPool(
+	ctx context.Context,
+	groupnet, subnet, name string, pool NetworkPool) error {
+
+	return api.UpdateIsiPool(ctx, c.API, groupnet, subnet, name, pool)
+}
+
+// DeleteNetworkPool removes an IP address pool.
+func (c *Client) DeleteNetworkPool(
+	ctx context.Context, groupnet, subnet, name string) error {
+
+	return api.DeleteIsiPool(ctx, c.API, groupnet, subnet, name)
+}
+
+// NetworkInterfaceList is a list of node network interfaces.
+type NetworkInterfaceList []*api.IsiNetworkInterface
+
+// NetworkPoolIP is an IP address allocated from a pool to a node interface.
+type NetworkPoolIP struct {
+	Address   string
+	LNN       int
+	Interface string
+	Up        bool
+
+	// Groupnet, Subnet and Pool identify the pool the address is from.
+	Groupnet string
+	Subnet   string
+	Pool     string
+}
+
+// ByNode returns the interfaces grouped by logical node number.
+func (l NetworkInterfaceList) ByNode() map[int]NetworkInterfaceList {
+	nodes := map[int]NetworkInterfaceList{}
+	for _, iface := range l {
+		nodes[iface.LNN] = append(nodes[iface.LNN], iface)
+	}
+	return nodes
+}
+
+// PoolIPs returns the IP addresses allocated from pools to the interfaces,
+// along with the node, interface and pool of each one.
+func (l NetworkInterfaceList) PoolIPs() []NetworkPoolIP {
+	var ips []NetworkPoolIP
+	for _, iface := range l {
+		for _, o := range iface.Owners {
+			for _, addr := range o.IPAddrs {
+				ips = append(ips, NetworkPoolIP{
+					Address:   addr,
+					LNN:       iface.LNN,
+					Interface: iface.Name,
+					Up:        iface.Status == "up",
+					Groupnet:  o.Groupnet,
+					Subnet:    o.Subnet,
+					Pool:      o.Pool,
+				})
+			}
+		}
+	}
+	return ips
+}
+
+// GetNetworkInterfaces returns the network interfaces on all nodes.
+func (c *Client) GetNetworkInterfaces(
+	ctx context.Context) (NetworkInterfaceList, error) {
+
+	return api.GetIsiNetworkInterfaces(ctx, c.API)
+}
+
+// GetNodeNetworkInterfaces returns the network interfaces on a node by its
+// logical node number.
+func (c *Client) GetNodeNetworkInterfaces(
+	ctx context.Context, lnn int) (NetworkInterfaceList, error) {
+
+	return api.GetIsiNodeNetworkInterfaces(ctx, c.API, lnn)
+}
+
+// GetNetworkPoolIPs returns the IP addresses currently allocated from a pool,
+// along with the node and interface that owns each one.
+func (c *Client) GetNetworkPoolIPs(
+	ctx context.Context,
+	groupnet, subnet, pool string) ([]NetworkPoolIP, error) {
+
+	ifaces, err := c.GetNetworkInterfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []NetworkPoolIP
+	for _, ip := range ifaces.PoolIPs() {
+		if ip.Groupnet == groupnet && ip.Subnet == subnet && ip.Pool == pool {
+			ips = append(ips, ip)
+		}
+	}
+	return ips, nil
+}
+
+// FindNetworkIP returns the node, interface and pool that an IP address is
+// allocated to, or nil if it is not allocated to any interface.
+func (c *Client) FindNetworkIP(
+	ctx context.Context, address string) (*NetworkPoolIP, error) {
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid ip address: %s", address)
+	}
+	ifaces, err := c.GetNetworkInterfaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range ifaces.PoolIPs() {
+		if ip.Equal(net.ParseIP(p.Address)) {
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetHealthyNetworkPoolIP returns an IP address allocated from a pool on an
+// interface that is up, so that clients can be given a concrete data address
+// when SmartConnect DNS delegation is not available. An empty string is
+// returned if no such address exists.
+func (c *Client) GetHealthyNetworkPoolIP(
+	ctx context.Context, groupnet, subnet, pool string) (string, error) {
+
+	ips, err := c.GetNetworkPoolIPs(ctx, groupnet, subnet, pool)
+	if err != nil {
+		return "", err
+	}
+	for _, ip := range ips {
+		if ip.Up {
+			return ip.Address, nil
+		}
+	}
+	return "", nil
+}
+
+// InternalNetworkSettings are the settings of the cluster's back-end
+// networks.
+type InternalNetworkSettings *api.IsiInternalNetworkSettings
+
+// GetInternalNetworkSettings returns the settings of the cluster's back-end
+// networks.
+func (c *Client) GetInternalNetworkSettings(
+	ctx context.Context) (InternalNetworkSettings, error) {
+
+	return api.GetIsiInternalNetworkSettings(ctx, c.API)
+}
+
+// UpdateInternalNetworkSettings modifies the settings of the cluster's
+// back-end networks. Fields that are nil are left unchanged. Changes take
+// effect when the nodes are rebooted.
+func (c *Client) UpdateInternalNetworkSettings(
+	ctx context.Context, settings InternalNetworkSettings) error {
+
+	return api.UpdateIsiInternalNetworkSettings(ctx, c.API, settings)
+}
+
+// PrefixLengthToNetmask returns the dotted IPv4 netmask of a prefix length,
+// ex. "255.255.255.0" for 24, as shown for the internal networks by older
+// OneFS tools.
+func PrefixLengthToNetmask(prefixLength int) string {
+	return net.IP(net.CIDRMask(prefixLength, 32)).String()
+}
@@ -0,0 +1,131 @@
+package goisilon
+
+import (
+	"testing"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetNetworkConfiguration(t *testing.T) {
+	groupnets, err := client.GetGroupnets(defaultCtx)
+	assertNoError(t, err)
+	for _, g := range groupnets {
+		assertNotNil(t, g.Name)
+		subnets, err := client.GetSubnets(defaultCtx, *g.Name)
+		assertNoError(t, err)
+		for _, s := range subnets {
+			assertNotNil(t, s.Name)
+			pools, err := client.GetNetworkPools(defaultCtx, *g.Name, *s.Name)
+			assertNoError(t, err)
+			for _, p := range pools {
+				t.Logf("pool=%s", p.ID)
+			}
+		}
+	}
+}
+
+func TestCreateNetworkPool(t *testing.T) {
+	groupnet, subnet, name := "groupnet0", "subnet0", "test_network_pool"
+
+	if _, err := client.GetSubnet(defaultCtx, groupnet, subnet); err != nil {
+		t.Skip("default subnet is not configured")
+	}
+
+	// an empty pool consumes no addresses
+	_, err := client.CreateNetworkPool(
+		defaultCtx, groupnet, subnet, &api.IsiPool{Name: &name})
+	assertNoError(t, err)
+	defer client.DeleteNetworkPool(defaultCtx, groupnet, subnet, name)
+
+	description := "goisilon test pool"
+	err = client.UpdateNetworkPool(
+		defaultCtx, groupnet, subnet, name,
+		&api.IsiPool{Description: &description})
+	assertNoError(t, err)
+
+	pool, err := client.GetNetworkPool(defaultCtx, groupnet, subnet, name)
+	assertNoError(t, err)
+	assertNotNil(t, pool.Description)
+	if *pool.Description != description {
+		t.Fatalf("description not updated: %s", *pool.Description)
+	}
+}
+
+func TestGetNetworkPoolIPs(t *testing.T) {
+	pools, err := client.GetAllNetworkPools(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range pools {
+		assertNotNil(t, p.Name)
+		ips, err := client.GetNetworkPoolIPs(
+			defaultCtx, p.Groupnet, p.Subnet, *p.Name)
+		assertNoError(t, err)
+		for _, ip := range ips {
+			t.Logf("pool=%s ip=%s lnn=%d up=%v",
+				p.ID, ip.Address, ip.LNN, ip.Up)
+		}
+	}
+}
+
+func TestGetNodeNetworkInterfaces(t *testing.T) {
+	ifaces, err := client.GetNetworkInterfaces(defaultCtx)
+	assertNoError(t, err)
+	for lnn, nodeIfaces := range ifaces.ByNode() {
+		got, err := client.GetNodeNetworkInterfaces(defaultCtx, lnn)
+		assertNoError(t, err)
+		assertLen(t, got, len(nodeIfaces))
+		for _, iface := range got {
+			if iface.LNN != lnn {
+				t.Fatalf("interface %s on node %d, want %d",
+					iface.ID, iface.LNN, lnn)
+			}
+			t.Logf("lnn=%d iface=%s status=%s ips=%v",
+				lnn, iface.Name, iface.Status, iface.IPAddrs)
+		}
+	}
+}
+
+func TestFindNetworkIP(t *testing.T) {
+	ifaces, err := client.GetNetworkInterfaces(defaultCtx)
+	assertNoError(t, err)
+	ips := ifaces.PoolIPs()
+	if len(ips) == 0 {
+		t.Skip("no pool addresses are allocated")
+	}
+
+	ip, err := client.FindNetworkIP(defaultCtx, ips[0].Address)
+	assertNoError(t, err)
+	assertNotNil(t, ip)
+	if ip.LNN != ips[0].LNN || ip.Pool != ips[0].Pool {
+		t.Fatalf("got lnn=%d pool=%s, want lnn=%d pool=%s",
+			ip.LNN, ip.Pool, ips[0].LNN, ips[0].Pool)
+	}
+
+	ip, err = client.FindNetworkIP(defaultCtx, "192.0.2.254")
+	assertNoError(t, err)
+	if ip != nil {
+		t.Fatalf("unallocated address found on node %d", ip.LNN)
+	}
+
+	_, err = client.FindNetworkIP(defaultCtx, "not-an-ip")
+	assertError(t, err)
+}
+
+func TestGetInternalNetworkSettings(t *testing.T) {
+	settings, err := client.GetInternalNetworkSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+	assertNotNil(t, settings.IntAIPAddresses)
+	assertNotNil(t, settings.IntAPrefixLength)
+	t.Logf("int-a netmask=%s",
+		PrefixLengthToNetmask(*settings.IntAPrefixLength))
+}
+
+func TestPrefixLengthToNetmask(t *testing.T) {
+	for l, mask := range map[int]string{
+		8: "255.0.0.0", 24: "255.255.255.0", 25: "255.255.255.128",
+	} {
+		if m := PrefixLengthToNetmask(l); m != mask {
+			t.Errorf("prefix %d: got %s, want %s", l, m, mask)
+		}
+	}
+}
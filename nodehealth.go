@@ -0,0 +1,244 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tenortim/goisilon/api"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// NodeSensor is a reading of a hardware sensor of a node.
+type NodeSensor struct {
+
+	// Group is the kind of sensor, ex. "Fan" or "Temp".
+	Group string `json:"group"`
+
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Units       string `json:"units"`
+
+	// Value is the reading as reported by the sensor.
+	Value string `json:"value"`
+}
+
+// Float returns the reading as a number, and false if the sensor did not
+// report one.
+func (s *NodeSensor) Float() (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s.Value), 64)
+	return v, err == nil
+}
+
+// NodePowerSupply is a power supply of a node.
+type NodePowerSupply struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Firmware string `json:"firmware"`
+
+	// OK is whether the cluster reports the power supply as good.
+	OK bool `json:"ok"`
+}
+
+// NodeBattery is an NVRAM battery of a node.
+type NodeBattery struct {
+	ID      int    `json:"id"`
+	Status  string `json:"status"`
+	Voltage string `json:"voltage"`
+
+	// TestResult is the result of the battery's last self test, if any.
+	TestResult string `json:"test_result"`
+
+	// OK is whether the battery's status light is green and its last self
+	// test did not fail.
+	OK bool `json:"ok"`
+}
+
+// NodeHealth is the hardware health of a node.
+type NodeHealth struct {
+	LNN int `json:"lnn"`
+
+	// Sensors are the readings of all sensors. Fans and Temperatures are
+	// the fan and temperature readings among them.
+	Sensors      []*NodeSensor `json:"sensors"`
+	Fans         []*NodeSensor `json:"fans"`
+	Temperatures []*NodeSensor `json:"temperatures"`
+
+	PowerSupplies []*NodePowerSupply `json:"power_supplies"`
+	Batteries     []*NodeBattery     `json:"batteries"`
+
+	// NVRAMChargeStatus is the charge status of the NVRAM, ex. "Ready".
+	NVRAMChargeStatus string `json:"nvram_charge_status"`
+}
+
+// Failures returns a description of each failing component of the node, or
+// nil if none are failing.
+func (h *NodeHealth) Failures() []string {
+	var failures []string
+	for _, p := range h.PowerSupplies {
+		if !p.OK {
+			failures = append(failures, fmt.Sprintf(
+				"node %d: power supply %s: %s", h.LNN, p.Name, p.Status))
+		}
+	}
+	for _, b := range h.Batteries {
+		if !b.OK {
+			failures = append(failures, fmt.Sprintf(
+				"node %d: battery %d: %s", h.LNN, b.ID, b.Status))
+		}
+	}
+	return failures
+}
+
+// GetNodeSensors returns the readings of the hardware sensors of a node.
+func (c *Client) GetNodeSensors(
+	ctx context.Context, lnn int) ([]*NodeSensor, error) {
+
+	groups, err := apiv3.GetIsiNodeSensors(ctx, c.API, lnn)
+	if err != nil {
+		return nil, err
+	}
+	return nodeSensors(groups), nil
+}
+
+// GetNodeHealth returns the hardware health of a node.
+func (c *Client) GetNodeHealth(
+	ctx context.Context, lnn int) (*NodeHealth, error) {
+
+	groups, err := apiv3.GetIsiNodeSensors(ctx, c.API, lnn)
+	if err != nil {
+		return nil, err
+	}
+	status, err := apiv3.GetIsiNodeStatus(ctx, c.API, lnn)
+	if err != nil {
+		return nil, err
+	}
+	return nodeHealth(lnn, groups, status), nil
+}
+
+// GetNodesHealth returns the hardware health of every node, ordered by
+// logical node number.
+func (c *Client) GetNodesHealth(ctx context.Context) ([]*NodeHealth, error) {
+	nodes, err := apiv3.GetIsiNodes(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	health := make([]*NodeHealth, len(nodes))
+	calls := make([]func(ctx context.Context) error, len(nodes))
+	for i, n := range nodes {
+		i, lnn := i, n.LNN
+		calls[i] = func(ctx context.Context) (err error) {
+			health[i], err = c.GetNodeHealth(ctx, lnn)
+			return err
+		}
+	}
+	if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+		return nil, err
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return health[i].LNN < health[j].LNN
+	})
+	return health, nil
+}
+
+func nodeSensors(groups []*apiv3.IsiNodeSensorGroup) []*NodeSensor {
+	sensors := []*NodeSensor{}
+	for _, g := range groups {
+		for _, v := range g.Values {
+			sensors = append(sensors, &NodeSensor{
+				Group:       g.Name,
+				Name:        v.Name,
+				Description: v.Desc,
+				Units:       v.Units,
+				Value:       v.Value,
+			})
+		}
+	}
+	return sensors
+}
+
+func nodeHealth(
+	lnn int,
+	groups []*apiv3.IsiNodeSensorGroup,
+	status *apiv3.IsiNodeStatus) *NodeHealth {
+
+	h := &NodeHealth{
+		LNN:           lnn,
+		Sensors:       nodeSensors(groups),
+		Fans:          []*NodeSensor{},
+		Temperatures:  []*NodeSensor{},
+		PowerSupplies: []*NodePowerSupply{},
+		Batteries:     []*NodeBattery{},
+	}
+	for _, s := range h.Sensors {
+		switch group := strings.ToLower(s.Group); {
+		case strings.HasPrefix(group, "fan"):
+			h.Fans = append(h.Fans, s)
+		case strings.HasPrefix(group, "temp"):
+			h.Temperatures = append(h.Temperatures, s)
+		}
+	}
+	if status == nil {
+		return h
+	}
+
+	if ps := status.PowerSupplies; ps != nil {
+		for _, p := range ps.Supplies {
+			good := p.Good
+			if good == "" {
+				good = p.Status
+			}
+			h.PowerSupplies = append(h.PowerSupplies, &NodePowerSupply{
+				ID:       p.ID,
+				Name:     p.Name,
+				Status:   p.Status,
+				Firmware: p.Firmware,
+				OK:       isGoodStatus(good),
+			})
+		}
+	}
+
+	if nv := status.NVRAM; nv != nil {
+		h.NVRAMChargeStatus = nv.ChargeStatus
+		for _, b := range nv.Batteries {
+			result := batteryTestResult(status.BatteryStatus, b.ID)
+			h.Batteries = append(h.Batteries, &NodeBattery{
+				ID:         b.ID,
+				Status:     b.Status,
+				Voltage:    b.Voltage,
+				TestResult: result,
+				OK: (b.Color == "" || strings.EqualFold(b.Color, "green")) &&
+					!strings.Contains(strings.ToLower(result), "fail"),
+			})
+		}
+	}
+	return h
+}
+
+// batteryTestResult returns the result of the last self test of a battery
+// by its number, which starts at 1.
+func batteryTestResult(bs *apiv3.IsiNodeBatteryStatus, id int) string {
+	if bs == nil {
+		return ""
+	}
+	switch id {
+	case 1:
+		return bs.Result1
+	case 2:
+		return bs.Result2
+	}
+	return ""
+}
+
+// isGoodStatus returns whether a status reported by the cluster means that a
+// component is working.
+func isGoodStatus(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "good", "ok", "yes", "true":
+		return true
+	}
+	return false
+}
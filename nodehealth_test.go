@@ -0,0 +1,60 @@
+package goisilon
+
+import (
+	"testing"
+
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetNodesHealth(t *testing.T) {
+	health, err := client.GetNodesHealth(defaultCtx)
+	assertNoError(t, err)
+	for _, h := range health {
+		for _, s := range h.Temperatures {
+			t.Logf("lnn=%d sensor=%s value=%s%s",
+				h.LNN, s.Name, s.Value, s.Units)
+		}
+		for _, f := range h.Failures() {
+			t.Logf("failure: %s", f)
+		}
+	}
+}
+
+func TestNodeHealthFailures(t *testing.T) {
+	h := nodeHealth(2,
+		[]*apiv3.IsiNodeSensorGroup{
+			{Name: "Fan", Values: []*apiv3.IsiNodeSensor{
+				{Name: "Fan1_Speed", Units: "rpm", Value: "7920"},
+			}},
+			{Name: "Temp", Values: []*apiv3.IsiNodeSensor{
+				{Name: "Temp_CPU", Units: "C", Value: "n/a"},
+			}},
+		},
+		&apiv3.IsiNodeStatus{
+			BatteryStatus: &apiv3.IsiNodeBatteryStatus{
+				Result1: "passed", Result2: "failed"},
+			NVRAM: &apiv3.IsiNodeNVRAM{
+				Batteries: []*apiv3.IsiNodeNVRAMBattery{
+					{ID: 1, Color: "green", Status: "Good"},
+					{ID: 2, Color: "green", Status: "Good"},
+				},
+			},
+			PowerSupplies: &apiv3.IsiNodePowerSupplies{
+				Supplies: []*apiv3.IsiNodePowerSupply{
+					{ID: 1, Name: "PS1", Good: "Good"},
+					{ID: 2, Name: "PS2", Good: "No", Status: "Failed"},
+				},
+			},
+		})
+
+	assertLen(t, h.Sensors, 2)
+	assertLen(t, h.Fans, 1)
+	assertLen(t, h.Temperatures, 1)
+	if v, ok := h.Fans[0].Float(); !ok || v != 7920 {
+		t.Fatalf("fan speed = %v, %v", v, ok)
+	}
+	if _, ok := h.Temperatures[0].Float(); ok {
+		t.Fatal("non-numeric reading parsed")
+	}
+	assertLen(t, h.Failures(), 2)
+}
@@ -0,0 +1,102 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// NodeReadOnly is the read-only state of a node.
+type NodeReadOnly *api.IsiNodeReadOnly
+
+// NodeHardware is the hardware identity of a node.
+type NodeHardware *api.IsiNodeHardware
+
+// NodePowerOptions confirm a node shutdown or reboot.
+type NodePowerOptions struct {
+
+	// ConfirmSerial must be the serial number of the node being shut down
+	// or rebooted, as returned by GetNodeHardware. It is checked against
+	// the cluster before the request is sent, which guards against acting
+	// on the wrong node, ex. after LNNs have been renumbered.
+	ConfirmSerial string
+
+	// AllowDown permits the operation even if it would take the cluster
+	// below quorum, which makes the cluster unavailable. It should only be
+	// set when the whole cluster is being shut down.
+	AllowDown bool
+}
+
+// ErrNotConfirmed is returned when a disruptive operation is requested
+// without the required confirmation.
+var ErrNotConfirmed = errors.New("operation not confirmed")
+
+// confirmNodePower verifies that the options confirm the serial number of
+// the node.
+func (c *Client) confirmNodePower(
+	ctx context.Context, lnn int, opts *NodePowerOptions) error {
+
+	if opts == nil || opts.ConfirmSerial == "" {
+		return fmt.Errorf("node %d: %w", lnn, ErrNotConfirmed)
+	}
+	hardware, err := api.GetIsiNodeHardware(ctx, c.API, lnn)
+	if err != nil {
+		return err
+	}
+	if hardware.SerialNumber != opts.ConfirmSerial {
+		return fmt.Errorf("node %d has serial number %s, not %s: %w",
+			lnn, hardware.SerialNumber, opts.ConfirmSerial, ErrNotConfirmed)
+	}
+	return nil
+}
+
+// GetNodeHardware returns the hardware identity of a node, including the
+// serial number that confirms a shutdown or reboot.
+func (c *Client) GetNodeHardware(
+	ctx context.Context, lnn int) (NodeHardware, error) {
+
+	return api.GetIsiNodeHardware(ctx, c.API, lnn)
+}
+
+// ShutdownNode shuts down a node by its logical node number. The options
+// must confirm the node's serial number, otherwise an error wrapping
+// ErrNotConfirmed is returned and nothing is sent.
+func (c *Client) ShutdownNode(
+	ctx context.Context, lnn int, opts *NodePowerOptions) error {
+
+	if err := c.confirmNodePower(ctx, lnn, opts); err != nil {
+		return err
+	}
+	return api.ShutdownIsiNode(
+		ctx, c.API, lnn, &api.IsiNodePowerReq{AllowDown: opts.AllowDown})
+}
+
+// RebootNode reboots a node by its logical node number. The options must
+// confirm the node's serial number, otherwise an error wrapping
+// ErrNotConfirmed is returned and nothing is sent.
+func (c *Client) RebootNode(
+	ctx context.Context, lnn int, opts *NodePowerOptions) error {
+
+	if err := c.confirmNodePower(ctx, lnn, opts); err != nil {
+		return err
+	}
+	return api.RebootIsiNode(
+		ctx, c.API, lnn, &api.IsiNodePowerReq{AllowDown: opts.AllowDown})
+}
+
+// GetNodeReadOnly returns the read-only state of a node.
+func (c *Client) GetNodeReadOnly(
+	ctx context.Context, lnn int) (NodeReadOnly, error) {
+
+	return api.GetIsiNodeReadOnly(ctx, c.API, lnn)
+}
+
+// SetNodeReadOnly enables or disables read-only mode on a node, which
+// prevents writes to its drives, ex. while draining it for maintenance.
+func (c *Client) SetNodeReadOnly(
+	ctx context.Context, lnn int, enabled bool) error {
+
+	return api.SetIsiNodeReadOnly(ctx, c.API, lnn, enabled)
+}
@@ -0,0 +1,73 @@
+package goisilon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// nodePowerClient is an API client for a cluster with a node whose
+// serial number is "SN1", which records the paths posted to.
+type nodePowerClient struct {
+	api.Client
+	posted []string
+}
+
+func (c *nodePowerClient) Get(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	resp interface{}) error {
+
+	return json.Unmarshal(
+		[]byte(`{"nodes":[{"id":1,"lnn":1,"serial_number":"SN1"}]}`), resp)
+}
+
+func (c *nodePowerClient) Post(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	body, resp interface{}) error {
+
+	c.posted = append(c.posted, path)
+	return nil
+}
+
+func TestNodePowerConfirmation(t *testing.T) {
+	fake := &nodePowerClient{}
+	c := &Client{fake}
+
+	// none of these may be sent
+	err := c.ShutdownNode(defaultCtx, 1, nil)
+	assert.True(t, errors.Is(err, ErrNotConfirmed), "%v", err)
+	err = c.RebootNode(defaultCtx, 1, &NodePowerOptions{})
+	assert.True(t, errors.Is(err, ErrNotConfirmed), "%v", err)
+	err = c.RebootNode(defaultCtx, 1, &NodePowerOptions{ConfirmSerial: "SN2"})
+	assert.True(t, errors.Is(err, ErrNotConfirmed), "%v", err)
+	assert.Empty(t, fake.posted)
+
+	opts := &NodePowerOptions{ConfirmSerial: "SN1"}
+	assertNoError(t, c.ShutdownNode(defaultCtx, 1, opts))
+	assertNoError(t, c.RebootNode(defaultCtx, 1, opts))
+	assert.Equal(t, []string{
+		"platform/3/cluster/nodes/1/shutdown",
+		"platform/3/cluster/nodes/1/reboot",
+	}, fake.posted)
+}
+
+func TestGetNodeHardware(t *testing.T) {
+	hardware, err := client.GetNodeHardware(defaultCtx, 1)
+	assertNoError(t, err)
+	assert.NotEmpty(t, hardware.SerialNumber)
+}
+
+func TestGetNodeReadOnly(t *testing.T) {
+	readOnly, err := client.GetNodeReadOnly(defaultCtx, 1)
+	assertNoError(t, err)
+	assertNotNil(t, readOnly)
+}
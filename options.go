@@ -0,0 +1,163 @@
+package goisilon
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// Option configures a client created with New.
+type Option func(*Config) error
+
+// New returns a new Isilon client configured with the provided options. An
+// endpoint and credentials are required.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	return NewClientFromConfig(ctx, &Config{}, opts...)
+}
+
+// WithConfig sets all of the client's configuration from a Config. Options
+// that follow it override its values.
+func WithConfig(config *Config) Option {
+	return func(c *Config) error {
+		if config == nil {
+			return errors.New("invalid config: nil")
+		}
+		*c = *config
+		return nil
+	}
+}
+
+// WithEndpoint sets the API endpoint, ex. https://172.17.177.230:8080.
+func WithEndpoint(endpoint string) Option {
+	return func(c *Config) error {
+		if err := validateEndpoint(endpoint); err != nil {
+			return err
+		}
+		c.Endpoint = endpoint
+		return nil
+	}
+}
+
+// WithCredentials sets the user name and password used to access the API.
+func WithCredentials(username, password string) Option {
+	return func(c *Config) error {
+		if username == "" || password == "" {
+			return errors.New("invalid credentials: empty user name or password")
+		}
+		c.Username, c.Password = username, password
+		return nil
+	}
+}
+
+// WithGroup sets the user's group.
+func WithGroup(group string) Option {
+	return func(c *Config) error {
+		c.Group = group
+		return nil
+	}
+}
+
+// WithInsecure sets whether or not to skip SSL validation.
+func WithInsecure(insecure bool) Option {
+	return func(c *Config) error {
+		c.Insecure = insecure
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Config) error {
+		if config == nil {
+			return errors.New("invalid tls config: nil")
+		}
+		c.TLSConfig = config
+		return nil
+	}
+}
+
+// WithRedactor sets a function applied to HTTP requests and responses dumped
+// to the debug log, after credentials have been redacted.
+func WithRedactor(redactor api.Redactor) Option {
+	return func(c *Config) error {
+		c.Redactor = redactor
+		return nil
+	}
+}
+
+// WithCapture records every HTTP exchange of the client, with credentials
+// redacted, to a Capturer such as an api.HARCapture or api.FileCapture, ex.
+// to attach to a support case.
+func WithCapture(capturer api.Capturer) Option {
+	return func(c *Config) error {
+		if capturer == nil {
+			return errors.New("invalid capturer: nil")
+		}
+		c.Capturer = capturer
+		return nil
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker that fails requests with
+// api.ErrCircuitOpen, without sending them, once the share of requests to
+// the cluster that fail reaches the configured error rate. See
+// api.CircuitBreakerOptions for the defaults used for unset fields.
+func WithCircuitBreaker(opts *api.CircuitBreakerOptions) Option {
+	return func(c *Config) error {
+		if opts == nil {
+			return errors.New("invalid circuit breaker options: nil")
+		}
+		if opts.ErrorRate < 0 || opts.ErrorRate > 1 {
+			return fmt.Errorf(
+				"invalid circuit breaker error rate: %v", opts.ErrorRate)
+		}
+		c.CircuitBreaker = opts
+		return nil
+	}
+}
+
+// WithAuthenticator sets how the client authenticates its requests, ex.
+// with an api.SessionAuth to log in once rather than send the password with
+// every request.
+func WithAuthenticator(authenticator api.Authenticator) Option {
+	return func(c *Config) error {
+		if authenticator == nil {
+			return errors.New("invalid authenticator: nil")
+		}
+		c.Authenticator = authenticator
+		return nil
+	}
+}
+
+// WithVolumesPath sets the base path of volume directories.
+func WithVolumesPath(volumesPath string) Option {
+	return func(c *Config) error {
+		c.VolumesPath = volumesPath
+		return nil
+	}
+}
+
+// WithZone sets the access zone used to scope zone-aware calls and the
+// volumes path.
+func WithZone(zone string) Option {
+	return func(c *Config) error {
+		c.Zone = zone
+		return nil
+	}
+}
+
+// WithTimeout sets a time limit for requests made by the client. A zero
+// timeout means no limit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) error {
+		if err := validateTimeout(timeout); err != nil {
+			return err
+		}
+		c.Timeout = timeout
+		return nil
+	}
+}
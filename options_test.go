@@ -0,0 +1,52 @@
+package goisilon
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	insecure, _ := strconv.ParseBool(os.Getenv("GOISILON_INSECURE"))
+	c, err := New(
+		defaultCtx,
+		WithEndpoint(os.Getenv("GOISILON_ENDPOINT")),
+		WithCredentials(
+			os.Getenv("GOISILON_USERNAME"), os.Getenv("GOISILON_PASSWORD")),
+		WithGroup(os.Getenv("GOISILON_GROUP")),
+		WithInsecure(insecure),
+		WithZone("System"),
+		WithTimeout(time.Minute),
+		WithAuthenticator(&api.SessionAuth{}))
+	assertNoError(t, err)
+	assert.Equal(t, "System", c.API.Zone())
+}
+
+func TestNewWithInvalidOptions(t *testing.T) {
+	_, err := New(defaultCtx, WithEndpoint("172.17.177.230:8080"))
+	assertError(t, err)
+
+	_, err = New(defaultCtx, WithEndpoint("https://172.17.177.230:8080"))
+	assert.EqualError(t, err, "no credentials set")
+
+	_, err = New(defaultCtx, WithTimeout(-time.Second))
+	assert.EqualError(t, err, "invalid timeout: -1s")
+
+	_, err = New(defaultCtx, WithTLSConfig(nil))
+	assertError(t, err)
+
+	_, err = New(defaultCtx, WithCircuitBreaker(nil))
+	assertError(t, err)
+
+	_, err = New(defaultCtx,
+		WithCircuitBreaker(&api.CircuitBreakerOptions{ErrorRate: 2}))
+	assert.EqualError(t, err, "invalid circuit breaker error rate: 2")
+
+	_, err = New(defaultCtx, WithAuthenticator(nil))
+	assert.EqualError(t, err, "invalid authenticator: nil")
+}
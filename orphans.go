@@ -0,0 +1,187 @@
+package goisilon
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/tenortim/goisilon/api"
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// OrphanedExport is an NFS export with paths that no longer exist.
+type OrphanedExport struct {
+	Export Export
+
+	// MissingPaths are the export's paths that no longer exist.
+	MissingPaths []string
+
+	// Removed is whether the export was removed by a cleanup.
+	Removed bool
+}
+
+// OrphanedQuota is a quota whose directory no longer exists.
+type OrphanedQuota struct {
+	Quota *Quota
+
+	// Removed is whether the quota was removed by a cleanup.
+	Removed bool
+}
+
+// OrphanReport lists the exports and directory quotas whose paths no longer
+// exist, ex. because the directories were deleted out of band.
+type OrphanReport struct {
+	Exports []*OrphanedExport
+	Quotas  []*OrphanedQuota
+}
+
+// OrphanOptions configure FindOrphans.
+type OrphanOptions struct {
+
+	// Zone is the access zone of the exports. If empty the client's zone
+	// is used.
+	Zone string
+
+	// Cleanup removes the orphaned quotas, and the orphaned exports none of
+	// whose paths exist. Exports with some paths that still exist are only
+	// reported.
+	Cleanup bool
+}
+
+// FindOrphans returns the NFS exports and directory quotas whose paths no
+// longer exist. The paths are checked concurrently. If opts.Cleanup is set
+// the orphans are removed, and the report records which ones were.
+func (c *Client) FindOrphans(
+	ctx context.Context, opts *OrphanOptions) (*OrphanReport, error) {
+
+	if opts == nil {
+		opts = &OrphanOptions{}
+	}
+
+	exports, err := c.ListExports(opts.Zone).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quotas, err := c.ListQuotas().Filter(
+		func(q *Quota) bool { return q.Type == "directory" }).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, ex := range exports {
+		if ex.Paths != nil {
+			paths = append(paths, *ex.Paths...)
+		}
+	}
+	for _, q := range quotas {
+		paths = append(paths, q.Path)
+	}
+	missing, err := c.missingPaths(ctx, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	report := orphanReport(exports, quotas, missing)
+	if opts.Cleanup {
+		if err := c.removeOrphans(ctx, opts.Zone, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// missingPaths returns the set of the absolute paths that do not exist.
+func (c *Client) missingPaths(
+	ctx context.Context, paths []string) (map[string]bool, error) {
+
+	var (
+		mu      sync.Mutex
+		missing = map[string]bool{}
+		calls   []func(ctx context.Context) error
+		seen    = map[string]bool{}
+	)
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		p := p
+		calls = append(calls, func(ctx context.Context) error {
+			_, err := apiv2.ACLInspectPath(ctx, c.API, p)
+			if IsNotFound(err) {
+				mu.Lock()
+				missing[p] = true
+				mu.Unlock()
+				return nil
+			}
+			return err
+		})
+	}
+	if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+func orphanReport(
+	exports []*apiv2.Export,
+	quotas []*Quota,
+	missing map[string]bool) *OrphanReport {
+
+	report := &OrphanReport{
+		Exports: []*OrphanedExport{},
+		Quotas:  []*OrphanedQuota{},
+	}
+	for _, ex := range exports {
+		if ex.Paths == nil {
+			continue
+		}
+		var paths []string
+		for _, p := range *ex.Paths {
+			if missing[p] {
+				paths = append(paths, p)
+			}
+		}
+		if len(paths) > 0 {
+			report.Exports = append(report.Exports,
+				&OrphanedExport{Export: ex, MissingPaths: paths})
+		}
+	}
+	for _, q := range quotas {
+		if missing[q.Path] {
+			report.Quotas = append(report.Quotas, &OrphanedQuota{Quota: q})
+		}
+	}
+	sort.Slice(report.Exports, func(i, j int) bool {
+		return report.Exports[i].Export.ID < report.Exports[j].Export.ID
+	})
+	sort.Slice(report.Quotas, func(i, j int) bool {
+		return report.Quotas[i].Quota.Path < report.Quotas[j].Quota.Path
+	})
+	return report
+}
+
+func (c *Client) removeOrphans(
+	ctx context.Context, zone string, report *OrphanReport) error {
+
+	for _, o := range report.Exports {
+		if len(o.MissingPaths) < len(*o.Export.Paths) {
+			continue
+		}
+		err := apiv2.UnexportWithZone(ctx, c.API, o.Export.ID, zone)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+		o.Removed = true
+	}
+	for _, o := range report.Quotas {
+		err := apiv1.DeleteIsiQuotaByID(ctx, c.API, o.Quota.Id)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+		o.Removed = true
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+func TestFindOrphans(t *testing.T) {
+	volumeName := "test_find_orphans"
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	id, err := client.Export(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.UnexportByID(defaultCtx, id)
+
+	// delete the directory out from under the export
+	assertNoError(t, client.DeleteVolume(defaultCtx, volumeName))
+
+	report, err := client.FindOrphans(defaultCtx, nil)
+	assertNoError(t, err)
+	var found *OrphanedExport
+	for _, o := range report.Exports {
+		if o.Export.ID == id {
+			found = o
+		}
+	}
+	assertNotNil(t, found)
+	assert.False(t, found.Removed)
+
+	report, err = client.FindOrphans(defaultCtx, &OrphanOptions{Cleanup: true})
+	assertNoError(t, err)
+	for _, o := range report.Exports {
+		if o.Export.ID == id {
+			assert.True(t, o.Removed)
+		}
+	}
+	ok, _, err := client.IsExported(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestOrphanReport(t *testing.T) {
+	paths := func(p ...string) *[]string { return &p }
+	report := orphanReport(
+		[]*apiv2.Export{
+			{ID: 1, Paths: paths("/ifs/a")},
+			{ID: 2, Paths: paths("/ifs/b", "/ifs/c")},
+			{ID: 3, Paths: paths("/ifs/d")},
+		},
+		[]*Quota{{Path: "/ifs/a"}, {Path: "/ifs/d"}},
+		map[string]bool{"/ifs/a": true, "/ifs/c": true})
+
+	assertLen(t, report.Exports, 2)
+	assert.Equal(t, []string{"/ifs/c"}, report.Exports[1].MissingPaths)
+	assertLen(t, report.Quotas, 1)
+	assert.Equal(t, "/ifs/a", report.Quotas[0].Quota.Path)
+}
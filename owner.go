@@ -0,0 +1,59 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// ClusterOwner is the cluster's owner and contact information.
+type ClusterOwner *api.IsiClusterOwner
+
+// ClusterContact is a contact for the cluster's administration.
+type ClusterContact struct {
+	Name   string
+	Email  string
+	Phone1 string
+	Phone2 string
+}
+
+// GetClusterOwner returns the cluster owner and contact information.
+func (c *Client) GetClusterOwner(ctx context.Context) (ClusterOwner, error) {
+	return api.GetIsiClusterOwner(ctx, c.API)
+}
+
+// UpdateClusterOwner modifies the cluster owner and contact information.
+// Fields that are nil are left unchanged.
+func (c *Client) UpdateClusterOwner(
+	ctx context.Context, owner ClusterOwner) error {
+
+	return api.UpdateIsiClusterOwner(ctx, c.API, owner)
+}
+
+// SetClusterOwner sets the cluster's company, location and contacts. The
+// secondary contact is left unchanged if nil, and the primary contact must
+// be set.
+func (c *Client) SetClusterOwner(
+	ctx context.Context,
+	company, location string, primary, secondary *ClusterContact) error {
+
+	if primary == nil {
+		return errors.New("no primary contact set")
+	}
+	owner := &api.IsiClusterOwner{
+		Company:       &company,
+		Location:      &location,
+		PrimaryName:   &primary.Name,
+		PrimaryEmail:  &primary.Email,
+		PrimaryPhone1: &primary.Phone1,
+		PrimaryPhone2: &primary.Phone2,
+	}
+	if secondary != nil {
+		owner.SecondaryName = &secondary.Name
+		owner.SecondaryEmail = &secondary.Email
+		owner.SecondaryPhone1 = &secondary.Phone1
+		owner.SecondaryPhone2 = &secondary.Phone2
+	}
+	return api.UpdateIsiClusterOwner(ctx, c.API, owner)
+}
@@ -0,0 +1,27 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterOwner(t *testing.T) {
+	owner, err := client.GetClusterOwner(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, owner)
+	defer client.UpdateClusterOwner(defaultCtx, owner)
+
+	assertError(t, client.SetClusterOwner(defaultCtx, "", "", nil, nil))
+	assertNoError(t, client.SetClusterOwner(
+		defaultCtx, "Example Inc.", "Rack 12, DC1",
+		&ClusterContact{Name: "Storage Team", Email: "storage@example.com"},
+		nil))
+
+	updated, err := client.GetClusterOwner(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, "Example Inc.", *updated.Company)
+	assert.Equal(t, "Rack 12, DC1", *updated.Location)
+	assert.Equal(t, "storage@example.com", *updated.PrimaryEmail)
+	assert.Equal(t, owner.SecondaryEmail, updated.SecondaryEmail)
+}
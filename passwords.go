@@ -0,0 +1,77 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// PasswordPolicy is the password and lockout policy of the local provider of
+// an access zone.
+type PasswordPolicy *api.IsiLocalProvider
+
+// systemZone is the name of the default access zone.
+const systemZone = "System"
+
+// ChangeUserPassword changes the password of a user in an access zone. If
+// the user is the one the client is authenticated as, the client is updated
+// to use the new password for subsequent requests.
+func (c *Client) ChangeUserPassword(
+	ctx context.Context,
+	zone, user, oldPassword, newPassword string) error {
+
+	if err := api.ChangeIsiUserPassword(
+		ctx, c.API, zone, user, oldPassword, newPassword); err != nil {
+		return err
+	}
+	if user == c.API.User() {
+		c.API.SetPassword(newPassword)
+	}
+	return nil
+}
+
+// ChangePassword changes the password of the user the client is
+// authenticated as, and updates the client to use the new password.
+func (c *Client) ChangePassword(
+	ctx context.Context, oldPassword, newPassword string) error {
+
+	return c.ChangeUserPassword(
+		ctx, "", c.API.User(), oldPassword, newPassword)
+}
+
+// SetUserPasswordExpires sets whether or not the password of a user in an
+// access zone expires according to the password policy.
+func (c *Client) SetUserPasswordExpires(
+	ctx context.Context, zone, user string, expires bool) error {
+
+	return c.UpdateUser(
+		ctx, zone, user, &api.IsiUserReq{PasswordExpires: &expires})
+}
+
+// GetPasswordPolicy returns the password policy of an access zone. If zone
+// is empty the client's zone is used, or the System zone if the client has
+// none.
+func (c *Client) GetPasswordPolicy(
+	ctx context.Context, zone string) (PasswordPolicy, error) {
+
+	return api.GetIsiLocalProvider(ctx, c.API, c.localProviderName(zone))
+}
+
+// UpdatePasswordPolicy modifies the password policy of an access zone.
+// Fields that are nil are left unchanged.
+func (c *Client) UpdatePasswordPolicy(
+	ctx context.Context, zone string, policy PasswordPolicy) error {
+
+	return api.UpdateIsiLocalProvider(
+		ctx, c.API, c.localProviderName(zone), policy)
+}
+
+func (c *Client) localProviderName(zone string) string {
+	if zone == "" {
+		zone = c.API.Zone()
+	}
+	if zone == "" {
+		zone = systemZone
+	}
+	return zone
+}
@@ -0,0 +1,36 @@
+package goisilon
+
+import (
+	"testing"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestChangeUserPassword(t *testing.T) {
+	name := "test_goisilon_password_user"
+
+	_, err := client.CreateUser(
+		defaultCtx, "", name, 0, "Password123!", "", "")
+	assertNoError(t, err)
+	defer client.DeleteUser(defaultCtx, "", name)
+
+	err = client.ChangeUserPassword(
+		defaultCtx, "", name, "Password123!", "Password456!")
+	assertNoError(t, err)
+
+	err = client.SetUserPasswordExpires(defaultCtx, "", name, false)
+	assertNoError(t, err)
+}
+
+func TestGetPasswordPolicy(t *testing.T) {
+	policy, err := client.GetPasswordPolicy(defaultCtx, "")
+	assertNoError(t, err)
+	assertNotNil(t, policy.MinPasswordLength)
+	t.Logf("min length=%d", *policy.MinPasswordLength)
+
+	// re-applying the current minimum length should be a no-op
+	err = client.UpdatePasswordPolicy(
+		defaultCtx, "",
+		&api.IsiLocalProvider{MinPasswordLength: policy.MinPasswordLength})
+	assertNoError(t, err)
+}
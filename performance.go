@@ -0,0 +1,84 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v7"
+)
+
+// PerformanceDatasetList is a list of performance datasets.
+type PerformanceDatasetList []*api.IsiPerformanceDataset
+
+// PerformanceDataset groups workload statistics by a set of metrics.
+type PerformanceDataset *api.IsiPerformanceDataset
+
+// PerformanceWorkloadList is a list of pinned workloads.
+type PerformanceWorkloadList []*api.IsiPerformanceWorkload
+
+// PerformanceWorkload is a workload pinned to a dataset.
+type PerformanceWorkload *api.IsiPerformanceWorkload
+
+// WorkloadStatList is a list of per-workload statistics.
+type WorkloadStatList []*api.IsiWorkloadStat
+
+// GetPerformanceDatasets returns the performance datasets. Partitioned
+// performance requires OneFS 8.2 or later.
+func (c *Client) GetPerformanceDatasets(
+	ctx context.Context) (PerformanceDatasetList, error) {
+
+	return api.GetIsiPerformanceDatasets(ctx, c.API)
+}
+
+// GetPerformanceDataset returns the performance dataset with the given ID or
+// name.
+func (c *Client) GetPerformanceDataset(
+	ctx context.Context, id string) (PerformanceDataset, error) {
+
+	return api.GetIsiPerformanceDataset(ctx, c.API, id)
+}
+
+// CreatePerformanceDataset creates a performance dataset that groups
+// workloads by the given metrics, such as "username", "protocol",
+// "export_id", or "path", and returns its ID.
+func (c *Client) CreatePerformanceDataset(
+	ctx context.Context, name string, metrics ...string) (int64, error) {
+
+	return api.CreateIsiPerformanceDataset(
+		ctx, c.API,
+		&api.IsiPerformanceDataset{Name: &name, Metrics: metrics})
+}
+
+// DeletePerformanceDataset removes a performance dataset.
+func (c *Client) DeletePerformanceDataset(ctx context.Context, id int64) error {
+	return api.DeleteIsiPerformanceDataset(ctx, c.API, id)
+}
+
+// GetPinnedWorkloads returns the workloads pinned to a dataset.
+func (c *Client) GetPinnedWorkloads(
+	ctx context.Context, dataset int64) (PerformanceWorkloadList, error) {
+
+	return api.GetIsiPerformanceWorkloads(ctx, c.API, dataset)
+}
+
+// PinWorkload pins a workload to a dataset so its statistics are always
+// reported, and returns the workload's ID.
+func (c *Client) PinWorkload(
+	ctx context.Context,
+	dataset int64, workload PerformanceWorkload) (int64, error) {
+
+	return api.PinIsiPerformanceWorkload(ctx, c.API, dataset, workload)
+}
+
+// UnpinWorkload removes a pinned workload from a dataset.
+func (c *Client) UnpinWorkload(
+	ctx context.Context, dataset, id int64) error {
+
+	return api.UnpinIsiPerformanceWorkload(ctx, c.API, dataset, id)
+}
+
+// GetWorkloadStats returns the current per-workload statistics for a dataset.
+func (c *Client) GetWorkloadStats(
+	ctx context.Context, dataset int64) (WorkloadStatList, error) {
+
+	return api.GetIsiWorkloadStats(ctx, c.API, dataset)
+}
@@ -0,0 +1,37 @@
+package goisilon
+
+import (
+	"testing"
+
+	api "github.com/tenortim/goisilon/api/v7"
+)
+
+func TestPerformanceDatasetPinWorkload(t *testing.T) {
+	if client.API.APIVersion() < 7 {
+		t.Skip("partitioned performance requires OneFS 8.2 or later")
+	}
+
+	id, err := client.CreatePerformanceDataset(
+		defaultCtx, "test_performance_dataset", "protocol")
+	assertNoError(t, err)
+	defer client.DeletePerformanceDataset(defaultCtx, id)
+
+	name, protocol := "test_workload", "nfs3"
+	wid, err := client.PinWorkload(
+		defaultCtx, id,
+		&api.IsiPerformanceWorkload{Name: &name, Protocol: &protocol})
+	assertNoError(t, err)
+	defer client.UnpinWorkload(defaultCtx, id, wid)
+
+	workloads, err := client.GetPinnedWorkloads(defaultCtx, id)
+	assertNoError(t, err)
+	if len(workloads) == 0 {
+		t.Fatal("pinned workload missing from dataset")
+	}
+
+	stats, err := client.GetWorkloadStats(defaultCtx, id)
+	assertNoError(t, err)
+	for _, s := range stats {
+		t.Logf("workload=%d ops=%f cpu=%f", s.WorkloadID, s.Ops, s.CPU)
+	}
+}
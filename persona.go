@@ -0,0 +1,195 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// personaIDString returns the form of a persona ID used to address users and
+// groups in the auth API, such as "UID:2000" or "SID:S-1-5-21-...". Names
+// are returned as is.
+func personaIDString(id *apiv2.PersonaID) string {
+	switch id.Type {
+	case apiv2.PersonaIDTypeUnknown,
+		apiv2.PersonaIDTypeUser,
+		apiv2.PersonaIDTypeGroup:
+		return id.ID
+	}
+	return id.Type.String() + ":" + id.ID
+}
+
+// LookupUID returns the UID of the user in an access zone with the given
+// name.
+func (c *Client) LookupUID(
+	ctx context.Context, zone, name string) (int, error) {
+
+	u, err := api.GetIsiUser(ctx, c.API, zone, name)
+	if err != nil {
+		return 0, err
+	}
+	if u.UID == nil || u.UID.ID == nil {
+		return 0, errors.New("user UID missing from response")
+	}
+	return strconv.Atoi(u.UID.ID.ID)
+}
+
+// LookupGID returns the GID of the group in an access zone with the given
+// name.
+func (c *Client) LookupGID(
+	ctx context.Context, zone, name string) (int, error) {
+
+	g, err := api.GetIsiGroup(ctx, c.API, zone, name)
+	if err != nil {
+		return 0, err
+	}
+	if g.GID == nil || g.GID.ID == nil {
+		return 0, errors.New("group GID missing from response")
+	}
+	return strconv.Atoi(g.GID.ID.ID)
+}
+
+// LookupUserName returns the name of the user in an access zone with the
+// given UID.
+func (c *Client) LookupUserName(
+	ctx context.Context, zone string, uid int) (string, error) {
+
+	u, err := c.GetUserByUID(ctx, zone, uid)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+
+// LookupGroupName returns the name of the group in an access zone with the
+// given GID.
+func (c *Client) LookupGroupName(
+	ctx context.Context, zone string, gid int) (string, error) {
+
+	g, err := c.GetGroupByGID(ctx, zone, gid)
+	if err != nil {
+		return "", err
+	}
+	return g.Name, nil
+}
+
+// LookupSID returns the SID of the user or group in an access zone with the
+// given name. Users are searched before groups.
+func (c *Client) LookupSID(
+	ctx context.Context, zone, name string) (string, error) {
+
+	var sid *apiv2.Persona
+	u, err := api.GetIsiUser(ctx, c.API, zone, name)
+	switch {
+	case err == nil:
+		sid = u.SID
+	case IsNotFound(err):
+		g, err := api.GetIsiGroup(ctx, c.API, zone, name)
+		if err != nil {
+			return "", err
+		}
+		sid = g.SID
+	default:
+		return "", err
+	}
+	if sid == nil || sid.ID == nil {
+		return "", errors.New("SID missing from response")
+	}
+	return sid.ID.ID, nil
+}
+
+// LookupSIDName returns the name of the user or group in an access zone with
+// the given SID, along with a flag that is true if it is a group.
+func (c *Client) LookupSIDName(
+	ctx context.Context, zone, sid string) (string, bool, error) {
+
+	id := apiv2.PersonaIDTypeSID.String() + ":" + sid
+	u, err := api.GetIsiUser(ctx, c.API, zone, id)
+	if err == nil {
+		return u.Name, false, nil
+	}
+	if !IsNotFound(err) {
+		return "", false, err
+	}
+	g, err := api.GetIsiGroup(ctx, c.API, zone, id)
+	if err != nil {
+		return "", false, err
+	}
+	return g.Name, true, nil
+}
+
+// ValidatePersona verifies that a persona refers to a user or group that
+// exists in an access zone, so that mistakes surface as clear errors before
+// the persona is sent to the cluster in an ACL update.
+func (c *Client) ValidatePersona(
+	ctx context.Context, zone string, p *apiv2.Persona) error {
+
+	if p == nil {
+		return errors.New("no persona set")
+	}
+
+	var (
+		id      string
+		isUser  bool
+		isGroup bool
+	)
+	switch {
+	case p.ID != nil:
+		id = personaIDString(p.ID)
+		switch p.ID.Type {
+		case apiv2.PersonaIDTypeUser, apiv2.PersonaIDTypeUID:
+			isUser = true
+		case apiv2.PersonaIDTypeGroup, apiv2.PersonaIDTypeGID:
+			isGroup = true
+		default:
+			isUser, isGroup = true, true
+		}
+	case p.Name != nil:
+		id = *p.Name
+		if p.Type == nil {
+			isUser, isGroup = true, true
+		} else {
+			switch *p.Type {
+			case apiv2.PersonaTypeUser:
+				isUser = true
+			case apiv2.PersonaTypeGroup:
+				isGroup = true
+			default:
+				isUser, isGroup = true, true
+			}
+		}
+	default:
+		return errors.New("persona has no id or name")
+	}
+
+	if isUser {
+		_, err := api.GetIsiUser(ctx, c.API, zone, id)
+		if err == nil {
+			return nil
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+	if isGroup {
+		_, err := api.GetIsiGroup(ctx, c.API, zone, id)
+		if err == nil {
+			return nil
+		}
+		if !IsNotFound(err) {
+			return err
+		}
+	}
+
+	switch {
+	case isUser && !isGroup:
+		return fmt.Errorf("no such user: %s", id)
+	case isGroup && !isUser:
+		return fmt.Errorf("no such group: %s", id)
+	}
+	return fmt.Errorf("no such user or group: %s", id)
+}
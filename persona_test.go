@@ -0,0 +1,49 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+func TestLookupPersonas(t *testing.T) {
+	uid, err := client.LookupUID(defaultCtx, "", "root")
+	assertNoError(t, err)
+	assert.Equal(t, 0, uid)
+
+	name, err := client.LookupUserName(defaultCtx, "", 0)
+	assertNoError(t, err)
+	assert.Equal(t, "root", name)
+
+	gid, err := client.LookupGID(defaultCtx, "", "wheel")
+	assertNoError(t, err)
+	assert.Equal(t, 0, gid)
+
+	sid, err := client.LookupSID(defaultCtx, "", "root")
+	assertNoError(t, err)
+	name, isGroup, err := client.LookupSIDName(defaultCtx, "", sid)
+	assertNoError(t, err)
+	assert.Equal(t, "root", name)
+	assert.False(t, isGroup)
+}
+
+func TestValidatePersona(t *testing.T) {
+	err := client.ValidatePersona(
+		defaultCtx, "",
+		&apiv2.Persona{
+			ID: &apiv2.PersonaID{ID: "0", Type: apiv2.PersonaIDTypeUID},
+		})
+	assertNoError(t, err)
+
+	err = client.ValidatePersona(
+		defaultCtx, "",
+		&apiv2.Persona{
+			ID: &apiv2.PersonaID{
+				ID:   "test_goisilon_no_such_user",
+				Type: apiv2.PersonaIDTypeUser,
+			},
+		})
+	assert.EqualError(t, err, "no such user: test_goisilon_no_such_user")
+}
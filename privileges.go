@@ -0,0 +1,159 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Capability is a group of library operations that require the same OneFS
+// privileges, ex. CapabilityQuotas for the quota functions.
+type Capability string
+
+// The capabilities that CheckCapabilities verifies.
+const (
+	CapabilityVolumes    Capability = "volumes"
+	CapabilityQuotas     Capability = "quotas"
+	CapabilitySnapshots  Capability = "snapshots"
+	CapabilityNFSExports Capability = "nfs exports"
+	CapabilitySMBShares  Capability = "smb shares"
+	CapabilityS3Buckets  Capability = "s3 buckets"
+	CapabilityJobs       Capability = "jobs"
+	CapabilitySyncIQ     Capability = "synciq"
+	CapabilityCloudPools Capability = "cloudpools"
+	CapabilityAntivirus  Capability = "antivirus"
+	CapabilityAudit      Capability = "audit"
+	CapabilityRoles      Capability = "roles"
+	CapabilityStatistics Capability = "statistics"
+)
+
+// RequiredPrivilege is a OneFS privilege, ex. "ISI_PRIV_QUOTA", and whether
+// write access to it is required.
+type RequiredPrivilege struct {
+	ID    string
+	Write bool
+}
+
+func (p RequiredPrivilege) String() string {
+	if p.Write {
+		return p.ID
+	}
+	return p.ID + " (read)"
+}
+
+// capabilityPrivileges are the privileges that each capability requires, in
+// addition to ISI_PRIV_LOGIN_PAPI.
+var capabilityPrivileges = map[Capability][]RequiredPrivilege{
+	CapabilityVolumes:    {{"ISI_PRIV_NS_IFS_ACCESS", true}},
+	CapabilityQuotas:     {{"ISI_PRIV_QUOTA", true}},
+	CapabilitySnapshots:  {{"ISI_PRIV_SNAPSHOT", true}},
+	CapabilityNFSExports: {{"ISI_PRIV_NFS", true}},
+	CapabilitySMBShares:  {{"ISI_PRIV_SMB", true}},
+	CapabilityS3Buckets:  {{"ISI_PRIV_S3", true}},
+	CapabilityJobs:       {{"ISI_PRIV_JOB_ENGINE", true}},
+	CapabilitySyncIQ:     {{"ISI_PRIV_SYNCIQ", true}},
+	CapabilityCloudPools: {{"ISI_PRIV_CLOUDPOOLS", true}},
+	CapabilityAntivirus:  {{"ISI_PRIV_ANTIVIRUS", true}},
+	CapabilityAudit:      {{"ISI_PRIV_AUDIT", true}},
+	CapabilityRoles:      {{"ISI_PRIV_ROLE", true}},
+	CapabilityStatistics: {{"ISI_PRIV_STATISTICS", false}},
+}
+
+// RequiredPrivileges returns the privileges that the capabilities require,
+// without duplicates. Write access is required of a privilege if any of the
+// capabilities requires it.
+func RequiredPrivileges(caps ...Capability) ([]RequiredPrivilege, error) {
+	var (
+		privs = []RequiredPrivilege{{"ISI_PRIV_LOGIN_PAPI", false}}
+		index = map[string]int{"ISI_PRIV_LOGIN_PAPI": 0}
+	)
+	for _, c := range caps {
+		required, ok := capabilityPrivileges[c]
+		if !ok {
+			return nil, fmt.Errorf("unknown capability: %s", c)
+		}
+		for _, p := range required {
+			if i, ok := index[p.ID]; ok {
+				privs[i].Write = privs[i].Write || p.Write
+				continue
+			}
+			index[p.ID] = len(privs)
+			privs = append(privs, p)
+		}
+	}
+	return privs, nil
+}
+
+// ErrMissingPrivilege is wrapped by the *PrivilegeError returned when the
+// authenticated user lacks a privilege that an operation requires.
+var ErrMissingPrivilege = errors.New("missing privilege")
+
+// PrivilegeError is returned by CheckCapabilities when the authenticated
+// user lacks privileges that the capabilities require.
+type PrivilegeError struct {
+	User string
+	Zone string
+
+	// Missing are the privileges the user lacks.
+	Missing []RequiredPrivilege
+}
+
+func (e *PrivilegeError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, p := range e.Missing {
+		missing[i] = p.String()
+	}
+	return fmt.Sprintf(
+		"user %s in zone %s is missing privileges %s; "+
+			"assign them to one of the user's roles",
+		e.User, e.Zone, strings.Join(missing, ", "))
+}
+
+// Unwrap returns ErrMissingPrivilege.
+func (e *PrivilegeError) Unwrap() error {
+	return ErrMissingPrivilege
+}
+
+// MissingPrivileges returns the privileges that the capabilities require
+// that the identity does not hold.
+func (i *Identity) MissingPrivileges(
+	caps ...Capability) ([]RequiredPrivilege, error) {
+
+	required, err := RequiredPrivileges(caps...)
+	if err != nil {
+		return nil, err
+	}
+	var missing []RequiredPrivilege
+	for _, p := range required {
+		if !i.HasPrivilege(p.ID, p.Write) {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
+// CheckCapabilities verifies that the authenticated user holds the
+// privileges that the capabilities require, so that a workflow can fail
+// before it is half done. A *PrivilegeError listing the missing privileges
+// is returned if the user lacks any.
+func (c *Client) CheckCapabilities(
+	ctx context.Context, caps ...Capability) error {
+
+	identity, err := c.WhoAmI(ctx)
+	if err != nil {
+		return err
+	}
+	missing, err := identity.MissingPrivileges(caps...)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &PrivilegeError{
+		User:    identity.User,
+		Zone:    identity.Zone,
+		Missing: missing,
+	}
+}
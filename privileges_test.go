@@ -0,0 +1,56 @@
+package goisilon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestRequiredPrivileges(t *testing.T) {
+	privs, err := RequiredPrivileges(
+		CapabilityStatistics, CapabilityQuotas, CapabilityQuotas)
+	assert.NoError(t, err)
+	assert.Equal(t, []RequiredPrivilege{
+		{"ISI_PRIV_LOGIN_PAPI", false},
+		{"ISI_PRIV_STATISTICS", false},
+		{"ISI_PRIV_QUOTA", true},
+	}, privs)
+
+	_, err = RequiredPrivileges("bogus")
+	assert.Error(t, err)
+}
+
+func TestMissingPrivileges(t *testing.T) {
+	identity := &Identity{
+		User: "svc",
+		Zone: "System",
+		Privileges: []*api.IsiPrivilege{
+			{ID: "ISI_PRIV_LOGIN_PAPI", ReadOnly: true},
+			{ID: "ISI_PRIV_QUOTA", ReadOnly: true},
+			{ID: "ISI_PRIV_SNAPSHOT"},
+		},
+	}
+	missing, err := identity.MissingPrivileges(
+		CapabilitySnapshots, CapabilityQuotas, CapabilityNFSExports)
+	assert.NoError(t, err)
+	assert.Equal(t, []RequiredPrivilege{
+		{"ISI_PRIV_QUOTA", true},
+		{"ISI_PRIV_NFS", true},
+	}, missing)
+
+	err = &PrivilegeError{User: "svc", Zone: "System", Missing: missing}
+	assert.True(t, errors.Is(err, ErrMissingPrivilege))
+	assert.Contains(t, err.Error(), "ISI_PRIV_QUOTA, ISI_PRIV_NFS")
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	err := client.CheckCapabilities(defaultCtx, CapabilityVolumes)
+	var perr *PrivilegeError
+	if errors.As(err, &perr) {
+		t.Skipf("test user lacks privileges: %v", err)
+	}
+	assertNoError(t, err)
+}
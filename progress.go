@@ -0,0 +1,105 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Progress receives the progress of long-running helpers, such as the
+// WaitFor helpers, including WaitForSyncJobCompletion and
+// WaitForQuotaReady, CopySnapshot, and TreeDeleteVolume. It is attached to
+// the context passed to the helper with ContextWithProgress. Op describes
+// the operation, ex. "job 12 to complete", and is the same for all the
+// calls made for one operation.
+type Progress interface {
+
+	// OnStart is called when the operation starts.
+	OnStart(op string)
+
+	// OnProgress is called when the operation reports progress.
+	OnProgress(op string, update ProgressUpdate)
+
+	// OnComplete is called when the operation ends, with its error if it
+	// failed.
+	OnComplete(op string, err error)
+}
+
+// ProgressUpdate is the progress of an operation. Current and Total are
+// zero if the operation does not report how far along it is.
+type ProgressUpdate struct {
+
+	// Current is the number of the step or phase in progress.
+	Current int64
+
+	// Total is the number of steps or phases.
+	Total int64
+
+	// Detail is a description of the progress, ex. the progress of a job.
+	Detail string
+}
+
+type progressKey struct{}
+
+// ContextWithProgress returns a copy of ctx that reports the progress of the
+// helpers it is passed to to p.
+func ContextWithProgress(ctx context.Context, p Progress) context.Context {
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// progressFrom returns the Progress of a context, which does nothing if
+// none is set.
+func progressFrom(ctx context.Context) Progress {
+	if p, ok := ctx.Value(progressKey{}).(Progress); ok && p != nil {
+		return p
+	}
+	return noProgress{}
+}
+
+type noProgress struct{}
+
+func (noProgress) OnStart(string)                    {}
+func (noProgress) OnProgress(string, ProgressUpdate) {}
+func (noProgress) OnComplete(string, error)          {}
+
+// ProgressWriter is a Progress that writes a line for each call to W, which
+// is safe for use by concurrent operations.
+type ProgressWriter struct {
+	W io.Writer
+
+	mu sync.Mutex
+}
+
+// OnStart writes that op started.
+func (p *ProgressWriter) OnStart(op string) {
+	p.printf("%s: started\n", op)
+}
+
+// OnProgress writes the progress of op.
+func (p *ProgressWriter) OnProgress(op string, update ProgressUpdate) {
+	switch {
+	case update.Total > 0 && update.Detail != "":
+		p.printf("%s: %d/%d %s\n", op, update.Current, update.Total,
+			update.Detail)
+	case update.Total > 0:
+		p.printf("%s: %d/%d\n", op, update.Current, update.Total)
+	case update.Detail != "":
+		p.printf("%s: %s\n", op, update.Detail)
+	}
+}
+
+// OnComplete writes whether op succeeded.
+func (p *ProgressWriter) OnComplete(op string, err error) {
+	if err != nil {
+		p.printf("%s: failed: %v\n", op, err)
+		return
+	}
+	p.printf("%s: done\n", op)
+}
+
+func (p *ProgressWriter) printf(format string, a ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprintf(p.W, format, a...)
+}
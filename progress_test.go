@@ -0,0 +1,135 @@
+package goisilon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// recordProgress is a Progress that records the calls made to it.
+type recordProgress struct {
+	calls []string
+}
+
+func (p *recordProgress) OnStart(op string) {
+	p.calls = append(p.calls, "start "+op)
+}
+
+func (p *recordProgress) OnProgress(op string, update ProgressUpdate) {
+	p.calls = append(p.calls, "progress "+op+" "+update.Detail)
+}
+
+func (p *recordProgress) OnComplete(op string, err error) {
+	if err != nil {
+		p.calls = append(p.calls, "fail "+op)
+		return
+	}
+	p.calls = append(p.calls, "done "+op)
+}
+
+func TestWaitForProgress(t *testing.T) {
+	defer func(min time.Duration) { waitMinInterval = min }(waitMinInterval)
+	waitMinInterval = time.Millisecond
+
+	p := &recordProgress{}
+	ctx := ContextWithProgress(defaultCtx, p)
+
+	var polls int
+	_, err := waitFor(ctx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			polls++
+			progressFrom(ctx).OnProgress("test", ProgressUpdate{Detail: "poll"})
+			return polls, polls == 2, nil
+		})
+	assertNoError(t, err)
+	assert.Equal(t, []string{
+		"start test", "progress test poll", "progress test poll", "done test",
+	}, p.calls)
+
+	p.calls = nil
+	_, err = waitFor(ctx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			return 0, false, errors.New("poll failed")
+		})
+	assertError(t, err)
+	assert.Equal(t, []string{"start test", "fail test"}, p.calls)
+
+	// without a Progress nothing is reported
+	_, err = waitFor(defaultCtx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			return 0, true, nil
+		})
+	assertNoError(t, err)
+}
+
+// syncJobClient is an API client for a cluster whose SyncIQ policy "p1"
+// has a job that runs for two polls.
+type syncJobClient struct {
+	api.Client
+	polls int
+}
+
+func (c *syncJobClient) Get(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	resp interface{}) error {
+
+	var body string
+	switch path {
+	case "platform/3/sync/jobs":
+		if c.polls++; c.polls > 2 {
+			return &api.JSONError{
+				StatusCode: 404,
+				Err:        []api.Error{{Code: "AEC_NOT_FOUND"}},
+			}
+		}
+		body = `{"jobs":[{"id":"p1","policy_name":"p1","state":"running",
+			"total_files":10,"files_transferred":4}]}`
+	case "platform/3/sync/reports":
+		body = `{"reports":[
+			{"id":"1-p1","policy_name":"p1","state":"finished","start_time":1},
+			{"id":"2-p1","policy_name":"p1","state":"failed","start_time":2}]}`
+	}
+	return json.Unmarshal([]byte(body), resp)
+}
+
+func TestWaitForSyncJobProgress(t *testing.T) {
+	defer func(min time.Duration) { waitMinInterval = min }(waitMinInterval)
+	waitMinInterval = time.Millisecond
+
+	p := &recordProgress{}
+	c := &Client{&syncJobClient{}}
+	report, err := c.WaitForSyncJobCompletion(
+		ContextWithProgress(defaultCtx, p), "p1")
+	assertNoError(t, err)
+	assert.Equal(t, "2-p1", report.ID)
+	assert.Equal(t, []string{
+		"start sync job of p1 to complete",
+		"progress sync job of p1 to complete running",
+		"progress sync job of p1 to complete running",
+		"done sync job of p1 to complete",
+	}, p.calls)
+}
+
+func TestProgressWriter(t *testing.T) {
+	var out bytes.Buffer
+	p := &ProgressWriter{W: &out}
+	p.OnStart("job 1 to complete")
+	p.OnProgress("job 1 to complete",
+		ProgressUpdate{Current: 1, Total: 3, Detail: "Phase 1: 40% complete"})
+	p.OnProgress("job 1 to complete", ProgressUpdate{})
+	p.OnComplete("job 1 to complete", errors.New("job failed"))
+	assert.Equal(t,
+		"job 1 to complete: started\n"+
+			"job 1 to complete: 1/3 Phase 1: 40% complete\n"+
+			"job 1 to complete: failed: job failed\n",
+		out.String())
+}
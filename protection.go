@@ -0,0 +1,159 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// SSD strategies of a data storage policy.
+const (
+	SSDStrategyMetadata      = "metadata"
+	SSDStrategyMetadataWrite = "metadata-write"
+	SSDStrategyData          = "data"
+	SSDStrategyAvoid         = "avoid"
+)
+
+// file pool policy actions used to manage protection
+const (
+	actionSetRequestedProtection = "set_requested_protection"
+	actionApplyDataStoragePolicy = "apply_data_storage_policy"
+)
+
+// VolumeProtection is the requested protection and SSD strategy of the files
+// in a volume. Empty fields are left to the cluster's default policy.
+type VolumeProtection struct {
+
+	// RequestedProtection is the protection level, ex. "+2d:1n" or "3x".
+	RequestedProtection string
+
+	// SSDStrategy is one of the SSDStrategy* constants.
+	SSDStrategy string
+}
+
+func protectionPolicyName(volumeName string) string {
+	return "goisilon_protection_" + volumeName
+}
+
+// SetVolumeProtection sets the requested protection and SSD strategy of the
+// files in a volume. They are set by a file pool policy named after the
+// volume that is created on first use, and are applied to existing files by
+// the next SmartPools job.
+func (c *Client) SetVolumeProtection(
+	ctx context.Context, name string, protection *VolumeProtection) error {
+
+	if protection == nil ||
+		protection.RequestedProtection == "" && protection.SSDStrategy == "" {
+		return errors.New("no protection set")
+	}
+
+	var actions []*api.IsiFilePoolAction
+	if protection.RequestedProtection != "" {
+		actions = append(actions, &api.IsiFilePoolAction{
+			ActionType:  actionSetRequestedProtection,
+			ActionParam: protection.RequestedProtection,
+		})
+	}
+	if protection.SSDStrategy != "" {
+		actions = append(actions, &api.IsiFilePoolAction{
+			ActionType: actionApplyDataStoragePolicy,
+			ActionParam: &api.IsiFilePoolStoragePolicy{
+				SSDStrategy: protection.SSDStrategy,
+				StoragePool: "anywhere",
+			},
+		})
+	}
+
+	var (
+		policyName = protectionPolicyName(name)
+		pattern    = volumeProtectionPattern(c.API.VolumePath(name))
+	)
+	_, err := api.GetIsiFilePoolPolicy(ctx, c.API, policyName)
+	if err == nil {
+		return api.UpdateIsiFilePoolPolicy(
+			ctx, c.API, policyName,
+			&api.IsiFilePoolPolicy{
+				FileMatchingPattern: pattern,
+				Actions:             &actions,
+			})
+	}
+	if !IsNotFound(err) {
+		return err
+	}
+
+	description := "goisilon protection of volume " + name
+	return api.CreateIsiFilePoolPolicy(
+		ctx, c.API,
+		&api.IsiFilePoolPolicy{
+			Name:                &policyName,
+			Description:         &description,
+			FileMatchingPattern: pattern,
+			Actions:             &actions,
+		})
+}
+
+// volumeProtectionPattern matches a volume's directory and the files in it,
+// but not other volumes whose names begin with the volume's name.
+func volumeProtectionPattern(volumePath string) *api.IsiFilePoolPattern {
+	return &api.IsiFilePoolPattern{
+		OrCriteria: []*api.IsiFilePoolOrCriteria{
+			{AndCriteria: []*api.IsiFilePoolCriterion{{
+				Type:     "path",
+				Operator: "==",
+				Value:    volumePath,
+			}}},
+			{AndCriteria: []*api.IsiFilePoolCriterion{{
+				Type:       "path",
+				Operator:   "==",
+				Value:      volumePath + "/",
+				BeginsWith: true,
+			}}},
+		},
+	}
+}
+
+// GetVolumeProtection returns the protection set on a volume by
+// SetVolumeProtection, or nil if none is set.
+func (c *Client) GetVolumeProtection(
+	ctx context.Context, name string) (*VolumeProtection, error) {
+
+	policy, err := api.GetIsiFilePoolPolicy(
+		ctx, c.API, protectionPolicyName(name))
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return volumeProtection(policy), nil
+}
+
+// ClearVolumeProtection removes the protection set on a volume by
+// SetVolumeProtection. Its files return to the default policy at the next
+// SmartPools job.
+func (c *Client) ClearVolumeProtection(ctx context.Context, name string) error {
+	err := api.DeleteIsiFilePoolPolicy(ctx, c.API, protectionPolicyName(name))
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func volumeProtection(policy *api.IsiFilePoolPolicy) *VolumeProtection {
+	protection := &VolumeProtection{}
+	if policy.Actions == nil {
+		return protection
+	}
+	for _, a := range *policy.Actions {
+		switch a.ActionType {
+		case actionSetRequestedProtection:
+			protection.RequestedProtection, _ = a.ActionParam.(string)
+		case actionApplyDataStoragePolicy:
+			if m, ok := a.ActionParam.(map[string]interface{}); ok {
+				protection.SSDStrategy, _ = m["ssd_strategy"].(string)
+			}
+		}
+	}
+	return protection
+}
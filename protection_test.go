@@ -0,0 +1,95 @@
+package goisilon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+func TestVolumeProtectionPattern(t *testing.T) {
+	pattern := volumeProtectionPattern("/ifs/volumes/a")
+	assert.Equal(t, &api.IsiFilePoolPattern{
+		OrCriteria: []*api.IsiFilePoolOrCriteria{
+			{AndCriteria: []*api.IsiFilePoolCriterion{{
+				Type: "path", Operator: "==", Value: "/ifs/volumes/a",
+			}}},
+			{AndCriteria: []*api.IsiFilePoolCriterion{{
+				Type: "path", Operator: "==", Value: "/ifs/volumes/a/",
+				BeginsWith: true,
+			}}},
+		},
+	}, pattern)
+
+	// evaluate the path criteria as the cluster does
+	matches := func(p string) bool {
+		for _, or := range pattern.OrCriteria {
+			c := or.AndCriteria[0]
+			if p == c.Value || c.BeginsWith && strings.HasPrefix(p, c.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	for p, want := range map[string]bool{
+		"/ifs/volumes/a":       true,
+		"/ifs/volumes/a/file":  true,
+		"/ifs/volumes/a/b/c":   true,
+		"/ifs/volumes/ab":      false,
+		"/ifs/volumes/a_old/x": false,
+		"/ifs/volumes":         false,
+	} {
+		assert.Equal(t, want, matches(p), p)
+	}
+}
+
+func TestVolumeProtection(t *testing.T) {
+	volumeName := "test_volume_protection"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	protection, err := client.GetVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Nil(t, protection)
+
+	defer client.ClearVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, client.SetVolumeProtection(defaultCtx, volumeName,
+		&VolumeProtection{RequestedProtection: "+2d:1n"}))
+	assertNoError(t, client.SetVolumeProtection(defaultCtx, volumeName,
+		&VolumeProtection{
+			RequestedProtection: "+3",
+			SSDStrategy:         SSDStrategyMetadata,
+		}))
+
+	protection, err = client.GetVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, &VolumeProtection{
+		RequestedProtection: "+3",
+		SSDStrategy:         SSDStrategyMetadata,
+	}, protection)
+
+	assertNoError(t, client.ClearVolumeProtection(defaultCtx, volumeName))
+	protection, err = client.GetVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Nil(t, protection)
+}
+
+func TestApplyVolumeProtection(t *testing.T) {
+	volumeName := "test_apply_volume_protection"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	defer client.ClearVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, client.SetVolumeProtection(defaultCtx, volumeName,
+		&VolumeProtection{RequestedProtection: "+2d:1n"}))
+
+	job, err := client.ApplyVolumeProtection(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, JobStateSucceeded, job.State)
+}
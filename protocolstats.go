@@ -0,0 +1,178 @@
+package goisilon
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// DefaultStatsProtocols are the protocols GetProtocolStats reports if none
+// are given.
+var DefaultStatsProtocols = []string{"nfs3", "nfs4", "smb2", "s3", "hdfs"}
+
+// ProtocolOpStats are the statistics of an operation of a protocol across
+// the cluster.
+type ProtocolOpStats struct {
+	Operation string  `json:"operation"`
+	Class     string  `json:"class"`
+	OpRate    float64 `json:"op_rate"`
+
+	// LatencyAvg is the average latency of the operation, weighted by the
+	// rate on each node.
+	LatencyAvg time.Duration `json:"latency_avg"`
+}
+
+// ProtocolStats are the statistics of a protocol across the cluster. Rates
+// are per second. The cluster reports the average, minimum, maximum and
+// standard deviation of latencies rather than percentiles.
+type ProtocolStats struct {
+	Protocol string `json:"protocol"`
+
+	// OpRate is the total rate of operations.
+	OpRate float64 `json:"op_rate"`
+
+	// InRate and OutRate are the rates of bytes received and sent.
+	InRate  float64 `json:"in_rate"`
+	OutRate float64 `json:"out_rate"`
+
+	// LatencyAvg is the average latency of all operations, weighted by
+	// their rates. LatencyMin and LatencyMax are the extremes across
+	// operations and nodes, and LatencyStddev is the largest standard
+	// deviation of an operation's latency on a node.
+	LatencyAvg    time.Duration `json:"latency_avg"`
+	LatencyMin    time.Duration `json:"latency_min"`
+	LatencyMax    time.Duration `json:"latency_max"`
+	LatencyStddev time.Duration `json:"latency_stddev"`
+
+	// ActiveClients is the number of distinct clients with recent
+	// operations.
+	ActiveClients int `json:"active_clients"`
+
+	// Operations are the statistics of each operation, by decreasing rate.
+	Operations []*ProtocolOpStats `json:"operations"`
+}
+
+// GetProtocolStats returns the statistics of protocols across the cluster,
+// in the order of the protocols, or of DefaultStatsProtocols if none are
+// given. A protocol with no recent operations has zero statistics.
+func (c *Client) GetProtocolStats(
+	ctx context.Context, protocols ...string) ([]*ProtocolStats, error) {
+
+	if len(protocols) == 0 {
+		protocols = DefaultStatsProtocols
+	}
+	ops, err := apiv3.GetIsiProtocolSummary(
+		ctx, c.API, protocols, StatNodesAll)
+	if err != nil {
+		return nil, err
+	}
+	clients, err := apiv3.GetIsiClientSummary(
+		ctx, c.API, protocols, StatNodesAll)
+	if err != nil {
+		return nil, err
+	}
+	return protocolStats(protocols, ops, clients), nil
+}
+
+// WatchProtocolStats starts a Watcher that polls the statistics of
+// protocols. See GetProtocolStats.
+func (c *Client) WatchProtocolStats(
+	ctx context.Context,
+	opts *WatchOptions,
+	protocols ...string) *Watcher[[]*ProtocolStats] {
+
+	return NewWatcher(ctx, opts,
+		func(ctx context.Context) ([]*ProtocolStats, error) {
+			return c.GetProtocolStats(ctx, protocols...)
+		})
+}
+
+// microseconds converts a time in microseconds to a duration.
+func microseconds(v float64) time.Duration {
+	return time.Duration(v * float64(time.Microsecond))
+}
+
+func protocolStats(
+	protocols []string,
+	ops []*apiv3.IsiProtocolSummaryStat,
+	clients []*apiv3.IsiClientSummaryStat) []*ProtocolStats {
+
+	type opKey struct{ protocol, operation string }
+	var (
+		stats      = make([]*ProtocolStats, len(protocols))
+		byProtocol = map[string]*ProtocolStats{}
+		opStats    = map[opKey]*ProtocolOpStats{}
+		latency    = map[string]float64{}
+		opLatency  = map[opKey]float64{}
+		minLatency = map[string]float64{}
+		clientSets = map[string]map[string]bool{}
+	)
+	for i, p := range protocols {
+		stats[i] = &ProtocolStats{
+			Protocol:   p,
+			Operations: []*ProtocolOpStats{},
+		}
+		byProtocol[p] = stats[i]
+		clientSets[p] = map[string]bool{}
+		minLatency[p] = math.Inf(1)
+	}
+
+	for _, o := range ops {
+		s, ok := byProtocol[o.Protocol]
+		if !ok {
+			continue
+		}
+		s.OpRate += o.OperationRate
+		s.InRate += o.In
+		s.OutRate += o.Out
+		latency[o.Protocol] += o.TimeAvg * o.OperationRate
+		if o.OperationCount > 0 || o.OperationRate > 0 {
+			minLatency[o.Protocol] = math.Min(minLatency[o.Protocol], o.TimeMin)
+		}
+		if t := microseconds(o.TimeMax); t > s.LatencyMax {
+			s.LatencyMax = t
+		}
+		if t := microseconds(o.TimeStddev); t > s.LatencyStddev {
+			s.LatencyStddev = t
+		}
+
+		k := opKey{o.Protocol, o.Operation}
+		op, ok := opStats[k]
+		if !ok {
+			op = &ProtocolOpStats{Operation: o.Operation, Class: o.Class}
+			opStats[k] = op
+			s.Operations = append(s.Operations, op)
+		}
+		op.OpRate += o.OperationRate
+		opLatency[k] += o.TimeAvg * o.OperationRate
+	}
+
+	for _, cl := range clients {
+		if set, ok := clientSets[cl.Protocol]; ok {
+			set[cl.Client] = true
+		}
+	}
+
+	for _, s := range stats {
+		if s.OpRate > 0 {
+			s.LatencyAvg = microseconds(latency[s.Protocol] / s.OpRate)
+		}
+		if m := minLatency[s.Protocol]; !math.IsInf(m, 1) {
+			s.LatencyMin = microseconds(m)
+		}
+		s.ActiveClients = len(clientSets[s.Protocol])
+		for _, op := range s.Operations {
+			if op.OpRate > 0 {
+				op.LatencyAvg = microseconds(
+					opLatency[opKey{s.Protocol, op.Operation}] / op.OpRate)
+			}
+		}
+		sort.SliceStable(s.Operations, func(i, j int) bool {
+			return s.Operations[i].OpRate > s.Operations[j].OpRate
+		})
+	}
+	return stats
+}
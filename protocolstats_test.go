@@ -0,0 +1,79 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestProtocolStatsAggregation(t *testing.T) {
+	ops := []*apiv3.IsiProtocolSummaryStat{
+		{Protocol: "nfs3", Node: 1, Operation: "read", Class: "read",
+			OperationCount: 30, OperationRate: 30, In: 10, Out: 3000,
+			TimeAvg: 100, TimeMin: 50, TimeMax: 400, TimeStddev: 20},
+		{Protocol: "nfs3", Node: 2, Operation: "read", Class: "read",
+			OperationCount: 10, OperationRate: 10, In: 5, Out: 1000,
+			TimeAvg: 300, TimeMin: 80, TimeMax: 900, TimeStddev: 60},
+		{Protocol: "nfs3", Node: 1, Operation: "write", Class: "write",
+			OperationCount: 60, OperationRate: 60, In: 6000, Out: 10,
+			TimeAvg: 200, TimeMin: 40, TimeMax: 800, TimeStddev: 30},
+		{Protocol: "ftp", Node: 1, Operation: "read", OperationRate: 5},
+	}
+	clients := []*apiv3.IsiClientSummaryStat{
+		{Protocol: "nfs3", Client: "10.0.0.1", Node: 1},
+		{Protocol: "nfs3", Client: "10.0.0.1", Node: 2},
+		{Protocol: "nfs3", Client: "10.0.0.2", Node: 1},
+		{Protocol: "ftp", Client: "10.0.0.3", Node: 1},
+	}
+
+	stats := protocolStats([]string{"nfs3", "smb2"}, ops, clients)
+	assertLen(t, stats, 2)
+
+	s := stats[0]
+	assert.Equal(t, "nfs3", s.Protocol)
+	assert.Equal(t, 100.0, s.OpRate)
+	assert.Equal(t, 6015.0, s.InRate)
+	assert.Equal(t, 4010.0, s.OutRate)
+	// (30*100 + 10*300 + 60*200) / 100
+	assert.Equal(t, 180*time.Microsecond, s.LatencyAvg)
+	assert.Equal(t, 40*time.Microsecond, s.LatencyMin)
+	assert.Equal(t, 900*time.Microsecond, s.LatencyMax)
+	assert.Equal(t, 60*time.Microsecond, s.LatencyStddev)
+	assert.Equal(t, 2, s.ActiveClients)
+
+	assertLen(t, s.Operations, 2)
+	assert.Equal(t, "write", s.Operations[0].Operation)
+	assert.Equal(t, 60.0, s.Operations[0].OpRate)
+	assert.Equal(t, "read", s.Operations[1].Operation)
+	assert.Equal(t, 40.0, s.Operations[1].OpRate)
+	// (30*100 + 10*300) / 40
+	assert.Equal(t, 150*time.Microsecond, s.Operations[1].LatencyAvg)
+
+	s = stats[1]
+	assert.Equal(t, "smb2", s.Protocol)
+	assert.Zero(t, s.OpRate)
+	assert.Zero(t, s.LatencyMin)
+	assert.Zero(t, s.ActiveClients)
+	assert.Empty(t, s.Operations)
+}
+
+func TestGetProtocolStats(t *testing.T) {
+	stats, err := client.GetProtocolStats(defaultCtx)
+	assertNoError(t, err)
+	assertLen(t, stats, len(DefaultStatsProtocols))
+}
+
+func TestWatchProtocolStats(t *testing.T) {
+	w := client.WatchProtocolStats(
+		defaultCtx, &WatchOptions{Interval: time.Second}, "nfs3", "smb2")
+	defer w.Stop()
+
+	for i := 0; i < 2; i++ {
+		s := <-w.C()
+		assertNoError(t, s.Err)
+		assertLen(t, s.Value, 2)
+		assert.Equal(t, "nfs3", s.Value[0].Protocol)
+	}
+}
@@ -0,0 +1,160 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tenortim/goisilon/api"
+)
+
+// ProvisionOptions configure the optional steps of ProvisionVolume.
+type ProvisionOptions struct {
+
+	// Owner, if set, is the name of the user made owner of the volume.
+	Owner string
+
+	// ACL, if set, is applied to the volume after the owner is set.
+	ACL *ACL
+
+	// QuotaSize, if not zero, is the hard threshold in bytes of a directory
+	// quota created on the volume.
+	QuotaSize int64
+
+	// QuotaContainer sets the container option of the quota.
+	QuotaContainer bool
+
+	// Export creates an NFS export of the volume.
+	Export bool
+
+	// ExportZone is the access zone of the export. If empty the client's
+	// zone is used.
+	ExportZone string
+}
+
+// ProvisionError is returned by ProvisionVolume when a step fails.
+type ProvisionError struct {
+
+	// Volume is the name of the volume being provisioned.
+	Volume string
+
+	// Step is the step that failed.
+	Step string
+
+	// Err is the error returned by the step.
+	Err error
+
+	// RollbackErr holds the errors of any completed steps that could not be
+	// undone, or nil if the rollback succeeded.
+	RollbackErr error
+}
+
+func (e *ProvisionError) Error() string {
+	msg := fmt.Sprintf("provision volume %s: %s: %v", e.Volume, e.Step, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(" (rollback failed: %v)", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the error of the failed step.
+func (e *ProvisionError) Unwrap() error {
+	return e.Err
+}
+
+// ProvisionVolume creates a volume, sets its owner and ACL, creates its
+// quota and exports it as a unit. If a step fails the completed steps are
+// undone in reverse order and a *ProvisionError is returned. The volume must
+// not already exist, so that a rollback never removes existing data.
+func (c *Client) ProvisionVolume(
+	ctx context.Context,
+	name string, opts *ProvisionOptions) (*Volume, error) {
+
+	if opts == nil {
+		opts = &ProvisionOptions{}
+	}
+
+	var (
+		undo []func(context.Context) error
+		step string
+	)
+	fail := func(err error) (*Volume, error) {
+		// undo even if ctx was canceled, which may be why the step failed
+		rctx := context.WithoutCancel(ctx)
+		var errs []error
+		for i := len(undo) - 1; i >= 0; i-- {
+			if err := undo[i](rctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return nil, &ProvisionError{
+			Volume:      name,
+			Step:        step,
+			Err:         err,
+			RollbackErr: errors.Join(errs...),
+		}
+	}
+
+	step = "create volume"
+	if _, err := c.GetVolume(ctx, "", name); err == nil {
+		return fail(&api.JSONError{
+			StatusCode: http.StatusConflict,
+			Err: []api.Error{{
+				Code:    string(api.ErrExists),
+				Message: fmt.Sprintf("Volume already exists: %s", name),
+			}},
+		})
+	} else if !IsNotFound(err) {
+		return fail(err)
+	}
+	volume, err := c.CreateVolume(ctx, name)
+	if err != nil {
+		return fail(err)
+	}
+	undo = append(undo, func(ctx context.Context) error {
+		return c.DeleteVolume(ctx, name)
+	})
+
+	if opts.Owner != "" {
+		step = "set owner"
+		if err := c.SetVolumeOwner(ctx, name, opts.Owner); err != nil {
+			return fail(err)
+		}
+	}
+
+	if opts.ACL != nil {
+		step = "set acl"
+		if err := c.SetVolumeACL(ctx, name, opts.ACL); err != nil {
+			return fail(err)
+		}
+	}
+
+	if opts.QuotaSize != 0 {
+		step = "create quota"
+		err := c.CreateQuota(ctx, name, opts.QuotaContainer, opts.QuotaSize)
+		if err != nil {
+			return fail(err)
+		}
+		undo = append(undo, func(ctx context.Context) error {
+			return c.ClearQuota(ctx, name)
+		})
+	}
+
+	if opts.Export {
+		step = "export"
+		zone := opts.ExportZone
+		if zone == "" {
+			zone = c.API.Zone()
+		}
+		id, err := c.ExportWithZone(ctx, name, zone)
+		if err != nil {
+			return fail(err)
+		}
+		undo = append(undo, func(ctx context.Context) error {
+			return c.UnexportByIDWithZone(ctx, id, zone)
+		})
+	}
+
+	return volume, nil
+}
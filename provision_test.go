@@ -0,0 +1,52 @@
+package goisilon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvisionVolume(t *testing.T) {
+	volumeName := "test_provision_volume"
+	quotaSize := int64(12345)
+
+	volume, err := client.ProvisionVolume(defaultCtx, volumeName,
+		&ProvisionOptions{QuotaSize: quotaSize, Export: true})
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	defer client.ClearQuota(defaultCtx, volumeName)
+	defer client.Unexport(defaultCtx, volumeName)
+	assert.Equal(t, volumeName, volume.Name)
+
+	quota, err := client.GetQuota(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, quotaSize, quota.HardThreshold())
+	ok, _, err := client.IsExported(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.True(t, ok)
+
+	// provisioning an existing volume fails without removing it
+	_, err = client.ProvisionVolume(defaultCtx, volumeName, nil)
+	assert.True(t, IsConflict(err), "%v", err)
+	_, err = client.GetVolume(defaultCtx, "", volumeName)
+	assertNoError(t, err)
+}
+
+func TestProvisionVolumeRollback(t *testing.T) {
+	volumeName := "test_provision_volume_rollback"
+
+	_, err := client.ProvisionVolume(defaultCtx, volumeName,
+		&ProvisionOptions{Owner: "test_provision_no_such_user"})
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	var perr *ProvisionError
+	if !assert.True(t, errors.As(err, &perr), "%v", err) {
+		return
+	}
+	assert.Equal(t, "set owner", perr.Step)
+	assertNoError(t, perr.RollbackErr)
+
+	_, err = client.GetVolume(defaultCtx, "", volumeName)
+	assert.True(t, IsNotFound(err), "%v", err)
+}
@@ -2,25 +2,142 @@ package goisilon
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	api "github.com/tenortim/goisilon/api/v1"
 )
 
 // Quota maps to an Isilon filesystem quota.
-type Quota *api.IsiQuota
+type Quota api.IsiQuota
 
-// GetQuota returns a specific quota by path
-func (c *Client) GetQuota(ctx context.Context, name string) (Quota, error) {
+// QuotaFromAPI converts an API quota to a Quota.
+func QuotaFromAPI(q *api.IsiQuota) *Quota {
+	return (*Quota)(q)
+}
+
+// API returns the quota as an API quota.
+func (q *Quota) API() *api.IsiQuota {
+	return (*api.IsiQuota)(q)
+}
+
+// HardThreshold returns the quota's hard threshold in bytes, or zero if the
+// quota is nil.
+func (q *Quota) HardThreshold() int64 {
+	if q == nil {
+		return 0
+	}
+	return q.Thresholds.Hard
+}
+
+// LogicalUsage returns the logical size of the files under the quota in
+// bytes, or zero if the quota is nil.
+func (q *Quota) LogicalUsage() int64 {
+	if q == nil {
+		return 0
+	}
+	return q.Usage.Logical
+}
+
+// PhysicalUsage returns the size of the files under the quota on disk in
+// bytes, including protection overhead, or zero if the quota is nil.
+func (q *Quota) PhysicalUsage() int64 {
+	if q == nil {
+		return 0
+	}
+	return q.Usage.Physical
+}
+
+// ThresholdUsage returns the usage that the quota's thresholds apply to in
+// bytes: the physical usage if the thresholds include overhead, and the
+// logical usage otherwise.
+func (q *Quota) ThresholdUsage() int64 {
+	if q == nil {
+		return 0
+	}
+	if q.ThresholdsIncludeOverhead {
+		return q.Usage.Physical
+	}
+	return q.Usage.Logical
+}
+
+// IsExceeded returns whether any of the quota's thresholds are exceeded.
+func (q *Quota) IsExceeded() bool {
+	if q == nil {
+		return false
+	}
+	t := q.Thresholds
+	return t.AdvisoryExceeded || t.SoftExceeded || t.HardExceeded
+}
+
+// ListQuotas returns a list of all quotas on the cluster that is fetched a
+// page at a time.
+func (c *Client) ListQuotas() *List[*Quota] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*Quota, string, error) {
+
+		quotas, resume, err := api.GetIsiQuotasPage(ctx, c.API, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		list := make([]*Quota, len(quotas))
+		for i, q := range quotas {
+			list[i] = QuotaFromAPI(q)
+		}
+		return list, resume, nil
+	})
+}
+
+// ListExceededQuotas returns a list of the quotas with an exceeded threshold
+// that is fetched a page at a time.
+func (c *Client) ListExceededQuotas() *List[*Quota] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*Quota, string, error) {
+
+		quotas, resume, err := api.GetIsiExceededQuotasPage(
+			ctx, c.API, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		list := make([]*Quota, len(quotas))
+		for i, q := range quotas {
+			list[i] = QuotaFromAPI(q)
+		}
+		return list, resume, nil
+	})
+}
+
+// GetQuota returns the quota of a volume that excludes snapshots, as
+// created by CreateQuota. See GetQuotaWithSnapshots for the quota that
+// includes them.
+func (c *Client) GetQuota(ctx context.Context, name string) (*Quota, error) {
 	quota, err := api.GetIsiQuota(ctx, c.API, c.API.VolumePath(name))
 	if err != nil {
 		return nil, err
 	}
 
-	return quota, nil
+	return QuotaFromAPI(quota), nil
+}
+
+// GetQuotaWithSnapshots returns the quota of a volume that includes or
+// excludes snapshots. A volume may have one of each.
+func (c *Client) GetQuotaWithSnapshots(
+	ctx context.Context, name string, includeSnapshots bool) (*Quota, error) {
+
+	quota, err := api.GetIsiQuotaWithSnapshots(
+		ctx, c.API, c.API.VolumePath(name), includeSnapshots)
+	if err != nil {
+		return nil, err
+	}
+	return QuotaFromAPI(quota), nil
 }
 
 // TODO: Add a means to set/update more fields of the quota
 
+// QuotaOptions are the accounting options of a quota.
+type QuotaOptions api.IsiQuotaOptions
+
 // CreateQuota creates a new hard directory quota with the specified size
 // and container option
 func (c *Client) CreateQuota(
@@ -30,6 +147,112 @@ func (c *Client) CreateQuota(
 		ctx, c.API, c.API.VolumePath(name), container, size)
 }
 
+// CreateQuotaWithOptions creates a new hard directory quota with the
+// specified size, container option and accounting options. A volume may have
+// a quota that includes snapshots as well as one that does not, ex. to
+// report both numbers.
+func (c *Client) CreateQuotaWithOptions(
+	ctx context.Context,
+	name string, container bool, size int64, opts *QuotaOptions) error {
+
+	return api.CreateIsiQuotaWithOptions(
+		ctx, c.API, c.API.VolumePath(name), container, size,
+		(*api.IsiQuotaOptions)(opts))
+}
+
+// UpdateQuotaOptions updates the accounting options of the quota of a volume
+// that includes snapshots if opts.IncludeSnapshots is set, or excludes them
+// otherwise, as whether a quota includes snapshots cannot be changed.
+func (c *Client) UpdateQuotaOptions(
+	ctx context.Context, name string, opts *QuotaOptions) error {
+
+	if opts == nil {
+		return errors.New("no quota options set")
+	}
+	quota, err := c.GetQuotaWithSnapshots(ctx, name, opts.IncludeSnapshots)
+	if err != nil {
+		return err
+	}
+	if quota.ThresholdsIncludeOverhead == opts.ThresholdsIncludeOverhead {
+		return nil
+	}
+	return api.UpdateIsiQuotaAccounting(
+		ctx, c.API, quota.Id, opts.ThresholdsIncludeOverhead)
+}
+
+// QuotaUsage is the usage of a volume as accounted for by its quotas.
+type QuotaUsage struct {
+	Path string
+
+	// Exclusive is the usage of the quota that excludes snapshots, or nil
+	// if the volume has no such quota.
+	Exclusive *api.IsiQuotaUsage
+
+	// Inclusive is the usage of the quota that includes snapshots, or nil
+	// if the volume has no such quota.
+	Inclusive *api.IsiQuotaUsage
+}
+
+// GetQuotaUsage returns the usage of a volume with and without snapshots, as
+// accounted for by its quotas, so that either may be used for billing.
+func (c *Client) GetQuotaUsage(
+	ctx context.Context, name string) (*QuotaUsage, error) {
+
+	path := c.API.VolumePath(name)
+	quotas, err := api.GetIsiQuotas(ctx, c.API, path)
+	if err != nil {
+		return nil, err
+	}
+	usage := &QuotaUsage{Path: path}
+	for _, quota := range quotas {
+		if quota.IncludeSnapshots {
+			usage.Inclusive = &quota.Usage
+		} else {
+			usage.Exclusive = &quota.Usage
+		}
+	}
+	return usage, nil
+}
+
+// EnsureQuota creates a hard directory quota with the specified size and
+// container option, or returns the quota if it already exists. An existing
+// quota must have the requested size and container option, otherwise an
+// error wrapping ErrMismatch is returned.
+func (c *Client) EnsureQuota(
+	ctx context.Context,
+	name string, container bool, size int64) (*Quota, error) {
+
+	quota, err := c.GetQuota(ctx, name)
+	if IsNotFound(err) {
+		if err = c.CreateQuota(ctx, name, container, size); err != nil &&
+			!IsConflict(err) {
+			return nil, err
+		}
+		quota, err = c.GetQuota(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := quotaMatches(quota, container, size); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+func quotaMatches(quota *Quota, container bool, size int64) error {
+	if quota.HardThreshold() != size {
+		return fmt.Errorf(
+			"quota on %s has hard threshold %d, not %d: %w",
+			quota.Path, quota.HardThreshold(), size, ErrMismatch)
+	}
+	if quota.Container != container {
+		return fmt.Errorf(
+			"quota on %s has container %t, not %t: %w",
+			quota.Path, quota.Container, container, ErrMismatch)
+	}
+	return nil
+}
+
 // SetQuotaSize sets the max size (hard threshold) of a quota for a volume
 func (c *Client) SetQuotaSize(
 	ctx context.Context, name string, size int64) error {
@@ -46,7 +269,174 @@ func (c *Client) UpdateQuotaSize(
 		ctx, c.API, c.API.VolumePath(name), size)
 }
 
-// ClearQuota removes the quota from a volume
+// SetQuotaContainer sets whether the quota of a volume is a container, which
+// reports its hard threshold as the size of the volume to clients, without
+// removing the quota and so leaving the volume unenforced for a time.
+func (c *Client) SetQuotaContainer(
+	ctx context.Context, name string, container bool) error {
+
+	quota, err := c.GetQuota(ctx, name)
+	if err != nil {
+		return err
+	}
+	if quota.Container == container {
+		return nil
+	}
+	return api.UpdateIsiQuotaFlags(ctx, c.API, quota.Id, &container, nil)
+}
+
+// SetQuotaEnforced sets whether the quota of a volume enforces its
+// thresholds, or only accounts for usage, without removing the quota.
+func (c *Client) SetQuotaEnforced(
+	ctx context.Context, name string, enforced bool) error {
+
+	quota, err := c.GetQuota(ctx, name)
+	if err != nil {
+		return err
+	}
+	if quota.Enforced == enforced {
+		return nil
+	}
+	return api.UpdateIsiQuotaFlags(ctx, c.API, quota.Id, nil, &enforced)
+}
+
+// ErrInvalidQuotaThresholds is returned when the thresholds of a quota are
+// not in order or are incomplete.
+var ErrInvalidQuotaThresholds = errors.New("invalid quota thresholds")
+
+// QuotaThresholds are the thresholds of a quota in bytes. Zero thresholds
+// are not set. Sizes may be parsed from strings, ex. "1.5TiB", by ParseSize.
+type QuotaThresholds struct {
+
+	// Advisory is the usage at which a notification is sent.
+	Advisory int64
+
+	// Soft is the usage that may be exceeded for SoftGrace, after which
+	// writes are denied. SoftGrace must be set with it.
+	Soft      int64
+	SoftGrace time.Duration
+
+	// Hard is the usage beyond which writes are denied.
+	Hard int64
+}
+
+// Validate returns an error wrapping ErrInvalidQuotaThresholds unless no
+// threshold is negative, the thresholds that are set are ordered advisory,
+// soft then hard, and a soft threshold has a grace period.
+func (t *QuotaThresholds) Validate() error {
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("%s: %w",
+			fmt.Sprintf(format, a...), ErrInvalidQuotaThresholds)
+	}
+	if t.Advisory < 0 || t.Soft < 0 || t.Hard < 0 || t.SoftGrace < 0 {
+		return invalid("thresholds must not be negative")
+	}
+	if t.Advisory == 0 && t.Soft == 0 && t.Hard == 0 {
+		return invalid("no threshold set")
+	}
+	if t.Soft != 0 && t.SoftGrace < time.Second {
+		return invalid("soft threshold set without a grace period")
+	}
+	if t.Soft == 0 && t.SoftGrace != 0 {
+		return invalid("grace period set without a soft threshold")
+	}
+	if t.Advisory != 0 && t.Soft != 0 && t.Advisory > t.Soft {
+		return invalid("advisory threshold %s above soft threshold %s",
+			FormatSize(t.Advisory), FormatSize(t.Soft))
+	}
+	if t.Soft != 0 && t.Hard != 0 && t.Soft > t.Hard {
+		return invalid("soft threshold %s above hard threshold %s",
+			FormatSize(t.Soft), FormatSize(t.Hard))
+	}
+	if t.Advisory != 0 && t.Hard != 0 && t.Advisory > t.Hard {
+		return invalid("advisory threshold %s above hard threshold %s",
+			FormatSize(t.Advisory), FormatSize(t.Hard))
+	}
+	return nil
+}
+
+// request returns the thresholds rounded up by RoundQuotaSize, validated,
+// as they are sent to the cluster.
+func (t *QuotaThresholds) request() (api.IsiThresholdsReq, error) {
+	if t == nil {
+		return api.IsiThresholdsReq{}, fmt.Errorf(
+			"no threshold set: %w", ErrInvalidQuotaThresholds)
+	}
+	rounded := QuotaThresholds{
+		Advisory:  RoundQuotaSize(t.Advisory),
+		Soft:      RoundQuotaSize(t.Soft),
+		SoftGrace: t.SoftGrace,
+		Hard:      RoundQuotaSize(t.Hard),
+	}
+	if err := rounded.Validate(); err != nil {
+		return api.IsiThresholdsReq{}, err
+	}
+	// unset thresholds must be sent as null
+	threshold := func(v int64) interface{} {
+		if v == 0 {
+			return nil
+		}
+		return v
+	}
+	req := api.IsiThresholdsReq{
+		Advisory: threshold(rounded.Advisory),
+		Soft:     threshold(rounded.Soft),
+		Hard:     threshold(rounded.Hard),
+	}
+	if rounded.Soft != 0 {
+		req.SoftGrace = int64(rounded.SoftGrace / time.Second)
+	}
+	return req, nil
+}
+
+// CreateQuotaWithThresholds creates an enforced directory quota for a volume
+// with the specified container option and thresholds. The thresholds are
+// rounded up by RoundQuotaSize and validated before the quota is created.
+func (c *Client) CreateQuotaWithThresholds(
+	ctx context.Context,
+	name string, container bool, thresholds *QuotaThresholds) error {
+
+	req, err := thresholds.request()
+	if err != nil {
+		return err
+	}
+	return api.CreateIsiQuotaFromReq(ctx, c.API, &api.IsiQuotaReq{
+		Enforced:   true,
+		Path:       c.API.VolumePath(name),
+		Container:  container,
+		Type:       "directory",
+		Thresholds: req,
+	})
+}
+
+// UpdateQuotaThresholds replaces the thresholds of the quota of a volume,
+// keeping its enforcement and accounting. The thresholds are rounded up by
+// RoundQuotaSize and validated before the quota is updated.
+func (c *Client) UpdateQuotaThresholds(
+	ctx context.Context, name string, thresholds *QuotaThresholds) error {
+
+	req, err := thresholds.request()
+	if err != nil {
+		return err
+	}
+	return api.UpdateIsiQuotaThresholds(
+		ctx, c.API, c.API.VolumePath(name), req)
+}
+
+// ClearQuota removes the quota from a volume. A quota that includes
+// snapshots is kept; it is removed by ClearQuotaWithSnapshots.
 func (c *Client) ClearQuota(ctx context.Context, name string) error {
 	return api.DeleteIsiQuota(ctx, c.API, c.API.VolumePath(name))
 }
+
+// ClearQuotaWithSnapshots removes the quota of a volume that includes or
+// excludes snapshots.
+func (c *Client) ClearQuotaWithSnapshots(
+	ctx context.Context, name string, includeSnapshots bool) error {
+
+	quota, err := c.GetQuotaWithSnapshots(ctx, name, includeSnapshots)
+	if err != nil {
+		return err
+	}
+	return api.DeleteIsiQuotaByID(ctx, c.API, quota.Id)
+}
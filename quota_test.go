@@ -1,8 +1,16 @@
 package goisilon
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tenortim/goisilon/api"
 )
 
 // Test both GetQuota() and SetQuota()
@@ -49,6 +57,32 @@ func TestQuotaGetSet(t *testing.T) {
 
 }
 
+// Test EnsureQuota()
+func TestQuotaEnsure(t *testing.T) {
+	volumeName := "test_quota_ensure"
+	quotaSize := int64(12345)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	quota, err := client.EnsureQuota(defaultCtx, volumeName, true, quotaSize)
+	assertNoError(t, err)
+	assert.Equal(t, quotaSize, quota.HardThreshold())
+
+	// a retry returns the existing quota
+	quota, err = client.EnsureQuota(defaultCtx, volumeName, true, quotaSize)
+	assertNoError(t, err)
+	assert.Equal(t, quotaSize, quota.HardThreshold())
+
+	// but not when it differs from the request
+	_, err = client.EnsureQuota(defaultCtx, volumeName, true, 2*quotaSize)
+	assert.True(t, errors.Is(err, ErrMismatch), "%v", err)
+	_, err = client.EnsureQuota(defaultCtx, volumeName, false, quotaSize)
+	assert.True(t, errors.Is(err, ErrMismatch), "%v", err)
+}
+
 // Test UpdateQuota()
 func TestQuotaUpdate(t *testing.T) {
 
@@ -98,6 +132,9 @@ func TestQuotaUpdate(t *testing.T) {
 	if quota.Thresholds.Hard != updatedQuotaSize {
 		panic(fmt.Sprintf("Updated quota not set properly.  Expected: %d Actual: %d", updatedQuotaSize, quota.Thresholds.Hard))
 	}
+	if quota.HardThreshold() != updatedQuotaSize {
+		panic(fmt.Sprintf("HardThreshold not set properly.  Expected: %d Actual: %d", updatedQuotaSize, quota.HardThreshold()))
+	}
 
 }
 
@@ -148,3 +185,223 @@ func TestQuotaClear(t *testing.T) {
 	}
 
 }
+
+func TestQuotaOptions(t *testing.T) {
+	volumeName := "test_quota_options"
+	quotaSize := int64(12345)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	_, err = client.GetQuotaUsage(defaultCtx, volumeName)
+	assert.True(t, IsNotFound(err), "%v", err)
+
+	// a volume may have quotas with both accounting views
+	assertNoError(t, client.CreateQuotaWithOptions(
+		defaultCtx, volumeName, false, quotaSize,
+		&QuotaOptions{IncludeSnapshots: true}))
+	assertNoError(t, client.CreateQuotaWithOptions(
+		defaultCtx, volumeName, false, quotaSize,
+		&QuotaOptions{ThresholdsIncludeOverhead: true}))
+
+	quota, err := client.GetQuotaWithSnapshots(defaultCtx, volumeName, true)
+	assertNoError(t, err)
+	assert.True(t, quota.IncludeSnapshots)
+	assert.False(t, quota.ThresholdsIncludeOverhead)
+
+	quota, err = client.GetQuotaWithSnapshots(defaultCtx, volumeName, false)
+	assertNoError(t, err)
+	assert.False(t, quota.IncludeSnapshots)
+	assert.True(t, quota.ThresholdsIncludeOverhead)
+	assert.Equal(t, quota.PhysicalUsage(), quota.ThresholdUsage())
+
+	usage, err := client.GetQuotaUsage(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assertNotNil(t, usage.Inclusive)
+	assertNotNil(t, usage.Exclusive)
+
+	assertNoError(t, client.UpdateQuotaOptions(
+		defaultCtx, volumeName, &QuotaOptions{IncludeSnapshots: true,
+			ThresholdsIncludeOverhead: true}))
+	quota, err = client.GetQuotaWithSnapshots(defaultCtx, volumeName, true)
+	assertNoError(t, err)
+	assert.True(t, quota.ThresholdsIncludeOverhead)
+}
+
+func TestQuotaSetFlags(t *testing.T) {
+	volumeName := "test_quota_set_flags"
+	quotaSize := int64(12345)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	assertNoError(t, client.CreateQuota(
+		defaultCtx, volumeName, false, quotaSize))
+	quota, err := client.GetQuota(defaultCtx, volumeName)
+	assertNoError(t, err)
+	id := quota.Id
+
+	assertNoError(t, client.SetQuotaContainer(defaultCtx, volumeName, true))
+	assertNoError(t, client.SetQuotaEnforced(defaultCtx, volumeName, false))
+
+	// the quota is updated in place
+	quota, err = client.GetQuota(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, id, quota.Id)
+	assert.True(t, quota.Container)
+	assert.False(t, quota.Enforced)
+	assert.Equal(t, quotaSize, quota.HardThreshold())
+
+	// setting a flag to its current value is a no-op
+	assertNoError(t, client.SetQuotaContainer(defaultCtx, volumeName, true))
+}
+
+func TestQuotaThresholdsValidate(t *testing.T) {
+	valid := []*QuotaThresholds{
+		{Hard: 1 << 30},
+		{Advisory: 1 << 20},
+		{Advisory: 1 << 20, Soft: 1 << 25, SoftGrace: time.Hour, Hard: 1 << 30},
+		{Advisory: 1 << 30, Hard: 1 << 30},
+	}
+	for _, th := range valid {
+		assert.NoError(t, th.Validate(), "%+v", th)
+	}
+
+	invalid := []*QuotaThresholds{
+		{},
+		{Hard: -1},
+		{Soft: 1 << 20},
+		{SoftGrace: time.Hour, Hard: 1 << 30},
+		{Advisory: 1 << 30, Hard: 1 << 20},
+		{Advisory: 1 << 25, Soft: 1 << 20, SoftGrace: time.Hour},
+		{Soft: 1 << 30, SoftGrace: time.Hour, Hard: 1 << 20},
+	}
+	for _, th := range invalid {
+		err := th.Validate()
+		assert.True(t, errors.Is(err, ErrInvalidQuotaThresholds),
+			"%+v: %v", th, err)
+	}
+}
+
+func TestQuotaThresholdsRequest(t *testing.T) {
+	req, err := (&QuotaThresholds{
+		Soft:      1000,
+		SoftGrace: 7 * 24 * time.Hour,
+		Hard:      1 << 20,
+	}).request()
+	assert.NoError(t, err)
+	assert.Nil(t, req.Advisory)
+	assert.Equal(t, int64(QuotaBlockSize), req.Soft)
+	assert.Equal(t, int64(604800), req.SoftGrace)
+	assert.Equal(t, int64(1<<20), req.Hard)
+
+	_, err = (*QuotaThresholds)(nil).request()
+	assert.Error(t, err)
+}
+
+func TestQuotaThresholdsCreateUpdate(t *testing.T) {
+	volumeName := "test_quota_thresholds"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	hard, err := ParseSize("1GiB")
+	assertNoError(t, err)
+	err = client.CreateQuotaWithThresholds(defaultCtx, volumeName, false,
+		&QuotaThresholds{Advisory: hard / 2, Hard: hard})
+	assertNoError(t, err)
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	err = client.UpdateQuotaThresholds(defaultCtx, volumeName,
+		&QuotaThresholds{Soft: hard / 2, SoftGrace: time.Hour, Hard: hard})
+	assertNoError(t, err)
+
+	quota, err := client.GetQuota(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, hard/2, quota.Thresholds.Soft)
+	assert.Equal(t, int64(0), quota.Thresholds.Advisory)
+	assert.Equal(t, hard, quota.Thresholds.Hard)
+
+	err = client.UpdateQuotaThresholds(defaultCtx, volumeName,
+		&QuotaThresholds{Soft: hard * 2, SoftGrace: time.Hour, Hard: hard})
+	assert.True(t, errors.Is(err, ErrInvalidQuotaThresholds), "%v", err)
+}
+
+// twoQuotasClient is an API client for a cluster whose volume "v" has a
+// user quota and two directory quotas, one of which includes snapshots. It
+// records the ids of the quotas that are updated or deleted.
+type twoQuotasClient struct {
+	api.Client
+	updated, deleted []string
+}
+
+func (c *twoQuotasClient) VolumePath(name string) string {
+	return "/ifs/volumes/" + name
+}
+
+func (c *twoQuotasClient) Get(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	resp interface{}) error {
+
+	return json.Unmarshal([]byte(`{"quotas":[
+		{"id":"user","path":"/ifs/volumes/v","type":"user"},
+		{"id":"inclusive","path":"/ifs/volumes/v","type":"directory",
+		 "include_snapshots":true},
+		{"id":"exclusive","path":"/ifs/volumes/v","type":"directory",
+		 "include_snapshots":false}]}`), resp)
+}
+
+func (c *twoQuotasClient) Put(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	body, resp interface{}) error {
+
+	c.updated = append(c.updated, id)
+	return nil
+}
+
+func (c *twoQuotasClient) Delete(
+	ctx context.Context,
+	path, id string,
+	params api.OrderedValues, headers map[string]string,
+	resp interface{}) error {
+
+	if id == "" {
+		return errors.New("quota deleted by path")
+	}
+	c.deleted = append(c.deleted, id)
+	return nil
+}
+
+func TestQuotaWithSnapshotsOnSamePath(t *testing.T) {
+	fake := &twoQuotasClient{}
+	c := &Client{fake}
+
+	quota, err := c.GetQuota(defaultCtx, "v")
+	assertNoError(t, err)
+	assert.Equal(t, "exclusive", quota.Id)
+	quota, err = c.GetQuotaWithSnapshots(defaultCtx, "v", true)
+	assertNoError(t, err)
+	assert.Equal(t, "inclusive", quota.Id)
+
+	assertNoError(t, c.SetQuotaEnforced(defaultCtx, "v", true))
+	assertNoError(t, c.SetQuotaContainer(defaultCtx, "v", true))
+	assertNoError(t, c.UpdateQuotaThresholds(
+		defaultCtx, "v", &QuotaThresholds{Hard: 1 << 30}))
+	assertNoError(t, c.UpdateQuotaSize(defaultCtx, "v", 1<<30))
+	assert.Equal(t,
+		[]string{"exclusive", "exclusive", "exclusive", "exclusive"},
+		fake.updated)
+
+	assertNoError(t, c.ClearQuota(defaultCtx, "v"))
+	assertNoError(t, c.ClearQuotaWithSnapshots(defaultCtx, "v", true))
+	assert.Equal(t, []string{"exclusive", "inclusive"}, fake.deleted)
+}
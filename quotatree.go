@@ -0,0 +1,134 @@
+package goisilon
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strings"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// QuotaTreeNode is a directory in a quota tree report. The usage and
+// thresholds of a node with a quota are those of its quota, which already
+// account for the nested directories. The usage and thresholds of a node
+// without a quota are the sums of those of its children.
+type QuotaTreeNode struct {
+
+	// Path is the directory's path.
+	Path string
+
+	// Quota is the directory's quota, or nil if it has none.
+	Quota *Quota
+
+	// Children are the nodes of the nearest descendant directories with
+	// quotas.
+	Children []*QuotaTreeNode
+
+	// Usage is the rolled-up usage of the directory.
+	Usage api.IsiQuotaUsage
+
+	// Advisory, Soft, and Hard are the rolled-up thresholds of the
+	// directory. Thresholds that are not set count as zero.
+	Advisory, Soft, Hard int64
+
+	// Exceeded is whether any quota in the tree rooted at the directory has
+	// an exceeded threshold.
+	Exceeded bool
+
+	// QuotaCount is the number of quotas in the tree rooted at the directory.
+	QuotaCount int
+}
+
+// ListDirectoryQuotas returns a list of the directory quotas on a path and its
+// descendants that is fetched a page at a time.
+func (c *Client) ListDirectoryQuotas(path string) *List[*Quota] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*Quota, string, error) {
+
+		quotas, resume, err := api.GetIsiDirectoryQuotasPage(
+			ctx, c.API, path, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		list := make([]*Quota, len(quotas))
+		for i, q := range quotas {
+			list[i] = QuotaFromAPI(q)
+		}
+		return list, resume, nil
+	})
+}
+
+// GetQuotaTree returns a report of the directory quotas on a path and its
+// descendants, arranged as a tree with usage and thresholds rolled up to
+// each directory.
+func (c *Client) GetQuotaTree(
+	ctx context.Context, path string) (*QuotaTreeNode, error) {
+
+	quotas, err := c.ListDirectoryQuotas(path).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildQuotaTree(path, quotas), nil
+}
+
+func buildQuotaTree(root string, quotas []*Quota) *QuotaTreeNode {
+	root = path.Clean(root)
+	nodes := map[string]*QuotaTreeNode{root: {Path: root}}
+
+	// insert parents before their descendants
+	sorted := make([]*Quota, 0, len(quotas))
+	for _, q := range quotas {
+		if q.Path == root || strings.HasPrefix(q.Path, root+"/") {
+			sorted = append(sorted, q)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return len(sorted[i].Path) < len(sorted[j].Path)
+	})
+
+	for _, q := range sorted {
+		if n, ok := nodes[q.Path]; ok {
+			n.Quota = q
+			continue
+		}
+		n := &QuotaTreeNode{Path: q.Path, Quota: q}
+		nodes[q.Path] = n
+		parent := path.Dir(q.Path)
+		for nodes[parent] == nil {
+			parent = path.Dir(parent)
+		}
+		nodes[parent].Children = append(nodes[parent].Children, n)
+	}
+
+	rollUpQuotaTree(nodes[root])
+	return nodes[root]
+}
+
+func rollUpQuotaTree(n *QuotaTreeNode) {
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Path < n.Children[j].Path
+	})
+	for _, c := range n.Children {
+		rollUpQuotaTree(c)
+		n.QuotaCount += c.QuotaCount
+		n.Exceeded = n.Exceeded || c.Exceeded
+	}
+	if q := n.Quota; q != nil {
+		n.Usage = q.Usage
+		n.Advisory = q.Thresholds.Advisory
+		n.Soft = q.Thresholds.Soft
+		n.Hard = q.Thresholds.Hard
+		n.Exceeded = n.Exceeded || q.IsExceeded()
+		n.QuotaCount++
+		return
+	}
+	for _, c := range n.Children {
+		n.Usage.Inodes += c.Usage.Inodes
+		n.Usage.Logical += c.Usage.Logical
+		n.Usage.Physical += c.Usage.Physical
+		n.Advisory += c.Advisory
+		n.Soft += c.Soft
+		n.Hard += c.Hard
+	}
+}
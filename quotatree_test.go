@@ -0,0 +1,67 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+func testQuota(path string, hard, logical int64, exceeded bool) *Quota {
+	q := &api.IsiQuota{Path: path, Type: "directory"}
+	q.Thresholds.Hard = hard
+	q.Thresholds.HardExceeded = exceeded
+	q.Usage.Logical = logical
+	return QuotaFromAPI(q)
+}
+
+func TestBuildQuotaTree(t *testing.T) {
+	tree := buildQuotaTree("/ifs/tenants/", []*Quota{
+		testQuota("/ifs/tenants/b/projects/p1", 100, 60, false),
+		testQuota("/ifs/tenants/a", 1000, 400, false),
+		testQuota("/ifs/tenants/b", 500, 450, false),
+		testQuota("/ifs/tenants/c/deep/dir", 50, 60, true),
+		testQuota("/ifs/other", 1, 1, true),
+	})
+
+	assert.Equal(t, "/ifs/tenants", tree.Path)
+	assert.Nil(t, tree.Quota)
+	assert.Equal(t, 4, tree.QuotaCount)
+	assert.True(t, tree.Exceeded)
+	assert.Equal(t, int64(1000+500+50), tree.Hard)
+	assert.Equal(t, int64(400+450+60), tree.Usage.Logical)
+
+	if !assert.Len(t, tree.Children, 3) {
+		t.FailNow()
+	}
+	a, b, c := tree.Children[0], tree.Children[1], tree.Children[2]
+	assert.Equal(t, "/ifs/tenants/a", a.Path)
+	assert.Equal(t, "/ifs/tenants/b", b.Path)
+	assert.Equal(t, "/ifs/tenants/c/deep/dir", c.Path)
+
+	// a parent's quota already accounts for its nested quotas
+	assert.Equal(t, int64(500), b.Hard)
+	assert.Equal(t, int64(450), b.Usage.Logical)
+	assert.Equal(t, 2, b.QuotaCount)
+	assert.False(t, b.Exceeded)
+	if assert.Len(t, b.Children, 1) {
+		assert.Equal(t, "/ifs/tenants/b/projects/p1", b.Children[0].Path)
+	}
+}
+
+func TestGetQuotaTree(t *testing.T) {
+	volumeName := "test_get_quota_tree"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	assertNoError(t, client.SetQuotaSize(defaultCtx, volumeName, 1<<30))
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	tree, err := client.GetQuotaTree(defaultCtx, client.API.VolumesPath())
+	assertNoError(t, err)
+	assert.True(t, tree.QuotaCount >= 1)
+	assert.True(t, tree.Hard >= 1<<30)
+}
@@ -0,0 +1,194 @@
+package goisilon
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// QuotaThreshold names one of a quota's thresholds.
+type QuotaThreshold string
+
+// The thresholds of a quota.
+const (
+	QuotaThresholdAdvisory QuotaThreshold = "advisory"
+	QuotaThresholdSoft     QuotaThreshold = "soft"
+	QuotaThresholdHard     QuotaThreshold = "hard"
+)
+
+// QuotaEvent reports that a quota's threshold was exceeded or is no longer
+// exceeded. Err is set instead of the other fields if a poll failed.
+type QuotaEvent struct {
+
+	// Time is when the poll that detected the change was scheduled.
+	Time time.Time
+
+	// Quota is the quota as of the poll. If the quota is no longer exceeded
+	// it is the quota as of the last poll in which it was.
+	Quota *Quota
+
+	// Threshold is the threshold that changed.
+	Threshold QuotaThreshold
+
+	// Exceeded is whether the threshold is now exceeded.
+	Exceeded bool
+
+	// Err is the error of the poll, if any.
+	Err error
+}
+
+// QuotaWatcher polls the quotas with exceeded thresholds and delivers an
+// event when a threshold of a quota is crossed in either direction. The
+// first poll delivers an event for each threshold that is already exceeded.
+type QuotaWatcher struct {
+	w      *Watcher[[]*Quota]
+	c      chan QuotaEvent
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// quotaThresholdState is the exceeded thresholds of a quota.
+type quotaThresholdState struct {
+	quota    *Quota
+	exceeded map[QuotaThreshold]bool
+}
+
+// WatchQuotaThresholds starts a QuotaWatcher that polls until ctx is done or
+// Stop is called. Polling continues while events wait to be received; polls
+// beyond the buffer configured by opts are dropped, as by a Watcher.
+func (c *Client) WatchQuotaThresholds(
+	ctx context.Context, opts *WatchOptions) *QuotaWatcher {
+
+	ctx, cancel := context.WithCancel(ctx)
+	qw := &QuotaWatcher{
+		w: NewWatcher(ctx, opts,
+			func(ctx context.Context) ([]*Quota, error) {
+				return c.ListExceededQuotas().Collect(ctx)
+			}),
+		c:      make(chan QuotaEvent),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go qw.run(ctx)
+	return qw
+}
+
+// C returns the channel on which events are delivered. It is closed when the
+// QuotaWatcher stops.
+func (qw *QuotaWatcher) C() <-chan QuotaEvent {
+	return qw.c
+}
+
+// Dropped returns the number of polls that were dropped because the receiver
+// fell behind. Thresholds that were crossed and crossed back within dropped
+// polls are not reported.
+func (qw *QuotaWatcher) Dropped() int64 {
+	return qw.w.Dropped()
+}
+
+// Stop stops polling and waits for the channel to be closed.
+func (qw *QuotaWatcher) Stop() {
+	qw.cancel()
+	qw.w.Stop()
+	<-qw.done
+}
+
+func (qw *QuotaWatcher) run(ctx context.Context) {
+	defer close(qw.done)
+	defer close(qw.c)
+
+	state := map[string]*quotaThresholdState{}
+	for s := range qw.w.C() {
+		var events []QuotaEvent
+		if s.Err != nil {
+			events = []QuotaEvent{{Time: s.Time, Err: s.Err}}
+		} else {
+			events, state = quotaThresholdEvents(s.Time, state, s.Value)
+		}
+		for _, e := range events {
+			select {
+			case qw.c <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// quotaThresholdEvents returns the events for the changes from the previous
+// state to the exceeded quotas, and the new state.
+func quotaThresholdEvents(
+	t time.Time,
+	prev map[string]*quotaThresholdState,
+	quotas []*Quota) ([]QuotaEvent, map[string]*quotaThresholdState) {
+
+	var events []QuotaEvent
+	next := make(map[string]*quotaThresholdState, len(quotas))
+	for _, q := range quotas {
+		th := q.Thresholds
+		cur := &quotaThresholdState{
+			quota: q,
+			exceeded: map[QuotaThreshold]bool{
+				QuotaThresholdAdvisory: th.AdvisoryExceeded,
+				QuotaThresholdSoft:     th.SoftExceeded,
+				QuotaThresholdHard:     th.HardExceeded,
+			},
+		}
+		next[q.Id] = cur
+
+		var was map[QuotaThreshold]bool
+		if p, ok := prev[q.Id]; ok {
+			was = p.exceeded
+		}
+		for _, name := range quotaThresholds {
+			if cur.exceeded[name] != was[name] {
+				events = append(events, QuotaEvent{
+					Time:      t,
+					Quota:     q,
+					Threshold: name,
+					Exceeded:  cur.exceeded[name],
+				})
+			}
+		}
+	}
+
+	// quotas that are missing are no longer exceeded, or were deleted
+	for _, p := range sortedQuotaStates(prev) {
+		if _, ok := next[p.quota.Id]; ok {
+			continue
+		}
+		for _, name := range quotaThresholds {
+			if p.exceeded[name] {
+				events = append(events, QuotaEvent{
+					Time:      t,
+					Quota:     p.quota,
+					Threshold: name,
+				})
+			}
+		}
+	}
+	return events, next
+}
+
+// quotaThresholds are the thresholds in the order their events are
+// delivered.
+var quotaThresholds = []QuotaThreshold{
+	QuotaThresholdAdvisory,
+	QuotaThresholdSoft,
+	QuotaThresholdHard,
+}
+
+// sortedQuotaStates returns the states ordered by quota id, so that events
+// are delivered in a stable order.
+func sortedQuotaStates(
+	states map[string]*quotaThresholdState) []*quotaThresholdState {
+
+	list := make([]*quotaThresholdState, 0, len(states))
+	for _, s := range states {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].quota.Id < list[j].quota.Id
+	})
+	return list
+}
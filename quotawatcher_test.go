@@ -0,0 +1,88 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "github.com/tenortim/goisilon/api/v1"
+)
+
+func TestQuotaThresholdEvents(t *testing.T) {
+	quota := func(id string, advisory, soft, hard bool) *Quota {
+		return &Quota{Id: id, Thresholds: apiv1.IsiThresholds{
+			AdvisoryExceeded: advisory,
+			SoftExceeded:     soft,
+			HardExceeded:     hard,
+		}}
+	}
+	type event struct {
+		id        string
+		threshold QuotaThreshold
+		exceeded  bool
+	}
+	summarize := func(events []QuotaEvent) []event {
+		var list []event
+		for _, e := range events {
+			list = append(list, event{e.Quota.Id, e.Threshold, e.Exceeded})
+		}
+		return list
+	}
+	now := time.Now()
+
+	// thresholds that are already exceeded are reported by the first poll
+	events, state := quotaThresholdEvents(now, nil, []*Quota{
+		quota("a", true, false, false),
+		quota("b", true, true, false),
+	})
+	assert.Equal(t, []event{
+		{"a", QuotaThresholdAdvisory, true},
+		{"b", QuotaThresholdAdvisory, true},
+		{"b", QuotaThresholdSoft, true},
+	}, summarize(events))
+	assert.Equal(t, now, events[0].Time)
+
+	// nothing changed
+	events, state = quotaThresholdEvents(now, state, []*Quota{
+		quota("a", true, false, false),
+		quota("b", true, true, false),
+	})
+	assert.Empty(t, events)
+
+	// a crosses its hard threshold, b drops below its soft threshold and c
+	// is new
+	events, state = quotaThresholdEvents(now, state, []*Quota{
+		quota("a", true, true, true),
+		quota("b", true, false, false),
+		quota("c", true, false, false),
+	})
+	assert.Equal(t, []event{
+		{"a", QuotaThresholdSoft, true},
+		{"a", QuotaThresholdHard, true},
+		{"b", QuotaThresholdSoft, false},
+		{"c", QuotaThresholdAdvisory, true},
+	}, summarize(events))
+
+	// quotas that are no longer listed are no longer exceeded
+	events, _ = quotaThresholdEvents(now, state, []*Quota{
+		quota("b", true, false, false),
+	})
+	assert.Equal(t, []event{
+		{"a", QuotaThresholdAdvisory, false},
+		{"a", QuotaThresholdSoft, false},
+		{"a", QuotaThresholdHard, false},
+		{"c", QuotaThresholdAdvisory, false},
+	}, summarize(events))
+}
+
+func TestWatchQuotaThresholds(t *testing.T) {
+	qw := client.WatchQuotaThresholds(
+		defaultCtx, &WatchOptions{Interval: time.Second})
+	defer qw.Stop()
+
+	select {
+	case e := <-qw.C():
+		assertNoError(t, e.Err)
+	case <-time.After(2 * time.Second):
+	}
+}
@@ -0,0 +1,97 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// PrivilegeList is a list of RBAC privileges.
+type PrivilegeList []*api.IsiPrivilege
+
+// RoleList is a list of RBAC roles.
+type RoleList []*api.IsiRole
+
+// Role is an RBAC role, which grants a set of privileges to its members.
+type Role *api.IsiRole
+
+// GetPrivileges returns the privileges that can be assigned to roles.
+func (c *Client) GetPrivileges(ctx context.Context) (PrivilegeList, error) {
+	return api.GetIsiPrivileges(ctx, c.API)
+}
+
+// GetRoles returns the roles in an access zone. If zone is empty the client's
+// zone is used.
+func (c *Client) GetRoles(ctx context.Context, zone string) (RoleList, error) {
+	return api.GetIsiRoles(ctx, c.API, zone)
+}
+
+// GetRole returns the role in an access zone with the given name.
+func (c *Client) GetRole(ctx context.Context, zone, name string) (Role, error) {
+	return api.GetIsiRole(ctx, c.API, zone, name)
+}
+
+// CreateRole creates a role with no members or privileges in an access zone
+// and returns its ID.
+func (c *Client) CreateRole(
+	ctx context.Context, zone, name, description string) (string, error) {
+
+	return api.CreateIsiRole(
+		ctx, c.API, zone,
+		&api.IsiRole{Name: &name, Description: &description})
+}
+
+// DeleteRole removes a role from an access zone.
+func (c *Client) DeleteRole(ctx context.Context, zone, name string) error {
+	return api.DeleteIsiRole(ctx, c.API, zone, name)
+}
+
+// AddRolePrivilege assigns a privilege, such as "ISI_PRIV_NFS", to a role.
+// If readOnly is true the privilege only grants read access.
+func (c *Client) AddRolePrivilege(
+	ctx context.Context, zone, role, privilege string, readOnly bool) error {
+
+	return api.AddIsiRolePrivilege(
+		ctx, c.API, zone, role,
+		&api.IsiPrivilege{ID: privilege, ReadOnly: readOnly})
+}
+
+// RemoveRolePrivilege removes a privilege from a role.
+func (c *Client) RemoveRolePrivilege(
+	ctx context.Context, zone, role, privilege string) error {
+
+	return api.RemoveIsiRolePrivilege(ctx, c.API, zone, role, privilege)
+}
+
+// AddUserToRole adds the user with the given name to a role.
+func (c *Client) AddUserToRole(
+	ctx context.Context, zone, role, user string) error {
+
+	u, err := c.GetUserByName(ctx, zone, user)
+	if err != nil {
+		return err
+	}
+	if u.SID == nil || u.SID.ID == nil {
+		return errors.New("user SID missing from response")
+	}
+	return api.AddIsiRoleMember(
+		ctx, c.API, zone, role, &apiv2.Persona{ID: u.SID.ID})
+}
+
+// RemoveUserFromRole removes the user with the given name from a role.
+func (c *Client) RemoveUserFromRole(
+	ctx context.Context, zone, role, user string) error {
+
+	u, err := c.GetUserByName(ctx, zone, user)
+	if err != nil {
+		return err
+	}
+	if u.SID == nil || u.SID.ID == nil {
+		return errors.New("user SID missing from response")
+	}
+	return api.RemoveIsiRoleMember(
+		ctx, c.API, zone, role,
+		apiv2.PersonaIDTypeSID.String()+":"+u.SID.ID.ID)
+}
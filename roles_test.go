@@ -0,0 +1,38 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestRolePrivilegesAndMembers(t *testing.T) {
+	roleName := "test_goisilon_role"
+	userName := "test_goisilon_role_user"
+
+	_, err := client.CreateRole(defaultCtx, "", roleName, "goisilon test role")
+	assertNoError(t, err)
+	defer client.DeleteRole(defaultCtx, "", roleName)
+
+	_, err = client.CreateUser(defaultCtx, "", userName, 0, "", "", "")
+	assertNoError(t, err)
+	defer client.DeleteUser(defaultCtx, "", userName)
+
+	err = client.AddRolePrivilege(
+		defaultCtx, "", roleName, "ISI_PRIV_LOGIN_PAPI", true)
+	assertNoError(t, err)
+	err = client.AddUserToRole(defaultCtx, "", roleName, userName)
+	assertNoError(t, err)
+
+	role, err := client.GetRole(defaultCtx, "", roleName)
+	assertNoError(t, err)
+	assertNotNil(t, role.Members)
+	assertNotNil(t, role.Privileges)
+	if len(*role.Members) != 1 || len(*role.Privileges) != 1 {
+		t.Fatalf("unexpected role: %+v", role)
+	}
+
+	err = client.RemoveUserFromRole(defaultCtx, "", roleName, userName)
+	assertNoError(t, err)
+	err = client.RemoveRolePrivilege(
+		defaultCtx, "", roleName, "ISI_PRIV_LOGIN_PAPI")
+	assertNoError(t, err)
+}
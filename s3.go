@@ -0,0 +1,201 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiv10 "github.com/tenortim/goisilon/api/v10"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// S3Bucket is an S3 bucket.
+type S3Bucket *apiv10.IsiS3Bucket
+
+// S3BucketGrant grants a grantee a permission on an S3 bucket.
+type S3BucketGrant *apiv10.IsiS3BucketGrant
+
+// ListS3Buckets returns a list of the S3 buckets in an access zone that is
+// fetched a page at a time. If zone is empty the client's zone is used. S3
+// requires OneFS 9.0 or later.
+func (c *Client) ListS3Buckets(zone string) *List[*apiv10.IsiS3Bucket] {
+	return newList(func(
+		ctx context.Context,
+		resume string) ([]*apiv10.IsiS3Bucket, string, error) {
+
+		return apiv10.GetIsiS3BucketsPage(ctx, c.API, zone, resume)
+	})
+}
+
+// GetS3Bucket returns an S3 bucket in an access zone by name. If zone is
+// empty the client's zone is used.
+func (c *Client) GetS3Bucket(
+	ctx context.Context, zone, name string) (S3Bucket, error) {
+
+	return apiv10.GetIsiS3Bucket(ctx, c.API, zone, name)
+}
+
+// CreateS3Bucket creates an S3 bucket in an access zone and returns its ID.
+// The bucket's name and path must be set.
+func (c *Client) CreateS3Bucket(
+	ctx context.Context, zone string, bucket S3Bucket) (string, error) {
+
+	return apiv10.CreateIsiS3Bucket(ctx, c.API, zone, bucket)
+}
+
+// UpdateS3Bucket modifies an S3 bucket in an access zone. Fields that are nil
+// are left unchanged.
+func (c *Client) UpdateS3Bucket(
+	ctx context.Context, zone, name string, bucket S3Bucket) error {
+
+	return apiv10.UpdateIsiS3Bucket(ctx, c.API, zone, name, bucket)
+}
+
+// DeleteS3Bucket removes an S3 bucket from an access zone. The bucket's
+// directory is not removed.
+func (c *Client) DeleteS3Bucket(
+	ctx context.Context, zone, name string) error {
+
+	return apiv10.DeleteIsiS3Bucket(ctx, c.API, zone, name)
+}
+
+// GetS3BucketACL returns the grants of an S3 bucket.
+func (c *Client) GetS3BucketACL(
+	ctx context.Context, zone, name string) ([]S3BucketGrant, error) {
+
+	bucket, err := apiv10.GetIsiS3Bucket(ctx, c.API, zone, name)
+	if err != nil {
+		return nil, err
+	}
+	grants := []S3BucketGrant{}
+	if bucket.ACL != nil {
+		for _, g := range *bucket.ACL {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+// SetS3BucketACL replaces the grants of an S3 bucket. The grantees are
+// validated before the update is sent.
+func (c *Client) SetS3BucketACL(
+	ctx context.Context,
+	zone, name string, grants ...S3BucketGrant) error {
+
+	acl := make([]*apiv10.IsiS3BucketGrant, len(grants))
+	for i, g := range grants {
+		if err := c.validateS3BucketGrant(ctx, zone, g); err != nil {
+			return err
+		}
+		acl[i] = g
+	}
+	return apiv10.UpdateIsiS3Bucket(
+		ctx, c.API, zone, name, &apiv10.IsiS3Bucket{ACL: &acl})
+}
+
+// GrantS3BucketAccess grants a grantee a permission, ex.
+// apiv10.S3PermissionRead, on an S3 bucket, leaving its other grants
+// unchanged.
+func (c *Client) GrantS3BucketAccess(
+	ctx context.Context,
+	zone, name string, grantee *apiv2.Persona, permission string) error {
+
+	grant := &apiv10.IsiS3BucketGrant{Grantee: grantee, Permission: permission}
+	if err := c.validateS3BucketGrant(ctx, zone, grant); err != nil {
+		return err
+	}
+	bucket, err := apiv10.GetIsiS3Bucket(ctx, c.API, zone, name)
+	if err != nil {
+		return err
+	}
+	var acl []*apiv10.IsiS3BucketGrant
+	if bucket.ACL != nil {
+		acl = *bucket.ACL
+	}
+	for _, g := range acl {
+		if g.Permission == permission && sameGrantee(g.Grantee, grantee) {
+			return nil
+		}
+	}
+	acl = append(acl, grant)
+	return apiv10.UpdateIsiS3Bucket(
+		ctx, c.API, zone, name, &apiv10.IsiS3Bucket{ACL: &acl})
+}
+
+// RevokeS3BucketAccess removes all grants to a grantee from an S3 bucket,
+// leaving its other grants unchanged.
+func (c *Client) RevokeS3BucketAccess(
+	ctx context.Context,
+	zone, name string, grantee *apiv2.Persona) error {
+
+	if grantee == nil {
+		return errors.New("no grantee set")
+	}
+	bucket, err := apiv10.GetIsiS3Bucket(ctx, c.API, zone, name)
+	if err != nil {
+		return err
+	}
+	if bucket.ACL == nil {
+		return nil
+	}
+	acl := []*apiv10.IsiS3BucketGrant{}
+	for _, g := range *bucket.ACL {
+		if !sameGrantee(g.Grantee, grantee) {
+			acl = append(acl, g)
+		}
+	}
+	if len(acl) == len(*bucket.ACL) {
+		return nil
+	}
+	return apiv10.UpdateIsiS3Bucket(
+		ctx, c.API, zone, name, &apiv10.IsiS3Bucket{ACL: &acl})
+}
+
+// SetS3ObjectACLPolicy sets what happens when a client modifies the ACL of
+// an object in an S3 bucket, apiv10.S3ObjectACLPolicyReplace or
+// apiv10.S3ObjectACLPolicyDeny.
+func (c *Client) SetS3ObjectACLPolicy(
+	ctx context.Context, zone, name, policy string) error {
+
+	switch policy {
+	case apiv10.S3ObjectACLPolicyReplace, apiv10.S3ObjectACLPolicyDeny:
+	default:
+		return fmt.Errorf("invalid object acl policy: %s", policy)
+	}
+	return apiv10.UpdateIsiS3Bucket(
+		ctx, c.API, zone, name, &apiv10.IsiS3Bucket{ObjectACLPolicy: &policy})
+}
+
+func (c *Client) validateS3BucketGrant(
+	ctx context.Context, zone string, g S3BucketGrant) error {
+
+	if g == nil {
+		return errors.New("no grant set")
+	}
+	switch g.Permission {
+	case apiv10.S3PermissionRead, apiv10.S3PermissionWrite,
+		apiv10.S3PermissionReadACP, apiv10.S3PermissionWriteACP,
+		apiv10.S3PermissionFullControl:
+	default:
+		return fmt.Errorf("invalid s3 permission: %s", g.Permission)
+	}
+	if t := g.Grantee; t != nil &&
+		(t.ID != nil && t.ID.Type == apiv2.PersonaIDTypeSID ||
+			t.Type != nil && *t.Type == apiv2.PersonaTypeWellKnown) {
+		// well-known SIDs such as Everyone are not users or groups
+		return nil
+	}
+	return c.ValidatePersona(ctx, zone, g.Grantee)
+}
+
+// sameGrantee returns whether two personas identify the same grantee by ID
+// or, if either has no ID, by name.
+func sameGrantee(a, b *apiv2.Persona) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a.ID != nil && b.ID != nil {
+		return a.ID.ID == b.ID.ID
+	}
+	return a.Name != nil && b.Name != nil && *a.Name == *b.Name
+}
@@ -0,0 +1,62 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv10 "github.com/tenortim/goisilon/api/v10"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+func TestS3BucketACL(t *testing.T) {
+	volumeName := "test_s3_bucket_acl"
+	name := "test-s3-bucket-acl"
+	path := client.API.VolumePath(volumeName)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	_, err = client.CreateS3Bucket(defaultCtx, "",
+		&apiv10.IsiS3Bucket{Name: &name, Path: &path})
+	if err != nil {
+		t.Skipf("s3 is not available: %v", err)
+	}
+	defer client.DeleteS3Bucket(defaultCtx, "", name)
+
+	everyone := apiv2.EveryonePersona()
+	assertNoError(t, client.GrantS3BucketAccess(
+		defaultCtx, "", name, everyone, apiv10.S3PermissionRead))
+	// granting the same permission again does nothing
+	assertNoError(t, client.GrantS3BucketAccess(
+		defaultCtx, "", name, everyone, apiv10.S3PermissionRead))
+	grants, err := client.GetS3BucketACL(defaultCtx, "", name)
+	assertNoError(t, err)
+	n := 0
+	for _, g := range grants {
+		if sameGrantee(g.Grantee, everyone) {
+			n++
+		}
+	}
+	assert.Equal(t, 1, n)
+
+	assertNoError(t, client.RevokeS3BucketAccess(
+		defaultCtx, "", name, everyone))
+	grants, err = client.GetS3BucketACL(defaultCtx, "", name)
+	assertNoError(t, err)
+	for _, g := range grants {
+		assert.False(t, sameGrantee(g.Grantee, everyone))
+	}
+
+	assertNoError(t, client.SetS3ObjectACLPolicy(
+		defaultCtx, "", name, apiv10.S3ObjectACLPolicyDeny))
+	bucket, err := client.GetS3Bucket(defaultCtx, "", name)
+	assertNoError(t, err)
+	assertNotNil(t, bucket.ObjectACLPolicy)
+	assert.Equal(t, apiv10.S3ObjectACLPolicyDeny, *bucket.ObjectACLPolicy)
+
+	assertError(t, client.SetS3ObjectACLPolicy(defaultCtx, "", name, "allow"))
+	assertError(t, client.GrantS3BucketAccess(
+		defaultCtx, "", name, everyone, "READ_WRITE"))
+}
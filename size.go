@@ -0,0 +1,115 @@
+package goisilon
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// QuotaBlockSize is the size of the blocks that quota usage is accounted in.
+// RoundQuotaSize rounds thresholds up to a multiple of it.
+const QuotaBlockSize = 8192
+
+// sizeUnits are the multipliers of the size units accepted by ParseSize.
+// Units with a lone letter, ex. "G", are binary as they are in the OneFS
+// CLI.
+var sizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gib": 1 << 30,
+	"t":   1 << 40,
+	"tib": 1 << 40,
+	"p":   1 << 50,
+	"pib": 1 << 50,
+	"e":   1 << 60,
+	"eib": 1 << 60,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"eb":  1e18,
+}
+
+// binarySizeUnits are the units used by FormatSize, largest first.
+var binarySizeUnits = []struct {
+	name string
+	size int64
+}{
+	{"EiB", 1 << 60},
+	{"PiB", 1 << 50},
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+// ParseSize parses a size in bytes, ex. "1.5TiB", "500GB" or "1048576".
+// Binary units (KiB to EiB, or K to E) are powers of 1024 and decimal units
+// (KB to EB) are powers of 1000. Units are not case sensitive. Fractional
+// sizes are rounded to the nearest byte.
+func ParseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && !unicode.IsDigit(r)
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	mult, ok := sizeUnits[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if n > math.MaxInt64/mult {
+			return 0, fmt.Errorf("size out of range: %q", s)
+		}
+		return n * mult, nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+	f = math.Round(f * float64(mult))
+	if f >= math.MaxInt64 {
+		return 0, fmt.Errorf("size out of range: %q", s)
+	}
+	return int64(f), nil
+}
+
+// FormatSize formats a size in bytes with the largest binary unit it is at
+// least one of and up to two decimal places, ex. "1.5TiB", so that
+// ParseSize returns about the same size.
+func FormatSize(n int64) string {
+	for _, u := range binarySizeUnits {
+		if n >= u.size || n <= -u.size {
+			v := strconv.FormatFloat(float64(n)/float64(u.size), 'f', 2, 64)
+			return strings.TrimSuffix(strings.TrimRight(v, "0"), ".") + u.name
+		}
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
+
+// RoundQuotaSize rounds a size up to a multiple of QuotaBlockSize, the
+// granularity that the cluster accounts quota usage in.
+func RoundQuotaSize(n int64) int64 {
+	if n <= 0 {
+		return n
+	}
+	if r := n % QuotaBlockSize; r != 0 {
+		if n > math.MaxInt64-QuotaBlockSize {
+			return n - r
+		}
+		n += QuotaBlockSize - r
+	}
+	return n
+}
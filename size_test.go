@@ -0,0 +1,62 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"0":        0,
+		"1048576":  1 << 20,
+		"512B":     512,
+		"1K":       1 << 10,
+		"1.5TiB":   3 << 39,
+		"1.5 tib":  3 << 39,
+		"500GB":    500e9,
+		"2gb":      2e9,
+		"10G":      10 << 30,
+		" 8EB ":    8e18,
+		"0.5KiB":   512,
+		"1.0001KB": 1000,
+		"7.5EiB":   15 << 59,
+	}
+	for s, want := range tests {
+		got, err := ParseSize(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+
+	for _, s := range []string{
+		"", "GiB", "-1GiB", "1.2.3GB", "10Q", "1 0GB", "8EiB", "10EB",
+	} {
+		_, err := ParseSize(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:           "0B",
+		1000:        "1000B",
+		1 << 10:     "1KiB",
+		1536:        "1.5KiB",
+		3 << 39:     "1.5TiB",
+		500e9:       "465.66GiB",
+		-(10 << 30): "-10GiB",
+	}
+	for n, want := range tests {
+		assert.Equal(t, want, FormatSize(n), "%d", n)
+	}
+	n, err := ParseSize(FormatSize(3 << 39))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3<<39), n)
+}
+
+func TestRoundQuotaSize(t *testing.T) {
+	assert.Equal(t, int64(0), RoundQuotaSize(0))
+	assert.Equal(t, int64(QuotaBlockSize), RoundQuotaSize(1))
+	assert.Equal(t, int64(QuotaBlockSize), RoundQuotaSize(QuotaBlockSize))
+	assert.Equal(t, int64(2*QuotaBlockSize), RoundQuotaSize(QuotaBlockSize+1))
+}
@@ -0,0 +1,259 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// SmartConnect connection balancing policies.
+const (
+	SmartConnectPolicyRoundRobin = "round_robin"
+	SmartConnectPolicyConnCount  = "conn_count"
+	SmartConnectPolicyThroughput = "throughput"
+	SmartConnectPolicyCPUUsage   = "cpu_usage"
+)
+
+// NetworkExternalSettings are the cluster-wide external network settings.
+type NetworkExternalSettings *api.IsiNetworkExternalSettings
+
+// GetNetworkExternalSettings returns the cluster-wide external network
+// settings.
+func (c *Client) GetNetworkExternalSettings(
+	ctx context.Context) (NetworkExternalSettings, error) {
+
+	return api.GetIsiNetworkExternalSettings(ctx, c.API)
+}
+
+// UpdateNetworkExternalSettings modifies the cluster-wide external network
+// settings. Fields that are nil are left unchanged.
+func (c *Client) UpdateNetworkExternalSettings(
+	ctx context.Context, settings NetworkExternalSettings) error {
+
+	return api.UpdateIsiNetworkExternalSettings(ctx, c.API, settings)
+}
+
+// SetSmartConnectServiceAddress sets the SmartConnect service IP address of
+// a subnet, which is the address DNS servers delegate zone lookups to.
+func (c *Client) SetSmartConnectServiceAddress(
+	ctx context.Context, groupnet, subnet, addr string) error {
+
+	return api.UpdateIsiSubnet(
+		ctx, c.API, groupnet, subnet, &api.IsiSubnet{SCServiceAddr: &addr})
+}
+
+// SetSmartConnectZone sets the SmartConnect DNS zone name and aliases of a
+// pool. The aliases replace any that are already configured.
+func (c *Client) SetSmartConnectZone(
+	ctx context.Context,
+	groupnet, subnet, pool, zoneName string, aliases ...string) error {
+
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return api.UpdateIsiPool(
+		ctx, c.API, groupnet, subnet, pool,
+		&api.IsiPool{SCDNSZone: &zoneName, SCDNSZoneAliases: &aliases})
+}
+
+// SetSmartConnectPolicy sets the SmartConnect connection balancing policy of
+// a pool.
+func (c *Client) SetSmartConnectPolicy(
+	ctx context.Context, groupnet, subnet, pool, policy string) error {
+
+	return api.UpdateIsiPool(
+		ctx, c.API, groupnet, subnet, pool,
+		&api.IsiPool{SCConnectPolicy: &policy})
+}
+
+// SetSmartConnectServiceSubnet sets the subnet whose SmartConnect service
+// address answers DNS requests for a pool's zone.
+func (c *Client) SetSmartConnectServiceSubnet(
+	ctx context.Context, groupnet, subnet, pool, serviceSubnet string) error {
+
+	return api.UpdateIsiPool(
+		ctx, c.API, groupnet, subnet, pool,
+		&api.IsiPool{SCSubnet: &serviceSubnet})
+}
+
+// GetSmartConnectZoneMap returns a map that relates SmartConnect DNS zone
+// names, including aliases, to the pools that serve them.
+func (c *Client) GetSmartConnectZoneMap(
+	ctx context.Context) (map[string]NetworkPool, error) {
+
+	pools, err := c.GetAllNetworkPools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneMap := map[string]NetworkPool{}
+	for _, p := range pools {
+		if p.SCDNSZone != nil && *p.SCDNSZone != "" {
+			zoneMap[*p.SCDNSZone] = p
+		}
+		if p.SCDNSZoneAliases == nil {
+			continue
+		}
+		for _, alias := range *p.SCDNSZoneAliases {
+			zoneMap[alias] = p
+		}
+	}
+	return zoneMap, nil
+}
+
+// AddSmartConnectZoneAlias adds a DNS zone alias to a pool, keeping the
+// aliases that are already configured.
+func (c *Client) AddSmartConnectZoneAlias(
+	ctx context.Context, groupnet, subnet, pool, alias string) error {
+
+	return c.updateSmartConnectZoneAliases(
+		ctx, groupnet, subnet, pool,
+		func(aliases []string) []string {
+			for _, a := range aliases {
+				if strings.EqualFold(a, alias) {
+					return nil
+				}
+			}
+			return append(aliases, alias)
+		})
+}
+
+// RemoveSmartConnectZoneAlias removes a DNS zone alias from a pool.
+func (c *Client) RemoveSmartConnectZoneAlias(
+	ctx context.Context, groupnet, subnet, pool, alias string) error {
+
+	return c.updateSmartConnectZoneAliases(
+		ctx, groupnet, subnet, pool,
+		func(aliases []string) []string {
+			for i, a := range aliases {
+				if strings.EqualFold(a, alias) {
+					return append(aliases[:i:i], aliases[i+1:]...)
+				}
+			}
+			return nil
+		})
+}
+
+// updateSmartConnectZoneAliases replaces the aliases of a pool with the
+// result of update, which returns nil if the aliases are unchanged.
+func (c *Client) updateSmartConnectZoneAliases(
+	ctx context.Context, groupnet, subnet, pool string,
+	update func(aliases []string) []string) error {
+
+	p, err := api.GetIsiPool(ctx, c.API, groupnet, subnet, pool)
+	if err != nil {
+		return err
+	}
+	var aliases []string
+	if p.SCDNSZoneAliases != nil {
+		aliases = *p.SCDNSZoneAliases
+	}
+	if aliases = update(aliases); aliases == nil {
+		return nil
+	}
+	return api.UpdateIsiPool(
+		ctx, c.API, groupnet, subnet, pool,
+		&api.IsiPool{SCDNSZoneAliases: &aliases})
+}
+
+// FlushDNSCache flushes the cluster's DNS cache.
+func (c *Client) FlushDNSCache(ctx context.Context) error {
+	return api.FlushIsiDNSCache(ctx, c.API)
+}
+
+// SmartConnectDelegation is the result of checking the DNS delegation of a
+// SmartConnect zone name or alias.
+type SmartConnectDelegation struct {
+
+	// Name is the zone name or alias.
+	Name string
+
+	// ServiceAddrs are the addresses returned by querying the subnet's
+	// SmartConnect service address directly.
+	ServiceAddrs []string
+
+	// ServiceErr is the error of the direct query, if any.
+	ServiceErr error
+
+	// DelegatedAddrs are the addresses returned by the client's resolver,
+	// which reach SmartConnect only if the zone is delegated to it.
+	DelegatedAddrs []string
+
+	// DelegatedErr is the error of the delegated query, if any.
+	DelegatedErr error
+
+	// Healthy is set if the delegated query returned only addresses that
+	// are allocated from the pool.
+	Healthy bool
+}
+
+// CheckSmartConnectDelegation resolves the SmartConnect zone name and
+// aliases of a pool both directly against the subnet's SmartConnect service
+// address and through the client's DNS resolver, and reports whether the
+// delegation returns the pool's addresses.
+func (c *Client) CheckSmartConnectDelegation(
+	ctx context.Context,
+	groupnet, subnet, pool string) ([]*SmartConnectDelegation, error) {
+
+	p, err := api.GetIsiPool(ctx, c.API, groupnet, subnet, pool)
+	if err != nil {
+		return nil, err
+	}
+	s, err := api.GetIsiSubnet(ctx, c.API, groupnet, subnet)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := c.GetNetworkPoolIPs(ctx, groupnet, subnet, pool)
+	if err != nil {
+		return nil, err
+	}
+	poolAddrs := map[string]bool{}
+	for _, ip := range ips {
+		poolAddrs[ip.Address] = true
+	}
+
+	var names []string
+	if p.SCDNSZone != nil && *p.SCDNSZone != "" {
+		names = append(names, *p.SCDNSZone)
+	}
+	if p.SCDNSZoneAliases != nil {
+		names = append(names, *p.SCDNSZoneAliases...)
+	}
+
+	var service *net.Resolver
+	if s.SCServiceAddr != nil && *s.SCServiceAddr != "" {
+		ssip := net.JoinHostPort(*s.SCServiceAddr, "53")
+		service = &net.Resolver{
+			PreferGo: true,
+			Dial: func(
+				ctx context.Context, network, _ string) (net.Conn, error) {
+
+				var d net.Dialer
+				return d.DialContext(ctx, network, ssip)
+			},
+		}
+	}
+
+	results := make([]*SmartConnectDelegation, len(names))
+	for i, name := range names {
+		r := &SmartConnectDelegation{Name: name}
+		if service != nil {
+			r.ServiceAddrs, r.ServiceErr = service.LookupHost(ctx, name)
+		} else {
+			r.ServiceErr = errors.New("no SmartConnect service address set")
+		}
+		r.DelegatedAddrs, r.DelegatedErr = net.DefaultResolver.LookupHost(
+			ctx, name)
+		r.Healthy = r.DelegatedErr == nil && len(r.DelegatedAddrs) > 0
+		for _, addr := range r.DelegatedAddrs {
+			if !poolAddrs[addr] {
+				r.Healthy = false
+			}
+		}
+		results[i] = r
+	}
+	return results, nil
+}
@@ -0,0 +1,85 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestGetSmartConnectZoneMap(t *testing.T) {
+	zoneMap, err := client.GetSmartConnectZoneMap(defaultCtx)
+	assertNoError(t, err)
+	for name, pool := range zoneMap {
+		t.Logf("zone=%s pool=%s", name, pool.ID)
+	}
+}
+
+func TestSetSmartConnectZone(t *testing.T) {
+	groupnet, subnet, name := "groupnet0", "subnet0", "test_smartconnect_pool"
+
+	if _, err := client.GetSubnet(defaultCtx, groupnet, subnet); err != nil {
+		t.Skip("default subnet is not configured")
+	}
+
+	_, err := client.CreateNetworkPool(
+		defaultCtx, groupnet, subnet, &api.IsiPool{Name: &name})
+	assertNoError(t, err)
+	defer client.DeleteNetworkPool(defaultCtx, groupnet, subnet, name)
+
+	err = client.SetSmartConnectZone(
+		defaultCtx, groupnet, subnet, name,
+		"goisilon.example.com", "alias.goisilon.example.com")
+	assertNoError(t, err)
+	err = client.SetSmartConnectPolicy(
+		defaultCtx, groupnet, subnet, name, SmartConnectPolicyConnCount)
+	assertNoError(t, err)
+
+	zoneMap, err := client.GetSmartConnectZoneMap(defaultCtx)
+	assertNoError(t, err)
+	if _, ok := zoneMap["alias.goisilon.example.com"]; !ok {
+		t.Fatal("zone alias missing from SmartConnect zone map")
+	}
+}
+
+func TestSmartConnectZoneAliases(t *testing.T) {
+	groupnet, subnet, name := "groupnet0", "subnet0", "test_smartconnect_alias"
+	alias := "alias.goisilon.example.com"
+
+	if _, err := client.GetSubnet(defaultCtx, groupnet, subnet); err != nil {
+		t.Skip("default subnet is not configured")
+	}
+
+	_, err := client.CreateNetworkPool(
+		defaultCtx, groupnet, subnet, &api.IsiPool{Name: &name})
+	assertNoError(t, err)
+	defer client.DeleteNetworkPool(defaultCtx, groupnet, subnet, name)
+
+	err = client.SetSmartConnectZone(
+		defaultCtx, groupnet, subnet, name, "goisilon.example.com")
+	assertNoError(t, err)
+
+	// adding twice leaves a single alias
+	for i := 0; i < 2; i++ {
+		err = client.AddSmartConnectZoneAlias(
+			defaultCtx, groupnet, subnet, name, alias)
+		assertNoError(t, err)
+	}
+	pool, err := client.GetNetworkPool(defaultCtx, groupnet, subnet, name)
+	assertNoError(t, err)
+	assertNotNil(t, pool.SCDNSZoneAliases)
+	assert.Equal(t, []string{alias}, *pool.SCDNSZoneAliases)
+
+	results, err := client.CheckSmartConnectDelegation(
+		defaultCtx, groupnet, subnet, name)
+	assertNoError(t, err)
+	assert.Len(t, results, 2)
+
+	err = client.RemoveSmartConnectZoneAlias(
+		defaultCtx, groupnet, subnet, name, alias)
+	assertNoError(t, err)
+	pool, err = client.GetNetworkPool(defaultCtx, groupnet, subnet, name)
+	assertNoError(t, err)
+	assert.Empty(t, *pool.SCDNSZoneAliases)
+}
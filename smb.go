@@ -0,0 +1,130 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// SMBShare is an SMB share.
+type SMBShare *api.IsiSMBShare
+
+// ListSMBShares returns a list of the SMB shares in an access zone that is
+// fetched a page at a time. If zone is empty the client's zone is used.
+func (c *Client) ListSMBShares(zone string) *List[*api.IsiSMBShare] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*api.IsiSMBShare, string, error) {
+
+		return api.GetIsiSMBSharesPage(ctx, c.API, zone, resume)
+	})
+}
+
+// GetSMBShare returns an SMB share in an access zone by name. If zone is
+// empty the client's zone is used.
+func (c *Client) GetSMBShare(
+	ctx context.Context, zone, name string) (SMBShare, error) {
+
+	return api.GetIsiSMBShare(ctx, c.API, zone, name)
+}
+
+// CreateSMBShare creates an SMB share in an access zone and returns its ID.
+// The share's name and path must be set.
+func (c *Client) CreateSMBShare(
+	ctx context.Context, zone string, share SMBShare) (string, error) {
+
+	return api.CreateIsiSMBShare(ctx, c.API, zone, share)
+}
+
+// SMBShareCreateOptions configure CreateSMBShareWithOptions.
+type SMBShareCreateOptions struct {
+
+	// CreatePath creates the share's path if it does not exist. Otherwise
+	// the path must already exist.
+	CreatePath bool
+
+	// InheritablePathACL sets an inheritable ACL on the share's path, as
+	// the WebUI's "Apply Windows default ACLs" does.
+	InheritablePathACL bool
+
+	// ACLTemplate is the absolute path of a directory, ex.
+	// /ifs/templates/share, whose ACL is applied to the share's path when
+	// the share is created. It may not be set with InheritablePathACL.
+	ACLTemplate string
+}
+
+// CreateSMBShareWithOptions creates an SMB share in an access zone, as
+// CreateSMBShare does, and returns its ID. The share's path is checked or
+// created, and its ACL set, according to opts. If the path does not exist
+// and is not to be created the error satisfies IsNotFound. If the template
+// ACL cannot be applied the share is removed again.
+func (c *Client) CreateSMBShareWithOptions(
+	ctx context.Context,
+	zone string, share SMBShare, opts *SMBShareCreateOptions) (string, error) {
+
+	if share == nil || share.Name == nil || share.Path == nil {
+		return "", errors.New("no share name or path set")
+	}
+	if opts == nil {
+		opts = &SMBShareCreateOptions{}
+	}
+	if opts.InheritablePathACL && opts.ACLTemplate != "" {
+		return "", errors.New(
+			"an inheritable path acl and an acl template are both set")
+	}
+
+	path := *share.Path
+	if !opts.CreatePath {
+		if _, err := apiv2.ACLInspectPath(ctx, c.API, path); err != nil {
+			return "", fmt.Errorf("share path %s: %w", path, err)
+		}
+	}
+	var template *apiv2.ACL
+	if opts.ACLTemplate != "" {
+		acl, err := apiv2.ACLInspectPath(ctx, c.API, opts.ACLTemplate)
+		if err != nil {
+			return "", fmt.Errorf("acl template %s: %w", opts.ACLTemplate, err)
+		}
+		template = &apiv2.ACL{
+			Action:        &apiv2.PActionTypeReplace,
+			Authoritative: &apiv2.PAuthoritativeTypeACL,
+			ACEs:          acl.ACEs,
+		}
+	}
+
+	req := *share
+	req.CreatePath = &opts.CreatePath
+	if opts.InheritablePathACL {
+		req.InheritablePathACL = &opts.InheritablePathACL
+	}
+	id, err := api.CreateIsiSMBShare(ctx, c.API, zone, &req)
+	if err != nil {
+		return "", err
+	}
+
+	if template != nil {
+		if err := apiv2.ACLUpdatePath(ctx, c.API, path, template); err != nil {
+			api.DeleteIsiSMBShare(
+				context.WithoutCancel(ctx), c.API, zone, *share.Name)
+			return "", fmt.Errorf("apply acl template to %s: %w", path, err)
+		}
+	}
+	return id, nil
+}
+
+// UpdateSMBShare modifies an SMB share in an access zone. Fields that are nil
+// are left unchanged.
+func (c *Client) UpdateSMBShare(
+	ctx context.Context, zone, name string, share SMBShare) error {
+
+	return api.UpdateIsiSMBShare(ctx, c.API, zone, name, share)
+}
+
+// DeleteSMBShare removes an SMB share from an access zone.
+func (c *Client) DeleteSMBShare(
+	ctx context.Context, zone, name string) error {
+
+	return api.DeleteIsiSMBShare(ctx, c.API, zone, name)
+}
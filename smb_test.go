@@ -0,0 +1,74 @@
+package goisilon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestSMBShares(t *testing.T) {
+	volumeName := "test_smb_shares"
+	name := "test_smb_share"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	path := client.API.VolumePath(volumeName)
+	everyone := "Everyone"
+	_, err = client.CreateSMBShare(defaultCtx, "", &api.IsiSMBShare{
+		Name: &name,
+		Path: &path,
+		Permissions: &[]*api.IsiSMBSharePermission{{
+			Permission:     api.SMBPermissionRead,
+			PermissionType: api.SMBPermissionTypeAllow,
+			Trustee:        &apiv2.Persona{Name: &everyone},
+		}},
+	})
+	assertNoError(t, err)
+	defer client.DeleteSMBShare(defaultCtx, "", name)
+
+	browsable := false
+	assertNoError(t, client.UpdateSMBShare(
+		defaultCtx, "", name, &api.IsiSMBShare{Browsable: &browsable}))
+
+	share, err := client.GetSMBShare(defaultCtx, "", name)
+	assertNoError(t, err)
+	assertNotNil(t, share.Path)
+	assert.Equal(t, path, *share.Path)
+	assertNotNil(t, share.Browsable)
+	assert.False(t, *share.Browsable)
+
+	_, found, err := client.ListSMBShares("").Filter(
+		func(s *api.IsiSMBShare) bool { return s.ID == name }).First(defaultCtx)
+	assertNoError(t, err)
+	assert.True(t, found)
+
+	assertNoError(t, client.DeleteSMBShare(defaultCtx, "", name))
+	_, err = client.GetSMBShare(defaultCtx, "", name)
+	assertError(t, err)
+}
+
+func TestCreateSMBShareWithOptions(t *testing.T) {
+	volumeName := "test_smb_share_options"
+	name := "test_smb_share_options"
+	path := client.API.VolumePath(volumeName)
+
+	// the path must exist unless it is to be created
+	_, err := client.CreateSMBShareWithOptions(defaultCtx, "",
+		&api.IsiSMBShare{Name: &name, Path: &path}, nil)
+	assert.True(t, IsNotFound(err), "%v", err)
+
+	_, err = client.CreateSMBShareWithOptions(defaultCtx, "",
+		&api.IsiSMBShare{Name: &name, Path: &path},
+		&SMBShareCreateOptions{CreatePath: true, InheritablePathACL: true})
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	defer client.DeleteSMBShare(defaultCtx, "", name)
+
+	_, err = client.GetVolume(defaultCtx, volumeName, volumeName)
+	assertNoError(t, err)
+}
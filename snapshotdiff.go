@@ -0,0 +1,114 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "github.com/tenortim/goisilon/api/v1"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// ChangedFile is a file or directory that changed between two snapshots.
+type ChangedFile struct {
+
+	// Path is the absolute path of the file.
+	Path string
+
+	// Type is the file type, ex. "regular", "directory" or "symlink".
+	Type string
+
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// ModTime is the last modification time of the file.
+	ModTime time.Time
+
+	// ChangeTypes are the kinds of change, ex. "ENTRY_ADDED".
+	ChangeTypes []string
+}
+
+func changedFileFromAPI(e *api.IsiChangelistEntry) *ChangedFile {
+	return &ChangedFile{
+		Path:        e.Path,
+		Type:        e.Type,
+		Size:        e.Size,
+		ModTime:     time.Unix(e.Mtime, e.Mtimensec),
+		ChangeTypes: e.ChangeTypes,
+	}
+}
+
+// ChangelistID returns the ID of the changelist of two snapshots.
+func ChangelistID(olderID, newerID int64) string {
+	return fmt.Sprintf("%d_%d", olderID, newerID)
+}
+
+// CreateChangelist starts a ChangelistCreate job that lists the differences
+// between two snapshots of the same path, and returns the ID of the job.
+func (c *Client) CreateChangelist(
+	ctx context.Context, olderID, newerID int64) (int64, error) {
+
+	return c.StartJob(ctx, &apiv3.IsiJobReq{
+		Type: "ChangelistCreate",
+		ChangelistCreateParams: &apiv3.IsiJobChangelistCreateParams{
+			OlderSnapID: olderID,
+			NewerSnapID: newerID,
+		},
+	})
+}
+
+// DeleteChangelist deletes the changelist of two snapshots.
+func (c *Client) DeleteChangelist(
+	ctx context.Context, olderID, newerID int64) error {
+
+	return api.DeleteIsiChangelist(ctx, c.API, ChangelistID(olderID, newerID))
+}
+
+// ListChangedFiles returns a list of the files in the existing changelist of
+// two snapshots that is fetched a page at a time, so that changelists with
+// millions of entries can be iterated without holding them in memory.
+func (c *Client) ListChangedFiles(olderID, newerID int64) *List[*ChangedFile] {
+	id := ChangelistID(olderID, newerID)
+	return newList(func(
+		ctx context.Context, resume string) ([]*ChangedFile, string, error) {
+
+		entries, resume, err := api.GetIsiChangelistEntriesPage(
+			ctx, c.API, id, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		files := make([]*ChangedFile, len(entries))
+		for i, e := range entries {
+			files[i] = changedFileFromAPI(e)
+		}
+		return files, resume, nil
+	})
+}
+
+// DiffSnapshots returns a list of the files that changed between two
+// snapshots of a volume. If the changelist of the snapshots does not exist
+// yet it is created, which waits for the ChangelistCreate job to finish. The
+// changelist is kept for later calls until DeleteChangelist is called.
+func (c *Client) DiffSnapshots(
+	ctx context.Context, olderID, newerID int64) (*List[*ChangedFile], error) {
+
+	_, err := api.GetIsiChangelist(
+		ctx, c.API, ChangelistID(olderID, newerID))
+	if IsNotFound(err) {
+		var id int64
+		if id, err = c.CreateChangelist(ctx, olderID, newerID); err != nil {
+			return nil, err
+		}
+		var job Job
+		if job, err = c.WaitForJobCompletion(ctx, id); err == nil &&
+			job.State != JobStateSucceeded && IsJobFinished(job) {
+			err = fmt.Errorf(
+				"changelist job %d for snapshots %d and %d %s",
+				id, olderID, newerID, job.State)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.ListChangedFiles(olderID, newerID), nil
+}
@@ -0,0 +1,41 @@
+package goisilon
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	volumeName := "test_diff_snapshots"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	older, err := client.CreateSnapshot(
+		defaultCtx, volumeName, "test_diff_snapshots_0")
+	assertNoError(t, err)
+	defer client.RemoveSnapshot(defaultCtx, older.Id, "")
+
+	assertNoError(t, client.CreateVolumeDir(
+		defaultCtx, volumeName, "changed", os.FileMode(0755), false, false))
+
+	newer, err := client.CreateSnapshot(
+		defaultCtx, volumeName, "test_diff_snapshots_1")
+	assertNoError(t, err)
+	defer client.RemoveSnapshot(defaultCtx, newer.Id, "")
+
+	files, err := client.DiffSnapshots(defaultCtx, older.Id, newer.Id)
+	assertNoError(t, err)
+	defer client.DeleteChangelist(defaultCtx, older.Id, newer.Id)
+
+	changed, ok, err := files.Filter(func(f *ChangedFile) bool {
+		return f.Path == client.API.VolumePath(volumeName)+"/changed"
+	}).First(defaultCtx)
+	assertNoError(t, err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "directory", changed.Type)
+	}
+}
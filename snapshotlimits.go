@@ -0,0 +1,187 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// The number of snapshots OneFS allows, across the cluster and of a single
+// directory.
+const (
+	DefaultMaxClusterSnapshots   = 20000
+	DefaultMaxDirectorySnapshots = 1024
+)
+
+// SnapshotSettings are the cluster's snapshot settings.
+type SnapshotSettings *api.IsiSnapshotSettings
+
+// SnapshotSummary is the number and size of the cluster's snapshots.
+type SnapshotSummary *api.IsiSnapshotSummary
+
+// ErrSnapshotLimit is returned when creating a snapshot would exceed a
+// snapshot count limit.
+var ErrSnapshotLimit = errors.New("snapshot limit exceeded")
+
+// SnapshotLimits are the snapshot count limits checked before creating a
+// snapshot. Zero values use the defaults.
+type SnapshotLimits struct {
+
+	// MaxCluster is the most snapshots of the cluster, by default
+	// DefaultMaxClusterSnapshots.
+	MaxCluster int
+
+	// MaxDirectory is the most snapshots of a directory, by default
+	// DefaultMaxDirectorySnapshots.
+	MaxDirectory int
+
+	// WarnPercent is the percentage of a limit at which a warning is given,
+	// by default 90.
+	WarnPercent int
+}
+
+// SnapshotLimitStatus is how close the cluster and a directory are to their
+// snapshot count limits.
+type SnapshotLimitStatus struct {
+	ClusterCount   int
+	MaxCluster     int
+	DirectoryCount int
+	MaxDirectory   int
+
+	// Warnings describe the limits that creating another snapshot would
+	// bring within the warning percentage.
+	Warnings []string
+
+	// Exceeded describes the limits that creating another snapshot would
+	// exceed.
+	Exceeded []string
+}
+
+// Err returns an error wrapping ErrSnapshotLimit if creating another
+// snapshot would exceed a limit, otherwise nil.
+func (s *SnapshotLimitStatus) Err() error {
+	if len(s.Exceeded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", s.Exceeded[0], ErrSnapshotLimit)
+}
+
+// GetSnapshotSettings returns the cluster's snapshot settings.
+func (c *Client) GetSnapshotSettings(
+	ctx context.Context) (SnapshotSettings, error) {
+
+	return api.GetIsiSnapshotSettings(ctx, c.API)
+}
+
+// GetSnapshotReserve returns the percentage of the file system reserved for
+// snapshots.
+func (c *Client) GetSnapshotReserve(ctx context.Context) (int, error) {
+	settings, err := api.GetIsiSnapshotSettings(ctx, c.API)
+	if err != nil {
+		return 0, err
+	}
+	if settings.Reserve == nil {
+		return 0, nil
+	}
+	return *settings.Reserve, nil
+}
+
+// SetSnapshotReserve sets the percentage of the file system reserved for
+// snapshots.
+func (c *Client) SetSnapshotReserve(ctx context.Context, percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("invalid snapshot reserve: %d%%", percent)
+	}
+	return api.UpdateIsiSnapshotSettings(
+		ctx, c.API, &api.IsiSnapshotSettings{Reserve: &percent})
+}
+
+// GetSnapshotSummary returns the number and size of the cluster's snapshots.
+func (c *Client) GetSnapshotSummary(
+	ctx context.Context) (SnapshotSummary, error) {
+
+	return api.GetIsiSnapshotSummary(ctx, c.API)
+}
+
+// CheckSnapshotLimits returns how close the cluster and the volume path are
+// to their snapshot count limits. If limits is nil the defaults are used.
+func (c *Client) CheckSnapshotLimits(
+	ctx context.Context,
+	path string, limits *SnapshotLimits) (*SnapshotLimitStatus, error) {
+
+	summary, err := api.GetIsiSnapshotSummary(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := c.GetSnapshotsByPath(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return snapshotLimitStatus(
+		path, limits, int(summary.Count), len(snapshots)), nil
+}
+
+// CreateSnapshotWithinLimits creates a snapshot called name of the given
+// path, as CreateSnapshot does, unless it would exceed a snapshot count
+// limit, in which case an error wrapping ErrSnapshotLimit is returned.
+// Warnings for limits that are nearly reached are reported to the context's
+// Progress. If limits is nil the defaults are used.
+func (c *Client) CreateSnapshotWithinLimits(
+	ctx context.Context,
+	path, name string, limits *SnapshotLimits) (*Snapshot, error) {
+
+	status, err := c.CheckSnapshotLimits(ctx, path, limits)
+	if err != nil {
+		return nil, err
+	}
+	if err := status.Err(); err != nil {
+		return nil, err
+	}
+	op := fmt.Sprintf("create snapshot of %s", path)
+	for _, w := range status.Warnings {
+		progressFrom(ctx).OnProgress(op, ProgressUpdate{Detail: w})
+	}
+	return c.CreateSnapshot(ctx, path, name)
+}
+
+func snapshotLimitStatus(
+	path string,
+	limits *SnapshotLimits,
+	clusterCount, directoryCount int) *SnapshotLimitStatus {
+
+	var l SnapshotLimits
+	if limits != nil {
+		l = *limits
+	}
+	if l.MaxCluster <= 0 {
+		l.MaxCluster = DefaultMaxClusterSnapshots
+	}
+	if l.MaxDirectory <= 0 {
+		l.MaxDirectory = DefaultMaxDirectorySnapshots
+	}
+	if l.WarnPercent <= 0 {
+		l.WarnPercent = 90
+	}
+
+	s := &SnapshotLimitStatus{
+		ClusterCount:   clusterCount,
+		MaxCluster:     l.MaxCluster,
+		DirectoryCount: directoryCount,
+		MaxDirectory:   l.MaxDirectory,
+	}
+	check := func(what string, count, max int) {
+		switch next := count + 1; {
+		case next > max:
+			s.Exceeded = append(s.Exceeded, fmt.Sprintf(
+				"%s has %d of %d snapshots", what, count, max))
+		case next*100 >= max*l.WarnPercent:
+			s.Warnings = append(s.Warnings, fmt.Sprintf(
+				"%s will have %d of %d snapshots", what, next, max))
+		}
+	}
+	check("cluster", clusterCount, l.MaxCluster)
+	check(path, directoryCount, l.MaxDirectory)
+	return s
+}
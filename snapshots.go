@@ -4,78 +4,204 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strings"
+	"time"
 
 	api "github.com/tenortim/goisilon/api/v1"
 )
 
 // SnapshotList represents a list of Isilon snapshots.
-type SnapshotList []*api.IsiSnapshot
+type SnapshotList []*Snapshot
 
 // Snapshot represents an Isilon snapshot.
-type Snapshot *api.IsiSnapshot
+type Snapshot api.IsiSnapshot
 
-// GetSnapshots returns a list of snapshots from the cluster.
-func (c *Client) GetSnapshots(ctx context.Context) (SnapshotList, error) {
-	snapshots, err := api.GetIsiSnapshots(ctx, c.API)
-	if err != nil {
-		return nil, err
+// SnapshotFromAPI converts an API snapshot to a Snapshot.
+func SnapshotFromAPI(s *api.IsiSnapshot) *Snapshot {
+	return (*Snapshot)(s)
+}
+
+// API returns the snapshot as an API snapshot.
+func (s *Snapshot) API() *api.IsiSnapshot {
+	return (*api.IsiSnapshot)(s)
+}
+
+// CreatedTime returns the time the snapshot was created.
+func (s *Snapshot) CreatedTime() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return time.Unix(s.Created, 0)
+}
+
+// ExpiresTime returns the time the snapshot expires, or the zero time if the
+// snapshot does not expire.
+func (s *Snapshot) ExpiresTime() time.Time {
+	if s == nil || s.Expires == 0 {
+		return time.Time{}
+	}
+	return time.Unix(s.Expires, 0)
+}
+
+// IsActive returns whether the snapshot is active.
+func (s *Snapshot) IsActive() bool {
+	return s != nil && s.State == "active"
+}
+
+// SnapshotOrigin is what created a snapshot.
+type SnapshotOrigin string
+
+// The origins of snapshots.
+const (
+	SnapshotOriginUser     SnapshotOrigin = "user"
+	SnapshotOriginSchedule SnapshotOrigin = "schedule"
+	SnapshotOriginSyncIQ   SnapshotOrigin = "synciq"
+)
+
+// syncIQSnapshotPrefix is the prefix of the names of the snapshots that
+// SyncIQ takes of the source and target of a policy, ex.
+// "SIQ-<policy id>-latest".
+const syncIQSnapshotPrefix = "SIQ-"
+
+// Origin returns what created the snapshot. Snapshots that SyncIQ depends on
+// have the SnapshotOriginSyncIQ origin.
+func (s *Snapshot) Origin() SnapshotOrigin {
+	switch {
+	case strings.HasPrefix(s.Name, syncIQSnapshotPrefix):
+		return SnapshotOriginSyncIQ
+	case s.Schedule != "":
+		return SnapshotOriginSchedule
+	}
+	return SnapshotOriginUser
+}
+
+// IsSyncIQ returns whether the snapshot was taken by SyncIQ replication.
+func (s *Snapshot) IsSyncIQ() bool {
+	return s.Origin() == SnapshotOriginSyncIQ
+}
+
+// IsScheduled returns whether the snapshot was taken by a snapshot schedule.
+func (s *Snapshot) IsScheduled() bool {
+	return s.Origin() == SnapshotOriginSchedule
+}
+
+// IsUserCreated returns whether the snapshot was taken by a user rather than
+// by a schedule or SyncIQ.
+func (s *Snapshot) IsUserCreated() bool {
+	return s.Origin() == SnapshotOriginUser
+}
+
+// ByOrigin returns the snapshots that were created by one of the origins.
+func (l SnapshotList) ByOrigin(origins ...SnapshotOrigin) SnapshotList {
+	var list SnapshotList
+	for _, s := range l {
+		if snapshotHasOrigin(s, origins) {
+			list = append(list, s)
+		}
 	}
+	return list
+}
 
-	return snapshots.SnapshotList, nil
+// ListSnapshotsByOrigin returns a list of the snapshots on the cluster that
+// were created by one of the origins, ex. SnapshotOriginUser to exclude the
+// snapshots that replication depends on, that is fetched a page at a time.
+func (c *Client) ListSnapshotsByOrigin(
+	origins ...SnapshotOrigin) *List[*Snapshot] {
+
+	return c.ListSnapshots().Filter(func(s *Snapshot) bool {
+		return snapshotHasOrigin(s, origins)
+	})
+}
+
+func snapshotHasOrigin(s *Snapshot, origins []SnapshotOrigin) bool {
+	origin := s.Origin()
+	for _, o := range origins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// ListSnapshots returns a list of all snapshots on the cluster that is
+// fetched a page at a time.
+func (c *Client) ListSnapshots() *List[*Snapshot] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*Snapshot, string, error) {
+
+		snapshots, resume, err := api.GetIsiSnapshotsPage(ctx, c.API, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		list := make([]*Snapshot, len(snapshots))
+		for i, s := range snapshots {
+			list[i] = SnapshotFromAPI(s)
+		}
+		return list, resume, nil
+	})
+}
+
+// GetSnapshots returns a list of snapshots from the cluster.
+func (c *Client) GetSnapshots(ctx context.Context) (SnapshotList, error) {
+	return c.ListSnapshots().Collect(ctx)
 }
 
 // GetSnapshotsByPath returns a list of snapshots covering the supplied path.
 func (c *Client) GetSnapshotsByPath(
 	ctx context.Context, path string) (SnapshotList, error) {
 
-	snapshots, err := api.GetIsiSnapshots(ctx, c.API)
-	if err != nil {
-		return nil, err
-	}
 	// find all the snapshots with the same path
-	snapshotsWithPath := make(SnapshotList, 0, len(snapshots.SnapshotList))
-	for _, snapshot := range snapshots.SnapshotList {
-		if snapshot.Path == c.API.VolumePath(path) {
-			snapshotsWithPath = append(snapshotsWithPath, snapshot)
-		}
-	}
-	return snapshotsWithPath, nil
+	volumePath := c.API.VolumePath(path)
+	return c.ListSnapshots().Filter(func(s *Snapshot) bool {
+		return s.Path == volumePath
+	}).Collect(ctx)
 }
 
 // GetSnapshot returns a snapshot matching id, or if that is not found, matching name
 func (c *Client) GetSnapshot(
-	ctx context.Context, id int64, name string) (Snapshot, error) {
+	ctx context.Context, id int64, name string) (*Snapshot, error) {
 
 	// if we have an id, use it to find the snapshot
-	snapshot, err := api.GetIsiSnapshot(ctx, c.API, id)
-	if err == nil {
-		return snapshot, nil
+	if id != 0 || name == "" {
+		isiSnapshot, err := api.GetIsiSnapshot(ctx, c.API, id)
+		if err == nil {
+			return SnapshotFromAPI(isiSnapshot), nil
+		}
+		if name == "" {
+			return nil, err
+		}
 	}
 
-	// there's no id or it didn't match, iterate through all snapshots and match
-	// based on name
-	if name == "" {
-		return nil, err
+	// there's no id or it didn't match, so look the snapshot up by name
+	snapshot, err := c.GetSnapshotByName(ctx, name)
+	if IsNotFound(err) {
+		return nil, nil
 	}
-	snapshotList, err := c.GetSnapshots(ctx)
+	return snapshot, err
+}
+
+// GetSnapshotByName returns the snapshot with the given name. The snapshot
+// is requested by name rather than found by listing all snapshots.
+func (c *Client) GetSnapshotByName(
+	ctx context.Context, name string) (*Snapshot, error) {
+
+	snapshot, err := api.GetIsiSnapshotByName(ctx, c.API, name)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, snapshot = range snapshotList {
-		if snapshot.Name == name {
-			return snapshot, nil
-		}
-	}
-
-	return nil, nil
+	return SnapshotFromAPI(snapshot), nil
 }
 
 // CreateSnapshot creates a snapshot called name of the given path.
 func (c *Client) CreateSnapshot(
-	ctx context.Context, path, name string) (Snapshot, error) {
+	ctx context.Context, path, name string) (*Snapshot, error) {
 
-	return api.CreateIsiSnapshot(ctx, c.API, c.API.VolumePath(path), name)
+	snapshot, err := api.CreateIsiSnapshot(
+		ctx, c.API, c.API.VolumePath(path), name)
+	if err != nil {
+		return nil, err
+	}
+	return SnapshotFromAPI(snapshot), nil
 }
 
 // RemoveSnapshot removes the snapshot by id, or failing that, the snapshot matching name.
@@ -91,9 +217,15 @@ func (c *Client) RemoveSnapshot(
 }
 
 // CopySnapshot copies all files/directories in a snapshot to a new directory.
+// The start and end of the copy are reported to the context's Progress.
 func (c *Client) CopySnapshot(
 	ctx context.Context,
-	sourceID int64, sourceName, destinationName string) (Volume, error) {
+	sourceID int64, sourceName, destinationName string) (_ *Volume, err error) {
+
+	op := fmt.Sprintf("copy snapshot to %s", destinationName)
+	progress := progressFrom(ctx)
+	progress.OnStart(op)
+	defer func() { progress.OnComplete(op, err) }()
 
 	snapshot, err := c.GetSnapshot(ctx, sourceID, sourceName)
 	if err != nil {
@@ -1,8 +1,11 @@
 package goisilon
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSnapshotsGet(t *testing.T) {
@@ -311,3 +314,89 @@ func TestSnapshotCopy(t *testing.T) {
 		panic(fmt.Sprintf("Sub directory has incorrect name.  Expected: (%s) Acutal: (%s)", destinationSubDirectory, subDirectory.Name))
 	}
 }
+
+func TestSnapshotGetByName(t *testing.T) {
+
+	snapshotPath := "test_snapshot_get_by_name_volume"
+	snapshotName := "test_snapshot_get_by_name_snapshot"
+
+	_, err := client.CreateVolume(defaultCtx, snapshotPath)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, snapshotPath)
+
+	_, err = client.GetSnapshotByName(defaultCtx, snapshotName)
+	assert.True(t, IsNotFound(err))
+
+	testSnapshot, err := client.CreateSnapshot(
+		defaultCtx, snapshotPath, snapshotName)
+	assertNoError(t, err)
+	defer client.RemoveSnapshot(defaultCtx, testSnapshot.Id, snapshotName)
+
+	snapshot, err := client.GetSnapshotByName(defaultCtx, snapshotName)
+	assertNoError(t, err)
+	assert.Equal(t, testSnapshot.Id, snapshot.Id)
+	assert.Equal(t, snapshotName, snapshot.Name)
+
+	// the name is used when the id does not match
+	snapshot, err = client.GetSnapshot(defaultCtx, 0, snapshotName)
+	assertNoError(t, err)
+	assert.Equal(t, testSnapshot.Id, snapshot.Id)
+}
+
+func TestSnapshotReserve(t *testing.T) {
+	reserve, err := client.GetSnapshotReserve(defaultCtx)
+	assertNoError(t, err)
+	defer client.SetSnapshotReserve(defaultCtx, reserve)
+
+	assertNoError(t, client.SetSnapshotReserve(defaultCtx, 5))
+	got, err := client.GetSnapshotReserve(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, 5, got)
+
+	assertError(t, client.SetSnapshotReserve(defaultCtx, 101))
+}
+
+func TestCreateSnapshotWithinLimits(t *testing.T) {
+	volumeName := "test_snapshot_limits_volume"
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	snapshot, err := client.CreateSnapshotWithinLimits(
+		defaultCtx, volumeName, "test_snapshot_limits_0", nil)
+	assertNoError(t, err)
+	defer client.RemoveSnapshot(defaultCtx, snapshot.Id, "")
+
+	// a directory limit of one is reached by the first snapshot
+	_, err = client.CreateSnapshotWithinLimits(
+		defaultCtx, volumeName, "test_snapshot_limits_1",
+		&SnapshotLimits{MaxDirectory: 1})
+	assert.True(t, errors.Is(err, ErrSnapshotLimit), "%v", err)
+}
+
+func TestSnapshotLimitStatus(t *testing.T) {
+	s := snapshotLimitStatus("/ifs/data", nil, 100, 921)
+	assertLen(t, s.Warnings, 1)
+	assertLen(t, s.Exceeded, 0)
+	assertNoError(t, s.Err())
+
+	s = snapshotLimitStatus(
+		"/ifs/data", &SnapshotLimits{MaxCluster: 100}, 100, 0)
+	assertLen(t, s.Exceeded, 1)
+	assert.True(t, errors.Is(s.Err(), ErrSnapshotLimit))
+}
+
+func TestSnapshotOrigin(t *testing.T) {
+	snapshots := SnapshotList{
+		{Name: "backup"},
+		{Name: "daily_2024-01-01", Schedule: "daily"},
+		{Name: "SIQ-0123456789abcdef-latest"},
+	}
+	assert.True(t, snapshots[0].IsUserCreated())
+	assert.True(t, snapshots[1].IsScheduled())
+	assert.True(t, snapshots[2].IsSyncIQ())
+
+	assertLen(t, snapshots.ByOrigin(SnapshotOriginUser), 1)
+	assertLen(t, snapshots.ByOrigin(
+		SnapshotOriginUser, SnapshotOriginSchedule), 2)
+}
@@ -0,0 +1,585 @@
+package goisilon
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snapshotNameTokens are the strftime conversions accepted in snapshot
+// naming patterns.
+const snapshotNameTokens = "AaBbCcDdeFGgHhIjklMmnpRrSsTtUuVvWwXxYyZz+%"
+
+// ValidateSnapshotNamePattern checks that a snapshot naming pattern, ex.
+// "daily_%Y-%m-%d_%H:%M", only uses the date and time conversions and
+// %{Variable} expansions that OneFS accepts.
+func ValidateSnapshotNamePattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("invalid snapshot name pattern: empty pattern")
+	}
+	if strings.Contains(pattern, "/") {
+		return fmt.Errorf(
+			"invalid snapshot name pattern %q: contains '/'", pattern)
+	}
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			continue
+		}
+		i++
+		switch {
+		case i == len(pattern):
+			return fmt.Errorf(
+				"invalid snapshot name pattern %q: trailing '%%'", pattern)
+		case pattern[i] == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return fmt.Errorf(
+					"invalid snapshot name pattern %q: unterminated %%{",
+					pattern)
+			}
+			name := pattern[i+1 : i+end]
+			if name == "" || strings.IndexFunc(name, func(r rune) bool {
+				return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+			}) >= 0 {
+				return fmt.Errorf(
+					"invalid snapshot name pattern %q: bad variable %%{%s}",
+					pattern, name)
+			}
+			i += end
+		case strings.IndexByte(snapshotNameTokens, pattern[i]) < 0:
+			return fmt.Errorf(
+				"invalid snapshot name pattern %q: unknown conversion %%%c",
+				pattern, pattern[i])
+		}
+	}
+	return nil
+}
+
+// scheduleUnit is the period of the date part of a schedule.
+type scheduleUnit int
+
+const (
+	scheduleDaily scheduleUnit = iota
+	scheduleWeekly
+	scheduleMonthly
+	scheduleYearly
+)
+
+// scheduleDayKind is what the ordinal of a monthly or yearly schedule
+// counts, ex. the last weekday of the month.
+type scheduleDayKind int
+
+const (
+	scheduleDayOfMonth scheduleDayKind = iota
+	scheduleWeekdayOfMonth
+	scheduleNamedDayOfMonth
+)
+
+// Schedule is a parsed OneFS schedule string, as used by snapshot
+// schedules and SyncIQ policies, ex. "Every Monday, Friday at 10:30 PM" or
+// "Every day every 4 hours between 8:00 AM and 6:00 PM".
+type Schedule struct {
+
+	// Start is the time from which intervals such as "every other week" are
+	// counted. OneFS counts from when the schedule was created; if Start is
+	// zero the Unix epoch is used.
+	Start time.Time
+
+	spec     string
+	unit     scheduleUnit
+	interval int
+	days     [7]bool // matching weekdays of daily and weekly schedules
+	month    time.Month
+	ordinal  int // day of the month, or the nth day of dayKind; -1 is last
+	dayKind  scheduleDayKind
+	day      time.Weekday // the named day of scheduleNamedDayOfMonth
+	minutes  []int        // the run times as minutes after midnight
+}
+
+// String returns the schedule string that was parsed.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// ParseSchedule parses a OneFS schedule string. The date part is one of:
+//
+//	Every [other | <n>] {day | weekday}
+//	Every [other | <n>] week [on <day>[, <day>...]]
+//	Every <day>[, <day>...] [of every [other | <n>] week]
+//	Every [other | <n>] month [on the <n>]
+//	The {last | <n>} {day | weekday | <day>} of every [other | <n>] month
+//	Yearly on <month> <n>
+//	Yearly on the {last | <n>} {day | weekday | <day>} of <month>
+//
+// followed by a time part, which defaults to midnight:
+//
+//	at <hh>[:<mm>] [AM | PM]
+//	every [<n>] {hours | minutes} [between <time> and <time>]
+//	every [<n>] {hours | minutes} [from <time> to <time>]
+//
+// Matching is case insensitive.
+func ParseSchedule(spec string) (*Schedule, error) {
+	p := &scheduleParser{
+		toks: strings.Fields(
+			strings.ReplaceAll(strings.ToLower(spec), ",", " , ")),
+	}
+	s := &Schedule{spec: spec, interval: 1}
+	if err := p.parseDate(s); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+	}
+	if err := p.parseTime(s); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("invalid schedule %q: unexpected %q", spec, tok)
+	}
+	return s, nil
+}
+
+// ValidateSchedule checks that a schedule string can be parsed.
+func ValidateSchedule(spec string) error {
+	_, err := ParseSchedule(spec)
+	return err
+}
+
+// Next returns the first run time of the schedule after t, in t's location,
+// or the zero time if the schedule never runs.
+func (s *Schedule) Next(t time.Time) time.Time {
+	start := s.Start
+	if start.IsZero() {
+		start = time.Unix(0, 0)
+	}
+	start = dateOf(start.In(t.Location()))
+
+	// a yearly schedule on February 29th may not run for eight years
+	d := dateOf(t)
+	for i := 0; i < 9*366; i++ {
+		if s.matchDate(start, d) {
+			for _, m := range s.minutes {
+				run := time.Date(d.Year(), d.Month(), d.Day(),
+					m/60, m%60, 0, 0, t.Location())
+				if run.After(t) {
+					return run
+				}
+			}
+		}
+		d = d.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// NextN returns up to n run times of the schedule after t.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	var runs []time.Time
+	for len(runs) < n {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysBetween returns the number of days from a to b, which are midnights.
+func daysBetween(a, b time.Time) int {
+	// round, as days are not 24 hours long across daylight saving changes
+	return int(math.Round(b.Sub(a).Hours() / 24))
+}
+
+// onInterval returns whether n periods is a multiple of the interval.
+func (s *Schedule) onInterval(n int) bool {
+	return (n%s.interval+s.interval)%s.interval == 0
+}
+
+func (s *Schedule) matchDate(start, d time.Time) bool {
+	switch s.unit {
+	case scheduleDaily:
+		return s.days[d.Weekday()] && s.onInterval(daysBetween(start, d))
+	case scheduleWeekly:
+		weekOf := func(t time.Time) time.Time {
+			return t.AddDate(0, 0, -int(t.Weekday()))
+		}
+		weeks := daysBetween(weekOf(start), weekOf(d)) / 7
+		return s.days[d.Weekday()] && s.onInterval(weeks)
+	case scheduleMonthly:
+		months := (d.Year()-start.Year())*12 + int(d.Month()-start.Month())
+		return s.onInterval(months) && s.matchDayOfMonth(d)
+	case scheduleYearly:
+		return d.Month() == s.month && s.matchDayOfMonth(d)
+	}
+	return false
+}
+
+func (s *Schedule) matchDayOfMonth(d time.Time) bool {
+	last := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, d.Location())
+	var match func(time.Time) bool
+	switch s.dayKind {
+	case scheduleDayOfMonth:
+		if s.ordinal == -1 {
+			return d.Day() == last.Day()
+		}
+		return d.Day() == s.ordinal
+	case scheduleWeekdayOfMonth:
+		match = func(t time.Time) bool {
+			return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+		}
+	case scheduleNamedDayOfMonth:
+		match = func(t time.Time) bool { return t.Weekday() == s.day }
+	}
+	if !match(d) {
+		return false
+	}
+	if s.ordinal == -1 {
+		for t := d.AddDate(0, 0, 1); !t.After(last); t = t.AddDate(0, 0, 1) {
+			if match(t) {
+				return false
+			}
+		}
+		return true
+	}
+	n := 0
+	for t := d; t.Month() == d.Month(); t = t.AddDate(0, 0, -1) {
+		if match(t) {
+			n++
+		}
+	}
+	return n == s.ordinal
+}
+
+// scheduleParser parses the lowercased, whitespace separated tokens of a
+// schedule string.
+type scheduleParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *scheduleParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *scheduleParser) next() string {
+	tok := p.peek()
+	if tok != "" {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of words.
+func (p *scheduleParser) accept(words ...string) bool {
+	tok := p.peek()
+	for _, w := range words {
+		if tok == w {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *scheduleParser) expect(words ...string) error {
+	if !p.accept(words...) {
+		return fmt.Errorf("expected %q, got %q", words[0], p.peek())
+	}
+	return nil
+}
+
+// parseInterval parses an optional "other" or positive integer.
+func (p *scheduleParser) parseInterval() (int, error) {
+	if p.accept("other") {
+		return 2, nil
+	}
+	n, err := strconv.Atoi(p.peek())
+	if err != nil {
+		return 1, nil
+	}
+	p.pos++
+	if n < 1 {
+		return 0, fmt.Errorf("interval must be positive, got %d", n)
+	}
+	return n, nil
+}
+
+var scheduleWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var scheduleMonths = map[string]time.Month{}
+
+func init() {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		scheduleMonths[name] = m
+		scheduleMonths[name[:3]] = m
+	}
+}
+
+// parseDays parses a list of day names separated by commas or "and".
+func (p *scheduleParser) parseDays(days *[7]bool) error {
+	for {
+		d, ok := scheduleWeekdays[p.peek()]
+		if !ok {
+			return fmt.Errorf("expected day name, got %q", p.peek())
+		}
+		p.pos++
+		days[d] = true
+		if !p.accept(",", "and") {
+			return nil
+		}
+		p.accept("and")
+	}
+}
+
+var scheduleOrdinals = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+}
+
+// parseOrdinal parses "last", "first" to "fifth", or a day number with an
+// optional suffix, ex. "2nd".
+func (p *scheduleParser) parseOrdinal() (int, error) {
+	tok := p.next()
+	if n, ok := scheduleOrdinals[tok]; ok {
+		return n, nil
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		tok = strings.TrimSuffix(tok, suffix)
+	}
+	n, err := strconv.Atoi(tok)
+	if err != nil || n < 1 || n > 31 {
+		return 0, fmt.Errorf("invalid day of month %q", p.toks[p.pos-1])
+	}
+	return n, nil
+}
+
+// parseDayOfMonth parses "{last | <n>} {day | weekday | <day>}".
+func (p *scheduleParser) parseDayOfMonth(s *Schedule) (err error) {
+	if s.ordinal, err = p.parseOrdinal(); err != nil {
+		return err
+	}
+	tok := p.next()
+	switch {
+	case tok == "day":
+		s.dayKind = scheduleDayOfMonth
+	case tok == "weekday":
+		s.dayKind = scheduleWeekdayOfMonth
+	default:
+		d, ok := scheduleWeekdays[tok]
+		if !ok {
+			return fmt.Errorf("expected day name, got %q", tok)
+		}
+		s.dayKind, s.day = scheduleNamedDayOfMonth, d
+	}
+	if s.dayKind != scheduleDayOfMonth && s.ordinal > 5 {
+		return fmt.Errorf("invalid ordinal %d", s.ordinal)
+	}
+	return nil
+}
+
+func (p *scheduleParser) parseDate(s *Schedule) (err error) {
+	tok := p.next()
+	switch tok {
+	case "every":
+		if _, ok := scheduleWeekdays[p.peek()]; ok {
+			s.unit = scheduleWeekly
+			if err = p.parseDays(&s.days); err != nil {
+				return err
+			}
+			if p.accept("of") {
+				if err = p.expect("every"); err != nil {
+					return err
+				}
+				if s.interval, err = p.parseInterval(); err != nil {
+					return err
+				}
+				return p.expect("week", "weeks")
+			}
+			return nil
+		}
+		if s.interval, err = p.parseInterval(); err != nil {
+			return err
+		}
+		switch tok := p.next(); tok {
+		case "day", "days":
+			s.unit = scheduleDaily
+			s.days = [7]bool{true, true, true, true, true, true, true}
+		case "weekday", "weekdays":
+			if s.interval != 1 {
+				return fmt.Errorf("weekdays do not take an interval")
+			}
+			s.unit = scheduleDaily
+			s.days = [7]bool{false, true, true, true, true, true, false}
+		case "week", "weeks":
+			s.unit = scheduleWeekly
+			if !p.accept("on") {
+				s.days[time.Sunday] = true
+				return nil
+			}
+			return p.parseDays(&s.days)
+		case "month", "months":
+			s.unit, s.ordinal = scheduleMonthly, 1
+			if p.accept("on") {
+				if err = p.expect("the"); err != nil {
+					return err
+				}
+				if s.ordinal, err = p.parseOrdinal(); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("unknown period %q", tok)
+		}
+		return nil
+	case "the":
+		s.unit = scheduleMonthly
+		if err = p.parseDayOfMonth(s); err != nil {
+			return err
+		}
+		for _, w := range []string{"of", "every"} {
+			if err = p.expect(w); err != nil {
+				return err
+			}
+		}
+		if s.interval, err = p.parseInterval(); err != nil {
+			return err
+		}
+		return p.expect("month", "months")
+	case "yearly":
+		s.unit = scheduleYearly
+		if err = p.expect("on"); err != nil {
+			return err
+		}
+		if p.accept("the") {
+			if err = p.parseDayOfMonth(s); err != nil {
+				return err
+			}
+			if err = p.expect("of"); err != nil {
+				return err
+			}
+			return p.parseMonth(s)
+		}
+		if err = p.parseMonth(s); err != nil {
+			return err
+		}
+		s.ordinal, err = p.parseOrdinal()
+		return err
+	}
+	return fmt.Errorf(
+		"expected \"every\", \"the\" or \"yearly\", got %q", tok)
+}
+
+func (p *scheduleParser) parseMonth(s *Schedule) error {
+	m, ok := scheduleMonths[p.peek()]
+	if !ok {
+		return fmt.Errorf("expected month name, got %q", p.peek())
+	}
+	p.pos++
+	s.month = m
+	return nil
+}
+
+// parseClock parses "<hh>[:<mm>] [AM | PM]" as minutes after midnight.
+func (p *scheduleParser) parseClock() (int, error) {
+	tok := p.next()
+	meridiem := ""
+	for _, m := range []string{"am", "pm"} {
+		if strings.HasSuffix(tok, m) {
+			tok, meridiem = strings.TrimSuffix(tok, m), m
+		}
+	}
+	if meridiem == "" && p.accept("am") {
+		meridiem = "am"
+	} else if meridiem == "" && p.accept("pm") {
+		meridiem = "pm"
+	}
+
+	hh, mm, _ := strings.Cut(tok, ":")
+	h, err := strconv.Atoi(hh)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", tok)
+	}
+	m := 0
+	if mm != "" {
+		if m, err = strconv.Atoi(mm); err != nil || len(mm) != 2 || m > 59 {
+			return 0, fmt.Errorf("invalid time %q", tok)
+		}
+	}
+	switch {
+	case meridiem == "" && (h < 0 || h > 23):
+		return 0, fmt.Errorf("invalid time %q", tok)
+	case meridiem != "" && (h < 1 || h > 12):
+		return 0, fmt.Errorf("invalid time %q %s", tok, meridiem)
+	case meridiem == "am" && h == 12:
+		h = 0
+	case meridiem == "pm" && h != 12:
+		h += 12
+	}
+	return h*60 + m, nil
+}
+
+func (p *scheduleParser) parseTime(s *Schedule) error {
+	tok := p.next()
+	switch tok {
+	case "":
+		s.minutes = []int{0}
+		return nil
+	case "at":
+		m, err := p.parseClock()
+		if err != nil {
+			return err
+		}
+		s.minutes = []int{m}
+		return nil
+	case "every":
+		n, err := p.parseInterval()
+		if err != nil {
+			return err
+		}
+		switch tok = p.next(); tok {
+		case "hour", "hours":
+			n *= 60
+		case "minute", "minutes":
+		default:
+			return fmt.Errorf("expected \"hours\" or \"minutes\", got %q", tok)
+		}
+		if n >= 24*60 {
+			return fmt.Errorf("interval must be less than a day")
+		}
+		from, to := 0, 24*60-1
+		and := map[string]string{"between": "and", "from": "to"}[p.peek()]
+		if and != "" {
+			p.pos++
+			if from, err = p.parseClock(); err != nil {
+				return err
+			}
+			if err = p.expect(and); err != nil {
+				return err
+			}
+			if to, err = p.parseClock(); err != nil {
+				return err
+			}
+			if to < from {
+				return fmt.Errorf("end time is before start time")
+			}
+		}
+		for m := from; m <= to; m += n {
+			s.minutes = append(s.minutes, m)
+		}
+		return nil
+	}
+	return fmt.Errorf("expected \"at\" or \"every\", got %q", tok)
+}
@@ -0,0 +1,156 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSnapshotNamePattern(t *testing.T) {
+	for _, pattern := range []string{
+		"daily_%Y-%m-%d_%H:%M",
+		"%{PolicyName}-%{SrcCluster}-%s",
+		"100%%",
+	} {
+		assert.NoError(t, ValidateSnapshotNamePattern(pattern), pattern)
+	}
+	for _, pattern := range []string{
+		"",
+		"daily_%Q",
+		"daily_%",
+		"%{Policy",
+		"%{}",
+		"a/%Y",
+	} {
+		assert.Error(t, ValidateSnapshotNamePattern(pattern), pattern)
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"daily",
+		"Every day at 25:00",
+		"Every day at 13:00 PM",
+		"Every day at 10:5",
+		"Every fortnight",
+		"Every 0 days",
+		"Every 2 weekdays",
+		"Every day every 2 hours between 6:00 PM and 8:00 AM",
+		"Every day every 24 hours",
+		"The 6th Monday of every month",
+		"Every Funday",
+		"Every day at noon",
+		"Every day at 10:00 AM extra",
+	} {
+		assert.Error(t, ValidateSchedule(spec), spec)
+	}
+}
+
+func TestScheduleNextN(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, time.January, 3, 12, 0, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec  string
+		start time.Time
+		runs  []time.Time
+	}{
+		{
+			"Every day at 12:00 AM",
+			time.Time{},
+			[]time.Time{at(1, 4, 0, 0), at(1, 5, 0, 0), at(1, 6, 0, 0)},
+		},
+		{
+			"every 2 days at 22:30",
+			from,
+			[]time.Time{at(1, 3, 22, 30), at(1, 5, 22, 30), at(1, 7, 22, 30)},
+		},
+		{
+			"Every weekday at 6 PM",
+			time.Time{},
+			[]time.Time{at(1, 3, 18, 0), at(1, 4, 18, 0), at(1, 5, 18, 0),
+				at(1, 8, 18, 0)},
+		},
+		{
+			"Every Monday, Friday at 10:30 PM",
+			time.Time{},
+			[]time.Time{at(1, 5, 22, 30), at(1, 8, 22, 30), at(1, 12, 22, 30)},
+		},
+		{
+			"Every other week on Tuesday at 1:00 AM",
+			from,
+			[]time.Time{at(1, 16, 1, 0), at(1, 30, 1, 0)},
+		},
+		{
+			"Every Saturday of every other week",
+			from,
+			[]time.Time{at(1, 6, 0, 0), at(1, 20, 0, 0)},
+		},
+		{
+			"Every month on the 15th at 3:00 AM",
+			time.Time{},
+			[]time.Time{at(1, 15, 3, 0), at(2, 15, 3, 0)},
+		},
+		{
+			"The last day of every month at 11:00 PM",
+			time.Time{},
+			[]time.Time{at(1, 31, 23, 0), at(2, 29, 23, 0)},
+		},
+		{
+			"The 2nd Tuesday of every month at 4:00 AM",
+			time.Time{},
+			[]time.Time{at(1, 9, 4, 0), at(2, 13, 4, 0)},
+		},
+		{
+			"The last weekday of every month at 8:00 PM",
+			time.Time{},
+			[]time.Time{at(1, 31, 20, 0), at(2, 29, 20, 0), at(3, 29, 20, 0)},
+		},
+		{
+			"Yearly on March 1st at 12:00 AM",
+			time.Time{},
+			[]time.Time{at(3, 1, 0, 0)},
+		},
+		{
+			"Every day every 4 hours between 8:00 AM and 6:00 PM",
+			time.Time{},
+			[]time.Time{at(1, 3, 16, 0), at(1, 4, 8, 0), at(1, 4, 12, 0),
+				at(1, 4, 16, 0)},
+		},
+		{
+			"Every day every 30 minutes from 12:30 PM to 1:30 PM",
+			time.Time{},
+			[]time.Time{at(1, 3, 12, 30), at(1, 3, 13, 0), at(1, 3, 13, 30),
+				at(1, 4, 12, 30)},
+		},
+	}
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.spec)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		s.Start = tt.start
+		assert.Equal(t, tt.runs, s.NextN(from, len(tt.runs)), tt.spec)
+	}
+}
+
+func TestScheduleNextDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	s, err := ParseSchedule("Every other day at 1:00 AM")
+	assertNoError(t, err)
+	s.Start = time.Date(2024, time.March, 9, 0, 0, 0, 0, loc)
+
+	runs := s.NextN(time.Date(2024, time.March, 9, 0, 0, 0, 0, loc), 2)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.March, 9, 1, 0, 0, 0, loc),
+		time.Date(2024, time.March, 11, 1, 0, 0, 0, loc),
+	}, runs)
+}
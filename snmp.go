@@ -0,0 +1,72 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// SNMPSettings are the SNMP service settings.
+type SNMPSettings *api.IsiSNMPSettings
+
+// GetSNMPSettings returns the SNMP service settings.
+func (c *Client) GetSNMPSettings(ctx context.Context) (SNMPSettings, error) {
+	return api.GetIsiSNMPSettings(ctx, c.API)
+}
+
+// UpdateSNMPSettings modifies the SNMP service settings. Fields that are nil
+// are left unchanged.
+func (c *Client) UpdateSNMPSettings(
+	ctx context.Context, settings SNMPSettings) error {
+
+	return api.UpdateIsiSNMPSettings(ctx, c.API, settings)
+}
+
+// EnableSNMPv2c enables the SNMP service with v1/v2c access using the given
+// read-only community.
+func (c *Client) EnableSNMPv2c(ctx context.Context, community string) error {
+	enabled := true
+	return api.UpdateIsiSNMPSettings(
+		ctx, c.API,
+		&api.IsiSNMPSettings{
+			Service:           &enabled,
+			SNMPv1v2cAccess:   &enabled,
+			ReadOnlyCommunity: &community,
+		})
+}
+
+// EnableSNMPv3 enables the SNMP service with v3 access for the given
+// read-only user. The privacy password may be empty to use authentication
+// without privacy.
+func (c *Client) EnableSNMPv3(
+	ctx context.Context, user, authPassword, privPassword string) error {
+
+	var (
+		enabled       = true
+		securityLevel = "authNoPriv"
+		settings      = &api.IsiSNMPSettings{
+			Service:            &enabled,
+			SNMPv3Access:       &enabled,
+			SNMPv3ReadOnlyUser: &user,
+			SNMPv3Password:     &authPassword,
+		}
+	)
+	if privPassword != "" {
+		securityLevel = "authPriv"
+		settings.SNMPv3PrivPassword = &privPassword
+	}
+	settings.SNMPv3SecurityLevel = &securityLevel
+	return api.UpdateIsiSNMPSettings(ctx, c.API, settings)
+}
+
+// SetSNMPSystemInfo sets the system contact and location reported by SNMP.
+func (c *Client) SetSNMPSystemInfo(
+	ctx context.Context, contact, location string) error {
+
+	return api.UpdateIsiSNMPSettings(
+		ctx, c.API,
+		&api.IsiSNMPSettings{
+			SystemContact:  &contact,
+			SystemLocation: &location,
+		})
+}
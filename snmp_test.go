@@ -0,0 +1,31 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestSNMPSystemInfo(t *testing.T) {
+	settings, err := client.GetSNMPSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+
+	var contact, location string
+	if settings.SystemContact != nil {
+		contact = *settings.SystemContact
+	}
+	if settings.SystemLocation != nil {
+		location = *settings.SystemLocation
+	}
+	defer client.SetSNMPSystemInfo(defaultCtx, contact, location)
+
+	err = client.SetSNMPSystemInfo(
+		defaultCtx, "goisilon@example.com", "test_snmp_location")
+	assertNoError(t, err)
+
+	settings, err = client.GetSNMPSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings.SystemLocation)
+	if *settings.SystemLocation != "test_snmp_location" {
+		t.Fatalf("unexpected location: %s", *settings.SystemLocation)
+	}
+}
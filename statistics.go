@@ -0,0 +1,160 @@
+package goisilon
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/tenortim/goisilon/api/json"
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+// StatSample is the value of a statistics key on a node, or on the cluster
+// if Node is zero.
+type StatSample struct {
+	Key   string
+	Node  int
+	Time  time.Time
+	Value interface{}
+
+	// Error is the reason the value could not be collected, if any.
+	Error string
+}
+
+// Float returns the value as a float64 and whether it is a number.
+func (s *StatSample) Float() (float64, bool) {
+	switch v := s.Value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// StatNodesAll selects the statistics of every node and of the cluster.
+const StatNodesAll = "all"
+
+// GetStats returns the current values of statistics keys, ex.
+// "cluster.cpu.user.avg". Nodes selects the nodes, ex. StatNodesAll or
+// "1,2"; if empty only the cluster values are returned.
+func (c *Client) GetStats(
+	ctx context.Context,
+	nodes string, keys ...string) ([]*StatSample, error) {
+
+	stats, err := api.GetIsiStatsCurrent(ctx, c.API, keys, nodes)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]*StatSample, len(stats))
+	for i, s := range stats {
+		samples[i] = &StatSample{
+			Key:   s.Key,
+			Node:  s.DevID,
+			Time:  time.Unix(s.Time, 0),
+			Value: s.Value,
+		}
+		switch {
+		case s.Error != nil:
+			samples[i].Error = *s.Error
+		case s.ErrorCode != nil:
+			samples[i].Error = "error code " + strconv.Itoa(*s.ErrorCode)
+		}
+	}
+	return samples, nil
+}
+
+// WatchStats starts a Watcher that polls statistics keys. See GetStats.
+func (c *Client) WatchStats(
+	ctx context.Context,
+	opts *WatchOptions,
+	nodes string, keys ...string) *Watcher[[]*StatSample] {
+
+	return NewWatcher(ctx, opts,
+		func(ctx context.Context) ([]*StatSample, error) {
+			return c.GetStats(ctx, nodes, keys...)
+		})
+}
+
+// StatAggregate is the aggregate of the values of a statistics key across
+// nodes. Nodes whose value could not be collected, such as nodes that are
+// down, or is not a number are excluded.
+type StatAggregate struct {
+	Key string
+
+	// Nodes are the nodes whose values were aggregated, in order.
+	Nodes []int
+
+	// Excluded are the nodes whose values were excluded, in order.
+	Excluded []int
+
+	Sum     float64
+	Min     float64
+	Max     float64
+	Average float64
+
+	// MinNode and MaxNode are the nodes with the minimum and maximum values.
+	MinNode int
+	MaxNode int
+}
+
+// AggregateStats aggregates the per-node samples of each key, ex. as
+// returned by GetStats for StatNodesAll. Cluster samples, whose Node is
+// zero, are ignored. The aggregates are ordered by key; a key none of whose
+// nodes has a numeric value has an aggregate with no Nodes and zero values.
+func AggregateStats(samples []*StatSample) []*StatAggregate {
+	byKey := map[string]*StatAggregate{}
+	var keys []string
+	for _, s := range samples {
+		if s.Node == 0 {
+			continue
+		}
+		a, ok := byKey[s.Key]
+		if !ok {
+			a = &StatAggregate{Key: s.Key}
+			byKey[s.Key] = a
+			keys = append(keys, s.Key)
+		}
+		v, ok := s.Float()
+		if s.Error != "" || !ok {
+			a.Excluded = append(a.Excluded, s.Node)
+			continue
+		}
+		if len(a.Nodes) == 0 || v < a.Min {
+			a.Min, a.MinNode = v, s.Node
+		}
+		if len(a.Nodes) == 0 || v > a.Max {
+			a.Max, a.MaxNode = v, s.Node
+		}
+		a.Sum += v
+		a.Nodes = append(a.Nodes, s.Node)
+	}
+
+	sort.Strings(keys)
+	aggregates := make([]*StatAggregate, len(keys))
+	for i, k := range keys {
+		a := byKey[k]
+		sort.Ints(a.Nodes)
+		sort.Ints(a.Excluded)
+		if len(a.Nodes) > 0 {
+			a.Average = a.Sum / float64(len(a.Nodes))
+		}
+		aggregates[i] = a
+	}
+	return aggregates
+}
+
+// GetStatsAggregates returns the aggregates across all nodes of the current
+// values of statistics keys, ex. "node.ifs.bytes.in.rate". See
+// AggregateStats.
+func (c *Client) GetStatsAggregates(
+	ctx context.Context, keys ...string) ([]*StatAggregate, error) {
+
+	samples, err := c.GetStats(ctx, StatNodesAll, keys...)
+	if err != nil {
+		return nil, err
+	}
+	return AggregateStats(samples), nil
+}
@@ -0,0 +1,73 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStats(t *testing.T) {
+	samples, err := client.GetStats(
+		defaultCtx, StatNodesAll, "node.uptime", "cluster.node.count.all")
+	assertNoError(t, err)
+	assert.NotEmpty(t, samples)
+	for _, s := range samples {
+		assert.NotEmpty(t, s.Key)
+	}
+
+	_, err = client.GetStats(defaultCtx, "")
+	assertError(t, err)
+}
+
+func TestWatchStats(t *testing.T) {
+	w := client.WatchStats(
+		defaultCtx, &WatchOptions{Interval: time.Second},
+		"", "cluster.node.count.all")
+	defer w.Stop()
+
+	for i := 0; i < 2; i++ {
+		s := <-w.C()
+		assertNoError(t, s.Err)
+		assertLen(t, s.Value, 1)
+		_, ok := s.Value[0].Float()
+		assert.True(t, ok)
+	}
+}
+
+func TestAggregateStats(t *testing.T) {
+	samples := []*StatSample{
+		{Key: "node.load.1min", Node: 0, Value: 100.0},
+		{Key: "node.load.1min", Node: 3, Value: 4.0},
+		{Key: "node.load.1min", Node: 1, Value: 2.0},
+		{Key: "node.load.1min", Node: 2, Error: "Node is down"},
+		{Key: "node.load.1min", Node: 4, Value: 6.0},
+		{Key: "node.disk.count", Node: 1, Value: "n/a"},
+	}
+	aggregates := AggregateStats(samples)
+	assertLen(t, aggregates, 2)
+
+	a := aggregates[0]
+	assert.Equal(t, "node.disk.count", a.Key)
+	assert.Empty(t, a.Nodes)
+	assert.Equal(t, []int{1}, a.Excluded)
+	assert.Equal(t, 0.0, a.Average)
+
+	a = aggregates[1]
+	assert.Equal(t, "node.load.1min", a.Key)
+	assert.Equal(t, []int{1, 3, 4}, a.Nodes)
+	assert.Equal(t, []int{2}, a.Excluded)
+	assert.Equal(t, 12.0, a.Sum)
+	assert.Equal(t, 2.0, a.Min)
+	assert.Equal(t, 1, a.MinNode)
+	assert.Equal(t, 6.0, a.Max)
+	assert.Equal(t, 4, a.MaxNode)
+	assert.Equal(t, 4.0, a.Average)
+}
+
+func TestGetStatsAggregates(t *testing.T) {
+	aggregates, err := client.GetStatsAggregates(defaultCtx, "node.uptime")
+	assertNoError(t, err)
+	assertLen(t, aggregates, 1)
+	assert.NotEmpty(t, aggregates[0].Nodes)
+}
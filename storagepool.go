@@ -0,0 +1,111 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// StoragePoolList is a list of node pools and tiers.
+type StoragePoolList []*api.IsiStoragePool
+
+// StoragePool is a node pool or tier.
+type StoragePool *api.IsiStoragePool
+
+// NodePoolList is a list of node pools.
+type NodePoolList []*api.IsiNodePool
+
+// NodePool is a pool of nodes with compatible hardware.
+type NodePool *api.IsiNodePool
+
+// TierList is a list of tiers.
+type TierList []*api.IsiTier
+
+// Tier is a group of node pools.
+type Tier *api.IsiTier
+
+// StoragePoolSettings are the global SmartPools settings.
+type StoragePoolSettings *api.IsiStoragePoolSettings
+
+// GetStoragePools returns all node pools and tiers.
+func (c *Client) GetStoragePools(ctx context.Context) (StoragePoolList, error) {
+	return api.GetIsiStoragePools(ctx, c.API)
+}
+
+// GetStoragePoolWithMostFreeSpace returns the node pool with the most
+// available capacity, or nil if there are no node pools.
+func (c *Client) GetStoragePoolWithMostFreeSpace(
+	ctx context.Context) (StoragePool, error) {
+
+	pools, err := c.GetStoragePools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var best StoragePool
+	for _, p := range pools {
+		if p.Type != "nodepool" {
+			continue
+		}
+		if best == nil || p.Usage.AvailBytes > best.Usage.AvailBytes {
+			best = p
+		}
+	}
+	return best, nil
+}
+
+// GetNodePools returns the node pools.
+func (c *Client) GetNodePools(ctx context.Context) (NodePoolList, error) {
+	return api.GetIsiNodePools(ctx, c.API)
+}
+
+// GetNodePool returns the node pool with the given ID or name.
+func (c *Client) GetNodePool(
+	ctx context.Context, id string) (NodePool, error) {
+
+	return api.GetIsiNodePool(ctx, c.API, id)
+}
+
+// UpdateNodePool modifies a node pool.
+func (c *Client) UpdateNodePool(ctx context.Context, pool NodePool) error {
+	return api.UpdateIsiNodePool(ctx, c.API, pool)
+}
+
+// GetTiers returns the tiers.
+func (c *Client) GetTiers(ctx context.Context) (TierList, error) {
+	return api.GetIsiTiers(ctx, c.API)
+}
+
+// CreateTier creates a tier containing the given node pools and returns its
+// ID.
+func (c *Client) CreateTier(
+	ctx context.Context, name string, nodePools ...string) (int64, error) {
+
+	return api.CreateIsiTier(
+		ctx, c.API, &api.IsiTier{Name: &name, Children: &nodePools})
+}
+
+// UpdateTier modifies a tier.
+func (c *Client) UpdateTier(ctx context.Context, tier Tier) error {
+	return api.UpdateIsiTier(ctx, c.API, tier)
+}
+
+// DeleteTier removes a tier.
+func (c *Client) DeleteTier(ctx context.Context, id int64) error {
+	return api.DeleteIsiTier(ctx, c.API, id)
+}
+
+// GetStoragePoolSettings returns the global SmartPools settings.
+func (c *Client) GetStoragePoolSettings(
+	ctx context.Context) (StoragePoolSettings, error) {
+
+	return api.GetIsiStoragePoolSettings(ctx, c.API)
+}
+
+// UpdateStoragePoolSettings modifies the global SmartPools settings. Fields
+// that are nil are left unchanged.
+func (c *Client) UpdateStoragePoolSettings(
+	ctx context.Context, settings StoragePoolSettings) error {
+
+	return api.UpdateIsiStoragePoolSettings(ctx, c.API, settings)
+}
@@ -0,0 +1,31 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetStoragePools(t *testing.T) {
+	pools, err := client.GetStoragePools(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range pools {
+		t.Logf("pool=%s type=%s avail=%d total=%d",
+			p.Name, p.Type, p.Usage.AvailBytes, p.Usage.TotalBytes)
+	}
+}
+
+func TestGetNodePools(t *testing.T) {
+	pools, err := client.GetNodePools(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range pools {
+		assertNotNil(t, p.Name)
+		pool, err := client.GetNodePool(defaultCtx, *p.Name)
+		assertNoError(t, err)
+		assertNotNil(t, pool)
+	}
+}
+
+func TestGetStoragePoolSettings(t *testing.T) {
+	settings, err := client.GetStoragePoolSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+}
@@ -0,0 +1,195 @@
+package goisilon
+
+import (
+	"context"
+	"sort"
+
+	apiv16 "github.com/tenortim/goisilon/api/v16"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// ESRSSettings are the ESRS remote support settings used by OneFS releases
+// prior to 9.5.
+type ESRSSettings *api.IsiESRSSettings
+
+// SupportAssistSettings are the SupportAssist remote support settings used
+// by OneFS 9.5 and later.
+type SupportAssistSettings *apiv16.IsiSupportAssistSettings
+
+// GetESRSSettings returns the ESRS settings.
+func (c *Client) GetESRSSettings(ctx context.Context) (ESRSSettings, error) {
+	return api.GetIsiESRSSettings(ctx, c.API)
+}
+
+// UpdateESRSSettings modifies the ESRS settings. Fields that are nil are left
+// unchanged.
+func (c *Client) UpdateESRSSettings(
+	ctx context.Context, settings ESRSSettings) error {
+
+	return api.UpdateIsiESRSSettings(ctx, c.API, settings)
+}
+
+// EnableESRS enables ESRS using the given primary and optional secondary
+// gateways.
+func (c *Client) EnableESRS(
+	ctx context.Context, primaryGateway, secondaryGateway string) error {
+
+	enabled := true
+	settings := &api.IsiESRSSettings{
+		Enabled:            &enabled,
+		PrimaryESRSGateway: &primaryGateway,
+	}
+	if secondaryGateway != "" {
+		settings.SecondaryESRSGateway = &secondaryGateway
+	}
+	return api.UpdateIsiESRSSettings(ctx, c.API, settings)
+}
+
+// DisableESRS disables ESRS.
+func (c *Client) DisableESRS(ctx context.Context) error {
+	enabled := false
+	return api.UpdateIsiESRSSettings(
+		ctx, c.API, &api.IsiESRSSettings{Enabled: &enabled})
+}
+
+// GetSupportAssistSettings returns the SupportAssist settings.
+func (c *Client) GetSupportAssistSettings(
+	ctx context.Context) (SupportAssistSettings, error) {
+
+	return apiv16.GetIsiSupportAssistSettings(ctx, c.API)
+}
+
+// UpdateSupportAssistSettings modifies the SupportAssist settings. Fields that
+// are nil are left unchanged.
+func (c *Client) UpdateSupportAssistSettings(
+	ctx context.Context, settings SupportAssistSettings) error {
+
+	return apiv16.UpdateIsiSupportAssistSettings(ctx, c.API, settings)
+}
+
+// EnrollSupportAssist accepts the terms of service, enrolls the cluster with
+// the given access key and PIN, and enables SupportAssist. The telemetry
+// flag records the customer's consent to sending telemetry.
+func (c *Client) EnrollSupportAssist(
+	ctx context.Context, accessKey, pin string, telemetry bool) error {
+
+	accepted := true
+	if err := apiv16.UpdateIsiSupportAssistSettings(
+		ctx, c.API,
+		&apiv16.IsiSupportAssistSettings{AcceptTerms: &accepted}); err != nil {
+		return err
+	}
+
+	if err := apiv16.ProvisionIsiSupportAssist(
+		ctx, c.API, accessKey, pin); err != nil {
+		return err
+	}
+
+	enabled := true
+	return apiv16.UpdateIsiSupportAssistSettings(
+		ctx, c.API,
+		&apiv16.IsiSupportAssistSettings{
+			SupportAssistEnabled: &enabled,
+			Telemetry: &apiv16.IsiSupportAssistTelemetry{
+				TelemetryEnabled: &telemetry,
+			},
+		})
+}
+
+// DisableSupportAssist disables SupportAssist.
+func (c *Client) DisableSupportAssist(ctx context.Context) error {
+	enabled := false
+	return apiv16.UpdateIsiSupportAssistSettings(
+		ctx, c.API,
+		&apiv16.IsiSupportAssistSettings{SupportAssistEnabled: &enabled})
+}
+
+// The remote support mechanisms reported by RemoteSupportStatus.
+const (
+	RemoteSupportSupportAssist = "supportassist"
+	RemoteSupportESRS          = "esrs"
+)
+
+// RemoteSupportStatus is the remote support configuration of a cluster,
+// whichever mechanism its OneFS release uses.
+type RemoteSupportStatus struct {
+
+	// Mechanism is RemoteSupportSupportAssist or RemoteSupportESRS.
+	Mechanism string
+
+	Enabled bool
+
+	// Gateways are the hosts of the gateways the cluster connects through,
+	// in order of preference. They are empty if it connects directly.
+	Gateways []string
+}
+
+// GetRemoteSupportStatus returns the cluster's remote support configuration
+// from the SupportAssist settings, or from the ESRS settings on releases
+// before OneFS 9.5.
+func (c *Client) GetRemoteSupportStatus(
+	ctx context.Context) (*RemoteSupportStatus, error) {
+
+	return withFallback(ctx, c, 16,
+		func(ctx context.Context) (*RemoteSupportStatus, error) {
+			s, err := apiv16.GetIsiSupportAssistSettings(ctx, c.API)
+			if err != nil {
+				return nil, err
+			}
+			return supportAssistStatus(s), nil
+		},
+		func(ctx context.Context) (*RemoteSupportStatus, error) {
+			s, err := api.GetIsiESRSSettings(ctx, c.API)
+			if err != nil {
+				return nil, err
+			}
+			return esrsStatus(s), nil
+		})
+}
+
+func supportAssistStatus(
+	s *apiv16.IsiSupportAssistSettings) *RemoteSupportStatus {
+
+	status := &RemoteSupportStatus{
+		Mechanism: RemoteSupportSupportAssist,
+		Gateways:  []string{},
+	}
+	if s.SupportAssistEnabled != nil {
+		status.Enabled = *s.SupportAssistEnabled
+	}
+	if s.Connection == nil || s.Connection.GatewayEndpoints == nil {
+		return status
+	}
+	gateways := make([]*apiv16.IsiSupportAssistGateway, 0,
+		len(*s.Connection.GatewayEndpoints))
+	for _, g := range *s.Connection.GatewayEndpoints {
+		if g != nil && (g.Enabled == nil || *g.Enabled) {
+			gateways = append(gateways, g)
+		}
+	}
+	sort.SliceStable(gateways, func(i, j int) bool {
+		return gateways[i].Priority < gateways[j].Priority
+	})
+	for _, g := range gateways {
+		status.Gateways = append(status.Gateways, g.Host)
+	}
+	return status
+}
+
+func esrsStatus(s *api.IsiESRSSettings) *RemoteSupportStatus {
+	status := &RemoteSupportStatus{
+		Mechanism: RemoteSupportESRS,
+		Gateways:  []string{},
+	}
+	if s.Enabled != nil {
+		status.Enabled = *s.Enabled
+	}
+	for _, g := range []*string{
+		s.PrimaryESRSGateway, s.SecondaryESRSGateway} {
+
+		if g != nil && *g != "" {
+			status.Gateways = append(status.Gateways, *g)
+		}
+	}
+	return status
+}
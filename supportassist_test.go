@@ -0,0 +1,17 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetRemoteSupportSettings(t *testing.T) {
+	if client.API.APIVersion() >= 16 {
+		settings, err := client.GetSupportAssistSettings(defaultCtx)
+		assertNoError(t, err)
+		assertNotNil(t, settings)
+		return
+	}
+	settings, err := client.GetESRSSettings(defaultCtx)
+	assertNoError(t, err)
+	assertNotNil(t, settings)
+}
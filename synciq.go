@@ -0,0 +1,231 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// SyncPolicy is a SyncIQ replication policy.
+type SyncPolicy *apiv3.IsiSyncPolicy
+
+// SyncReport is the report of a run of a SyncIQ policy.
+type SyncReport *apiv3.IsiSyncReport
+
+// SyncJob is a running job of a SyncIQ policy.
+type SyncJob *apiv3.IsiSyncJob
+
+// The terminal states of a SyncIQ job.
+const (
+	SyncJobStateFinished       = "finished"
+	SyncJobStateFailed         = "failed"
+	SyncJobStateCanceled       = "canceled"
+	SyncJobStateNeedsAttention = "needs_attention"
+	SyncJobStateSkipped        = "skipped"
+)
+
+// SyncReportRetention is how long SyncIQ keeps replication reports.
+type SyncReportRetention struct {
+
+	// MaxAge is the age after which reports are deleted.
+	MaxAge time.Duration
+
+	// MaxCount is the most reports kept for each policy.
+	MaxCount int
+}
+
+// licenseSyncIQ is the name of the SyncIQ license.
+const licenseSyncIQ = "SyncIQ"
+
+// ListSyncReports returns a list of the SyncIQ reports of a policy by name,
+// or of all policies if policyName is empty, that is fetched a page at a
+// time.
+func (c *Client) ListSyncReports(policyName string) *List[SyncReport] {
+	return newList(func(
+		ctx context.Context, resume string) ([]SyncReport, string, error) {
+
+		reports, resume, err := apiv3.GetIsiSyncReportsPage(
+			ctx, c.API, policyName, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		list := make([]SyncReport, len(reports))
+		for i, r := range reports {
+			list[i] = r
+		}
+		return list, resume, nil
+	})
+}
+
+// GetSyncReportRetention returns how long SyncIQ keeps replication reports.
+func (c *Client) GetSyncReportRetention(
+	ctx context.Context) (*SyncReportRetention, error) {
+
+	settings, err := apiv3.GetIsiSyncSettings(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	r := &SyncReportRetention{}
+	if settings.ReportMaxAge != nil {
+		r.MaxAge = time.Duration(*settings.ReportMaxAge) * time.Second
+	}
+	if settings.ReportMaxCount != nil {
+		r.MaxCount = *settings.ReportMaxCount
+	}
+	return r, nil
+}
+
+// SetSyncReportRetention sets how long SyncIQ keeps replication reports.
+// Zero fields are left unchanged. Reports beyond the new retention are
+// deleted by the next prune.
+func (c *Client) SetSyncReportRetention(
+	ctx context.Context, r *SyncReportRetention) error {
+
+	if r == nil {
+		return errors.New("no retention set")
+	}
+	if r.MaxAge < 0 || r.MaxCount < 0 {
+		return errors.New("invalid sync report retention")
+	}
+	settings := &apiv3.IsiSyncSettings{}
+	if r.MaxAge > 0 {
+		maxAge := int64(r.MaxAge / time.Second)
+		settings.ReportMaxAge = &maxAge
+	}
+	if r.MaxCount > 0 {
+		settings.ReportMaxCount = &r.MaxCount
+	}
+	return apiv3.UpdateIsiSyncSettings(ctx, c.API, settings)
+}
+
+// PruneSyncReports deletes the SyncIQ reports that are beyond the report
+// retention now, rather than waiting for the cluster to rotate them.
+func (c *Client) PruneSyncReports(ctx context.Context) error {
+	return apiv3.RotateIsiSyncReports(ctx, c.API)
+}
+
+// GetSyncPolicies returns all SyncIQ policies.
+func (c *Client) GetSyncPolicies(ctx context.Context) ([]SyncPolicy, error) {
+	policies, err := apiv3.GetIsiSyncPolicies(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]SyncPolicy, len(policies))
+	for i, p := range policies {
+		list[i] = p
+	}
+	return list, nil
+}
+
+// GetSyncPolicy returns a SyncIQ policy by id or name.
+func (c *Client) GetSyncPolicy(
+	ctx context.Context, name string) (SyncPolicy, error) {
+
+	return apiv3.GetIsiSyncPolicy(ctx, c.API, name)
+}
+
+// SyncPolicyResetWarnings returns the consequences of resetting a SyncIQ
+// policy that an operator should be warned about.
+func SyncPolicyResetWarnings(policy SyncPolicy) []string {
+	p := (*apiv3.IsiSyncPolicy)(policy)
+	warnings := []string{}
+	if p.TargetCompareInitialSync {
+		warnings = append(warnings, fmt.Sprintf(
+			"the next run of policy %s will compare every file under %s "+
+				"with %s:%s", p.Name, p.SourceRootPath, p.TargetHost,
+			p.TargetPath))
+	} else {
+		warnings = append(warnings, fmt.Sprintf(
+			"the next run of policy %s will copy every file under %s "+
+				"to %s:%s", p.Name, p.SourceRootPath, p.TargetHost,
+			p.TargetPath))
+	}
+	switch p.LastJobState {
+	case "running", "paused":
+		warnings = append(warnings, fmt.Sprintf(
+			"a job of policy %s is %s", p.Name, p.LastJobState))
+	}
+	switch {
+	case !p.Enabled:
+		warnings = append(warnings, fmt.Sprintf(
+			"policy %s is disabled and will not resync until it is enabled",
+			p.Name))
+	case p.Schedule == "when-source-modified":
+		warnings = append(warnings, fmt.Sprintf(
+			"policy %s runs when its source is modified and may resync "+
+				"immediately", p.Name))
+	}
+	return warnings
+}
+
+// ResetSyncPolicy clears the replication state of a SyncIQ policy by id or
+// name, which repairs a broken replication relationship at the cost of a
+// full or initial diff sync on the policy's next run. The warnings of
+// SyncPolicyResetWarnings are returned along with whether the reset
+// succeeded.
+func (c *Client) ResetSyncPolicy(
+	ctx context.Context, name string) ([]string, error) {
+
+	policy, err := apiv3.GetIsiSyncPolicy(ctx, c.API, name)
+	if err != nil {
+		return nil, err
+	}
+	warnings := SyncPolicyResetWarnings(policy)
+	return warnings, apiv3.ResetIsiSyncPolicy(ctx, c.API, policy.ID)
+}
+
+// GetSyncJob returns the running job of a SyncIQ policy by id or name.
+func (c *Client) GetSyncJob(ctx context.Context, name string) (SyncJob, error) {
+	return apiv3.GetIsiSyncJob(ctx, c.API, name)
+}
+
+// IsSyncJobFinished returns whether a SyncIQ job is in a terminal state.
+func IsSyncJobFinished(job SyncJob) bool {
+	switch job.State {
+	case SyncJobStateFinished, SyncJobStateFailed, SyncJobStateCanceled,
+		SyncJobStateNeedsAttention, SyncJobStateSkipped:
+		return true
+	}
+	return false
+}
+
+// latestSyncReport returns the most recent report of a SyncIQ policy by
+// name.
+func (c *Client) latestSyncReport(
+	ctx context.Context, name string) (SyncReport, error) {
+
+	var latest SyncReport
+	err := c.ListSyncReports(name).ForEach(ctx, func(r SyncReport) bool {
+		if latest == nil || r.StartTime > latest.StartTime {
+			latest = r
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no report of sync policy %s", name)
+	}
+	return latest, nil
+}
+
+// RunSyncPolicy starts a job of a SyncIQ policy by id or name.
+func (c *Client) RunSyncPolicy(ctx context.Context, name string) error {
+	return apiv3.StartIsiSyncJob(ctx, c.API, &apiv3.IsiSyncJobReq{ID: name})
+}
+
+// ForceFullResync resets a SyncIQ policy by id or name, as ResetSyncPolicy
+// does, and starts the job that resyncs it.
+func (c *Client) ForceFullResync(
+	ctx context.Context, name string) ([]string, error) {
+
+	warnings, err := c.ResetSyncPolicy(ctx, name)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, c.RunSyncPolicy(ctx, name)
+}
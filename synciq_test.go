@@ -0,0 +1,66 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func skipUnlessSyncIQ(t *testing.T) {
+	licensed, err := client.IsLicensed(defaultCtx, licenseSyncIQ)
+	if err != nil || !licensed {
+		t.Skip("SyncIQ is not licensed")
+	}
+}
+
+func TestSyncReportRetention(t *testing.T) {
+	skipUnlessSyncIQ(t)
+
+	orig, err := client.GetSyncReportRetention(defaultCtx)
+	assertNoError(t, err)
+	defer client.SetSyncReportRetention(defaultCtx, orig)
+
+	want := &SyncReportRetention{MaxAge: 30 * 24 * time.Hour, MaxCount: 500}
+	assertNoError(t, client.SetSyncReportRetention(defaultCtx, want))
+	got, err := client.GetSyncReportRetention(defaultCtx)
+	assertNoError(t, err)
+	assert.Equal(t, want, got)
+
+	assertNoError(t, client.PruneSyncReports(defaultCtx))
+
+	reports, err := client.ListSyncReports("").Limit(10).Collect(defaultCtx)
+	assertNoError(t, err)
+	for _, r := range reports {
+		t.Logf("policy=%s state=%s", r.PolicyName, r.State)
+	}
+}
+
+func TestGetSyncPolicies(t *testing.T) {
+	skipUnlessSyncIQ(t)
+
+	policies, err := client.GetSyncPolicies(defaultCtx)
+	assertNoError(t, err)
+	for _, p := range policies {
+		got, err := client.GetSyncPolicy(defaultCtx, p.Name)
+		assertNoError(t, err)
+		assert.Equal(t, p.ID, got.ID)
+	}
+}
+
+func TestSyncPolicyResetWarnings(t *testing.T) {
+	policy := &apiv3.IsiSyncPolicy{
+		Name:           "dr",
+		SourceRootPath: "/ifs/data",
+		TargetHost:     "dr.example.com",
+		TargetPath:     "/ifs/dr",
+		Enabled:        true,
+		LastJobState:   "finished",
+	}
+	assertLen(t, SyncPolicyResetWarnings(policy), 1)
+
+	policy.Enabled = false
+	policy.LastJobState = "paused"
+	assertLen(t, SyncPolicyResetWarnings(policy), 3)
+}
@@ -0,0 +1,140 @@
+package goisilon
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tenortim/goisilon/api"
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// UsageReportEntry is the usage of a directory with a quota, in bytes.
+// Thresholds that are not set are nil.
+type UsageReportEntry struct {
+	Path             string `json:"path"`
+	Owner            string `json:"owner"`
+	Hard             *int64 `json:"hard"`
+	Soft             *int64 `json:"soft"`
+	Advisory         *int64 `json:"advisory"`
+	Logical          int64  `json:"logical"`
+	Physical         int64  `json:"physical"`
+	Inodes           int64  `json:"inodes"`
+	IncludeSnapshots bool   `json:"include_snapshots"`
+}
+
+// UsageReport is a normalized report of the usage of directories with
+// quotas, ex. for chargeback, which can be written as CSV or JSON.
+type UsageReport struct {
+	Time    time.Time           `json:"time"`
+	Entries []*UsageReportEntry `json:"entries"`
+}
+
+// usageReportHeader is the header row of a usage report in CSV.
+var usageReportHeader = []string{
+	"path", "owner", "hard", "soft", "advisory",
+	"logical", "physical", "inodes", "include_snapshots",
+}
+
+// GetUsageReport returns a report of the usage of the directory quotas on a
+// path and its descendants, or of all directory quotas if path is empty. The
+// owner of each directory is looked up concurrently; it is empty if the
+// directory no longer exists.
+func (c *Client) GetUsageReport(
+	ctx context.Context, path string) (*UsageReport, error) {
+
+	quotas := c.ListQuotas().Filter(
+		func(q *Quota) bool { return q.Type == "directory" })
+	if path != "" {
+		quotas = c.ListDirectoryQuotas(path)
+	}
+
+	report := &UsageReport{Time: time.Now(), Entries: []*UsageReportEntry{}}
+	var calls []func(ctx context.Context) error
+	err := quotas.ForEach(ctx, func(q *Quota) bool {
+		e := usageReportEntry(q)
+		report.Entries = append(report.Entries, e)
+		calls = append(calls, func(ctx context.Context) error {
+			acl, err := apiv2.ACLInspectPath(ctx, c.API, e.Path)
+			if IsNotFound(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			e.Owner = ACLFromAPI(acl).OwnerName()
+			return nil
+		})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func usageReportEntry(q *Quota) *UsageReportEntry {
+	threshold := func(v int64) *int64 {
+		if v == 0 {
+			return nil
+		}
+		return &v
+	}
+	return &UsageReportEntry{
+		Path:             q.Path,
+		Hard:             threshold(q.Thresholds.Hard),
+		Soft:             threshold(q.Thresholds.Soft),
+		Advisory:         threshold(q.Thresholds.Advisory),
+		Logical:          q.Usage.Logical,
+		Physical:         q.Usage.Physical,
+		Inodes:           q.Usage.Inodes,
+		IncludeSnapshots: q.IncludeSnapshots,
+	}
+}
+
+// WriteCSV writes the report to w as CSV with a header row. Thresholds that
+// are not set are empty.
+func (r *UsageReport) WriteCSV(w io.Writer) error {
+	formatInt := func(v int64) string { return strconv.FormatInt(v, 10) }
+	formatThreshold := func(v *int64) string {
+		if v == nil {
+			return ""
+		}
+		return formatInt(*v)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(usageReportHeader); err != nil {
+		return err
+	}
+	for _, e := range r.Entries {
+		if err := cw.Write([]string{
+			e.Path,
+			e.Owner,
+			formatThreshold(e.Hard),
+			formatThreshold(e.Soft),
+			formatThreshold(e.Advisory),
+			formatInt(e.Logical),
+			formatInt(e.Physical),
+			formatInt(e.Inodes),
+			strconv.FormatBool(e.IncludeSnapshots),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report to w as JSON.
+func (r *UsageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
@@ -0,0 +1,64 @@
+package goisilon
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/tenortim/goisilon/api/v1"
+)
+
+func TestUsageReportWrite(t *testing.T) {
+	q := &Quota{
+		Path:       "/ifs/data/a,b",
+		Thresholds: api.IsiThresholds{Hard: 2000, Advisory: 1000},
+		Usage:      api.IsiQuotaUsage{Inodes: 3, Logical: 100, Physical: 300},
+	}
+	e := usageReportEntry(q)
+	e.Owner = "admin"
+	report := &UsageReport{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Entries: []*UsageReportEntry{e},
+	}
+
+	var buf bytes.Buffer
+	assertNoError(t, report.WriteCSV(&buf))
+	assert.Equal(t,
+		"path,owner,hard,soft,advisory,logical,physical,inodes,include_snapshots\n"+
+			"\"/ifs/data/a,b\",admin,2000,,1000,100,300,3,false\n",
+		buf.String())
+
+	buf.Reset()
+	assertNoError(t, report.WriteJSON(&buf))
+	var read UsageReport
+	assertNoError(t, json.Unmarshal(buf.Bytes(), &read))
+	assert.Equal(t, report.Time, read.Time)
+	assert.Equal(t, e, read.Entries[0])
+	assert.Contains(t, buf.String(), `"soft": null`)
+}
+
+func TestGetUsageReport(t *testing.T) {
+	volumeName := "test_get_usage_report"
+	quotaSize := int64(12345)
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	assertNoError(t, client.CreateQuota(
+		defaultCtx, volumeName, false, quotaSize))
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	report, err := client.GetUsageReport(
+		defaultCtx, client.API.VolumePath(volumeName))
+	assertNoError(t, err)
+	assertLen(t, report.Entries, 1)
+	e := report.Entries[0]
+	assert.Equal(t, client.API.VolumePath(volumeName), e.Path)
+	assert.Equal(t, client.API.User(), e.Owner)
+	if assert.NotNil(t, e.Hard) {
+		assert.Equal(t, quotaSize, *e.Hard)
+	}
+}
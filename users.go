@@ -0,0 +1,86 @@
+package goisilon
+
+import (
+	"context"
+	"strconv"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// UserList is a list of users.
+type UserList []*api.IsiUser
+
+// User is a user known to an authentication provider.
+type User *api.IsiUser
+
+// UserUpdate holds the user properties to modify.
+type UserUpdate *api.IsiUserReq
+
+// GetUsers returns the users in an access zone. If zone is empty the client's
+// zone is used.
+func (c *Client) GetUsers(ctx context.Context, zone string) (UserList, error) {
+	return api.GetIsiUsers(ctx, c.API, zone)
+}
+
+// GetUserByName returns the user in an access zone with the given name.
+func (c *Client) GetUserByName(
+	ctx context.Context, zone, name string) (User, error) {
+
+	return api.GetIsiUser(ctx, c.API, zone, name)
+}
+
+// GetUserByUID returns the user in an access zone with the given UID.
+func (c *Client) GetUserByUID(
+	ctx context.Context, zone string, uid int) (User, error) {
+
+	return api.GetIsiUser(ctx, c.API, zone, "UID:"+strconv.Itoa(uid))
+}
+
+// CreateUser creates a user with the local provider of an access zone and
+// returns its ID. If uid is zero the cluster assigns the next available UID,
+// and empty optional values are left at the provider's defaults.
+func (c *Client) CreateUser(
+	ctx context.Context,
+	zone, name string, uid int,
+	password, homeDir, shell string) (string, error) {
+
+	user := &api.IsiUserReq{Name: &name}
+	if uid != 0 {
+		user.UID = &uid
+	}
+	if password != "" {
+		user.Password = &password
+	}
+	if homeDir != "" {
+		user.HomeDirectory = &homeDir
+	}
+	if shell != "" {
+		user.Shell = &shell
+	}
+	return api.CreateIsiUser(ctx, c.API, zone, user)
+}
+
+// UpdateUser modifies a user in an access zone. Fields that are nil are left
+// unchanged.
+func (c *Client) UpdateUser(
+	ctx context.Context, zone, name string, user UserUpdate) error {
+
+	return api.UpdateIsiUser(ctx, c.API, zone, name, user)
+}
+
+// EnableUser enables a user in an access zone.
+func (c *Client) EnableUser(ctx context.Context, zone, name string) error {
+	enabled := true
+	return c.UpdateUser(ctx, zone, name, &api.IsiUserReq{Enabled: &enabled})
+}
+
+// DisableUser disables a user in an access zone.
+func (c *Client) DisableUser(ctx context.Context, zone, name string) error {
+	enabled := false
+	return c.UpdateUser(ctx, zone, name, &api.IsiUserReq{Enabled: &enabled})
+}
+
+// DeleteUser removes a user from an access zone.
+func (c *Client) DeleteUser(ctx context.Context, zone, name string) error {
+	return api.DeleteIsiUser(ctx, c.API, zone, name)
+}
@@ -0,0 +1,39 @@
+package goisilon
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestUserCreateDisableDelete(t *testing.T) {
+	name := "test_goisilon_user"
+
+	_, err := client.CreateUser(
+		defaultCtx, "", name, 0, "Password123!", "", "/bin/sh")
+	assertNoError(t, err)
+	defer client.DeleteUser(defaultCtx, "", name)
+
+	user, err := client.GetUserByName(defaultCtx, "", name)
+	assertNoError(t, err)
+	assertNotNil(t, user.UID)
+	assertNotNil(t, user.UID.ID)
+	if !user.Enabled {
+		t.Fatal("new user should be enabled")
+	}
+
+	uid, err := strconv.Atoi(user.UID.ID.ID)
+	assertNoError(t, err)
+	byUID, err := client.GetUserByUID(defaultCtx, "", uid)
+	assertNoError(t, err)
+	if byUID.Name != name {
+		t.Fatalf("user lookup by UID returned %s", byUID.Name)
+	}
+
+	err = client.DisableUser(defaultCtx, "", name)
+	assertNoError(t, err)
+	user, err = client.GetUserByName(defaultCtx, "", name)
+	assertNoError(t, err)
+	if user.Enabled {
+		t.Fatal("user should be disabled")
+	}
+}
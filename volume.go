@@ -2,6 +2,7 @@ package goisilon
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path"
 	"strings"
@@ -9,12 +10,38 @@ import (
 
 	log "github.com/akutz/gournal"
 
+	"github.com/tenortim/goisilon/api"
 	apiv1 "github.com/tenortim/goisilon/api/v1"
 	apiv2 "github.com/tenortim/goisilon/api/v2"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
 )
 
 // Volume represents an Isilon Volume (namespace API).
-type Volume *apiv1.IsiVolume
+type Volume apiv1.IsiVolume
+
+// VolumeFromAPI converts an API volume to a Volume.
+func VolumeFromAPI(v *apiv1.IsiVolume) *Volume {
+	return (*Volume)(v)
+}
+
+// API returns the volume as an API volume.
+func (v *Volume) API() *apiv1.IsiVolume {
+	return (*apiv1.IsiVolume)(v)
+}
+
+// Attribute returns the value of the volume attribute with the given name.
+// Numeric values are returned as json.Number to preserve their precision.
+func (v *Volume) Attribute(name string) (interface{}, bool) {
+	if v == nil {
+		return nil, false
+	}
+	for _, a := range v.AttributeMap {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return nil, false
+}
 
 // VolumeChildren is a list of a container's children.
 type VolumeChildren apiv2.ContainerChildList
@@ -24,7 +51,7 @@ type VolumeChildrenMap map[string]*apiv2.ContainerChild
 
 // GetVolume returns a specific volume by name or ID
 func (c *Client) GetVolume(
-	ctx context.Context, id, name string) (Volume, error) {
+	ctx context.Context, id, name string) (*Volume, error) {
 
 	if id != "" {
 		name = id
@@ -33,48 +60,107 @@ func (c *Client) GetVolume(
 	if err != nil {
 		return nil, err
 	}
-	var isiVolume = &apiv1.IsiVolume{Name: name, AttributeMap: volume.AttributeMap}
+	var isiVolume = &Volume{Name: name, AttributeMap: volume.AttributeMap}
 	return isiVolume, nil
 }
 
 // GetVolumes returns a list of volumes
-func (c *Client) GetVolumes(ctx context.Context) ([]Volume, error) {
+func (c *Client) GetVolumes(ctx context.Context) ([]*Volume, error) {
+	return c.ListVolumes().Collect(ctx)
+}
 
-	volumes, err := apiv1.GetIsiVolumes(ctx, c.API)
-	if err != nil {
-		return nil, err
-	}
-	var isiVolumes []Volume
-	for _, volume := range volumes.Children {
-		newVolume := &apiv1.IsiVolume{Name: volume.Name}
-		isiVolumes = append(isiVolumes, newVolume)
-	}
-	return isiVolumes, nil
+// ListVolumes returns a list of the volumes under the client's volumes path
+// that is fetched a page at a time.
+func (c *Client) ListVolumes() *List[*Volume] {
+	return newList(func(
+		ctx context.Context, resume string) ([]*Volume, string, error) {
+
+		names, resume, err := apiv1.GetIsiVolumesPage(ctx, c.API, resume)
+		if err != nil {
+			return nil, "", err
+		}
+		volumes := make([]*Volume, len(names))
+		for i, v := range names {
+			volumes[i] = &Volume{Name: v.Name}
+		}
+		return volumes, resume, nil
+	})
 }
 
 // CreateVolume creates a volume
 func (c *Client) CreateVolume(
-	ctx context.Context, name string) (Volume, error) {
+	ctx context.Context, name string) (*Volume, error) {
 
 	_, err := apiv1.CreateIsiVolume(ctx, c.API, name)
 	if err != nil {
 		return nil, err
 	}
 
-	var isiVolume = &apiv1.IsiVolume{Name: name, AttributeMap: nil}
+	var isiVolume = &Volume{Name: name, AttributeMap: nil}
 	return isiVolume, nil
 }
 
+// CreateVolumeIfAbsent creates a volume, or returns the volume if it already
+// exists. An existing volume must be a directory with the mode CreateVolume
+// would have given it, otherwise an error wrapping ErrMismatch is returned.
+func (c *Client) CreateVolumeIfAbsent(
+	ctx context.Context, name string) (*Volume, error) {
+
+	volume, err := c.GetVolume(ctx, "", name)
+	if IsNotFound(err) {
+		if volume, err = c.CreateVolume(ctx, name); !IsConflict(err) {
+			return volume, err
+		}
+		// lost a race with another creator, so check what it made
+		volume, err = c.GetVolume(ctx, "", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	acl, err := c.GetVolumeACL(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := volumeMatches(volume, acl); err != nil {
+		return nil, err
+	}
+	return volume, nil
+}
+
+// volumeMatches checks that an existing volume is what CreateVolume makes:
+// a directory whose public_read_write ACL gives it mode 0777.
+func volumeMatches(volume *Volume, acl *ACL) error {
+	if err := volumeIsContainer(volume); err != nil {
+		return err
+	}
+	if m := acl.FileMode().Perm(); m != 0777 {
+		return fmt.Errorf(
+			"volume %s has mode %#o, not 0777: %w",
+			volume.Name, m, ErrMismatch)
+	}
+	return nil
+}
+
+// volumeIsContainer checks that an existing volume is a directory.
+func volumeIsContainer(volume *Volume) error {
+	if t, ok := volume.Attribute("type"); ok && t != "container" {
+		return fmt.Errorf(
+			"volume %s is a %v, not a container: %w",
+			volume.Name, t, ErrMismatch)
+	}
+	return nil
+}
+
 // CreateVolume creates a volume
 func (c *Client) CreateVolumeNoACL(
-	ctx context.Context, name string) (Volume, error) {
+	ctx context.Context, name string) (*Volume, error) {
 
 	_, err := apiv1.CreateIsiVolumeWithACL(ctx, c.API, name, "0777")
 	if err != nil {
 		return nil, err
 	}
 
-	var isiVolume = &apiv1.IsiVolume{Name: name, AttributeMap: nil}
+	var isiVolume = &Volume{Name: name, AttributeMap: nil}
 	return isiVolume, nil
 }
 
@@ -190,9 +276,21 @@ func (c *Client) ForceDeleteVolume(ctx context.Context, name string) error {
 	return c.DeleteVolume(ctx, name)
 }
 
+// TreeDeleteVolume deletes a volume and its contents with a TreeDelete job,
+// which is faster than deleting a large tree over the API, and waits for
+// the job to finish. The job's progress is reported to the context's
+// Progress.
+func (c *Client) TreeDeleteVolume(ctx context.Context, name string) error {
+	_, err := c.RunJob(ctx, &apiv3.IsiJobReq{
+		Type:  JobTypeTreeDelete,
+		Paths: []string{c.API.VolumePath(name)},
+	})
+	return err
+}
+
 //CopyVolume creates a volume based on an existing volume
 func (c *Client) CopyVolume(
-	ctx context.Context, src, dest string) (Volume, error) {
+	ctx context.Context, src, dest string) (*Volume, error) {
 
 	_, err := apiv1.CopyIsiVolume(ctx, c.API, src, dest)
 	if err != nil {
@@ -250,7 +348,7 @@ func (c *Client) CreateVolumeDir(
 // Volume-to-Export relationship.
 func (c *Client) GetVolumeExportMap(
 	ctx context.Context,
-	includeRootClients bool) (map[Volume]Export, error) {
+	includeRootClients bool) (map[*Volume]Export, error) {
 
 	volumes, err := c.GetVolumes(ctx)
 	if err != nil {
@@ -261,7 +359,7 @@ func (c *Client) GetVolumeExportMap(
 		return nil, err
 	}
 
-	volToExpMap := map[Volume]Export{}
+	volToExpMap := map[*Volume]Export{}
 
 	for _, v := range volumes {
 		vp := c.API.VolumePath(v.Name)
@@ -301,3 +399,54 @@ func (c *Client) GetVolumeExportMap(
 
 	return volToExpMap, nil
 }
+
+// VolumeDetails is a volume along with its ACL, quota, export, and
+// snapshots.
+type VolumeDetails struct {
+	Volume *Volume
+	ACL    *ACL
+
+	// Quota is nil if the volume has no quota.
+	Quota *Quota
+
+	// Export is the first export of the volume in the client's zone, or nil
+	// if it is not exported.
+	Export Export
+
+	Snapshots SnapshotList
+}
+
+// GetVolumeDetails returns a volume along with its ACL, quota, export, and
+// snapshots, which are queried concurrently.
+func (c *Client) GetVolumeDetails(
+	ctx context.Context, name string) (*VolumeDetails, error) {
+
+	d := &VolumeDetails{}
+	err := api.Batch(ctx, ConcurrentHTTPConnections,
+		func(ctx context.Context) (err error) {
+			d.Volume, err = c.GetVolume(ctx, name, name)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			d.ACL, err = c.GetVolumeACL(ctx, name)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			if d.Quota, err = c.GetQuota(ctx, name); IsNotFound(err) {
+				return nil
+			}
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			d.Export, err = c.GetExportByName(ctx, name)
+			return err
+		},
+		func(ctx context.Context) (err error) {
+			d.Snapshots, err = c.GetSnapshotsByPath(ctx, name)
+			return err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
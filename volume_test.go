@@ -3,6 +3,7 @@ package goisilon
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -104,6 +105,25 @@ func TestVolumeGetCreate(*testing.T) {
 	}
 }
 
+func TestVolumeCreateIfAbsent(t *testing.T) {
+	volumeName := "test_create_if_absent_volume_name"
+
+	volume, err := client.CreateVolumeIfAbsent(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+	assert.Equal(t, volumeName, volume.Name)
+
+	// a retry returns the existing volume
+	volume, err = client.CreateVolumeIfAbsent(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assert.Equal(t, volumeName, volume.Name)
+
+	// but not once it no longer matches the request
+	assertNoError(t, client.SetVolumeMode(defaultCtx, volumeName, 0755))
+	_, err = client.CreateVolumeIfAbsent(defaultCtx, volumeName)
+	assert.True(t, errors.Is(err, ErrMismatch), "%v", err)
+}
+
 func TestVolumeDelete(*testing.T) {
 	volumeName := "test_remove_volume_name"
 
@@ -133,6 +153,25 @@ func TestVolumeDelete(*testing.T) {
 	}
 }
 
+func TestVolumeTreeDelete(t *testing.T) {
+	volumeName := "test_tree_delete_volume_name"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	err = client.CreateVolumeDir(
+		defaultCtx, volumeName, "a/b/c", 0755, false, true)
+	assertNoError(t, err)
+
+	var out bytes.Buffer
+	ctx := ContextWithProgress(defaultCtx, &ProgressWriter{W: &out})
+	assertNoError(t, client.TreeDeleteVolume(ctx, volumeName))
+	assert.Contains(t, out.String(), "started")
+	assert.Contains(t, out.String(), "done")
+
+	_, err = client.GetVolume(defaultCtx, volumeName, volumeName)
+	assertError(t, err)
+}
+
 func TestVolumeCopy(*testing.T) {
 	sourceVolumeName := "test_copy_source_volume_name"
 	destinationVolumeName := "test_copy_destination_volume_name"
@@ -229,7 +268,7 @@ func TestVolumeQueryChildren(t *testing.T) {
 		//context.WithValue(defaultCtx, log.LevelKey(), log.InfoLevel)
 
 		err      error
-		volume   Volume
+		volume   *Volume
 		children VolumeChildrenMap
 
 		volumeName   = "test_volume_query_children"
@@ -832,3 +871,21 @@ func (b *bufReadCloser) Read(p []byte) (n int, err error) {
 func (b *bufReadCloser) Close() error {
 	return nil
 }
+
+func TestVolumeGetDetails(t *testing.T) {
+	volumeName := "test_get_volume_details"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	d, err := client.GetVolumeDetails(defaultCtx, volumeName)
+	assertNoError(t, err)
+	assertNotNil(t, d.Volume)
+	assertNotNil(t, d.ACL)
+	assert.Nil(t, d.Quota)
+	assert.Nil(t, d.Export)
+
+	_, err = client.GetVolumeDetails(defaultCtx, "test_no_such_volume")
+	assertError(t, err)
+}
@@ -0,0 +1,278 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+// CopyVolumeOptions configure CopyVolumeToCluster.
+type CopyVolumeOptions struct {
+
+	// Workers is the number of files and directories copied concurrently. If
+	// zero, ConcurrentHTTPConnections is used.
+	Workers int
+
+	// Retries is the number of times copying a file or directory is retried.
+	Retries int
+
+	// Overwrite replaces files that already exist in the destination volume.
+	// If false copying such files fails.
+	Overwrite bool
+
+	// SkipACLs disables copying the owner, group, mode and ACL of each file
+	// and directory, ex. if the source's users and groups do not exist on
+	// the destination cluster. The destination's default permissions are
+	// used instead.
+	SkipACLs bool
+}
+
+// CopyVolumeToCluster copies a volume and its contents to a volume on
+// another cluster, using the namespace API of both, for one-off migrations
+// where SyncIQ is not licensed. Directories are recreated, the contents of
+// files are streamed from one cluster to the other without being buffered,
+// and the owner, group, mode and ACL of each file and directory are copied
+// unless opts.SkipACLs is set. The destination volume is created if it does
+// not exist. Other file types, such as symbolic links, are not copied and
+// are reported as failures.
+//
+// The returned report has the result of each file and directory, in path
+// order. The error is that of the report, or the reason the copy could not
+// be started. The number of files and directories copied is reported to the
+// context's Progress.
+func (c *Client) CopyVolumeToCluster(
+	ctx context.Context,
+	dst *Client, src, dest string,
+	opts *CopyVolumeOptions) (report *BulkReport, err error) {
+
+	if dst == nil {
+		return nil, errors.New("no destination client set")
+	}
+	if opts == nil {
+		opts = &CopyVolumeOptions{}
+	}
+
+	op := fmt.Sprintf("copy volume %s to %s", src, dest)
+	progress := progressFrom(ctx)
+	progress.OnStart(op)
+	defer func() { progress.OnComplete(op, err) }()
+
+	children, err := c.QueryVolumeChildren(ctx, src)
+	if err != nil {
+		return nil, err
+	}
+	if err = dst.createCopyDestination(ctx, dest); err != nil {
+		return nil, err
+	}
+
+	// directories are created a level at a time so that their parents
+	// exist, then files are copied, then the directories' ACLs are copied
+	// deepest first so that a restrictive ACL does not get in the way
+	var (
+		root   = c.API.VolumePath(src) + "/"
+		levels [][]BulkOp
+		files  []BulkOp
+		acls   []BulkOp
+	)
+	paths := make([]string, 0, len(children))
+	for p := range children {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		child := children[p]
+		rel := strings.TrimPrefix(p, root)
+		var childType string
+		if child.Type != nil {
+			childType = *child.Type
+		}
+		switch childType {
+		case "container":
+			depth := strings.Count(rel, "/")
+			for len(levels) <= depth {
+				levels = append(levels, nil)
+			}
+			levels[depth] = append(
+				levels[depth], c.copyDirOp(dst, dest, rel, child))
+			if !opts.SkipACLs {
+				acls = append(acls, c.copyACLOp(dst, src, dest, rel))
+			}
+		case "object":
+			files = append(files, c.copyFileOp(dst, src, dest, rel, child, opts))
+		default:
+			files = append(files, BulkOp{
+				Name: "copy " + rel,
+				Do: func(context.Context) error {
+					return fmt.Errorf("unsupported file type: %s", childType)
+				},
+			})
+		}
+	}
+	if !opts.SkipACLs {
+		acls = append(acls, c.copyACLOp(dst, src, dest, ""))
+	}
+	for i, j := 0, len(acls)-1; i < j; i, j = i+1, j-1 {
+		acls[i], acls[j] = acls[j], acls[i]
+	}
+
+	var (
+		done  int64
+		total = int64(len(paths))
+	)
+	bulkOpts := &BulkOptions{Workers: opts.Workers, Retries: opts.Retries}
+	run := func(ops []BulkOp, count bool) *BulkReport {
+		if count {
+			for i := range ops {
+				do, name := ops[i].Do, ops[i].Name
+				ops[i].Do = func(ctx context.Context) error {
+					err := do(ctx)
+					if err == nil {
+						progress.OnProgress(op, ProgressUpdate{
+							Current: atomic.AddInt64(&done, 1),
+							Total:   total,
+							Detail:  name,
+						})
+					}
+					return err
+				}
+			}
+		}
+		return Bulk(ctx, ops, bulkOpts)
+	}
+
+	report = &BulkReport{}
+	merge := func(r *BulkReport) {
+		report.Results = append(report.Results, r.Results...)
+		report.Succeeded += r.Succeeded
+		report.Failed += r.Failed
+	}
+	for _, level := range levels {
+		merge(run(level, true))
+	}
+	merge(run(files, true))
+	merge(run(acls, false))
+
+	return report, report.Err()
+}
+
+func (c *Client) copyDirOp(
+	dst *Client, dest, rel string, child *apiv2.ContainerChild) BulkOp {
+
+	mode := os.FileMode(0755)
+	if child.Mode != nil {
+		mode = os.FileMode(*child.Mode)
+	}
+	return BulkOp{
+		Name: "create directory " + rel,
+		Do: func(ctx context.Context) error {
+			return dst.CreateVolumeDir(ctx, dest, rel, mode, true, false)
+		},
+	}
+}
+
+func (c *Client) copyFileOp(
+	dst *Client, src, dest, rel string,
+	child *apiv2.ContainerChild, opts *CopyVolumeOptions) BulkOp {
+
+	var (
+		size int64
+		mode = apiv2.FileMode(0644)
+	)
+	if child.Size != nil {
+		size = *child.Size
+	}
+	if child.Mode != nil {
+		mode = *child.Mode
+	}
+	return BulkOp{
+		Name: "copy file " + rel,
+		Do: func(ctx context.Context) error {
+			if err := c.copyFile(
+				ctx, dst, src, dest, rel, size, mode, opts.Overwrite); err != nil {
+				return err
+			}
+			if opts.SkipACLs {
+				return nil
+			}
+			return c.copyACL(ctx, dst, src, dest, rel)
+		},
+	}
+}
+
+// copyFile streams the contents of a file from one cluster to the other.
+func (c *Client) copyFile(
+	ctx context.Context,
+	dst *Client, src, dest, rel string,
+	size int64, mode apiv2.FileMode, overwrite bool) error {
+
+	pr, pw := io.Pipe()
+	getErr := make(chan error, 1)
+	go func() {
+		err := apiv2.ContainerGetFile(ctx, c.API, path.Join(src, rel), pw)
+		pw.CloseWithError(err)
+		getErr <- err
+	}()
+
+	putErr := apiv2.ContainerCreateFile(
+		ctx, dst.API, dest, rel, int(size), mode, pr, overwrite)
+	// unblock the download if the upload stopped reading it
+	pr.Close()
+
+	// a failed download also fails the upload, so its error is the cause
+	if err := <-getErr; err != nil && !errors.Is(err, io.ErrClosedPipe) {
+		return err
+	}
+	return putErr
+}
+
+func (c *Client) copyACLOp(dst *Client, src, dest, rel string) BulkOp {
+	return BulkOp{
+		Name: "copy acl " + path.Join(dest, rel),
+		Do: func(ctx context.Context) error {
+			return c.copyACL(ctx, dst, src, dest, rel)
+		},
+	}
+}
+
+// copyACL replaces the ACL of a file or directory with that of its source.
+func (c *Client) copyACL(
+	ctx context.Context, dst *Client, src, dest, rel string) error {
+
+	acl, err := apiv2.ACLInspect(ctx, c.API, path.Join(src, rel))
+	if err != nil {
+		return err
+	}
+	acl.Action = &apiv2.PActionTypeReplace
+	if acl.Authoritative != nil &&
+		*acl.Authoritative == apiv2.AuthoritativeTypeACL {
+		// the mode is derived from the ACEs
+		acl.Mode = nil
+	}
+	return apiv2.ACLUpdate(ctx, dst.API, path.Join(dest, rel), acl)
+}
+
+// createCopyDestination creates the destination volume of a copy if it does
+// not exist. Unlike CreateVolumeIfAbsent an existing volume's mode is not
+// checked, as an earlier copy may have replaced its ACL with the source's.
+func (c *Client) createCopyDestination(ctx context.Context, name string) error {
+	volume, err := c.GetVolume(ctx, "", name)
+	if IsNotFound(err) {
+		if _, err = c.CreateVolume(ctx, name); !IsConflict(err) {
+			return err
+		}
+		volume, err = c.GetVolume(ctx, "", name)
+	}
+	if err != nil {
+		return err
+	}
+	return volumeIsContainer(volume)
+}
@@ -0,0 +1,61 @@
+package goisilon
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+)
+
+func TestCopyVolumeToCluster(t *testing.T) {
+	src, dest := "test_copy_volume_src", "test_copy_volume_dest"
+	data := []byte("goisilon")
+
+	_, err := client.CreateVolume(defaultCtx, src)
+	assertNoError(t, err)
+	defer client.ForceDeleteVolume(defaultCtx, src)
+	defer client.ForceDeleteVolume(defaultCtx, dest)
+
+	assertNoError(t, client.SetVolumeMode(defaultCtx, src, 0750))
+	assertNoError(t, client.CreateVolumeDir(
+		defaultCtx, src, "a/b", os.FileMode(0750), false, true))
+	assertNoError(t, apiv2.ContainerCreateFile(
+		defaultCtx, client.API, src, "a/b/file", len(data),
+		apiv2.FileMode(0640), ioutil.NopCloser(bytes.NewReader(data)), false))
+
+	// the destination cluster is the same cluster in this test
+	report, err := client.CopyVolumeToCluster(
+		defaultCtx, client, src, dest, &CopyVolumeOptions{Workers: 2})
+	assertNoError(t, err)
+	assert.Equal(t, 3, report.Succeeded)
+
+	var buf bytes.Buffer
+	assertNoError(t, apiv2.ContainerGetFile(
+		defaultCtx, client.API, path.Join(dest, "a/b/file"), &buf))
+	assert.Equal(t, data, buf.Bytes())
+
+	children, err := client.QueryVolumeChildren(defaultCtx, dest)
+	assertNoError(t, err)
+	dir, ok := children[client.API.VolumePath(dest)+"/a/b"]
+	if assert.True(t, ok) && assert.NotNil(t, dir.Mode) {
+		assert.Equal(t, apiv2.FileMode(0750), *dir.Mode)
+	}
+
+	// existing files are not replaced unless Overwrite is set
+	_, err = client.CopyVolumeToCluster(
+		defaultCtx, client, src, dest, &CopyVolumeOptions{SkipACLs: true})
+	assertError(t, err)
+	// a rerun copies into the destination whose ACL was replaced by the
+	// source's
+	_, err = client.CopyVolumeToCluster(
+		defaultCtx, client, src, dest, &CopyVolumeOptions{Overwrite: true})
+	assertNoError(t, err)
+
+	_, err = client.CopyVolumeToCluster(defaultCtx, nil, src, dest, nil)
+	assertError(t, err)
+}
@@ -0,0 +1,215 @@
+package goisilon
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	// waitMinInterval is the delay before the first poll is repeated.
+	waitMinInterval = time.Second
+
+	// waitMaxInterval is the longest delay between polls.
+	waitMaxInterval = 30 * time.Second
+)
+
+// TimeoutError is returned by the WaitFor helpers when the context is done
+// before the awaited state is reached, including during a poll.
+type TimeoutError struct {
+
+	// Op describes what was being waited for.
+	Op string
+
+	// Err is the context's error, or the error of the poll that the
+	// context interrupted, which wraps the context's error if the poll
+	// was cancelled.
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for %s: %v", e.Op, e.Err)
+}
+
+// Unwrap returns the context's error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// waitFor calls poll, backing off exponentially between calls, until poll
+// returns true or an error, or the context is done. The value of the last
+// poll is returned along with any error. The start and end of the wait are
+// reported to the context's Progress.
+func waitFor[T any](
+	ctx context.Context, op string,
+	poll func(ctx context.Context) (T, bool, error)) (v T, err error) {
+
+	progress := progressFrom(ctx)
+	progress.OnStart(op)
+	defer func() { progress.OnComplete(op, err) }()
+
+	interval := waitMinInterval
+	for {
+		var done bool
+		v, done, err = poll(ctx)
+		if err != nil && ctx.Err() != nil {
+			// the context was done during the poll
+			return v, &TimeoutError{Op: op, Err: err}
+		}
+		if err != nil || done {
+			return v, err
+		}
+		select {
+		case <-ctx.Done():
+			return v, &TimeoutError{Op: op, Err: ctx.Err()}
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > waitMaxInterval {
+			interval = waitMaxInterval
+		}
+	}
+}
+
+// WaitForJobCompletion waits until a job engine job reaches a terminal state
+// and returns it. Finished jobs are removed from the job list, so if the job
+// can no longer be found the last observed job is returned. A *TimeoutError
+// is returned if the context is done first. The job's phase and progress are
+// reported to the context's Progress.
+func (c *Client) WaitForJobCompletion(
+	ctx context.Context, id int64) (Job, error) {
+
+	var (
+		last     Job
+		op       = fmt.Sprintf("job %d to complete", id)
+		progress = progressFrom(ctx)
+	)
+	return waitFor(ctx, op,
+		func(ctx context.Context) (Job, bool, error) {
+			job, err := c.GetJob(ctx, id)
+			if err != nil {
+				if last != nil && IsNotFound(err) {
+					return last, true, nil
+				}
+				return last, false, err
+			}
+			last = job
+			progress.OnProgress(op, ProgressUpdate{
+				Current: int64(job.CurrentPhase),
+				Total:   int64(job.TotalPhases),
+				Detail:  job.Progress,
+			})
+			return job, IsJobFinished(job), nil
+		})
+}
+
+// WaitForCloudJobCompletion waits until a CloudPools job reaches a terminal
+// state and returns it. A *TimeoutError is returned if the context is done
+// first. The job's state is reported to the context's Progress.
+func (c *Client) WaitForCloudJobCompletion(
+	ctx context.Context, id int64) (CloudJob, error) {
+
+	var (
+		op       = fmt.Sprintf("cloud job %d to complete", id)
+		progress = progressFrom(ctx)
+	)
+	return waitFor(ctx, op,
+		func(ctx context.Context) (CloudJob, bool, error) {
+			job, err := c.GetCloudJob(ctx, id)
+			if err != nil {
+				return nil, false, err
+			}
+			progress.OnProgress(op, ProgressUpdate{Detail: job.EffectiveState})
+			return job, IsCloudJobFinished(job), nil
+		})
+}
+
+// WaitForSyncJobCompletion waits until the running job of a SyncIQ policy
+// by name finishes and returns the policy's most recent report, which is
+// that of the job. Finished jobs are removed from the job list, so the
+// report is also returned once the job can no longer be found. A
+// *TimeoutError is returned if the context is done first. The number of
+// files transferred is reported to the context's Progress.
+func (c *Client) WaitForSyncJobCompletion(
+	ctx context.Context, name string) (SyncReport, error) {
+
+	var (
+		op       = fmt.Sprintf("sync job of %s to complete", name)
+		progress = progressFrom(ctx)
+	)
+	_, err := waitFor(ctx, op,
+		func(ctx context.Context) (SyncJob, bool, error) {
+			job, err := c.GetSyncJob(ctx, name)
+			if err != nil {
+				if IsNotFound(err) {
+					return nil, true, nil
+				}
+				return nil, false, err
+			}
+			progress.OnProgress(op, ProgressUpdate{
+				Current: job.FilesTransferred,
+				Total:   job.TotalFiles,
+				Detail:  job.State,
+			})
+			return job, IsSyncJobFinished(job), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return c.latestSyncReport(ctx, name)
+}
+
+// WaitForSnapshotState waits until a snapshot is in the given state, ex.
+// "active", and returns it. A *TimeoutError is returned if the context is
+// done first.
+func (c *Client) WaitForSnapshotState(
+	ctx context.Context, id int64, state string) (*Snapshot, error) {
+
+	return waitFor(ctx, fmt.Sprintf("snapshot %d to be %s", id, state),
+		func(ctx context.Context) (*Snapshot, bool, error) {
+			s, err := c.GetSnapshot(ctx, id, "")
+			if err != nil {
+				return nil, false, err
+			}
+			return s, s.State == state, nil
+		})
+}
+
+// WaitForQuotaReady waits until the quota of a volume is ready, which is
+// once its QuotaScan job has accounted for the existing files, and returns
+// it. A *TimeoutError is returned if the context is done first. The number
+// of files and bytes accounted for so far is reported to the context's
+// Progress.
+func (c *Client) WaitForQuotaReady(
+	ctx context.Context, name string) (*Quota, error) {
+
+	var (
+		op       = fmt.Sprintf("quota of %s to be ready", name)
+		progress = progressFrom(ctx)
+	)
+	return waitFor(ctx, op,
+		func(ctx context.Context) (*Quota, bool, error) {
+			q, err := c.GetQuota(ctx, name)
+			if err != nil {
+				return nil, false, err
+			}
+			progress.OnProgress(op, ProgressUpdate{
+				Current: q.Usage.Inodes,
+				Detail: fmt.Sprintf("accounted %d files, %s",
+					q.Usage.Inodes, FormatSize(q.Usage.Logical)),
+			})
+			return q, q.Ready, nil
+		})
+}
+
+// WaitForExportVisible waits until an export of a volume is listed in an
+// access zone and returns it. If zone is empty the client's zone is used. A
+// *TimeoutError is returned if the context is done first.
+func (c *Client) WaitForExportVisible(
+	ctx context.Context, name, zone string) (Export, error) {
+
+	return waitFor(ctx, fmt.Sprintf("export of %s to be visible", name),
+		func(ctx context.Context) (Export, bool, error) {
+			ex, err := c.GetExportByNameWithZone(ctx, name, zone)
+			return ex, ex != nil, err
+		})
+}
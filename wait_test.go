@@ -0,0 +1,99 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor(t *testing.T) {
+	defer func(min, max time.Duration) {
+		waitMinInterval, waitMaxInterval = min, max
+	}(waitMinInterval, waitMaxInterval)
+	waitMinInterval, waitMaxInterval = time.Millisecond, 4*time.Millisecond
+
+	var polls int
+	v, err := waitFor(defaultCtx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			polls++
+			return polls, polls == 5, nil
+		})
+	assertNoError(t, err)
+	assert.Equal(t, 5, v)
+
+	ctx, cancel := context.WithTimeout(defaultCtx, 10*time.Millisecond)
+	defer cancel()
+	v, err = waitFor(ctx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			return 7, false, nil
+		})
+	assert.Equal(t, 7, v)
+	var terr *TimeoutError
+	assert.True(t, errors.As(err, &terr))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualError(t, err,
+		"timed out waiting for test: context deadline exceeded")
+
+	// a poll interrupted by the deadline also times out
+	ctx, cancel = context.WithTimeout(defaultCtx, 10*time.Millisecond)
+	defer cancel()
+	_, err = waitFor(ctx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			<-ctx.Done()
+			return 0, false, &url.Error{
+				Op: "Get", URL: "https://1.2.3.4:8080/platform", Err: ctx.Err()}
+		})
+	assert.True(t, errors.As(err, &terr), "%v", err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// other poll errors are returned as they are
+	_, err = waitFor(defaultCtx, "test",
+		func(ctx context.Context) (int, bool, error) {
+			return 0, false, errors.New("poll failed")
+		})
+	assert.False(t, errors.As(err, &terr), "%v", err)
+}
+
+func TestWaitForExportVisible(t *testing.T) {
+	volumeName := "test_wait_for_export_visible"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	_, err = client.Export(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.Unexport(defaultCtx, volumeName)
+
+	ctx, cancel := context.WithTimeout(defaultCtx, time.Minute)
+	defer cancel()
+	ex, err := client.WaitForExportVisible(ctx, volumeName, "")
+	assertNoError(t, err)
+	assertNotNil(t, ex)
+}
+
+func TestWaitForQuotaReady(t *testing.T) {
+	volumeName := "test_wait_for_quota_ready"
+
+	_, err := client.CreateVolume(defaultCtx, volumeName)
+	assertNoError(t, err)
+	defer client.DeleteVolume(defaultCtx, volumeName)
+
+	assertNoError(t, client.SetQuotaSize(defaultCtx, volumeName, 1<<30))
+	defer client.ClearQuota(defaultCtx, volumeName)
+
+	p := &recordProgress{}
+	ctx, cancel := context.WithTimeout(
+		ContextWithProgress(defaultCtx, p), 5*time.Minute)
+	defer cancel()
+	q, err := client.WaitForQuotaReady(ctx, volumeName)
+	assertNoError(t, err)
+	assert.True(t, q.Ready)
+	assert.Contains(t, strings.Join(p.calls, "\n"),
+		"progress quota of "+volumeName+" to be ready accounted ")
+}
@@ -0,0 +1,136 @@
+package goisilon
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Sample is a value delivered by a Watcher. Err is set instead of Value if
+// the poll failed.
+type Sample[T any] struct {
+
+	// Time is when the poll was scheduled.
+	Time time.Time
+
+	// Value is the result of the poll.
+	Value T
+
+	// Err is the error of the poll, if any.
+	Err error
+}
+
+// WatchOptions configure a Watcher.
+type WatchOptions struct {
+
+	// Interval is the time between polls. It defaults to 10 seconds.
+	Interval time.Duration
+
+	// Buffer is the number of samples held for a slow receiver. It defaults
+	// to 1. When the buffer is full the oldest sample is dropped.
+	Buffer int
+}
+
+// Watcher polls a value at a fixed interval and delivers the samples on a
+// channel. Polls are scheduled at multiples of the interval from the start,
+// so a slow poll does not make later polls drift; intervals that are missed
+// entirely are skipped. A receiver that falls behind loses the oldest
+// samples rather than delaying the polls.
+type Watcher[T any] struct {
+	c       chan Sample[T]
+	cancel  context.CancelFunc
+	done    chan struct{}
+	dropped atomic.Int64
+}
+
+// NewWatcher starts a Watcher that calls poll until ctx is done or Stop is
+// called.
+func NewWatcher[T any](
+	ctx context.Context,
+	opts *WatchOptions,
+	poll func(ctx context.Context) (T, error)) *Watcher[T] {
+
+	interval, buffer := 10*time.Second, 1
+	if opts != nil && opts.Interval > 0 {
+		interval = opts.Interval
+	}
+	if opts != nil && opts.Buffer > 0 {
+		buffer = opts.Buffer
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher[T]{
+		c:      make(chan Sample[T], buffer),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go w.run(ctx, interval, poll)
+	return w
+}
+
+// C returns the channel on which samples are delivered. It is closed when
+// the Watcher stops.
+func (w *Watcher[T]) C() <-chan Sample[T] {
+	return w.c
+}
+
+// Dropped returns the number of samples that were dropped because the
+// receiver fell behind.
+func (w *Watcher[T]) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// Stop stops polling and waits for the channel to be closed.
+func (w *Watcher[T]) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *Watcher[T]) run(
+	ctx context.Context,
+	interval time.Duration,
+	poll func(ctx context.Context) (T, error)) {
+
+	defer close(w.done)
+	defer close(w.c)
+
+	next := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		v, err := poll(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		w.deliver(Sample[T]{Time: next, Value: v, Err: err})
+
+		next = next.Add(interval)
+		if now := time.Now(); next.Before(now) {
+			next = next.Add(now.Sub(next).Truncate(interval) + interval)
+		}
+		timer.Reset(time.Until(next))
+	}
+}
+
+// deliver sends a sample, dropping the oldest buffered sample if the
+// channel is full. Only run sends, so after a receive there is room.
+func (w *Watcher[T]) deliver(s Sample[T]) {
+	for {
+		select {
+		case w.c <- s:
+			return
+		default:
+		}
+		select {
+		case <-w.c:
+			w.dropped.Add(1)
+		default:
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package goisilon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	var polls int
+	w := NewWatcher(defaultCtx,
+		&WatchOptions{Interval: time.Millisecond, Buffer: 4},
+		func(ctx context.Context) (int, error) {
+			polls++
+			if polls == 2 {
+				return 0, errors.New("poll failed")
+			}
+			return polls, nil
+		})
+
+	var samples []Sample[int]
+	for s := range w.C() {
+		if samples = append(samples, s); len(samples) == 3 {
+			break
+		}
+	}
+	w.Stop()
+
+	assert.Equal(t, 1, samples[0].Value)
+	assert.EqualError(t, samples[1].Err, "poll failed")
+	assert.Equal(t, 3, samples[2].Value)
+	assert.True(t, samples[1].Time.After(samples[0].Time))
+
+	// the channel is closed once stopped
+	for range w.C() {
+	}
+}
+
+func TestWatcherDropsOldest(t *testing.T) {
+	var polls int
+	w := NewWatcher(defaultCtx,
+		&WatchOptions{Interval: time.Millisecond, Buffer: 2},
+		func(ctx context.Context) (int, error) {
+			polls++
+			return polls, nil
+		})
+
+	time.Sleep(20 * time.Millisecond)
+	w.Stop()
+
+	var values []int
+	for s := range w.C() {
+		values = append(values, s.Value)
+	}
+	assert.Len(t, values, 2)
+	assert.Equal(t, values[0]+1, values[1])
+	assert.Equal(t, int64(values[0]-1), w.Dropped())
+}
+
+func TestWatcherContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(defaultCtx)
+	w := NewWatcher(ctx, &WatchOptions{Interval: time.Hour},
+		func(ctx context.Context) (int, error) { return 1, nil })
+
+	s := <-w.C()
+	assert.Equal(t, 1, s.Value)
+	cancel()
+	_, ok := <-w.C()
+	assert.False(t, ok)
+}
@@ -0,0 +1,98 @@
+package goisilon
+
+import (
+	"context"
+
+	apiv2 "github.com/tenortim/goisilon/api/v2"
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// Identity describes the user that the client is authenticated as.
+type Identity struct {
+	// User is the name of the authenticated user.
+	User string
+
+	// UID is the user's UID.
+	UID string
+
+	// Zone is the name of the access zone the user is authenticated in.
+	Zone string
+
+	// Roles are the names of the roles the user is a member of. They are
+	// only known if the user has the privilege to read roles.
+	Roles []string
+
+	// Privileges are the user's effective privileges.
+	Privileges []*api.IsiPrivilege
+}
+
+// HasPrivilege returns a flag indicating whether or not the identity holds
+// a privilege, such as "ISI_PRIV_NFS". If write is true then read-only
+// grants of the privilege are not sufficient.
+func (i *Identity) HasPrivilege(id string, write bool) bool {
+	for _, p := range i.Privileges {
+		if p.ID == id && (!write || !p.ReadOnly) {
+			return true
+		}
+	}
+	return false
+}
+
+// WhoAmI returns the identity of the user that the client is authenticated
+// as, including the user's access zone, roles, and effective privileges, so
+// that applications can fail fast when the account lacks a required
+// privilege.
+func (c *Client) WhoAmI(ctx context.Context) (*Identity, error) {
+	id, err := api.GetIsiAuthID(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		User:       c.API.User(),
+		Zone:       id.ZoneName,
+		Privileges: id.Privilege,
+	}
+	if id.UID != nil {
+		if id.UID.Name != nil {
+			identity.User = *id.UID.Name
+		}
+		if id.UID.ID != nil {
+			identity.UID = id.UID.ID.ID
+		}
+	}
+
+	// reading roles requires a privilege the account may not hold, in which
+	// case the roles are simply left unknown
+	roles, err := api.GetIsiRoles(ctx, c.API, id.ZoneName)
+	if err != nil {
+		return identity, nil
+	}
+	for _, r := range roles {
+		if r.Name == nil || r.Members == nil {
+			continue
+		}
+		for _, m := range *r.Members {
+			if isSamePersona(m, id.UID, id.UserSID) {
+				identity.Roles = append(identity.Roles, *r.Name)
+				break
+			}
+		}
+	}
+	return identity, nil
+}
+
+// isSamePersona returns a flag indicating whether or not p has the same ID as
+// any of the candidates.
+func isSamePersona(p *apiv2.Persona, candidates ...*apiv2.Persona) bool {
+	if p == nil || p.ID == nil {
+		return false
+	}
+	for _, c := range candidates {
+		if c != nil && c.ID != nil &&
+			c.ID.Type == p.ID.Type && c.ID.ID == p.ID.ID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestWhoAmI(t *testing.T) {
+	identity, err := client.WhoAmI(defaultCtx)
+	assertNoError(t, err)
+	t.Logf("user=%s uid=%s zone=%s roles=%v",
+		identity.User, identity.UID, identity.Zone, identity.Roles)
+
+	// every PAPI session requires the login privilege
+	if !identity.HasPrivilege("ISI_PRIV_LOGIN_PAPI", false) {
+		t.Fatal("identity missing ISI_PRIV_LOGIN_PAPI")
+	}
+}
@@ -0,0 +1,132 @@
+package goisilon
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/tenortim/goisilon/api"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+// WormDomain is a SmartLock domain.
+type WormDomain *apiv3.IsiWormDomain
+
+// WormFile is a file committed to a WORM state in a SmartLock domain.
+type WormFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+
+	// RetentionDate is the date the file's retention expires, or the zero
+	// time if the file is retained indefinitely.
+	RetentionDate time.Time `json:"retention_date"`
+
+	// OverrideRetentionDate is the domain's override retention date, which
+	// extends the retention of all of its files, or the zero time if not set.
+	OverrideRetentionDate time.Time `json:"override_retention_date"`
+}
+
+// RetentionExpiry returns the date the file can be deleted, the later of its
+// retention date and override retention date. It is the zero time if the
+// file is retained indefinitely.
+func (f *WormFile) RetentionExpiry() time.Time {
+	if f.RetentionDate.IsZero() {
+		return time.Time{}
+	}
+	if f.OverrideRetentionDate.After(f.RetentionDate) {
+		return f.OverrideRetentionDate
+	}
+	return f.RetentionDate
+}
+
+// GetWormDomains returns the SmartLock domains.
+func (c *Client) GetWormDomains(ctx context.Context) ([]WormDomain, error) {
+	domains, err := apiv3.GetIsiWormDomains(ctx, c.API)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]WormDomain, len(domains))
+	for i, d := range domains {
+		res[i] = d
+	}
+	return res, nil
+}
+
+// GetWormDomain returns a SmartLock domain by id or path.
+func (c *Client) GetWormDomain(
+	ctx context.Context, id string) (WormDomain, error) {
+
+	return apiv3.GetIsiWormDomain(ctx, c.API, id)
+}
+
+// ListWormFiles returns a list of the files committed to a WORM state in a
+// SmartLock domain, identified by id or path, that is fetched a page at a
+// time. The WORM attributes of the files in a page are queried
+// concurrently.
+func (c *Client) ListWormFiles(domain string) *List[*WormFile] {
+	var domainPath string
+	return newList(func(
+		ctx context.Context, resume string) ([]*WormFile, string, error) {
+
+		if resume == "" {
+			d, err := apiv3.GetIsiWormDomain(ctx, c.API, domain)
+			if err != nil {
+				return nil, "", err
+			}
+			domainPath = d.Path
+		}
+
+		children, resume, err := apiv3.GetIsiWormFilesPage(
+			ctx, c.API, domainPath, resume)
+		if err != nil {
+			return nil, "", err
+		}
+
+		attrs := make([]*apiv3.IsiWormAttributes, len(children))
+		calls := make([]func(ctx context.Context) error, len(children))
+		for i, child := range children {
+			i, filePath := i, path.Join(*child.Path, *child.Name)
+			calls[i] = func(ctx context.Context) error {
+				a, err := apiv3.GetIsiWormAttributes(ctx, c.API, filePath)
+				if IsNotFound(err) {
+					// the file was removed after it was listed
+					return nil
+				}
+				attrs[i] = a
+				return err
+			}
+		}
+		if err := api.Batch(ctx, ConcurrentHTTPConnections, calls...); err != nil {
+			return nil, "", err
+		}
+
+		var files []*WormFile
+		for i, child := range children {
+			if attrs[i] == nil || !attrs[i].Committed {
+				continue
+			}
+			f := wormFileFromAPI(path.Join(*child.Path, *child.Name), attrs[i])
+			if child.Size != nil {
+				f.Size = *child.Size
+			}
+			files = append(files, f)
+		}
+		return files, resume, nil
+	})
+}
+
+func wormFileFromAPI(
+	filePath string, attrs *apiv3.IsiWormAttributes) *WormFile {
+
+	date := func(v *int64) time.Time {
+		if v == nil || *v <= 0 {
+			return time.Time{}
+		}
+		return time.Unix(*v, 0)
+	}
+	return &WormFile{
+		Path:                  filePath,
+		RetentionDate:         date(attrs.RetentionDate),
+		OverrideRetentionDate: date(attrs.OverrideRetentionDate),
+	}
+}
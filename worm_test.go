@@ -0,0 +1,48 @@
+package goisilon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv3 "github.com/tenortim/goisilon/api/v3"
+)
+
+func TestWormFileRetentionExpiry(t *testing.T) {
+	retention, override := int64(1700000000), int64(1800000000)
+
+	f := wormFileFromAPI("/ifs/worm/a", &apiv3.IsiWormAttributes{
+		Committed:     true,
+		RetentionDate: &retention,
+	})
+	assert.Equal(t, "/ifs/worm/a", f.Path)
+	assert.True(t, f.OverrideRetentionDate.IsZero())
+	assert.Equal(t, time.Unix(retention, 0), f.RetentionExpiry())
+
+	// the override extends the retention
+	f = wormFileFromAPI("/ifs/worm/a", &apiv3.IsiWormAttributes{
+		Committed:             true,
+		RetentionDate:         &retention,
+		OverrideRetentionDate: &override,
+	})
+	assert.Equal(t, time.Unix(override, 0), f.RetentionExpiry())
+
+	// a file without a retention date is retained indefinitely
+	f = wormFileFromAPI("/ifs/worm/a", &apiv3.IsiWormAttributes{
+		Committed:             true,
+		OverrideRetentionDate: &override,
+	})
+	assert.True(t, f.RetentionExpiry().IsZero())
+}
+
+func TestWormDomains(t *testing.T) {
+	domains, err := client.GetWormDomains(defaultCtx)
+	assertNoError(t, err)
+	for _, d := range domains {
+		files, err := client.ListWormFiles(d.Path).Limit(10).Collect(defaultCtx)
+		assertNoError(t, err)
+		for _, f := range files {
+			assert.True(t, isUnderPath(f.Path, d.Path))
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package goisilon
+
+import (
+	"context"
+
+	api "github.com/tenortim/goisilon/api/v3"
+)
+
+// ZoneList is a list of access zones.
+type ZoneList []*api.IsiZone
+
+// Zone is an access zone.
+type Zone *api.IsiZone
+
+// GetZones returns the access zones.
+func (c *Client) GetZones(ctx context.Context) (ZoneList, error) {
+	return api.GetIsiZones(ctx, c.API)
+}
+
+// GetZone returns the access zone with the given name.
+func (c *Client) GetZone(ctx context.Context, name string) (Zone, error) {
+	return api.GetIsiZone(ctx, c.API, name)
+}
@@ -0,0 +1,17 @@
+package goisilon
+
+import (
+	"testing"
+)
+
+func TestGetZones(t *testing.T) {
+	zones, err := client.GetZones(defaultCtx)
+	assertNoError(t, err)
+	if len(zones) == 0 {
+		t.Fatal("the System zone should always exist")
+	}
+
+	zone, err := client.GetZone(defaultCtx, "System")
+	assertNoError(t, err)
+	assertNotNil(t, zone.AuthProviders)
+}